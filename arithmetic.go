@@ -0,0 +1,205 @@
+package Netpbm // ✨ Opérations arithmétiques entre images
+
+import (
+	"fmt"
+	"math"
+)
+
+// ArithmeticOptions règle le calcul du résultat des opérations entre images (Add, Subtract, Multiply,
+// Divide, Min, Max, AbsDiff).
+type ArithmeticOptions struct {
+	Scale     float64 // Facteur appliqué au résultat de l'opération (0 : 1, le résultat est gardé tel quel)
+	Offset    float64 // Valeur ajoutée au résultat après Scale
+	Normalize bool    // Étirer les résultats pour que le plus petit devienne 0 et le plus grand la valeur maximale
+	Wrap      bool    // Résultats pris modulo (valeur maximale + 1) au lieu d'être limités à [0, valeur maximale]
+}
+
+// DefaultArithmeticOptions garde le résultat tel quel, limité à [0, valeur maximale].
+var DefaultArithmeticOptions = ArithmeticOptions{Scale: 1}
+
+// arithmeticOp est une opération entre deux valeurs de pixel, de même valeur maximale max.
+type arithmeticOp func(a, b, max float64) float64
+
+// Opérations élémentaires. Multiply et Divide traitent les valeurs comme des fractions de max : multiplier
+// par le blanc ou diviser par le blanc laisse l'image inchangée. Une division par 0 donne max (ou 0 si le
+// dividende est nul).
+var (
+	opAdd      arithmeticOp = func(a, b, _ float64) float64 { return a + b }
+	opSubtract arithmeticOp = func(a, b, _ float64) float64 { return a - b }
+	opMultiply arithmeticOp = func(a, b, max float64) float64 { return a * b / max }
+	opDivide   arithmeticOp = func(a, b, max float64) float64 {
+		if b == 0 {
+			if a == 0 {
+				return 0
+			}
+			return max
+		}
+		return a / b * max
+	}
+	opMin     arithmeticOp = func(a, b, _ float64) float64 { return math.Min(a, b) }
+	opMax     arithmeticOp = func(a, b, _ float64) float64 { return math.Max(a, b) }
+	opAbsDiff arithmeticOp = func(a, b, _ float64) float64 { return math.Abs(a - b) }
+)
+
+// Add ajoute à chaque pixel de l'image PGM celui de other, de même taille. Les valeurs de other sont
+// d'abord ramenées à la valeur maximale de l'image ; le résultat est ajusté selon options.
+func (pgm *PGM) Add(other *PGM, options ArithmeticOptions) error {
+	return pgm.arithmetic(other, opAdd, options)
+}
+
+// Subtract retranche de chaque pixel de l'image PGM celui de other, comme Add.
+func (pgm *PGM) Subtract(other *PGM, options ArithmeticOptions) error {
+	return pgm.arithmetic(other, opSubtract, options)
+}
+
+// Multiply multiplie chaque pixel de l'image PGM par celui de other, les valeurs étant prises comme des
+// fractions de la valeur maximale (multiplier par le blanc ne change rien), comme Add.
+func (pgm *PGM) Multiply(other *PGM, options ArithmeticOptions) error {
+	return pgm.arithmetic(other, opMultiply, options)
+}
+
+// Divide divise chaque pixel de l'image PGM par celui de other, les valeurs étant prises comme des
+// fractions de la valeur maximale (diviser par le blanc ne change rien), comme Add. Diviser par 0 donne
+// la valeur maximale, sauf pour un pixel nul qui le reste.
+func (pgm *PGM) Divide(other *PGM, options ArithmeticOptions) error {
+	return pgm.arithmetic(other, opDivide, options)
+}
+
+// Min garde en chaque pixel la plus petite des valeurs de l'image PGM et de other, comme Add.
+func (pgm *PGM) Min(other *PGM, options ArithmeticOptions) error {
+	return pgm.arithmetic(other, opMin, options)
+}
+
+// Max garde en chaque pixel la plus grande des valeurs de l'image PGM et de other, comme Add.
+func (pgm *PGM) Max(other *PGM, options ArithmeticOptions) error {
+	return pgm.arithmetic(other, opMax, options)
+}
+
+// AbsDiff remplace chaque pixel de l'image PGM par la valeur absolue de sa différence avec celui de
+// other, comme Add.
+func (pgm *PGM) AbsDiff(other *PGM, options ArithmeticOptions) error {
+	return pgm.arithmetic(other, opAbsDiff, options)
+}
+
+// arithmetic applique op à chaque pixel de l'image PGM et au pixel correspondant de other.
+func (pgm *PGM) arithmetic(other *PGM, op arithmeticOp, options ArithmeticOptions) error {
+	if pgm.width != other.width || pgm.height != other.height {
+		return fmt.Errorf("size mismatch: %dx%d and %dx%d", pgm.width, pgm.height, other.width, other.height)
+	}
+	pgm.own()
+	maxValue := float64(max(pgm.max, 1))
+	scale := maxValue / float64(max(other.max, 1))
+	values := make([]float64, 0, pgm.width*pgm.height)
+	for y := 0; y < pgm.height; y++ {
+		for x, v := range pgm.data[y] {
+			values = append(values, op(float64(v), float64(other.data[y][x])*scale, maxValue))
+		}
+	}
+	results := arithmeticResults(values, pgm.max, options)
+	for y := 0; y < pgm.height; y++ {
+		copy(pgm.data[y], results[y*pgm.width:])
+	}
+	return nil
+}
+
+// Add ajoute à chaque canal de chaque pixel de l'image PPM celui de other, de même taille, comme Add
+// pour une image PGM.
+func (ppm *PPM) Add(other *PPM, options ArithmeticOptions) error {
+	return ppm.arithmetic(other, opAdd, options)
+}
+
+// Subtract retranche de chaque canal de l'image PPM celui de other, comme Subtract pour une image PGM.
+func (ppm *PPM) Subtract(other *PPM, options ArithmeticOptions) error {
+	return ppm.arithmetic(other, opSubtract, options)
+}
+
+// Multiply multiplie chaque canal de l'image PPM par celui de other, comme Multiply pour une image PGM.
+func (ppm *PPM) Multiply(other *PPM, options ArithmeticOptions) error {
+	return ppm.arithmetic(other, opMultiply, options)
+}
+
+// Divide divise chaque canal de l'image PPM par celui de other, comme Divide pour une image PGM.
+func (ppm *PPM) Divide(other *PPM, options ArithmeticOptions) error {
+	return ppm.arithmetic(other, opDivide, options)
+}
+
+// Min garde en chaque canal la plus petite des valeurs de l'image PPM et de other, comme Min pour une
+// image PGM.
+func (ppm *PPM) Min(other *PPM, options ArithmeticOptions) error {
+	return ppm.arithmetic(other, opMin, options)
+}
+
+// Max garde en chaque canal la plus grande des valeurs de l'image PPM et de other, comme Max pour une
+// image PGM.
+func (ppm *PPM) Max(other *PPM, options ArithmeticOptions) error {
+	return ppm.arithmetic(other, opMax, options)
+}
+
+// AbsDiff remplace chaque canal de l'image PPM par la valeur absolue de sa différence avec celui de
+// other, comme AbsDiff pour une image PGM.
+func (ppm *PPM) AbsDiff(other *PPM, options ArithmeticOptions) error {
+	return ppm.arithmetic(other, opAbsDiff, options)
+}
+
+// arithmetic applique op à chaque canal de chaque pixel de l'image PPM et au canal correspondant de other.
+func (ppm *PPM) arithmetic(other *PPM, op arithmeticOp, options ArithmeticOptions) error {
+	if ppm.width != other.width || ppm.height != other.height {
+		return fmt.Errorf("size mismatch: %dx%d and %dx%d", ppm.width, ppm.height, other.width, other.height)
+	}
+	ppm.own()
+	maxValue := float64(max(ppm.max, 1))
+	scale := maxValue / float64(max(other.max, 1))
+	values := make([]float64, 0, 3*ppm.width*ppm.height)
+	for y := 0; y < ppm.height; y++ {
+		for x, p := range ppm.data[y] {
+			q := other.data[y][x]
+			values = append(values,
+				op(float64(p.R), float64(q.R)*scale, maxValue),
+				op(float64(p.G), float64(q.G)*scale, maxValue),
+				op(float64(p.B), float64(q.B)*scale, maxValue))
+		}
+	}
+	results := arithmeticResults(values, ppm.max, options)
+	for y := 0; y < ppm.height; y++ {
+		for x := range ppm.data[y] {
+			i := 3 * (y*ppm.width + x)
+			ppm.data[y][x] = Pixel{results[i], results[i+1], results[i+2]}
+		}
+	}
+	return nil
+}
+
+// arithmeticResults convertit les résultats bruts des opérations en valeurs entre 0 et maxValue, selon
+// options.
+func arithmeticResults(values []float64, maxValue int, options ArithmeticOptions) []uint8 {
+	scale := options.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for i, v := range values {
+		v = v*scale + options.Offset
+		values[i] = v
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	results := make([]uint8, len(values))
+	for i, v := range values {
+		if options.Normalize {
+			if high > low {
+				v = (v - low) / (high - low) * float64(maxValue)
+			} else {
+				v = 0
+			}
+		}
+		if options.Wrap {
+			n := math.Mod(math.Round(v), float64(maxValue+1))
+			if n < 0 {
+				n += float64(maxValue + 1)
+			}
+			results[i] = uint8(n)
+		} else {
+			results[i] = clampChannel(v, maxValue)
+		}
+	}
+	return results
+}
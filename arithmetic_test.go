@@ -0,0 +1,87 @@
+package Netpbm // 🧪 Test Opérations arithmétiques entre images
+
+import "testing"
+
+func TestPGMArithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(pgm, other *PGM, options ArithmeticOptions) error
+		a, b    uint8
+		options ArithmeticOptions
+		want    uint8
+	}{
+		{"Add", (*PGM).Add, 100, 50, DefaultArithmeticOptions, 150},
+		{"Add limité", (*PGM).Add, 200, 100, DefaultArithmeticOptions, 255},
+		{"Add modulo", (*PGM).Add, 200, 100, ArithmeticOptions{Scale: 1, Wrap: true}, 44},
+		{"Add moyenne", (*PGM).Add, 200, 100, ArithmeticOptions{Scale: 0.5}, 150},
+		{"Subtract limité", (*PGM).Subtract, 50, 100, DefaultArithmeticOptions, 0},
+		{"Subtract décalé", (*PGM).Subtract, 50, 100, ArithmeticOptions{Scale: 0.5, Offset: 128}, 103},
+		{"Multiply", (*PGM).Multiply, 200, 255, DefaultArithmeticOptions, 200},
+		{"Multiply moitié", (*PGM).Multiply, 200, 51, DefaultArithmeticOptions, 40},
+		{"Divide", (*PGM).Divide, 50, 100, DefaultArithmeticOptions, 128},
+		{"Divide par 0", (*PGM).Divide, 50, 0, DefaultArithmeticOptions, 255},
+		{"Divide 0 par 0", (*PGM).Divide, 0, 0, DefaultArithmeticOptions, 0},
+		{"Min", (*PGM).Min, 50, 100, DefaultArithmeticOptions, 50},
+		{"Max", (*PGM).Max, 50, 100, DefaultArithmeticOptions, 100},
+		{"AbsDiff", (*PGM).AbsDiff, 50, 120, DefaultArithmeticOptions, 70},
+	}
+	for _, test := range tests {
+		a, b := newUniformPGM(3, 2, test.a), newUniformPGM(3, 2, test.b)
+		if err := test.op(a, b, test.options); err != nil {
+			t.Fatalf("%s : %v", test.name, err)
+		}
+		if got := a.At(2, 1); got != test.want {
+			t.Errorf("%s : %d, attendu %d", test.name, got, test.want)
+		}
+		if b.At(2, 1) != test.b {
+			t.Errorf("%s : l'opérande a été modifié", test.name)
+		}
+	}
+
+	if err := NewPGM(2, 2, 255).Add(NewPGM(3, 2, 255), DefaultArithmeticOptions); err == nil {
+		t.Error("des images de tailles différentes doivent être refusées")
+	}
+}
+
+func TestPGMArithmeticScalesOther(t *testing.T) {
+	a := newUniformPGM(2, 2, 100)
+	b := NewPGM(2, 2, 15)
+	b.Set(0, 0, 15)
+	if err := a.Multiply(b, DefaultArithmeticOptions); err != nil {
+		t.Fatal(err)
+	}
+	if a.At(0, 0) != 100 || a.At(1, 1) != 0 {
+		t.Errorf("Multiply par une image de valeur maximale 15 : %d et %d, attendu 100 et 0", a.At(0, 0), a.At(1, 1))
+	}
+}
+
+func TestArithmeticZeroScale(t *testing.T) {
+	a := newUniformPGM(2, 2, 100)
+	if err := a.Add(newUniformPGM(2, 2, 20), ArithmeticOptions{Offset: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if a.At(1, 1) != 125 {
+		t.Errorf("Add avec Scale nul : %d, attendu 125", a.At(1, 1))
+	}
+}
+
+func TestPPMArithmeticNormalize(t *testing.T) {
+	a := newUniformPPM(2, 1, Pixel{10, 20, 30})
+	a.Set(1, 0, Pixel{40, 20, 30})
+	b := newUniformPPM(2, 1, Pixel{10, 10, 10})
+	if err := a.AbsDiff(b, ArithmeticOptions{Scale: 1, Normalize: true}); err != nil {
+		t.Fatal(err)
+	}
+	// Écarts 0, 10, 20 et 30 étirés sur [0, 255]
+	if a.data[0][0] != (Pixel{0, 85, 170}) || a.data[0][1] != (Pixel{255, 85, 170}) {
+		t.Errorf("AbsDiff normalisé = %v", a.data[0])
+	}
+
+	c := a.Clone()
+	if err := c.Max(newUniformPPM(2, 1, Pixel{100, 0, 200}), DefaultArithmeticOptions); err != nil {
+		t.Fatal(err)
+	}
+	if c.data[0][1] != (Pixel{255, 85, 200}) || a.data[0][1] != (Pixel{255, 85, 170}) {
+		t.Errorf("Max = %v, original = %v", c.data[0][1], a.data[0][1])
+	}
+}
@@ -0,0 +1,136 @@
+package Netpbm // ✨ Automates cellulaires
+
+// Rule est la règle d'un automate cellulaire à voisinage de Moore (8 voisins).
+// Une cellule morte naît si son nombre de voisins vivants figure dans Birth ; une cellule vivante survit
+// s'il figure dans Survive. Quand States dépasse 2, une cellule qui ne survit pas passe par States-2 états
+// mourants avant de mourir, et les cellules mourantes ne comptent pas comme voisines.
+type Rule struct {
+	Birth   []int
+	Survive []int
+	States  int
+}
+
+// Règles courantes.
+var (
+	Life        = Rule{Birth: []int{3}, Survive: []int{2, 3}, States: 2}
+	Seeds       = Rule{Birth: []int{2}, States: 2}
+	BriansBrain = Rule{Birth: []int{2}, States: 3}
+)
+
+// Automaton est une grille d'automate cellulaire. Les bords se rejoignent : la grille est un tore.
+type Automaton struct {
+	width, height int
+	cells         [][]uint8 // 0 mort, 1 vivant, 2 et plus mourant
+	generation    int
+}
+
+// NewAutomaton crée un automate dont les cellules vivantes sont les pixels noirs de l'image PBM.
+func NewAutomaton(pbm *PBM) *Automaton {
+	a := &Automaton{width: pbm.width, height: pbm.height, cells: make([][]uint8, pbm.height)}
+	for y := range a.cells {
+		a.cells[y] = make([]uint8, pbm.width)
+		for x := range a.cells[y] {
+			if pbm.data[y][x] {
+				a.cells[y][x] = 1
+			}
+		}
+	}
+	return a
+}
+
+// Generation renvoie le nombre de générations calculées depuis la création de l'automate.
+func (a *Automaton) Generation() int {
+	return a.generation
+}
+
+// Step calcule la génération suivante selon la règle donnée.
+func (a *Automaton) Step(rule Rule) {
+	var birth, survive [9]bool
+	for _, n := range rule.Birth {
+		if n >= 0 && n <= 8 {
+			birth[n] = true
+		}
+	}
+	for _, n := range rule.Survive {
+		if n >= 0 && n <= 8 {
+			survive[n] = true
+		}
+	}
+	states := uint8(min(max(rule.States, 2), 255))
+
+	next := make([][]uint8, a.height)
+	for y := range next {
+		next[y] = make([]uint8, a.width)
+		for x := range next[y] {
+			state := a.cells[y][x]
+			switch {
+			case state == 0:
+				if birth[a.liveNeighbours(x, y)] {
+					next[y][x] = 1
+				}
+			case state == 1:
+				if survive[a.liveNeighbours(x, y)] {
+					next[y][x] = 1
+				} else if states > 2 {
+					next[y][x] = 2
+				}
+			case state+1 < states:
+				next[y][x] = state + 1
+			}
+		}
+	}
+	a.cells = next
+	a.generation++
+}
+
+// liveNeighbours compte les voisins vivants de la cellule (x, y), les bords se rejoignant.
+func (a *Automaton) liveNeighbours(x, y int) int {
+	count := 0
+	for dy := -1; dy <= 1; dy++ {
+		row := a.cells[(y+dy+a.height)%a.height]
+		for dx := -1; dx <= 1; dx++ {
+			if (dx != 0 || dy != 0) && row[(x+dx+a.width)%a.width] == 1 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Run calcule n générations selon la règle donnée. Si frame n'est pas nil, elle est appelée avec l'image
+// de chaque nouvelle génération, par exemple pour en faire une animation.
+func (a *Automaton) Run(n int, rule Rule, frame func(generation int, pbm *PBM)) {
+	for i := 0; i < n; i++ {
+		a.Step(rule)
+		if frame != nil {
+			frame(a.generation, a.ToPBM())
+		}
+	}
+}
+
+// ToPBM renvoie l'état de l'automate sous forme d'image PBM : les cellules vivantes sont noires.
+func (a *Automaton) ToPBM() *PBM {
+	pbm := NewPBM(a.width, a.height)
+	for y, row := range a.cells {
+		for x, state := range row {
+			pbm.data[y][x] = state == 1
+		}
+	}
+	return pbm
+}
+
+// ToPGM renvoie l'état de l'automate sous forme d'image PGM, pour une règle à states états : les cellules
+// vivantes sont noires, les mortes blanches et les mourantes de plus en plus claires.
+func (a *Automaton) ToPGM(states int) *PGM {
+	pgm := NewPGM(a.width, a.height, 255)
+	for y, row := range a.cells {
+		for x, state := range row {
+			if state == 0 {
+				pgm.data[y][x] = 255
+			} else {
+				pgm.data[y][x] = uint8(255 * (int(state) - 1) / max(states-1, 1))
+			}
+		}
+	}
+	return pgm
+}
@@ -0,0 +1,74 @@
+package Netpbm // 🧪 Test automates cellulaires
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAutomatonLife(t *testing.T) {
+	// Un clignotant oscille avec une période de 2
+	blinker := newPBMFromRows(".....", ".....", ".###.", ".....", ".....")
+	a := NewAutomaton(blinker)
+	a.Step(Life)
+	vertical := newPBMFromRows(".....", "..#..", "..#..", "..#..", ".....")
+	if a.ToPBM().Hash() != vertical.Hash() {
+		t.Error("The blinker should become vertical")
+	}
+	a.Step(Life)
+	if a.ToPBM().Hash() != blinker.Hash() || a.Generation() != 2 {
+		t.Error("The blinker should come back after two generations")
+	}
+
+	// Un planeur revient à sa forme, décalé d'une case en diagonale, toutes les 4 générations
+	glider := newPBMFromRows(".#....", "..#...", "###...", "......", "......", "......")
+	a = NewAutomaton(glider)
+	frames := 0
+	a.Run(4, Life, func(generation int, pbm *PBM) {
+		frames++
+		if countBlack(pbm) != 5 {
+			t.Errorf("Generation %d: expected 5 live cells, got %d", generation, countBlack(pbm))
+		}
+	})
+	moved := newPBMFromRows("......", "..#...", "...#..", ".###..", "......", "......")
+	if frames != 4 || a.ToPBM().Hash() != moved.Hash() {
+		t.Error("The glider should move one cell diagonally")
+	}
+}
+
+func TestAutomatonWrap(t *testing.T) {
+	// Un clignotant sur le bord se referme de l'autre côté
+	a := NewAutomaton(newPBMFromRows("#....", "#....", "#....", ".....", "....."))
+	a.Step(Life)
+	if !a.ToPBM().At(4, 1) || !a.ToPBM().At(1, 1) {
+		t.Error("Edges should wrap around")
+	}
+}
+
+func TestAutomatonBriansBrain(t *testing.T) {
+	a := NewAutomaton(newPBMFromRows("......", "..##..", "......", "......"))
+	a.Step(BriansBrain)
+	pgm := a.ToPGM(BriansBrain.States)
+	// Les cellules vivantes deviennent mourantes, deux cellules naissent au-dessus et deux en dessous
+	if pgm.At(2, 0) != 0 || pgm.At(3, 2) != 0 || pgm.At(1, 0) != 255 {
+		t.Errorf("Unexpected state %v", pgm.data)
+	}
+	if pgm.At(2, 1) != 127 || a.ToPBM().At(2, 1) {
+		t.Errorf("Dying cells should be gray and not live, got %d", pgm.At(2, 1))
+	}
+	a.Step(BriansBrain)
+	if a.cells[1][2] != 0 {
+		t.Error("Dying cells should die")
+	}
+
+	a = NewAutomaton(newPBMFromRows("##", "##"))
+	a.Step(Seeds)
+	if countBlack(a.ToPBM()) != 0 {
+		t.Error("In Seeds, no cell survives")
+	}
+
+	err := NewAutomaton(GenerateMaze(8, 8, MazePrim, 2)).ToPGM(2).Save("./testImages/pgm/automaton.pgm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/pgm/automaton.pgm")
+}
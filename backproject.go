@@ -0,0 +1,92 @@
+package Netpbm // ✨ Rétroprojection d'histogramme
+
+// Histogram3D compte les couleurs d'une image dans Bins×Bins×Bins classes : chaque canal est découpé en
+// Bins intervalles égaux entre 0 et la valeur maximale de l'image.
+type Histogram3D struct {
+	Bins   int       // Nombre de classes par canal
+	Counts []float64 // Effectif de chaque classe, indexée par (r·Bins + g)·Bins + b
+}
+
+// ColorHistogram renvoie l'histogramme des couleurs de la zone r de l'image PPM (un échantillon de
+// l'objet à chercher, par exemple), avec bins classes par canal (entre 1 et 256).
+func (ppm *PPM) ColorHistogram(r Rect, bins int) Histogram3D {
+	bins = min(max(bins, 1), 256)
+	h := Histogram3D{Bins: bins, Counts: make([]float64, bins*bins*bins)}
+	r = r.clip(ppm.width, ppm.height)
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		for x := r.X; x < r.X+r.Width; x++ {
+			h.Counts[h.bin(ppm.data[y][x], ppm.max)]++
+		}
+	}
+	return h
+}
+
+// bin renvoie l'indice de la classe de la couleur p d'une image de valeur maximale maxValue.
+func (h Histogram3D) bin(p Pixel, maxValue int) int {
+	channel := func(v uint8) int {
+		return min(int(v)*h.Bins/(max(maxValue, 1)+1), h.Bins-1)
+	}
+	return (channel(p.R)*h.Bins+channel(p.G))*h.Bins + channel(p.B)
+}
+
+// BackProject renvoie la carte de vraisemblance de l'image PPM pour le modèle de couleur model : chaque
+// pixel y vaut l'effectif de la classe de sa couleur dans model, ramené entre 0 et 255 (255 pour la
+// classe la plus fréquente). Les zones claires de la carte ont les couleurs de l'échantillon.
+func (ppm *PPM) BackProject(model Histogram3D) *PGM {
+	pgm := NewPGM(ppm.width, ppm.height, 255)
+	peak := 0.0
+	for _, count := range model.Counts {
+		peak = max(peak, count)
+	}
+	if model.Bins <= 0 || len(model.Counts) != model.Bins*model.Bins*model.Bins || peak == 0 {
+		return pgm
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			pgm.data[y][x] = uint8(model.Counts[model.bin(ppm.data[y][x], ppm.max)]/peak*255 + 0.5)
+		}
+	}
+	return pgm
+}
+
+// LocateColor cherche dans l'image PPM l'objet dont model décrit les couleurs et renvoie le rectangle
+// qui entoure le plus grand groupe de pixels voisins (en comptant les diagonales) dont la vraisemblance
+// atteint threshold (au moins 1) dans BackProject. Il renvoie faux si aucun pixel ne l'atteint.
+func (ppm *PPM) LocateColor(model Histogram3D, threshold uint8) (Rect, bool) {
+	threshold = max(threshold, 1)
+	likelihood := ppm.BackProject(model)
+	visited := make([][]bool, ppm.height)
+	for y := range visited {
+		visited[y] = make([]bool, ppm.width)
+	}
+	best, bestSize := Rect{}, 0
+	var stack []Point
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			if visited[y][x] || likelihood.data[y][x] < threshold {
+				continue
+			}
+			visited[y][x] = true
+			stack = append(stack[:0], Point{x, y})
+			minX, minY, maxX, maxY, size := x, y, x, y, 0
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				size++
+				minX, minY, maxX, maxY = min(minX, p.X), min(minY, p.Y), max(maxX, p.X), max(maxY, p.Y)
+				for _, n := range neighbours {
+					q := Point{p.X + n.X, p.Y + n.Y}
+					if q.X >= 0 && q.Y >= 0 && q.X < ppm.width && q.Y < ppm.height && !visited[q.Y][q.X] &&
+						likelihood.data[q.Y][q.X] >= threshold {
+						visited[q.Y][q.X] = true
+						stack = append(stack, q)
+					}
+				}
+			}
+			if size > bestSize {
+				best, bestSize = Rect{minX, minY, maxX - minX + 1, maxY - minY + 1}, size
+			}
+		}
+	}
+	return best, bestSize > 0
+}
@@ -0,0 +1,49 @@
+package Netpbm // 🧪 Test Rétroprojection d'histogramme
+
+import "testing"
+
+// newBallPPM dessine une balle orange de rayon 4 en (30, 12) et une tache orange isolée sur un fond vert.
+func newBallPPM() *PPM {
+	ppm := newUniformPPM(40, 20, Pixel{40, 140, 60})
+	for y := 8; y <= 16; y++ {
+		for x := 26; x <= 34; x++ {
+			if (x-30)*(x-30)+(y-12)*(y-12) <= 16 {
+				ppm.Set(x, y, Pixel{240, 130, 20})
+			}
+		}
+	}
+	ppm.Set(3, 3, Pixel{235, 128, 25})
+	return ppm
+}
+
+func TestColorHistogram(t *testing.T) {
+	ppm := newBallPPM()
+	h := ppm.ColorHistogram(Rect{0, 0, 10, 10}, 8)
+	if h.Bins != 8 || len(h.Counts) != 512 {
+		t.Fatalf("histogramme de %d classes (%d)", h.Bins, len(h.Counts))
+	}
+	total := 0.0
+	for _, count := range h.Counts {
+		total += count
+	}
+	if total != 100 || h.Counts[h.bin(Pixel{40, 140, 60}, 255)] != 99 {
+		t.Errorf("effectifs : total %g, fond %g", total, h.Counts[h.bin(Pixel{40, 140, 60}, 255)])
+	}
+}
+
+func TestBackProject(t *testing.T) {
+	ppm := newBallPPM()
+	model := ppm.ColorHistogram(Rect{28, 10, 5, 5}, 8)
+	likelihood := ppm.BackProject(model)
+	if likelihood.At(30, 12) != 255 || likelihood.At(3, 3) != 255 || likelihood.At(10, 10) != 0 {
+		t.Errorf("vraisemblances %d, %d, %d", likelihood.At(30, 12), likelihood.At(3, 3), likelihood.At(10, 10))
+	}
+
+	r, ok := ppm.LocateColor(model, 128)
+	if !ok || r != (Rect{26, 8, 9, 9}) {
+		t.Errorf("LocateColor = %v, %v ; attendu la balle", r, ok)
+	}
+	if _, ok := ppm.LocateColor(ppm.ColorHistogram(Rect{}, 8), 128); ok {
+		t.Error("un modèle vide ne doit rien trouver")
+	}
+}
@@ -0,0 +1,44 @@
+package Netpbm // ✨ Fusion multi-échelle
+
+import "fmt"
+
+// Blend fusionne deux images PPM de même taille selon un masque PGM, bande de fréquences par bande de fréquences.
+// Là où le masque vaut sa valeur maximale, le résultat est a ; là où il vaut 0, c'est b.
+// Les transitions du masque sont adoucies à chaque échelle, ce qui évite les coutures visibles.
+func Blend(a, b *PPM, mask *PGM, levels int) (*PPM, error) {
+	if a.width != b.width || a.height != b.height {
+		return nil, fmt.Errorf("size mismatch: %dx%d and %dx%d", a.width, a.height, b.width, b.height)
+	}
+	if mask.width != a.width || mask.height != a.height {
+		return nil, fmt.Errorf("mask size mismatch: %dx%d, expected %dx%d", mask.width, mask.height, a.width, a.height)
+	}
+
+	// Le masque devient un plan de poids entre 0 et 1, identique sur les trois canaux
+	weights := newRGBPlane(mask.width, mask.height)
+	for y := 0; y < mask.height; y++ {
+		for x, v := range mask.data[y] {
+			w := float64(v) / float64(mask.max)
+			weights.data[y][x] = [3]float64{w, w, w}
+		}
+	}
+
+	pyramidA := a.BuildLaplacianPyramid(levels)
+	pyramidB := b.BuildLaplacianPyramid(levels)
+	maskPyramid := gaussianPlanes(weights, levels)
+
+	blended := &LaplacianPyramid{max: max(a.max, b.max)}
+	for i, bandA := range pyramidA.levels {
+		bandB, m := pyramidB.levels[i], maskPyramid[i]
+		band := newRGBPlane(bandA.width, bandA.height)
+		for y := range band.data {
+			for x := range band.data[y] {
+				for c := 0; c < 3; c++ {
+					w := m.data[y][x][c]
+					band.data[y][x][c] = w*bandA.data[y][x][c] + (1-w)*bandB.data[y][x][c]
+				}
+			}
+		}
+		blended.levels = append(blended.levels, band)
+	}
+	return blended.Reconstruct(), nil
+}
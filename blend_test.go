@@ -0,0 +1,58 @@
+package Netpbm // 🧪 Test fusion multi-échelle
+
+import "testing"
+
+// newUniformPPM crée une image PPM dont tous les pixels valent color.
+func newUniformPPM(width, height int, color Pixel) *PPM {
+	ppm := NewPPM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			ppm.data[y][x] = color
+		}
+	}
+	return ppm
+}
+
+func TestBlend(t *testing.T) {
+	a := newUniformPPM(128, 8, Pixel{200, 0, 0})
+	b := newUniformPPM(128, 8, Pixel{0, 0, 200})
+	mask := NewPGM(128, 8, 255)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 64; x++ {
+			mask.data[y][x] = 255
+		}
+	}
+
+	blended, err := Blend(a, b, mask, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blended.At(0, 4) != (Pixel{200, 0, 0}) || blended.At(127, 4) != (Pixel{0, 0, 200}) {
+		t.Errorf("Far pixels should come from one image: %v %v", blended.At(0, 4), blended.At(127, 4))
+	}
+	// La transition est progressive autour de la couture
+	previous := 255
+	for x := 40; x < 88; x++ {
+		r := int(blended.At(x, 4).R)
+		if r > previous {
+			t.Errorf("Transition not monotone at x=%d", x)
+		}
+		previous = r
+	}
+	if r := blended.At(63, 4).R; r == 200 || r == 0 {
+		t.Errorf("Seam should be softened, got %d", r)
+	}
+
+	// Sans réduction, la fusion est un simple mélange pixel à pixel
+	hard, err := Blend(a, b, mask, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hard.At(63, 4) != (Pixel{200, 0, 0}) || hard.At(64, 4) != (Pixel{0, 0, 200}) {
+		t.Error("Zero levels should give a hard seam")
+	}
+
+	if _, err := Blend(a, NewPPM(3, 3, 255), mask, 2); err == nil {
+		t.Error("Expected a size mismatch error")
+	}
+}
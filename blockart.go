@@ -0,0 +1,48 @@
+package Netpbm // ✨ Aperçu en couleurs pour terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// ToBlockArt écrit un aperçu en couleurs de l'image PPM pour un terminal, large de cols caractères :
+// chaque caractère « ▀ » (demi-bloc supérieur) montre deux pixels superposés, celui du haut en couleur
+// de texte et celui du bas en couleur de fond, avec les séquences ANSI en couleurs vraies (24 bits).
+// Un caractère de terminal étant à peu près deux fois plus haut que large, les pixels restent carrés.
+// Les couleurs ne sont réémises que lorsqu'elles changent, et chaque ligne se termine par un retour aux
+// couleurs par défaut.
+func (ppm *PPM) ToBlockArt(w io.Writer, cols int) error {
+	if cols <= 0 {
+		return fmt.Errorf("invalid width %d columns", cols)
+	}
+	if ppm.width == 0 || ppm.height == 0 {
+		return nil
+	}
+	rows := max((ppm.height*cols+ppm.width/2)/ppm.width, 1)
+	small := ppm.Resize(cols, rows+rows%2, Box)
+	color := func(p Pixel) Pixel {
+		if small.max == 255 || small.max <= 0 {
+			return p
+		}
+		return Pixel{uint8(int(p.R) * 255 / small.max), uint8(int(p.G) * 255 / small.max), uint8(int(p.B) * 255 / small.max)}
+	}
+
+	writer := bufio.NewWriter(w)
+	for y := 0; y < small.height; y += 2 {
+		var fg, bg Pixel
+		for x := 0; x < small.width; x++ {
+			top, bottom := color(small.data[y][x]), color(small.data[y+1][x])
+			if x == 0 || top != fg {
+				fmt.Fprintf(writer, "\x1b[38;2;%d;%d;%dm", top.R, top.G, top.B)
+			}
+			if x == 0 || bottom != bg {
+				fmt.Fprintf(writer, "\x1b[48;2;%d;%d;%dm", bottom.R, bottom.G, bottom.B)
+			}
+			fg, bg = top, bottom
+			writer.WriteString("▀")
+		}
+		writer.WriteString("\x1b[0m\n")
+	}
+	return writer.Flush()
+}
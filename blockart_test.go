@@ -0,0 +1,39 @@
+package Netpbm // 🧪 Test Aperçu en couleurs pour terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToBlockArt(t *testing.T) {
+	// Moitié haute rouge, moitié basse bleue, deux fois plus large que haute
+	ppm := newUniformPPM(8, 4, Pixel{255, 0, 0})
+	for y := 2; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			ppm.Set(x, y, Pixel{0, 0, 255})
+		}
+	}
+	var out strings.Builder
+	if err := ppm.ToBlockArt(&out, 4); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m▀▀▀▀\x1b[0m\n"
+	if out.String() != want {
+		t.Errorf("ToBlockArt = %q, attendu %q", out.String(), want)
+	}
+}
+
+func TestToBlockArtMaxValue(t *testing.T) {
+	ppm := newUniformPPM(2, 2, Pixel{15, 0, 0})
+	ppm.max = 15
+	var out strings.Builder
+	if err := ppm.ToBlockArt(&out, 2); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "\x1b[38;2;255;0;0m") || strings.Count(out.String(), "\n") != 1 {
+		t.Errorf("ToBlockArt = %q", out.String())
+	}
+	if err := ppm.ToBlockArt(&out, 0); err == nil {
+		t.Error("une largeur nulle doit être refusée")
+	}
+}
@@ -0,0 +1,142 @@
+package Netpbm // ✨ Bruit bleu
+
+import (
+	"math"
+)
+
+// blueNoiseSigma est l'écart type du filtre gaussien utilisé par l'algorithme void-and-cluster.
+const blueNoiseSigma = 1.5
+
+// BlueNoiseMask génère un masque de bruit bleu size×size par l'algorithme void-and-cluster d'Ulichney.
+// Le masque est renvoyé sous forme d'image PGM (valeurs de 0 à 255) qui se répète sans raccord.
+// Seed initialise le motif aléatoire de départ : une même graine donne toujours le même masque.
+func BlueNoiseMask(size int, seed int64) *PGM {
+	if size <= 0 {
+		return NewPGM(0, 0, 255)
+	}
+	total := size * size
+
+	// Noyau gaussien torique : la distance est calculée en tenant compte du bouclage
+	kernel := make([]float64, total)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx := float64(min(x, size-x))
+			dy := float64(min(y, size-y))
+			kernel[y*size+x] = math.Exp(-(dx*dx + dy*dy) / (2 * blueNoiseSigma * blueNoiseSigma))
+		}
+	}
+
+	vc := &voidAndCluster{size: size, kernel: kernel, pattern: make([]bool, total), energy: make([]float64, total)}
+
+	// Motif initial : environ 10 % de pixels tirés au hasard
+	random := newRandom(seed)
+	ones := max(1, total/10)
+	for count := 0; count < ones; {
+		i := random.Intn(total)
+		if !vc.pattern[i] {
+			vc.toggle(i)
+			count++
+		}
+	}
+
+	// Déplacer le pixel le plus serré vers le plus grand vide jusqu'à stabilité
+	for iteration := 0; iteration < total; iteration++ {
+		cluster := vc.tightestCluster()
+		vc.toggle(cluster)
+		void := vc.largestVoid()
+		if void == cluster {
+			vc.toggle(cluster)
+			break
+		}
+		vc.toggle(void)
+	}
+	prototype := append([]bool(nil), vc.pattern...)
+	prototypeEnergy := append([]float64(nil), vc.energy...)
+
+	ranks := make([]int, total)
+
+	// Phase 1 : retirer les pixels du prototype, du plus serré au moins serré
+	for rank := ones - 1; rank >= 0; rank-- {
+		cluster := vc.tightestCluster()
+		vc.toggle(cluster)
+		ranks[cluster] = rank
+	}
+
+	// Phases 2 et 3 : remplir les plus grands vides jusqu'à saturation
+	copy(vc.pattern, prototype)
+	copy(vc.energy, prototypeEnergy)
+	for rank := ones; rank < total; rank++ {
+		void := vc.largestVoid()
+		vc.toggle(void)
+		ranks[void] = rank
+	}
+
+	mask := NewPGM(size, size, 255)
+	for i, rank := range ranks {
+		mask.data[i/size][i%size] = uint8(rank * 256 / total)
+	}
+	return mask
+}
+
+// voidAndCluster conserve l'état de l'algorithme void-and-cluster.
+type voidAndCluster struct {
+	size    int
+	kernel  []float64
+	pattern []bool    // Pixels actuellement allumés
+	energy  []float64 // Somme des contributions gaussiennes des pixels allumés
+}
+
+// toggle inverse le pixel i et met à jour l'énergie de toute la grille.
+func (vc *voidAndCluster) toggle(i int) {
+	sign := 1.0
+	if vc.pattern[i] {
+		sign = -1.0
+	}
+	vc.pattern[i] = !vc.pattern[i]
+	px, py := i%vc.size, i/vc.size
+	for y := 0; y < vc.size; y++ {
+		ky := (y - py + vc.size) % vc.size
+		for x := 0; x < vc.size; x++ {
+			kx := (x - px + vc.size) % vc.size
+			vc.energy[y*vc.size+x] += sign * vc.kernel[ky*vc.size+kx]
+		}
+	}
+}
+
+// tightestCluster renvoie le pixel allumé dont l'énergie est la plus forte.
+func (vc *voidAndCluster) tightestCluster() int {
+	best := -1
+	for i, on := range vc.pattern {
+		if on && (best < 0 || vc.energy[i] > vc.energy[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// largestVoid renvoie le pixel éteint dont l'énergie est la plus faible.
+func (vc *voidAndCluster) largestVoid() int {
+	best := -1
+	for i, on := range vc.pattern {
+		if !on && (best < 0 || vc.energy[i] < vc.energy[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// ToPBMMask convertit l'image PGM en PBM en comparant chaque pixel au masque de seuils donné,
+// répété en mosaïque sur toute l'image (par exemple un masque créé par BlueNoiseMask).
+func (pgm *PGM) ToPBMMask(mask *PGM) *PBM {
+	if mask == nil || mask.width == 0 || mask.height == 0 {
+		return pgm.ToPBM()
+	}
+	pbm := NewPBM(pgm.width, pgm.height)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			threshold := (float64(mask.data[y%mask.height][x%mask.width]) + 0.5) / float64(mask.max+1)
+			pbm.data[y][x] = float64(pgm.data[y][x])/float64(pgm.max) < threshold
+		}
+	}
+	return pbm
+}
@@ -0,0 +1,47 @@
+package Netpbm // 🧪 Test bruit bleu
+
+import "testing"
+
+func TestBlueNoiseMask(t *testing.T) {
+	mask := BlueNoiseMask(16, 1)
+	w, h := mask.Size()
+	if w != 16 || h != 16 {
+		t.Error("Wrong size")
+	}
+	// Avec 256 pixels, chaque niveau de gris apparaît exactement une fois
+	seen := make(map[uint8]bool)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			seen[mask.At(x, y)] = true
+		}
+	}
+	if len(seen) != 256 {
+		t.Errorf("Expected 256 distinct levels, got %d", len(seen))
+	}
+
+	other := BlueNoiseMask(16, 1)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if mask.At(x, y) != other.At(x, y) {
+				t.Fatal("Same seed should give the same mask")
+			}
+		}
+	}
+}
+
+func TestToPBMMask(t *testing.T) {
+	mask := BlueNoiseMask(16, 7)
+	pbm := newUniformPGM(32, 32, 64).ToPBMMask(mask)
+	if countBlack(pbm) != 768 {
+		t.Errorf("Wrong number of black pixels: %d", countBlack(pbm))
+	}
+	// Les pixels noirs ne doivent pas se toucher horizontalement à faible densité
+	pbm = newUniformPGM(16, 16, 240).ToPBMMask(mask)
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 15; x++ {
+			if pbm.data[y][x] && pbm.data[y][x+1] {
+				t.Errorf("Clustered black pixels at (%d, %d)", x, y)
+			}
+		}
+	}
+}
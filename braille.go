@@ -0,0 +1,38 @@
+package Netpbm // ✨ Aperçu en Braille
+
+import (
+	"bufio"
+	"io"
+)
+
+// brailleDots donne le bit du caractère Braille Unicode allumé par chaque pixel d'un bloc 2×4,
+// indexé par [y][x] : les points 1 à 6 occupent les trois premières lignes, les points 7 et 8 la dernière.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// ToBraille écrit l'image PBM sous forme de texte pour un terminal : chaque bloc de 2×4 pixels devient
+// un caractère Braille Unicode (U+2800 à U+28FF) dont les points levés sont les pixels noirs, ce qui
+// donne un aperçu quatre fois plus fin qu'un caractère par pixel. Chaque ligne de texte se termine par
+// un saut de ligne ; les blocs qui dépassent de l'image sont complétés par du blanc.
+func (pbm *PBM) ToBraille(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	for y := 0; y < pbm.height; y += 4 {
+		for x := 0; x < pbm.width; x += 2 {
+			char := rune(0x2800)
+			for dy := 0; dy < 4 && y+dy < pbm.height; dy++ {
+				for dx := 0; dx < 2 && x+dx < pbm.width; dx++ {
+					if pbm.data[y+dy][x+dx] {
+						char |= brailleDots[dy][dx]
+					}
+				}
+			}
+			writer.WriteRune(char)
+		}
+		writer.WriteByte('\n')
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,43 @@
+package Netpbm // 🧪 Test Aperçu en Braille
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestToBraille(t *testing.T) {
+	pbm := NewPBM(5, 6)
+	for x := 0; x < 5; x++ {
+		pbm.Set(x, 0, true) // Ligne du haut : points 1 et 4
+	}
+	pbm.Set(1, 3, true) // Point 8 du premier bloc
+	pbm.Set(4, 5, true) // Troisième bloc de la deuxième ligne, colonne gauche, ligne 2 : point 2
+
+	var out strings.Builder
+	if err := pbm.ToBraille(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := "⢉⠉⠁\n⠀⠀⠂\n"
+	if out.String() != want {
+		t.Errorf("ToBraille = %q, attendu %q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := NewPBM(0, 0).ToBraille(&out); err != nil || out.Len() != 0 {
+		t.Errorf("image vide : %q, %v", out.String(), err)
+	}
+}
+
+// failingWriter refuse toute écriture.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disque plein")
+}
+
+func TestToBrailleError(t *testing.T) {
+	if err := NewPBM(4, 4).ToBraille(failingWriter{}); err == nil {
+		t.Error("l'erreur d'écriture doit être renvoyée")
+	}
+}
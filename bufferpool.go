@@ -0,0 +1,63 @@
+package Netpbm // ✨ Réserve de tampons
+
+import "sync"
+
+// BufferPool garde les tampons de travail des filtres (convolution, rotation) pour les réutiliser d'un
+// appel à l'autre au lieu de les réallouer. Elle est utile quand on traite de nombreuses images de même
+// taille, comme les images d'une vidéo. Une même BufferPool peut servir à plusieurs goroutines.
+type BufferPool struct {
+	bytes, pixels sync.Pool
+}
+
+// NewBufferPool crée une réserve de tampons vide.
+func NewBufferPool() *BufferPool {
+	return &BufferPool{}
+}
+
+// getBytes renvoie un tampon de n octets au contenu quelconque. Une réserve nil alloue un nouveau tampon.
+func (p *BufferPool) getBytes(n int) []uint8 {
+	if p != nil {
+		if b, ok := p.bytes.Get().(*[]uint8); ok && cap(*b) >= n {
+			return (*b)[:n]
+		}
+	}
+	return make([]uint8, n)
+}
+
+// putBytes rend un tampon d'octets à la réserve.
+func (p *BufferPool) putBytes(b []uint8) {
+	if p != nil && cap(b) > 0 {
+		p.bytes.Put(&b)
+	}
+}
+
+// getPixels renvoie un tampon de n pixels au contenu quelconque. Une réserve nil alloue un nouveau tampon.
+func (p *BufferPool) getPixels(n int) []Pixel {
+	if p != nil {
+		if b, ok := p.pixels.Get().(*[]Pixel); ok && cap(*b) >= n {
+			return (*b)[:n]
+		}
+	}
+	return make([]Pixel, n)
+}
+
+// putPixels rend un tampon de pixels à la réserve.
+func (p *BufferPool) putPixels(b []Pixel) {
+	if p != nil && cap(b) > 0 {
+		p.pixels.Put(&b)
+	}
+}
+
+// WithBuffers fait puiser les tampons de travail des filtres de l'image PGM dans pool (nil pour
+// allouer à chaque appel, le comportement par défaut) et renvoie l'image.
+func (pgm *PGM) WithBuffers(pool *BufferPool) *PGM {
+	pgm.buffers = pool
+	return pgm
+}
+
+// WithBuffers fait puiser les tampons de travail des filtres de l'image PPM dans pool (nil pour
+// allouer à chaque appel, le comportement par défaut) et renvoie l'image.
+func (ppm *PPM) WithBuffers(pool *BufferPool) *PPM {
+	ppm.buffers = pool
+	return ppm
+}
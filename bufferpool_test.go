@@ -0,0 +1,42 @@
+package Netpbm // 🧪 Test réserve de tampons
+
+import "testing"
+
+func TestWithBuffersSameResult(t *testing.T) {
+	pool := NewBufferPool()
+	for i := 0; i < 3; i++ {
+		plain, pooled := newGradientPGM(20, 15), newGradientPGM(20, 15).WithBuffers(pool)
+		plain.Blur(1)
+		pooled.Blur(1)
+		plain.Rotate(30, 0)
+		pooled.Rotate(30, 0)
+		if plain.Hash() != pooled.Hash() {
+			t.Fatalf("Round %d: pooled buffers changed the result", i)
+		}
+
+		color, pooledColor := newPatternPPM(20, 15), newPatternPPM(20, 15).WithBuffers(pool)
+		color.Blur(2)
+		pooledColor.Blur(2)
+		if color.Hash() != pooledColor.Hash() {
+			t.Fatalf("Round %d: pooled buffers changed the color result", i)
+		}
+	}
+}
+
+func TestWithBuffersAllocations(t *testing.T) {
+	plain := newPatternPPM(64, 64)
+	pooled := newPatternPPM(64, 64).WithBuffers(NewBufferPool())
+	pooled.Blur(1)
+	without := testing.AllocsPerRun(10, func() { plain.Blur(1) })
+	with := testing.AllocsPerRun(10, func() { pooled.Blur(1) })
+	if with >= without {
+		t.Errorf("Expected fewer allocations with a pool: %v with, %v without", with, without)
+	}
+}
+
+func BenchmarkBlurPPMPooled(b *testing.B) {
+	ppm := newPatternPPM(640, 480).WithBuffers(NewBufferPool())
+	for i := 0; i < b.N; i++ {
+		ppm.Blur(1)
+	}
+}
@@ -0,0 +1,60 @@
+package Netpbm // ✨ Étalonnage par images de noir et de plage uniforme
+
+import "fmt"
+
+// Calibrate étalonne l'image PGM light (pose du sujet) avec une image de noir dark (même pose, obturateur
+// fermé : courant d'obscurité et pixels chauds) et une plage uniforme flat (pose d'une surface uniformément
+// éclairée : vignetage, poussières, différences de sensibilité entre pixels), toutes de même taille :
+//
+//	résultat = (light - dark) / ((flat - dark) / moyenne(flat - dark))
+//
+// La plage uniforme est normalisée par sa moyenne, si bien que le niveau moyen de l'image est conservé.
+// Dark ou flat peut être nil pour sauter l'étape correspondante. Les pixels où la plage uniforme n'est pas
+// plus claire que le noir (pixels morts) ne sont pas corrigés du vignetage. Les calculs se font en réels :
+// la soustraction du noir n'est écrêtée qu'à la fin. Dark et flat sont souvent des moyennes de plusieurs
+// poses, obtenues avec StackMean ou StackMedian.
+func Calibrate(light, dark, flat *PGM) (*PGM, error) {
+	for _, frame := range []*PGM{dark, flat} {
+		if frame != nil && (frame.width != light.width || frame.height != light.height) {
+			return nil, fmt.Errorf("size mismatch: %dx%d and %dx%d", light.width, light.height, frame.width, frame.height)
+		}
+	}
+	maxValue := float64(max(light.max, 1))
+	// value renvoie la valeur d'un pixel de frame ramenée à la valeur maximale de light (0 si frame est nil).
+	value := func(frame *PGM, x, y int) float64 {
+		if frame == nil {
+			return 0
+		}
+		return float64(frame.data[y][x]) * maxValue / float64(max(frame.max, 1))
+	}
+
+	// Moyenne de la plage uniforme, noir déduit
+	flatMean := 1.0
+	if flat != nil {
+		sum := 0.0
+		for y := 0; y < light.height; y++ {
+			for x := 0; x < light.width; x++ {
+				sum += value(flat, x, y) - value(dark, x, y)
+			}
+		}
+		flatMean = sum / float64(max(light.width*light.height, 1))
+		if flatMean <= 0 {
+			return nil, fmt.Errorf("flat frame is not brighter than the dark frame")
+		}
+	}
+
+	calibrated := NewPGM(light.width, light.height, light.max)
+	calibrated.magicNumber = light.magicNumber
+	for y := 0; y < light.height; y++ {
+		for x := 0; x < light.width; x++ {
+			v := float64(light.data[y][x]) - value(dark, x, y)
+			if flat != nil {
+				if gain := (value(flat, x, y) - value(dark, x, y)) / flatMean; gain > 0 {
+					v /= gain
+				}
+			}
+			calibrated.data[y][x] = clampChannel(v, light.max)
+		}
+	}
+	return calibrated, nil
+}
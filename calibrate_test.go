@@ -0,0 +1,80 @@
+package Netpbm // 🧪 Test Étalonnage par images de noir et de plage uniforme
+
+import "testing"
+
+func TestCalibrate(t *testing.T) {
+	// Capteur simulé : noir de 10 avec un pixel chaud, vignetage de moitié sur la colonne de droite
+	dark := newUniformPGM(4, 2, 10)
+	dark.Set(1, 0, 60)
+	flat := newUniformPGM(4, 2, 210)
+	flat.Set(3, 0, 110)
+	flat.Set(3, 1, 110)
+	flat.Set(1, 0, 250) // Le pixel chaud s'ajoute aussi à la plage uniforme
+
+	// Sujet uniforme de niveau 100 vu à travers le même capteur
+	light := newUniformPGM(4, 2, 110)
+	light.Set(3, 0, 60)
+	light.Set(3, 1, 60)
+	light.Set(1, 0, 160)
+
+	calibrated, err := Calibrate(light, dark, flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Moyenne de la plage uniforme, noir déduit : (5×200 + 190 + 2×100) / 8 = 173,75 ; le vignetage
+	// disparaît (100 / (200 / 173,75) = 50 / (100 / 173,75) ≈ 87) et le pixel chaud est corrigé
+	// (100 / (190 / 173,75) ≈ 91)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			want := uint8(87)
+			if x == 1 && y == 0 {
+				want = 91
+			}
+			if got := calibrated.At(x, y); got != want {
+				t.Errorf("pixel (%d, %d) = %d, attendu %d", x, y, got, want)
+			}
+		}
+	}
+	if light.At(3, 0) != 60 || dark.At(1, 0) != 60 {
+		t.Error("les images d'entrée ont été modifiées")
+	}
+}
+
+func TestCalibrateOptionalFrames(t *testing.T) {
+	light := newUniformPGM(3, 3, 50)
+	dark := newUniformPGM(3, 3, 80)
+
+	// Noir seul : la soustraction est écrêtée à 0
+	calibrated, err := Calibrate(light, dark, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calibrated.At(1, 1) != 0 {
+		t.Errorf("noir seul = %d, attendu 0", calibrated.At(1, 1))
+	}
+
+	// Plage uniforme seule, de valeur maximale différente
+	flat := NewPGM(3, 3, 15)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			flat.Set(x, y, 12)
+		}
+	}
+	flat.Set(0, 0, 6)
+	calibrated, err = Calibrate(light, nil, flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Moyenne de la plage : (8×12 + 6) / 9 = 11,33 sur 15 ; gain du pixel (0, 0) : 6 / 11,33, soit
+	// 50 / 0,53 ≈ 94
+	if got, want := calibrated.At(0, 0), uint8(94); got != want {
+		t.Errorf("pixel vigneté = %d, attendu %d", got, want)
+	}
+
+	if _, err := Calibrate(light, NewPGM(2, 3, 255), nil); err == nil {
+		t.Error("des images de tailles différentes doivent être refusées")
+	}
+	if _, err := Calibrate(light, dark, newUniformPGM(3, 3, 40)); err == nil {
+		t.Error("une plage uniforme plus sombre que le noir doit être refusée")
+	}
+}
@@ -0,0 +1,194 @@
+package Netpbm // ✨ Dessin enregistré et export SVG
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Canvas enregistre des commandes de dessin pour les rejouer sur une image PPM ou les exporter en SVG :
+// le même code de dessin produit ainsi une image matricielle et une image vectorielle.
+type Canvas struct {
+	width, height int
+	background    Pixel
+	commands      []drawCommand
+	selection     *PBM   // Pixels modifiables par les commandes suivantes (nil : tout le dessin).
+	selectionID   string // Identifiant SVG de la zone de découpe de la sélection.
+	clipPaths     int    // Nombre de zones de découpe déjà enregistrées.
+}
+
+// drawCommand est une commande de dessin enregistrée, avec son équivalent SVG. Une sélection est
+// enregistrée comme une commande sans dessin, qui ne fait que définir une zone de découpe SVG.
+type drawCommand struct {
+	draw func(ppm *PPM)
+	svg  string
+}
+
+// NewCanvas crée un dessin vide de width×height pixels, sur un fond de couleur background.
+func NewCanvas(width, height int, background Pixel) *Canvas {
+	return &Canvas{width: width, height: height, background: background}
+}
+
+// Size renvoie la largeur et la hauteur du dessin.
+func (c *Canvas) Size() (int, int) {
+	return c.width, c.height
+}
+
+// record ajoute une commande au dessin, limitée à la sélection en cours.
+func (c *Canvas) record(draw func(ppm *PPM), svg string, args ...any) {
+	svg = fmt.Sprintf(svg, args...)
+	if mask := c.selection; mask != nil {
+		inner := draw
+		draw = func(ppm *PPM) { drawSelected(ppm, mask, inner) }
+		if svg != "" {
+			svg = fmt.Sprintf(`<g clip-path="url(#%s)">%s</g>`, c.selectionID, svg)
+		}
+	}
+	c.commands = append(c.commands, drawCommand{draw, svg})
+}
+
+// svgColor renvoie la couleur au format SVG.
+func svgColor(color Pixel) string {
+	return fmt.Sprintf("rgb(%d,%d,%d)", color.R, color.G, color.B)
+}
+
+// svgPoints renvoie la liste de points au format SVG, au centre des pixels.
+func svgPoints(points []Point) string {
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = fmt.Sprintf("%g,%g", float64(p.X)+0.5, float64(p.Y)+0.5)
+	}
+	return strings.Join(parts, " ")
+}
+
+// DrawLine enregistre une ligne entre deux points.
+func (c *Canvas) DrawLine(p1, p2 Point, color Pixel) {
+	c.record(func(ppm *PPM) { ppm.DrawLine(p1, p2, color) },
+		`<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="%s" stroke-linecap="square"/>`,
+		float64(p1.X)+0.5, float64(p1.Y)+0.5, float64(p2.X)+0.5, float64(p2.Y)+0.5, svgColor(color))
+}
+
+// DrawRectangle enregistre un rectangle.
+func (c *Canvas) DrawRectangle(p1 Point, width, height int, color Pixel) {
+	c.record(func(ppm *PPM) { ppm.DrawRectangle(p1, width, height, color) },
+		`<rect x="%g" y="%g" width="%d" height="%d" fill="none" stroke="%s"/>`,
+		float64(p1.X)+0.5, float64(p1.Y)+0.5, width, height, svgColor(color))
+}
+
+// DrawFilledRectangle enregistre un rectangle rempli.
+func (c *Canvas) DrawFilledRectangle(p1 Point, width, height int, color Pixel) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	c.record(func(ppm *PPM) { ppm.DrawFilledRectangle(p1, width, height, color) },
+		`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
+		p1.X, p1.Y, width+1, height+1, svgColor(color))
+}
+
+// DrawCircle enregistre un cercle.
+func (c *Canvas) DrawCircle(center Point, radius int, color Pixel) {
+	c.record(func(ppm *PPM) { ppm.DrawCircle(center, radius, color) },
+		`<circle cx="%g" cy="%g" r="%g" fill="none" stroke="%s"/>`,
+		float64(center.X)+0.5, float64(center.Y)+0.5, float64(radius)-0.5, svgColor(color))
+}
+
+// DrawFilledCircle enregistre un cercle rempli.
+func (c *Canvas) DrawFilledCircle(center Point, radius int, color Pixel) {
+	c.record(func(ppm *PPM) { ppm.DrawFilledCircle(center, radius, color) },
+		`<circle cx="%g" cy="%g" r="%d" fill="%s"/>`,
+		float64(center.X)+0.5, float64(center.Y)+0.5, radius, svgColor(color))
+}
+
+// DrawTriangle enregistre un triangle.
+func (c *Canvas) DrawTriangle(p1, p2, p3 Point, color Pixel) {
+	c.DrawPolygon([]Point{p1, p2, p3}, color)
+}
+
+// DrawFilledTriangle enregistre un triangle rempli.
+func (c *Canvas) DrawFilledTriangle(p1, p2, p3 Point, color Pixel) {
+	c.record(func(ppm *PPM) { ppm.DrawFilledTriangle(p1, p2, p3, color) },
+		`<polygon points="%s" fill="%[2]s" stroke="%[2]s"/>`, svgPoints([]Point{p1, p2, p3}), svgColor(color))
+}
+
+// DrawPolygon enregistre un polygone.
+func (c *Canvas) DrawPolygon(points []Point, color Pixel) {
+	points = append([]Point(nil), points...)
+	c.record(func(ppm *PPM) { ppm.DrawPolygon(points, color) },
+		`<polygon points="%s" fill="none" stroke="%s"/>`, svgPoints(points), svgColor(color))
+}
+
+// DrawFilledPolygon enregistre un polygone rempli.
+func (c *Canvas) DrawFilledPolygon(points []Point, color Pixel) {
+	points = append([]Point(nil), points...)
+	c.record(func(ppm *PPM) { ppm.DrawFilledPolygon(points, color) },
+		`<polygon points="%s" fill="%[2]s" stroke="%[2]s"/>`, svgPoints(points), svgColor(color))
+}
+
+// DrawPolylineStroke enregistre une ligne brisée ouverte tracée selon options.
+// Les marqueurs d'extrémité ne sont dessinés que sur l'image PPM.
+func (c *Canvas) DrawPolylineStroke(points []Point, color Pixel, options StrokeOptions) {
+	points = append([]Point(nil), points...)
+	options.Dash = append([]int(nil), options.Dash...)
+	dash := ""
+	if len(options.Dash) > 0 {
+		parts := make([]string, len(options.Dash))
+		for i, d := range options.Dash {
+			parts[i] = fmt.Sprint(d)
+		}
+		dash = fmt.Sprintf(` stroke-dasharray="%s"`, strings.Join(parts, " "))
+	}
+	c.record(func(ppm *PPM) { ppm.DrawPolylineStroke(points, color, options) },
+		`<polyline points="%s" fill="none" stroke="%s" stroke-width="%d"%s/>`,
+		svgPoints(points), svgColor(color), max(options.Width, 1), dash)
+}
+
+// Replay rejoue toutes les commandes enregistrées sur l'image PPM.
+func (c *Canvas) Replay(ppm *PPM) {
+	for _, command := range c.commands {
+		if command.draw != nil {
+			command.draw(ppm)
+		}
+	}
+}
+
+// ToPPM renvoie le dessin sous forme d'image PPM.
+func (c *Canvas) ToPPM() *PPM {
+	ppm := NewPPM(c.width, c.height, 255)
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			ppm.data[y][x] = c.background
+		}
+	}
+	c.Replay(ppm)
+	return ppm
+}
+
+// WriteSVG écrit le dessin sous forme de document SVG.
+func (c *Canvas) WriteSVG(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(writer, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d">`+"\n", c.width, c.height)
+	fmt.Fprintf(writer, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColor(c.background))
+	for _, command := range c.commands {
+		if command.svg != "" {
+			fmt.Fprintln(writer, command.svg)
+		}
+	}
+	fmt.Fprintln(writer, "</svg>")
+	return writer.Flush()
+}
+
+// SaveSVG enregistre le dessin dans un fichier SVG.
+func (c *Canvas) SaveSVG(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := c.WriteSVG(file); err != nil {
+		return fmt.Errorf("error writing SVG: %v", err)
+	}
+	return nil
+}
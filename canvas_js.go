@@ -0,0 +1,47 @@
+//go:build js && wasm
+
+package Netpbm // ✨ Pont vers le canevas HTML
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// ToImageData copie l'image PPM dans un nouvel objet ImageData, prêt pour putImageData. Hors navigateur
+// (Node.js), où ImageData n'existe pas, elle renvoie un objet {width, height, data} de même forme.
+func (ppm *PPM) ToImageData() js.Value {
+	pix := ppm.RGBA()
+	data := js.Global().Get("Uint8ClampedArray").New(len(pix))
+	js.CopyBytesToJS(data, pix)
+	if constructor := js.Global().Get("ImageData"); constructor.Truthy() {
+		return constructor.New(data, ppm.width, ppm.height)
+	}
+	object := js.Global().Get("Object").New()
+	object.Set("width", ppm.width)
+	object.Set("height", ppm.height)
+	object.Set("data", data)
+	return object
+}
+
+// PPMFromImageData crée une image PPM à partir d'un ImageData, obtenu par exemple avec getImageData.
+func PPMFromImageData(imageData js.Value) (*PPM, error) {
+	width, height := imageData.Get("width").Int(), imageData.Get("height").Int()
+	pix := make([]byte, imageData.Get("data").Length())
+	if js.CopyBytesToGo(pix, imageData.Get("data")) != len(pix) {
+		return nil, fmt.Errorf("error copying ImageData pixels")
+	}
+	return PPMFromRGBA(pix, width, height)
+}
+
+// DrawToCanvas dessine l'image PPM dans l'élément <canvas> canvas, en le redimensionnant à sa taille.
+func (ppm *PPM) DrawToCanvas(canvas js.Value) {
+	canvas.Set("width", ppm.width)
+	canvas.Set("height", ppm.height)
+	canvas.Call("getContext", "2d").Call("putImageData", ppm.ToImageData(), 0, 0)
+}
+
+// ReadCanvas lit le contenu de l'élément <canvas> canvas dans une nouvelle image PPM.
+func ReadCanvas(canvas js.Value) (*PPM, error) {
+	width, height := canvas.Get("width").Int(), canvas.Get("height").Int()
+	return PPMFromImageData(canvas.Call("getContext", "2d").Call("getImageData", 0, 0, width, height))
+}
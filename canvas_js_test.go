@@ -0,0 +1,20 @@
+//go:build js && wasm
+
+package Netpbm // 🧪 Test pont vers le canevas HTML
+
+import "testing"
+
+func TestImageDataRoundTrip(t *testing.T) {
+	ppm := newPatternPPM(7, 5)
+	imageData := ppm.ToImageData()
+	if imageData.Get("width").Int() != 7 || imageData.Get("data").Length() != 7*5*4 {
+		t.Fatal("Unexpected ImageData shape")
+	}
+	back, err := PPMFromImageData(imageData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Hash() != ppm.Hash() {
+		t.Error("The round trip should keep the pixels")
+	}
+}
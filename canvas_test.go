@@ -0,0 +1,73 @@
+package Netpbm // 🧪 Test dessin enregistré et export SVG
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCanvasReplay(t *testing.T) {
+	red, blue := Pixel{255, 0, 0}, Pixel{0, 0, 255}
+	canvas := NewCanvas(20, 20, Pixel{255, 255, 255})
+	canvas.DrawLine(Point{0, 0}, Point{19, 19}, red)
+	canvas.DrawFilledRectangle(Point{2, 10}, 4, 4, blue)
+	canvas.DrawPolygon([]Point{{10, 2}, {18, 2}, {14, 8}}, red)
+
+	// Le même dessin fait directement sur une image PPM
+	expected := newUniformPPM(20, 20, Pixel{255, 255, 255})
+	expected.DrawLine(Point{0, 0}, Point{19, 19}, red)
+	expected.DrawFilledRectangle(Point{2, 10}, 4, 4, blue)
+	expected.DrawPolygon([]Point{{10, 2}, {18, 2}, {14, 8}}, red)
+
+	ppm := canvas.ToPPM()
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if ppm.At(x, y) != expected.At(x, y) {
+				t.Fatalf("Pixel (%d, %d) differs from direct drawing", x, y)
+			}
+		}
+	}
+}
+
+func TestCanvasSVG(t *testing.T) {
+	canvas := NewCanvas(30, 20, Pixel{0, 0, 0})
+	canvas.DrawLine(Point{0, 0}, Point{10, 5}, Pixel{255, 0, 0})
+	canvas.DrawFilledCircle(Point{15, 10}, 4, Pixel{0, 128, 0})
+	canvas.DrawFilledTriangle(Point{0, 19}, Point{5, 10}, Point{10, 19}, Pixel{1, 2, 3})
+	canvas.DrawPolylineStroke([]Point{{0, 0}, {5, 5}, {9, 1}}, Pixel{9, 9, 9}, StrokeOptions{Width: 3, Dash: []int{4, 2}})
+
+	var svg strings.Builder
+	if err := canvas.WriteSVG(&svg); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`width="30" height="20" viewBox="0 0 30 20"`,
+		`<rect width="100%" height="100%" fill="rgb(0,0,0)"/>`,
+		`<line x1="0.5" y1="0.5" x2="10.5" y2="5.5" stroke="rgb(255,0,0)"`,
+		`<circle cx="15.5" cy="10.5" r="4" fill="rgb(0,128,0)"/>`,
+		`<polygon points="0.5,19.5 5.5,10.5 10.5,19.5" fill="rgb(1,2,3)" stroke="rgb(1,2,3)"/>`,
+		`stroke-width="3" stroke-dasharray="4 2"/>`,
+		"</svg>",
+	} {
+		if !strings.Contains(svg.String(), want) {
+			t.Errorf("SVG should contain %q:\n%s", want, svg.String())
+		}
+	}
+}
+
+func TestCanvasSaveSVG(t *testing.T) {
+	canvas := NewCanvas(4, 4, Pixel{255, 255, 255})
+	canvas.DrawRectangle(Point{0, 0}, 3, 3, Pixel{0, 0, 0})
+	filename := "./testImages/canvas.svg"
+	if err := canvas.SaveSVG(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `<rect x="0.5" y="0.5" width="3" height="3" fill="none" stroke="rgb(0,0,0)"/>`) {
+		t.Errorf("Unexpected SVG file:\n%s", content)
+	}
+}
@@ -0,0 +1,137 @@
+package Netpbm // ✨ Export en tableau C ou Go
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CArrayOptions règle l'export de ToCArray.
+type CArrayOptions struct {
+	Go       bool // Écrire un tableau Go plutôt qu'un tableau C
+	LSBFirst bool // Premier pixel de chaque octet dans le bit de poids faible (sinon de poids fort)
+	PadRows  bool // Chaque ligne commence sur un nouvel octet (sinon les pixels se suivent sans interruption)
+	Invert   bool // Bits à 1 pour les pixels blancs (PBM) ou niveaux inversés (PGM), comme sur les écrans OLED
+	Depth    int  // Bits par pixel d'une image PGM : 1, 2, 4 ou 8 (une image PBM a toujours 1 bit par pixel)
+	PerLine  int  // Nombre d'octets par ligne de texte (12 si nul)
+}
+
+// DefaultCArrayOptions écrit un tableau C, 8 pixels par octet en commençant par le bit de poids fort,
+// chaque ligne de l'image commençant sur un nouvel octet, comme le format P4.
+var DefaultCArrayOptions = CArrayOptions{PadRows: true, Depth: 8, PerLine: 12}
+
+// ToCArray écrit l'image PBM sous forme de tableau d'octets C (ou Go) nommé name, avec sa largeur et sa
+// hauteur, pour l'inclure dans le micrologiciel d'un microcontrôleur (écran de démarrage par exemple).
+// Un bit à 1 est un pixel noir, sauf si options.Invert est vrai.
+func (pbm *PBM) ToCArray(w io.Writer, name string, options CArrayOptions) error {
+	options.Depth = 1
+	return writeCArray(w, name, pbm.width, pbm.height, func(x, y int) uint8 {
+		if pbm.data[y][x] != options.Invert {
+			return 1
+		}
+		return 0
+	}, options)
+}
+
+// ToCArray écrit l'image PGM sous forme de tableau d'octets C (ou Go) nommé name, chaque pixel étant
+// ramené sur options.Depth bits (0 pour le noir, sauf si options.Invert est vrai). Avec 1 bit par pixel,
+// les pixels sombres valent 1 comme dans une image PBM.
+func (pgm *PGM) ToCArray(w io.Writer, name string, options CArrayOptions) error {
+	switch options.Depth {
+	case 1, 2, 4, 8:
+	default:
+		return fmt.Errorf("invalid depth %d bits per pixel (want 1, 2, 4 or 8)", options.Depth)
+	}
+	levels := 1<<options.Depth - 1
+	return writeCArray(w, name, pgm.width, pgm.height, func(x, y int) uint8 {
+		v := (int(pgm.data[y][x])*levels + max(pgm.max, 1)/2) / max(pgm.max, 1)
+		if options.Depth == 1 {
+			v = 1 - v // Comme en PBM : 1 pour un pixel sombre
+		}
+		if options.Invert {
+			v = levels - v
+		}
+		return uint8(v)
+	}, options)
+}
+
+// writeCArray écrit le tableau d'une image width×height dont value donne la valeur de chaque pixel, sur
+// options.Depth bits.
+func writeCArray(w io.Writer, name string, width, height int, value func(x, y int) uint8, options CArrayOptions) error {
+	if !isIdentifier(name) {
+		return fmt.Errorf("invalid array name %q", name)
+	}
+	depth := options.Depth
+
+	// Regroupement des pixels en octets
+	var data []byte
+	bit := 0 // Position du prochain pixel dans l'octet en cours, comptée depuis le premier pixel
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if bit == 0 {
+				data = append(data, 0)
+			}
+			shift := 8 - depth - bit
+			if options.LSBFirst {
+				shift = bit
+			}
+			data[len(data)-1] |= value(x, y) << shift
+			bit = (bit + depth) % 8
+		}
+		if options.PadRows {
+			bit = 0
+		}
+	}
+
+	order := "MSB first"
+	if options.LSBFirst {
+		order = "LSB first"
+	}
+	layout := "rows padded to whole bytes"
+	if !options.PadRows {
+		layout = "rows packed"
+	}
+	perLine := options.PerLine
+	if perLine <= 0 {
+		perLine = 12
+	}
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "// %s: %dx%d pixels, %d bit(s) per pixel, %s, %s\n", name, width, height, depth, order, layout)
+	if options.Go {
+		fmt.Fprintf(writer, "const (\n\t%sWidth  = %d\n\t%[1]sHeight = %[3]d\n)\n\n", name, width, height)
+		fmt.Fprintf(writer, "var %s = [%d]byte{\n", name, len(data))
+	} else {
+		upper := strings.ToUpper(name)
+		fmt.Fprintf(writer, "#define %s_WIDTH %d\n#define %[1]s_HEIGHT %[3]d\n\n", upper, width, height)
+		fmt.Fprintf(writer, "const unsigned char %s[%d] = {\n", name, len(data))
+	}
+	for i := 0; i < len(data); i += perLine {
+		writer.WriteString("\t")
+		for j, b := range data[i:min(i+perLine, len(data))] {
+			if j > 0 {
+				writer.WriteString(" ")
+			}
+			fmt.Fprintf(writer, "0x%02x,", b)
+		}
+		writer.WriteString("\n")
+	}
+	if options.Go {
+		writer.WriteString("}\n")
+	} else {
+		writer.WriteString("};\n")
+	}
+	return writer.Flush()
+}
+
+// isIdentifier indique si name est un identifiant valide en C comme en Go (lettres ASCII, chiffres et
+// soulignés, sans chiffre au début).
+func isIdentifier(name string) bool {
+	for i, c := range name {
+		letter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !letter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,84 @@
+package Netpbm // 🧪 Test Export en tableau C ou Go
+
+import (
+	"strings"
+	"testing"
+)
+
+// newCArrayTestPBM renvoie une image 10×2 dont la première ligne est noire sur ses trois premiers
+// pixels et la seconde sur son dernier.
+func newCArrayTestPBM() *PBM {
+	pbm := NewPBM(10, 2)
+	for x := 0; x < 3; x++ {
+		pbm.Set(x, 0, true)
+	}
+	pbm.Set(9, 1, true)
+	return pbm
+}
+
+func TestPBMToCArray(t *testing.T) {
+	var out strings.Builder
+	if err := newCArrayTestPBM().ToCArray(&out, "splash", DefaultCArrayOptions); err != nil {
+		t.Fatal(err)
+	}
+	want := "// splash: 10x2 pixels, 1 bit(s) per pixel, MSB first, rows padded to whole bytes\n" +
+		"#define SPLASH_WIDTH 10\n#define SPLASH_HEIGHT 2\n\n" +
+		"const unsigned char splash[4] = {\n\t0xe0, 0x00, 0x00, 0x40,\n};\n"
+	if out.String() != want {
+		t.Errorf("ToCArray =\n%s\nattendu\n%s", out.String(), want)
+	}
+}
+
+func TestPBMToCArrayOptions(t *testing.T) {
+	tests := []struct {
+		options CArrayOptions
+		want    string
+	}{
+		{CArrayOptions{LSBFirst: true, PadRows: true}, "0x07, 0x00, 0x00, 0x02,"},
+		{CArrayOptions{}, "0xe0, 0x00, 0x10,"}, // Sans remplissage : 20 bits sur 3 octets
+		{CArrayOptions{PadRows: true, Invert: true}, "0x1f, 0xc0, 0xff, 0x80,"},
+		{CArrayOptions{PadRows: true, PerLine: 2}, "\t0xe0, 0x00,\n\t0x00, 0x40,\n"},
+	}
+	for _, test := range tests {
+		var out strings.Builder
+		if err := newCArrayTestPBM().ToCArray(&out, "splash", test.options); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), test.want) {
+			t.Errorf("options %+v :\n%s\nattendu %q", test.options, out.String(), test.want)
+		}
+	}
+}
+
+func TestPGMToCArray(t *testing.T) {
+	pgm := NewPGM(3, 1, 255)
+	pgm.Set(0, 0, 0)
+	pgm.Set(1, 0, 128)
+	pgm.Set(2, 0, 255)
+
+	var out strings.Builder
+	options := CArrayOptions{Go: true, PadRows: true, Depth: 4, PerLine: 12}
+	if err := pgm.ToCArray(&out, "logo", options); err != nil {
+		t.Fatal(err)
+	}
+	want := "// logo: 3x1 pixels, 4 bit(s) per pixel, MSB first, rows padded to whole bytes\n" +
+		"const (\n\tlogoWidth  = 3\n\tlogoHeight = 1\n)\n\n" +
+		"var logo = [2]byte{\n\t0x08, 0xf0,\n}\n"
+	if out.String() != want {
+		t.Errorf("ToCArray =\n%s\nattendu\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	options = DefaultCArrayOptions
+	options.Depth = 1
+	if err := pgm.ToCArray(&out, "logo", options); err != nil || !strings.Contains(out.String(), "0x80,") {
+		t.Errorf("1 bit par pixel : %v\n%s", err, out.String())
+	}
+	options.Depth = 3
+	if err := pgm.ToCArray(&out, "logo", options); err == nil {
+		t.Error("une profondeur de 3 bits doit être refusée")
+	}
+	if err := pgm.ToCArray(&out, "2logo", DefaultCArrayOptions); err == nil {
+		t.Error("un nom invalide doit être refusé")
+	}
+}
@@ -0,0 +1,41 @@
+package Netpbm // ✨ Remplacement de couleur et incrustation
+
+// colorDistance renvoie le plus grand écart entre les canaux de deux pixels.
+func colorDistance(a, b Pixel) int {
+	return max(abs(int(a.R)-int(b.R)), abs(int(a.G)-int(b.G)), abs(int(a.B)-int(b.B)))
+}
+
+// ReplaceColor remplace par to tous les pixels de l'image PPM dont aucun canal ne s'écarte
+// de plus de tolerance de la couleur from.
+func (ppm *PPM) ReplaceColor(from, to Pixel, tolerance uint8) {
+	ppm.own()
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			if colorDistance(ppm.data[y][x], from) <= int(tolerance) {
+				ppm.data[y][x] = to
+			}
+		}
+	}
+}
+
+// ChromaKey renvoie le masque d'opacité de l'image PPM pour une incrustation sur fond uni (fond vert) :
+// les pixels à moins de tolerance de la couleur key sont transparents (0), ceux à plus de
+// tolerance+softness sont opaques (255), et l'opacité augmente progressivement entre les deux.
+// Le masque peut être passé à DrawImageTransformed ou à Blend.
+func (ppm *PPM) ChromaKey(key Pixel, tolerance, softness uint8) *PGM {
+	mask := NewPGM(ppm.width, ppm.height, 255)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			d := colorDistance(ppm.data[y][x], key) - int(tolerance)
+			switch {
+			case d <= 0:
+				mask.data[y][x] = 0
+			case d >= int(softness):
+				mask.data[y][x] = 255
+			default:
+				mask.data[y][x] = uint8(d * 255 / int(softness))
+			}
+		}
+	}
+	return mask
+}
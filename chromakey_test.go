@@ -0,0 +1,37 @@
+package Netpbm // 🧪 Test remplacement de couleur et incrustation
+
+import "testing"
+
+func TestReplaceColor(t *testing.T) {
+	ppm := NewPPM(3, 1, 255)
+	ppm.data[0] = []Pixel{{10, 200, 10}, {20, 190, 5}, {60, 200, 10}}
+	ppm.ReplaceColor(Pixel{10, 200, 10}, Pixel{255, 0, 0}, 12)
+	if ppm.At(0, 0) != (Pixel{255, 0, 0}) || ppm.At(1, 0) != (Pixel{255, 0, 0}) {
+		t.Error("Close colors should be replaced")
+	}
+	if ppm.At(2, 0) != (Pixel{60, 200, 10}) {
+		t.Error("Distant color should be kept")
+	}
+}
+
+func TestChromaKey(t *testing.T) {
+	ppm := NewPPM(4, 1, 255)
+	ppm.data[0] = []Pixel{{0, 255, 0}, {20, 235, 0}, {40, 215, 0}, {200, 50, 50}}
+	mask := ppm.ChromaKey(Pixel{0, 255, 0}, 20, 40)
+	expected := []uint8{0, 0, 127, 255}
+	for x, e := range expected {
+		if mask.At(x, 0) != e {
+			t.Errorf("Pixel %d: expected opacity %d, got %d", x, e, mask.At(x, 0))
+		}
+	}
+}
+
+func TestChromaKeyComposite(t *testing.T) {
+	foreground := newUniformPPM(4, 4, Pixel{0, 255, 0})
+	foreground.data[1][2] = Pixel{255, 0, 0}
+	background := newUniformPPM(4, 4, Pixel{0, 0, 255})
+	background.DrawImageTransformed(foreground, IdentityTransform(), NearestNeighbor, foreground.ChromaKey(Pixel{0, 255, 0}, 30, 0))
+	if background.At(2, 1) != (Pixel{255, 0, 0}) || countColor(background, Pixel{0, 0, 255}) != 15 {
+		t.Error("Only the subject should be composited over the background")
+	}
+}
@@ -0,0 +1,45 @@
+package Netpbm // ✨ Cartes de couleurs
+
+import "sort"
+
+// Colormap associe une couleur à chaque valeur entre 0 et 1, par interpolation entre des arrêts
+// triés par position croissante.
+type Colormap []ColorStop
+
+// Lookup renvoie la couleur de la valeur t, ramenée entre 0 et 1.
+func (c Colormap) Lookup(t float64) Pixel {
+	if len(c) == 0 {
+		return Pixel{}
+	}
+	return gradientAt(c, min(max(t, 0), 1))
+}
+
+// NewColormap crée une carte de couleurs à partir d'arrêts donnés dans n'importe quel ordre.
+// Avant le premier arrêt et après le dernier, la couleur est constante.
+func NewColormap(stops ...ColorStop) Colormap {
+	c := append(Colormap(nil), stops...)
+	sort.SliceStable(c, func(i, j int) bool { return c[i].Position < c[j].Position })
+	return c
+}
+
+// evenStops crée une carte de couleurs dont les arrêts, donnés en hexadécimal, sont régulièrement espacés.
+func evenStops(colors ...uint32) Colormap {
+	c := make(Colormap, len(colors))
+	for i, rgb := range colors {
+		c[i] = ColorStop{float64(i) / float64(len(colors)-1), Pixel{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb)}}
+	}
+	return c
+}
+
+// Cartes de couleurs prédéfinies. Viridis, Magma, Inferno et Cividis sont perçues de façon uniforme et
+// restent lisibles en niveaux de gris, Cividis l'étant aussi pour les daltoniens ; Turbo est un arc-en-ciel
+// plus régulier que Jet, l'arc-en-ciel classique.
+var (
+	Viridis   = evenStops(0x440154, 0x472c7a, 0x3b518b, 0x2c718e, 0x21908d, 0x27ad81, 0x5cc863, 0xaadc32, 0xfde725)
+	Magma     = evenStops(0x000004, 0x1c1044, 0x4f127b, 0x812581, 0xb5367a, 0xe55064, 0xfb8761, 0xfec287, 0xfcfdbf)
+	Inferno   = evenStops(0x000004, 0x1f0c48, 0x550f6d, 0x88226a, 0xba3655, 0xe35933, 0xf98e09, 0xf8c932, 0xfcffa4)
+	Cividis   = evenStops(0x00204c, 0x01356e, 0x404c6b, 0x5f636e, 0x7c7b78, 0x9a9377, 0xbcaf6f, 0xdfcb5d, 0xfee838)
+	Turbo     = evenStops(0x30123b, 0x4458cb, 0x3e9bfe, 0x18d6cb, 0x46f884, 0xa2fc3c, 0xe1dd37, 0xfea431, 0xf05b12, 0xc42503, 0x7a0403)
+	Jet       = Colormap{{0, Pixel{0, 0, 143}}, {0.125, Pixel{0, 0, 255}}, {0.375, Pixel{0, 255, 255}}, {0.625, Pixel{255, 255, 0}}, {0.875, Pixel{255, 0, 0}}, {1, Pixel{128, 0, 0}}}
+	Grayscale = evenStops(0x000000, 0xffffff)
+)
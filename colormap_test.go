@@ -0,0 +1,41 @@
+package Netpbm // 🧪 Test cartes de couleurs
+
+import "testing"
+
+func TestColormapLookup(t *testing.T) {
+	if Grayscale.Lookup(0.5) != (Pixel{128, 128, 128}) {
+		t.Errorf("Unexpected middle gray %v", Grayscale.Lookup(0.5))
+	}
+	if Viridis.Lookup(-1) != (Pixel{0x44, 0x01, 0x54}) || Viridis.Lookup(2) != (Pixel{0xfd, 0xe7, 0x25}) {
+		t.Error("Values outside 0..1 should be clamped to the end colors")
+	}
+	if Jet.Lookup(0.5) != (Pixel{128, 255, 128}) {
+		t.Errorf("Unexpected jet middle %v", Jet.Lookup(0.5))
+	}
+	if (Colormap{}).Lookup(0.5) != (Pixel{}) {
+		t.Error("An empty colormap should give black")
+	}
+}
+
+func TestNewColormap(t *testing.T) {
+	colormap := NewColormap(ColorStop{1, Pixel{255, 0, 0}}, ColorStop{0.5, Pixel{0, 0, 255}})
+	if colormap[0].Position != 0.5 {
+		t.Error("Stops should be sorted by position")
+	}
+	if colormap.Lookup(0.2) != (Pixel{0, 0, 255}) || colormap.Lookup(0.75) != (Pixel{128, 0, 128}) {
+		t.Errorf("Unexpected colors %v %v", colormap.Lookup(0.2), colormap.Lookup(0.75))
+	}
+	for _, c := range []Colormap{Inferno, Turbo, Cividis} {
+		if c[0].Position != 0 || c[len(c)-1].Position != 1 {
+			t.Error("Built-in colormaps should cover 0..1")
+		}
+	}
+}
+
+func TestDrawPerlinNoiseColormap(t *testing.T) {
+	ppm := NewPPM(4, 4, 255)
+	ppm.DrawPerlinNoiseColormap(Viridis, 1)
+	if ppm.At(0, 0) != Viridis.Lookup(1) {
+		t.Errorf("Unexpected color %v at the origin", ppm.At(0, 0))
+	}
+}
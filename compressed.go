@@ -0,0 +1,57 @@
+package Netpbm // ✨ Fichiers compressés
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// compressedFile lit un fichier en le décompressant à la volée.
+type compressedFile struct {
+	io.Reader
+	file   *os.File
+	closer io.Closer // Décompresseur à fermer avant le fichier, s'il en a besoin
+}
+
+func (c *compressedFile) Close() error {
+	if c.closer != nil {
+		c.closer.Close()
+	}
+	return c.file.Close()
+}
+
+// openImage ouvre un fichier image en reconnaissant, d'après ses premiers octets, une compression gzip
+// (.gz) ou bzip2 (.bz2), ou le conteneur compressé PZ, qui est alors retirée à la lecture. Les autres
+// fichiers sont lus tels quels.
+// Toutes les fonctions ReadPBM, ReadPGM, ReadPPM et ReadNetpbm lisent ainsi les fichiers compressés.
+func openImage(filename string) (io.ReadCloser, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(file)
+	magic, _ := reader.Peek(3)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		decompressor, err := gzip.NewReader(reader)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("error reading gzip header: %v", err)
+		}
+		return &compressedFile{decompressor, file, decompressor}, nil
+	case bytes.Equal(magic, []byte("BZh")):
+		return &compressedFile{bzip2.NewReader(reader), file, nil}, nil
+	case isCompressedMagic(magic):
+		decompressor, err := decompressContainer(reader)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &compressedFile{decompressor, file, decompressor}, nil
+	}
+	return &compressedFile{reader, file, nil}, nil
+}
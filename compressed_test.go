@@ -0,0 +1,72 @@
+package Netpbm // 🧪 Test fichiers compressés
+
+import (
+	"compress/gzip"
+	"os"
+	"testing"
+)
+
+// bzip2PGM est l'image PGM 2x2 "P2\n2 2\n255\n0 64\n128 255\n" compressée par bzip2 -9.
+var bzip2PGM = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xc0, 0x98,
+	0x18, 0x0e, 0x00, 0x00, 0x0b, 0xda, 0x00, 0x00, 0x10, 0x40, 0x00, 0x77,
+	0x40, 0x40, 0x00, 0x20, 0x00, 0x31, 0x00, 0x30, 0x12, 0xa7, 0xa4, 0x6d,
+	0x4f, 0x43, 0x6c, 0xa5, 0x1d, 0x99, 0xfa, 0x14, 0xc8, 0x43, 0xca, 0xf4,
+	0x5d, 0xc9, 0x14, 0xe1, 0x42, 0x43, 0x02, 0x60, 0x60, 0x38,
+}
+
+func TestReadGzip(t *testing.T) {
+	ppm := newPatternPPM(9, 7)
+	ppm.SetMagicNumber("P6")
+	filename := "./testImages/ppm/pattern.ppm"
+	if err := ppm.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	content, _ := os.ReadFile(filename)
+
+	file, err := os.Create(filename + ".gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename + ".gz")
+	writer := gzip.NewWriter(file)
+	writer.Write(content)
+	writer.Close()
+	file.Close()
+
+	read, err := ReadPPM(filename + ".gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Hash() != ppm.Hash() {
+		t.Error("The gzip file should decode to the same image")
+	}
+	if img, err := ReadNetpbm(filename + ".gz"); err != nil {
+		t.Error(err)
+	} else if _, ok := img.(*PPM); !ok {
+		t.Errorf("Expected a PPM, got %T", img)
+	}
+
+	// Un flux gzip tronqué donne une image partielle
+	compressed, _ := os.ReadFile(filename + ".gz")
+	os.WriteFile(filename+".gz", compressed[:len(compressed)/2], 0644)
+	if _, err := ReadPPMRecover(filename+".gz", Pixel{}); err == nil {
+		t.Error("Expected an error for a truncated gzip stream")
+	}
+}
+
+func TestReadBzip2(t *testing.T) {
+	filename := "./testImages/pgm/small.pgm.bz2"
+	if err := os.WriteFile(filename, bzip2PGM, 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	pgm, err := ReadPGM(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm.At(1, 0) != 64 || pgm.At(0, 1) != 128 || pgm.At(1, 1) != 255 {
+		t.Errorf("Unexpected pixels %v", pgm.data)
+	}
+}
@@ -0,0 +1,84 @@
+package Netpbm // ✨ Planche contact
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Mise en page de ContactSheet, en pixels
+const (
+	contactMargin = 8 // Marge autour de chaque vignette
+	contactGap    = 4 // Espace entre une vignette et sa légende
+)
+
+// ContactSheet lit les images Netpbm files et les dispose en planche contact de cols colonnes, chaque
+// vignette tenant dans un carré de thumbSize pixels de côté, centrée dans sa case sur un fond gris clair.
+// Si label est vrai, le nom de chaque fichier (sans son dossier, raccourci par « ... » s'il dépasse la
+// case) est écrit sous sa vignette. Un fichier illisible est remplacé par une case barrée d'une croix
+// rouge, pour qu'il reste repérable. L'image obtenue a 255 pour valeur maximale.
+func ContactSheet(files []string, cols int, thumbSize int, label bool) *PPM {
+	cols = max(min(cols, len(files)), 1)
+	thumbSize = max(thumbSize, 1)
+	rows := (len(files) + cols - 1) / cols
+	cellWidth := thumbSize + 2*contactMargin
+	cellHeight := cellWidth
+	if label {
+		cellHeight += contactGap + GlyphHeight
+	}
+
+	sheet := NewPPM(cols*cellWidth, rows*cellHeight, 255)
+	background := Pixel{224, 224, 224}
+	for y := range sheet.data {
+		for x := range sheet.data[y] {
+			sheet.data[y][x] = background
+		}
+	}
+
+	for i, file := range files {
+		cell := Point{(i % cols) * cellWidth, (i / cols) * cellHeight}
+		box := Point{cell.X + contactMargin, cell.Y + contactMargin}
+		if img, err := ReadNetpbm(file); err == nil {
+			thumbnail := imageToPPM(img).Thumbnail(thumbSize, thumbSize)
+			offset := GravityCenter.place(thumbSize, thumbSize, thumbnail.width, thumbnail.height)
+			sheet.Paste(thumbnail, Point{box.X + offset.X, box.Y + offset.Y})
+		} else {
+			red := Pixel{200, 0, 0}
+			sheet.DrawRectangle(box, thumbSize-1, thumbSize-1, red)
+			sheet.DrawLine(box, Point{box.X + thumbSize - 1, box.Y + thumbSize - 1}, red)
+			sheet.DrawLine(Point{box.X + thumbSize - 1, box.Y}, Point{box.X, box.Y + thumbSize - 1}, red)
+		}
+		if label {
+			caption := fitCaption(filepath.Base(file), cellWidth-2)
+			at := Point{cell.X + (cellWidth-TextWidth(caption, 1))/2, box.Y + thumbSize + contactGap}
+			sheet.DrawText(at, caption, 1, Pixel{})
+		}
+	}
+	return sheet
+}
+
+// fitCaption raccourcit caption, en remplaçant sa fin par « ... », pour qu'elle tienne dans width pixels.
+func fitCaption(caption string, width int) string {
+	if TextWidth(caption, 1) <= width {
+		return caption
+	}
+	runes := []rune(caption)
+	for n := len(runes) - 1; n > 0; n-- {
+		if short := string(runes[:n]) + "..."; TextWidth(short, 1) <= width {
+			return short
+		}
+	}
+	return strings.Repeat(".", max(min(3, (width+1)/(GlyphWidth+1)), 0))
+}
+
+// imageToPPM convertit une image *PBM, *PGM ou *PPM en image PPM dont la valeur maximale est 255.
+func imageToPPM(img Image) *PPM {
+	width, height := img.Size()
+	ppm := NewPPM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c, _ := layerColor(img, x, y)
+			ppm.data[y][x] = Pixel{unitToByte(c[0]), unitToByte(c[1]), unitToByte(c[2])}
+		}
+	}
+	return ppm
+}
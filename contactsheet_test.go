@@ -0,0 +1,66 @@
+package Netpbm // 🧪 Test Planche contact
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestContactSheet(t *testing.T) {
+	dir := t.TempDir()
+	red := newUniformPPM(40, 20, Pixel{255, 0, 0})
+	if err := red.Save(filepath.Join(dir, "red.ppm")); err != nil {
+		t.Fatal(err)
+	}
+	gray := NewPGM(10, 10, 15)
+	if err := gray.Save(filepath.Join(dir, "gray.pgm")); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{filepath.Join(dir, "red.ppm"), filepath.Join(dir, "gray.pgm"), filepath.Join(dir, "missing.ppm")}
+
+	sheet := ContactSheet(files, 2, 32, false)
+	cell := 32 + 2*contactMargin
+	if sheet.width != 2*cell || sheet.height != 2*cell || sheet.max != 255 {
+		t.Fatalf("planche %dx%d (max %d), attendu %dx%d (max 255)", sheet.width, sheet.height, sheet.max, 2*cell, 2*cell)
+	}
+	// Image rouge réduite à 32×16 et centrée verticalement dans sa case
+	if p := sheet.data[contactMargin+16][contactMargin+16]; p != (Pixel{255, 0, 0}) {
+		t.Errorf("centre de la première vignette = %v, attendu rouge", p)
+	}
+	if p := sheet.data[contactMargin+2][contactMargin+16]; p != (Pixel{224, 224, 224}) {
+		t.Errorf("au-dessus de la vignette = %v, attendu le fond", p)
+	}
+	// Image grise (noire, valeur 0) non agrandie, au centre de la deuxième case
+	if p := sheet.data[cell/2][cell+cell/2]; p != (Pixel{}) {
+		t.Errorf("centre de la deuxième vignette = %v, attendu noir", p)
+	}
+	// Fichier absent : croix rouge
+	if p := sheet.data[cell+contactMargin][contactMargin]; p != (Pixel{200, 0, 0}) {
+		t.Errorf("coin de la troisième case = %v, attendu la croix rouge", p)
+	}
+
+	labelled := ContactSheet(files, 3, 32, true)
+	if labelled.height != cell+contactGap+GlyphHeight {
+		t.Errorf("hauteur avec légendes = %d, attendu %d", labelled.height, cell+contactGap+GlyphHeight)
+	}
+	black := 0
+	for y := cell + contactGap - contactMargin; y < labelled.height; y++ {
+		for x := 0; x < cell; x++ {
+			if labelled.data[y][x] == (Pixel{}) {
+				black++
+			}
+		}
+	}
+	if black == 0 {
+		t.Error("aucune légende sous la première vignette")
+	}
+}
+
+func TestFitCaption(t *testing.T) {
+	if got := fitCaption("short.ppm", 100); got != "short.ppm" {
+		t.Errorf("fitCaption = %q", got)
+	}
+	got := fitCaption("a_very_long_file_name.ppm", 46)
+	if got != "a_ve..." || TextWidth(got, 1) > 46 {
+		t.Errorf("fitCaption = %q", got)
+	}
+}
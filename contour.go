@@ -0,0 +1,104 @@
+package Netpbm // ✨ Suivi de contours
+
+// neighbours liste les 8 voisins d'un pixel dans le sens des aiguilles d'une montre, en partant de l'est.
+var neighbours = [8]Point{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+
+// neighbourIndex renvoie la position de to parmi les voisins de from.
+func neighbourIndex(from, to Point) int {
+	for i, n := range neighbours {
+		if from.X+n.X == to.X && from.Y+n.Y == to.Y {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contours renvoie les bords des formes noires de l'image PBM (algorithme de suivi de Suzuki).
+// Chaque contour est une liste de pixels noirs voisins, utilisable avec DrawPolygon ;
+// les bords extérieurs comme ceux des trous sont renvoyés, dans l'ordre où ils sont rencontrés.
+func (pbm *PBM) Contours() [][]Point {
+	// Étiquettes des pixels, avec une marge blanche d'un pixel autour de l'image
+	labels := make([][]int, pbm.height+2)
+	for y := range labels {
+		labels[y] = make([]int, pbm.width+2)
+	}
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				labels[y+1][x+1] = 1
+			}
+		}
+	}
+	at := func(p Point) int { return labels[p.Y][p.X] }
+
+	var contours [][]Point
+	border := 1
+	for y := 1; y <= pbm.height; y++ {
+		for x := 1; x <= pbm.width; x++ {
+			start := Point{x, y}
+			var from Point
+			switch {
+			case labels[y][x] == 1 && labels[y][x-1] == 0:
+				from = Point{x - 1, y} // Bord extérieur
+			case labels[y][x] >= 1 && labels[y][x+1] == 0:
+				from = Point{x + 1, y} // Bord d'un trou
+			default:
+				continue
+			}
+			border++
+			contours = append(contours, traceBorder(labels, at, start, from, border))
+		}
+	}
+	return contours
+}
+
+// traceBorder suit le bord qui commence en start, en venant du pixel blanc from, et étiquette ses pixels avec border.
+func traceBorder(labels [][]int, at func(Point) int, start, from Point, border int) []Point {
+	// Premier voisin noir, dans le sens des aiguilles d'une montre à partir de from
+	first := Point{-1, -1}
+	d := neighbourIndex(start, from)
+	for i := 0; i < 8; i++ {
+		n := neighbours[(d+i)%8]
+		if p := (Point{start.X + n.X, start.Y + n.Y}); at(p) != 0 {
+			first = p
+			break
+		}
+	}
+	if first.X < 0 {
+		// Pixel isolé
+		labels[start.Y][start.X] = -border
+		return []Point{{start.X - 1, start.Y - 1}}
+	}
+
+	var contour []Point
+	previous, current := first, start
+	for {
+		contour = append(contour, Point{current.X - 1, current.Y - 1})
+
+		// Voisin noir suivant, dans le sens inverse des aiguilles d'une montre après previous
+		d := neighbourIndex(current, previous)
+		eastChecked := false
+		var next Point
+		for i := 1; i <= 8; i++ {
+			k := (d - i + 8) % 8
+			n := neighbours[k]
+			next = Point{current.X + n.X, current.Y + n.Y}
+			if at(next) != 0 {
+				break
+			}
+			if k == 0 {
+				eastChecked = true
+			}
+		}
+
+		if eastChecked {
+			labels[current.Y][current.X] = -border
+		} else if labels[current.Y][current.X] == 1 {
+			labels[current.Y][current.X] = border
+		}
+		if next == start && current == first {
+			return contour
+		}
+		previous, current = current, next
+	}
+}
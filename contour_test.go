@@ -0,0 +1,83 @@
+package Netpbm // 🧪 Test suivi de contours
+
+import "testing"
+
+// fillRectPBM noircit la zone r de l'image PBM.
+func fillRectPBM(pbm *PBM, r Rect) {
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		for x := r.X; x < r.X+r.Width; x++ {
+			pbm.data[y][x] = true
+		}
+	}
+}
+
+func TestContoursSquare(t *testing.T) {
+	pbm := NewPBM(6, 6)
+	fillRectPBM(pbm, Rect{1, 1, 3, 3})
+	contours := pbm.Contours()
+	if len(contours) != 1 {
+		t.Fatalf("Expected 1 contour, got %d", len(contours))
+	}
+	expected := []Point{{1, 1}, {1, 2}, {1, 3}, {2, 3}, {3, 3}, {3, 2}, {3, 1}, {2, 1}}
+	if len(contours[0]) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, contours[0])
+	}
+	for i, p := range expected {
+		if contours[0][i] != p {
+			t.Fatalf("Expected %v, got %v", expected, contours[0])
+		}
+	}
+}
+
+func TestContoursWithHole(t *testing.T) {
+	pbm := NewPBM(9, 9)
+	fillRectPBM(pbm, Rect{1, 1, 7, 7})
+	for y := 3; y < 6; y++ {
+		for x := 3; x < 6; x++ {
+			pbm.data[y][x] = false
+		}
+	}
+	contours := pbm.Contours()
+	if len(contours) != 2 {
+		t.Fatalf("Expected an outer border and a hole, got %d contours", len(contours))
+	}
+	if len(contours[0]) != 24 {
+		t.Errorf("Outer border should have 24 pixels, got %d", len(contours[0]))
+	}
+	// Le bord du trou est formé des pixels noirs qui l'entourent
+	if len(contours[1]) != 12 {
+		t.Errorf("Hole border should have 12 pixels, got %d", len(contours[1]))
+	}
+	for _, p := range contours[1] {
+		if !pbm.data[p.Y][p.X] || p.X < 2 || p.X > 6 || p.Y < 2 || p.Y > 6 {
+			t.Errorf("Unexpected hole border pixel %v", p)
+		}
+	}
+}
+
+func TestContoursSeparateShapes(t *testing.T) {
+	pbm := NewPBM(10, 5)
+	fillRectPBM(pbm, Rect{0, 0, 2, 2})
+	fillRectPBM(pbm, Rect{5, 1, 5, 4})
+	pbm.data[4][0] = true
+	contours := pbm.Contours()
+	if len(contours) != 3 {
+		t.Fatalf("Expected 3 contours, got %d", len(contours))
+	}
+	if len(contours[2]) != 1 || contours[2][0] != (Point{0, 4}) {
+		t.Errorf("Isolated pixel should give a one-point contour, got %v", contours[2])
+	}
+	if len(NewPBM(4, 4).Contours()) != 0 {
+		t.Error("White image should have no contour")
+	}
+}
+
+func TestContoursDrawPolygon(t *testing.T) {
+	pbm := NewPBM(8, 8)
+	fillRectPBM(pbm, Rect{2, 2, 4, 4})
+	ppm := NewPPM(8, 8, 255)
+	ppm.DrawPolygon(pbm.Contours()[0], Pixel{255, 0, 0})
+	if countColor(ppm, Pixel{255, 0, 0}) != 12 {
+		t.Errorf("Drawn contour should cover the 12 border pixels, got %d", countColor(ppm, Pixel{255, 0, 0}))
+	}
+}
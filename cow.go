@@ -0,0 +1,88 @@
+package Netpbm // ✨ Copies paresseuses
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// sharedData compte les images qui partagent les mêmes lignes de pixels depuis un appel à Clone.
+type sharedData struct {
+	refs atomic.Int32
+}
+
+// shareMu protège la création des données partagées d'une image, pour que plusieurs goroutines puissent
+// la cloner en même temps.
+var shareMu sync.Mutex
+
+// share rattache une image de plus aux données partagées, en les créant au premier Clone. Les méthodes
+// Clone l'appellent avant de copier l'image, si bien que cette copie voit toujours le champ écrit ici.
+func share(shared **sharedData) *sharedData {
+	shareMu.Lock()
+	defer shareMu.Unlock()
+	if *shared == nil {
+		*shared = &sharedData{}
+		(*shared).refs.Store(1)
+	}
+	(*shared).refs.Add(1)
+	return *shared
+}
+
+// release détache une image des données partagées. Elle ne doit être appelée qu'après avoir copié
+// les pixels, sans quoi la dernière image rattachée pourrait écrire pendant la copie.
+func release(shared **sharedData) {
+	(*shared).refs.Add(-1)
+	*shared = nil
+}
+
+// Clone renvoie une copie de l'image en temps constant : les pixels restent partagés jusqu'à la
+// première modification de l'une ou l'autre image, qui copie alors ses propres lignes.
+// Les deux images peuvent ensuite être utilisées dans des goroutines différentes, et plusieurs goroutines
+// peuvent cloner la même image à la fois tant qu'aucune ne la modifie.
+func (ppm *PPM) Clone() *PPM {
+	shared := share(&ppm.shared)
+	clone := *ppm
+	clone.shared = shared
+	clone.metadata = maps.Clone(ppm.metadata)
+	return &clone
+}
+
+// own copie les pixels de l'image s'ils sont encore partagés avec un clone. Toute méthode qui modifie
+// l'image sur place l'appelle avant d'écrire.
+func (ppm *PPM) own() {
+	if ppm.shared == nil {
+		return
+	}
+	if ppm.shared.refs.Load() > 1 {
+		data := ppm.data
+		ppm.data = make([][]Pixel, len(data))
+		for y := range data {
+			ppm.data[y] = append([]Pixel(nil), data[y]...)
+		}
+	}
+	release(&ppm.shared)
+}
+
+// Clone renvoie une copie de l'image PGM en temps constant, comme Clone pour une image PPM.
+func (pgm *PGM) Clone() *PGM {
+	shared := share(&pgm.shared)
+	clone := *pgm
+	clone.shared = shared
+	clone.metadata = maps.Clone(pgm.metadata)
+	return &clone
+}
+
+// own copie les pixels de l'image PGM s'ils sont encore partagés avec un clone.
+func (pgm *PGM) own() {
+	if pgm.shared == nil {
+		return
+	}
+	if pgm.shared.refs.Load() > 1 {
+		data := pgm.data
+		pgm.data = make([][]uint8, len(data))
+		for y := range data {
+			pgm.data[y] = append([]uint8(nil), data[y]...)
+		}
+	}
+	release(&pgm.shared)
+}
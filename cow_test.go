@@ -0,0 +1,95 @@
+package Netpbm // 🧪 Test copies paresseuses
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCloneSharesUntilWrite(t *testing.T) {
+	ppm := newPatternPPM(16, 12)
+	ppm.SetMetadata("author", "test")
+	clone := ppm.Clone()
+	if &clone.data[0][0] != &ppm.data[0][0] {
+		t.Fatal("Clone should share the pixels")
+	}
+	clone.SetMetadata("author", "clone")
+	if ppm.Metadata()["author"] != "test" {
+		t.Error("Clone should not share the metadata")
+	}
+
+	original := ppm.Hash()
+	clone.Set(0, 0, Pixel{1, 2, 3})
+	if ppm.Hash() != original || clone.At(0, 0) != (Pixel{1, 2, 3}) {
+		t.Error("Writing to the clone should not change the original")
+	}
+	// L'original est désormais le seul à utiliser ses lignes et les modifie sur place
+	row := &ppm.data[0][0]
+	ppm.Invert()
+	if &ppm.data[0][0] != row {
+		t.Error("The last owner should not copy its pixels")
+	}
+}
+
+func TestCloneOriginalWrite(t *testing.T) {
+	pgm := newGradientPGM(10, 10)
+	clone := pgm.Clone()
+	before := clone.Hash()
+	pgm.Threshold(100)
+	pgm.Rotate(45, 0)
+	if clone.Hash() != before {
+		t.Error("Writing to the original should not change the clone")
+	}
+}
+
+func TestCloneBranches(t *testing.T) {
+	source := newPatternPPM(64, 48)
+	expectedBlur, expectedThreshold := newPatternPPM(64, 48), newPatternPPM(64, 48)
+	expectedBlur.Blur(2)
+	expectedThreshold.Threshold(128)
+
+	branches := []*PPM{source.Clone(), source.Clone()}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); branches[0].Blur(2) }()
+	go func() { defer wg.Done(); branches[1].Threshold(128) }()
+	wg.Wait()
+
+	if branches[0].Hash() != expectedBlur.Hash() || branches[1].Hash() != expectedThreshold.Hash() {
+		t.Error("Branches should match the same operations on independent copies")
+	}
+	if source.Hash() != newPatternPPM(64, 48).Hash() {
+		t.Error("The source should stay unchanged")
+	}
+}
+
+func TestConcurrentClone(t *testing.T) {
+	source := newPatternPPM(32, 24)
+	gray := newGradientPGM(32, 24)
+	expected, expectedGray := source.Hash(), gray.Hash()
+
+	// Plusieurs branches clonent la même source en même temps, puis modifient leur copie
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			branch, grayBranch := source.Clone(), gray.Clone()
+			branch.Set(i, 0, Pixel{255, 0, 0})
+			grayBranch.Set(i, 0, 1)
+			if branch.At(i, 0) != (Pixel{255, 0, 0}) || grayBranch.At(i, 0) != 1 {
+				t.Errorf("Branch %d lost its write", i)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if source.Hash() != expected || gray.Hash() != expectedGray {
+		t.Error("The sources should stay unchanged")
+	}
+}
+
+func BenchmarkClonePPM(b *testing.B) {
+	ppm := NewPPM(3840, 2160, 255)
+	for i := 0; i < b.N; i++ {
+		ppm.Clone()
+	}
+}
@@ -0,0 +1,110 @@
+package Netpbm // ✨ Recadrage
+
+// CropCircle renvoie le carré qui entoure le disque de centre center et de rayon radius,
+// où seuls les pixels du disque sont gardés, les autres prenant la couleur fill (pratique pour les avatars).
+func (ppm *PPM) CropCircle(center Point, radius int, fill Pixel) *PPM {
+	return ppm.CropEllipse(center, radius, radius, fill)
+}
+
+// CropEllipse renvoie le rectangle qui entoure l'ellipse de centre center et de demi-axes radiusX et radiusY,
+// où seuls les pixels de l'ellipse sont gardés, les autres prenant la couleur fill.
+func (ppm *PPM) CropEllipse(center Point, radiusX, radiusY int, fill Pixel) *PPM {
+	radiusX, radiusY = max(radiusX, 0), max(radiusY, 0)
+	cropped := NewPPM(2*radiusX+1, 2*radiusY+1, ppm.max)
+	cropped.magicNumber = ppm.magicNumber
+	// Les demi-axes sont agrandis d'un demi-pixel pour qu'une ellipse de rayon nul garde son centre
+	rx, ry := float64(radiusX)+0.5, float64(radiusY)+0.5
+	for y := 0; y < cropped.height; y++ {
+		for x := 0; x < cropped.width; x++ {
+			dx, dy := float64(x-radiusX)/rx, float64(y-radiusY)/ry
+			sx, sy := center.X-radiusX+x, center.Y-radiusY+y
+			if dx*dx+dy*dy <= 1 && sx >= 0 && sy >= 0 && sx < ppm.width && sy < ppm.height {
+				cropped.data[y][x] = ppm.data[sy][sx]
+			} else {
+				cropped.data[y][x] = fill
+			}
+		}
+	}
+	return cropped
+}
+
+// Crop réduit l'image PBM à la zone r (limitée aux bords de l'image).
+func (pbm *PBM) Crop(r Rect) {
+	r = r.clip(pbm.width, pbm.height)
+	data := make([][]bool, r.Height)
+	for y := range data {
+		data[y] = append([]bool(nil), pbm.data[r.Y+y][r.X:r.X+r.Width]...)
+	}
+	pbm.data, pbm.width, pbm.height = data, r.Width, r.Height
+}
+
+// Crop réduit l'image PGM à la zone r (limitée aux bords de l'image).
+func (pgm *PGM) Crop(r Rect) {
+	pgm.own()
+	r = r.clip(pgm.width, pgm.height)
+	data := make([][]uint8, r.Height)
+	for y := range data {
+		data[y] = append([]uint8(nil), pgm.data[r.Y+y][r.X:r.X+r.Width]...)
+	}
+	pgm.data, pgm.width, pgm.height = data, r.Width, r.Height
+}
+
+// Crop réduit l'image PPM à la zone r (limitée aux bords de l'image).
+func (ppm *PPM) Crop(r Rect) {
+	ppm.own()
+	r = r.clip(ppm.width, ppm.height)
+	data := make([][]Pixel, r.Height)
+	for y := range data {
+		data[y] = append([]Pixel(nil), ppm.data[r.Y+y][r.X:r.X+r.Width]...)
+	}
+	ppm.data, ppm.width, ppm.height = data, r.Width, r.Height
+}
+
+// Trim enlève les bords de l'image PPM dont la couleur ne s'écarte pas de plus de tolerance
+// de celle du coin supérieur gauche, et renvoie la zone gardée pour pouvoir recadrer de la même façon
+// d'autres images alignées (des masques par exemple). Une image uniforme n'est pas modifiée.
+func (ppm *PPM) Trim(tolerance uint8) Rect {
+	ppm.own()
+	if ppm.width == 0 || ppm.height == 0 {
+		return Rect{}
+	}
+	background := ppm.data[0][0]
+	r := trimRect(ppm.width, ppm.height, func(x, y int) bool {
+		return colorDistance(ppm.data[y][x], background) > int(tolerance)
+	})
+	ppm.Crop(r)
+	return r
+}
+
+// Trim enlève les bords de l'image PGM dont le niveau de gris ne s'écarte pas de plus de tolerance
+// de celui du coin supérieur gauche, et renvoie la zone gardée. Une image uniforme n'est pas modifiée.
+func (pgm *PGM) Trim(tolerance uint8) Rect {
+	pgm.own()
+	if pgm.width == 0 || pgm.height == 0 {
+		return Rect{}
+	}
+	background := int(pgm.data[0][0])
+	r := trimRect(pgm.width, pgm.height, func(x, y int) bool {
+		return abs(int(pgm.data[y][x])-background) > int(tolerance)
+	})
+	pgm.Crop(r)
+	return r
+}
+
+// trimRect renvoie la plus petite zone qui contient tous les pixels pour lesquels content est vrai,
+// ou l'image entière s'il n'y en a aucun.
+func trimRect(width, height int, content func(x, y int) bool) Rect {
+	x0, y0, x1, y1 := width, height, -1, -1
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if content(x, y) {
+				x0, y0 = min(x0, x), min(y0, y)
+				x1, y1 = max(x1, x), max(y1, y)
+			}
+		}
+	}
+	if x1 < 0 {
+		return Rect{0, 0, width, height}
+	}
+	return Rect{x0, y0, x1 - x0 + 1, y1 - y0 + 1}
+}
@@ -0,0 +1,89 @@
+package Netpbm // 🧪 Test recadrage
+
+import "testing"
+
+func TestCropCircle(t *testing.T) {
+	src := newPatternPPM(20, 20)
+	fill := Pixel{1, 2, 3}
+	avatar := src.CropCircle(Point{10, 8}, 4, fill)
+	width, height := avatar.Size()
+	if width != 9 || height != 9 {
+		t.Fatalf("Wrong size: %dx%d", width, height)
+	}
+	if avatar.At(4, 4) != src.At(10, 8) || avatar.At(0, 4) != src.At(6, 8) || avatar.At(4, 8) != src.At(10, 12) {
+		t.Error("Pixels inside the circle should be kept")
+	}
+	for _, corner := range []Point{{0, 0}, {8, 0}, {0, 8}, {8, 8}} {
+		if avatar.At(corner.X, corner.Y) != fill {
+			t.Errorf("Corner %v should be filled", corner)
+		}
+	}
+}
+
+func TestCropEllipse(t *testing.T) {
+	src := newUniformPPM(10, 10, Pixel{200, 0, 0})
+	fill := Pixel{255, 255, 255}
+	// Ellipse qui dépasse du bord gauche de l'image
+	cropped := src.CropEllipse(Point{1, 5}, 3, 1, fill)
+	width, height := cropped.Size()
+	if width != 7 || height != 3 {
+		t.Fatalf("Wrong size: %dx%d", width, height)
+	}
+	if cropped.At(0, 1) != fill || cropped.At(1, 1) != fill {
+		t.Error("Pixels outside the source image should be filled")
+	}
+	if cropped.At(2, 1) != (Pixel{200, 0, 0}) || cropped.At(6, 1) != (Pixel{200, 0, 0}) || cropped.At(3, 0) != (Pixel{200, 0, 0}) {
+		t.Error("Pixels inside the ellipse should be kept")
+	}
+	if cropped.At(6, 0) != fill {
+		t.Error("Pixels outside the ellipse should be filled")
+	}
+}
+
+func TestCrop(t *testing.T) {
+	ppm := newPatternPPM(8, 6)
+	expected := cropForTest(ppm, Rect{2, 1, 4, 3})
+	ppm.Crop(Rect{2, 1, 4, 3})
+	if width, height := ppm.Size(); width != 4 || height != 3 || ppm.At(3, 2) != expected.At(3, 2) {
+		t.Errorf("Unexpected crop %dx%d", width, height)
+	}
+	// La zone est limitée aux bords de l'image
+	pgm := newUniformPGM(5, 5, 9)
+	pgm.Crop(Rect{3, -2, 10, 4})
+	if width, height := pgm.Size(); width != 2 || height != 2 {
+		t.Errorf("Crop should be clipped, got %dx%d", width, height)
+	}
+	pbm := NewPBM(4, 4)
+	pbm.data[2][3] = true
+	pbm.Crop(Rect{2, 2, 2, 2})
+	if !pbm.At(1, 0) || pbm.At(0, 0) {
+		t.Error("PBM crop should keep pixel positions")
+	}
+}
+
+func TestTrim(t *testing.T) {
+	ppm := newUniformPPM(10, 8, Pixel{250, 250, 250})
+	ppm.data[2][3] = Pixel{0, 0, 0}
+	ppm.data[5][6] = Pixel{10, 10, 10}
+	ppm.data[7][0] = Pixel{245, 252, 250} // Bruit léger dans le fond
+	r := ppm.Trim(8)
+	if r != (Rect{3, 2, 4, 4}) {
+		t.Errorf("Unexpected trim rectangle %+v", r)
+	}
+	if width, height := ppm.Size(); width != 4 || height != 4 || ppm.At(0, 0) != (Pixel{0, 0, 0}) {
+		t.Errorf("Unexpected trimmed image %dx%d", width, height)
+	}
+
+	// Le même recadrage s'applique à un masque aligné
+	mask := newUniformPGM(10, 8, 0)
+	mask.data[2][3] = 255
+	mask.Crop(r)
+	if mask.At(0, 0) != 255 {
+		t.Error("Aligned mask should be cropped the same way")
+	}
+
+	uniform := newUniformPGM(4, 4, 7)
+	if r := uniform.Trim(0); r != (Rect{0, 0, 4, 4}) {
+		t.Errorf("Uniform image should be kept, got %+v", r)
+	}
+}
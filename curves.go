@@ -0,0 +1,123 @@
+package Netpbm // ✨ Courbes de tons
+
+import (
+	"math"
+	"sort"
+)
+
+// IdentityLUT renvoie la table de correspondance qui ne modifie aucune valeur.
+func IdentityLUT() [256]uint8 {
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = uint8(i)
+	}
+	return lut
+}
+
+// CurveLUT construit une table de correspondance à partir de points de contrôle (X en entrée, Y en sortie, de 0 à 255).
+// Les points sont reliés par une spline cubique monotone, qui ne dépasse jamais les points de contrôle.
+// Avant le premier point et après le dernier, la courbe reste constante.
+func CurveLUT(points []Point) [256]uint8 {
+	if len(points) == 0 {
+		return IdentityLUT()
+	}
+	sorted := append([]Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].X < sorted[j].X })
+
+	// Ne garder qu'un point par abscisse
+	pts := sorted[:1]
+	for _, p := range sorted[1:] {
+		if p.X == pts[len(pts)-1].X {
+			pts[len(pts)-1] = p
+		} else {
+			pts = append(pts, p)
+		}
+	}
+
+	var lut [256]uint8
+	if len(pts) == 1 {
+		for i := range lut {
+			lut[i] = clampChannel(float64(pts[0].Y), 255)
+		}
+		return lut
+	}
+
+	// Pentes des segments puis tangentes de Fritsch-Carlson
+	n := len(pts)
+	slopes := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		slopes[i] = float64(pts[i+1].Y-pts[i].Y) / float64(pts[i+1].X-pts[i].X)
+	}
+	tangents := make([]float64, n)
+	tangents[0], tangents[n-1] = slopes[0], slopes[n-2]
+	for i := 1; i < n-1; i++ {
+		if slopes[i-1]*slopes[i] <= 0 {
+			tangents[i] = 0
+		} else {
+			tangents[i] = (slopes[i-1] + slopes[i]) / 2
+		}
+	}
+	for i := 0; i < n-1; i++ {
+		if slopes[i] == 0 {
+			tangents[i], tangents[i+1] = 0, 0
+			continue
+		}
+		a, b := tangents[i]/slopes[i], tangents[i+1]/slopes[i]
+		if h := math.Hypot(a, b); h > 3 {
+			tangents[i] = 3 / h * a * slopes[i]
+			tangents[i+1] = 3 / h * b * slopes[i]
+		}
+	}
+
+	segment := 0
+	for i := range lut {
+		x := float64(i)
+		switch {
+		case i <= pts[0].X:
+			lut[i] = clampChannel(float64(pts[0].Y), 255)
+		case i >= pts[n-1].X:
+			lut[i] = clampChannel(float64(pts[n-1].Y), 255)
+		default:
+			for i > pts[segment+1].X {
+				segment++
+			}
+			// Interpolation d'Hermite sur le segment courant
+			x0, x1 := float64(pts[segment].X), float64(pts[segment+1].X)
+			y0, y1 := float64(pts[segment].Y), float64(pts[segment+1].Y)
+			h := x1 - x0
+			t := (x - x0) / h
+			t2, t3 := t*t, t*t*t
+			y := (2*t3-3*t2+1)*y0 + (t3-2*t2+t)*h*tangents[segment] + (-2*t3+3*t2)*y1 + (t3-t2)*h*tangents[segment+1]
+			lut[i] = clampChannel(y, 255)
+		}
+	}
+	return lut
+}
+
+// ApplyLUT remplace chaque pixel de l'image PGM par sa valeur dans la table de correspondance.
+func (pgm *PGM) ApplyLUT(lut [256]uint8) {
+	pgm.own()
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = min(lut[pgm.data[y][x]], uint8(pgm.max))
+		}
+	}
+}
+
+// ApplyCurves remplace chaque canal de l'image PPM par sa valeur dans la table de correspondance du canal.
+func (ppm *PPM) ApplyCurves(r, g, b [256]uint8) {
+	ppm.own()
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			pixel := &ppm.data[y][x]
+			pixel.R = min(r[pixel.R], uint8(ppm.max))
+			pixel.G = min(g[pixel.G], uint8(ppm.max))
+			pixel.B = min(b[pixel.B], uint8(ppm.max))
+		}
+	}
+}
+
+// ApplyLUT applique la même table de correspondance aux trois canaux de l'image PPM.
+func (ppm *PPM) ApplyLUT(lut [256]uint8) {
+	ppm.ApplyCurves(lut, lut, lut)
+}
@@ -0,0 +1,56 @@
+package Netpbm // 🧪 Test courbes
+
+import "testing"
+
+func TestCurveLUT(t *testing.T) {
+	lut := CurveLUT([]Point{{X: 0, Y: 0}, {X: 255, Y: 255}})
+	if lut != IdentityLUT() {
+		t.Error("Two end points should give the identity")
+	}
+
+	// Courbe en S : les points de contrôle sont respectés et la courbe reste croissante
+	points := []Point{{X: 0, Y: 0}, {X: 64, Y: 40}, {X: 192, Y: 220}, {X: 255, Y: 255}}
+	lut = CurveLUT(points)
+	for _, p := range points {
+		if lut[p.X] != uint8(p.Y) {
+			t.Errorf("Control point (%d, %d) not honored: %d", p.X, p.Y, lut[p.X])
+		}
+	}
+	for i := 1; i < 256; i++ {
+		if lut[i] < lut[i-1] {
+			t.Fatalf("Curve not monotone at %d", i)
+		}
+	}
+
+	lut = CurveLUT([]Point{{X: 200, Y: 10}, {X: 100, Y: 50}})
+	if lut[0] != 50 || lut[255] != 10 || lut[150] > 50 || lut[150] < 10 {
+		t.Error("Unsorted points or clamping not handled")
+	}
+}
+
+func TestApplyLUT(t *testing.T) {
+	pgm, err := ReadPGM("./testImages/pgm/testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = uint8(255 - i)
+	}
+	pgm.ApplyLUT(lut)
+	for i := 0; i < imagePGMWidth*imagePGMHeight; i++ {
+		x := i % imagePGMWidth
+		y := i / imagePGMWidth
+		if pgm.data[y][x] != min(255-testData[i], uint8(pgm.max)) {
+			t.Errorf("Pixel at (%d, %d) not mapped correctly", x, y)
+		}
+	}
+
+	ppm := NewPPM(1, 1, 255)
+	ppm.Set(0, 0, Pixel{10, 20, 30})
+	var zero [256]uint8
+	ppm.ApplyCurves(IdentityLUT(), zero, lut)
+	if ppm.At(0, 0) != (Pixel{10, 0, 225}) {
+		t.Errorf("Curves not applied per channel: %v", ppm.At(0, 0))
+	}
+}
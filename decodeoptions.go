@@ -0,0 +1,148 @@
+package Netpbm // ✨ Écarts tolérés à la lecture
+
+import (
+	"fmt"
+	"io"
+)
+
+// DecodeOptions déclare les écarts au format Netpbm qu'accepte DecodeBinary. Par défaut aucun écart
+// n'est toléré, et un écart reconnu dans le fichier donne une erreur qui le nomme.
+type DecodeOptions struct {
+	LittleEndian bool // Échantillons 16 bits stockés octet de poids faible en premier (la norme impose l'inverse)
+	RowAlignment int  // Lignes complétées jusqu'à un multiple de RowAlignment octets (0 ou 1 : aucun remplissage)
+}
+
+// rowAlignments sont les remplissages de lignes cherchés pour expliquer des octets en trop.
+var rowAlignments = []int{2, 4, 8, 16}
+
+// DecodeBinary lit une image PGM (P5) ou PPM (P6) en tolérant les écarts déclarés dans options. Les
+// échantillons 16 bits sont ramenés sur 0-255, la valeur maximale de l'image devenant 255.
+func DecodeBinary(r io.Reader, options DecodeOptions) (Image, error) {
+	info, err := ProbeHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if info.MagicNumber != "P5" && info.MagicNumber != "P6" {
+		return nil, fmt.Errorf("DecodeBinary needs a P5 or P6 image, got %s", info.MagicNumber)
+	}
+	rowSize := info.RowSize()
+	stride := rowSize
+	if options.RowAlignment > 1 {
+		stride = (rowSize + options.RowAlignment - 1) / options.RowAlignment * options.RowAlignment
+	}
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading pixel data: %v", err)
+	}
+	if err := checkRowPadding(payload, info.Height, rowSize, stride); err != nil {
+		return nil, err
+	}
+
+	sample, err := sampleReader(payload, info, stride, options.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	channels := info.Channels()
+	if channels == 1 {
+		pgm := NewPGM(info.Width, info.Height, min(info.Max, 255))
+		pgm.magicNumber, pgm.metadata = "P5", info.Metadata
+		for y := range pgm.data {
+			for x := range pgm.data[y] {
+				pgm.data[y][x] = sample(y, x)
+			}
+		}
+		return pgm, nil
+	}
+	ppm := NewPPM(info.Width, info.Height, min(info.Max, 255))
+	ppm.magicNumber, ppm.metadata = "P6", info.Metadata
+	for y := range ppm.data {
+		for x := range ppm.data[y] {
+			ppm.data[y][x] = Pixel{sample(y, 3*x), sample(y, 3*x+1), sample(y, 3*x+2)}
+		}
+	}
+	return ppm, nil
+}
+
+// checkRowPadding vérifie que payload contient height lignes de stride octets. Des octets en trop qui
+// s'expliquent par un remplissage des lignes non déclaré (des zéros en fin de chaque ligne) donnent une
+// erreur qui le nomme ; les autres sont ignorés, un flux pouvant contenir plusieurs images à la suite.
+func checkRowPadding(payload []byte, height, rowSize, stride int) error {
+	size := len(payload)
+	// La dernière ligne n'est pas toujours complétée
+	if expected := (height-1)*stride + rowSize; size < expected {
+		return fmt.Errorf("unexpected end of pixel data: %d bytes, expected %d", size, expected)
+	}
+	if stride != rowSize || size == height*rowSize {
+		return nil
+	}
+	for _, alignment := range rowAlignments {
+		padded := (rowSize + alignment - 1) / alignment * alignment
+		if padded != rowSize && (size == height*padded || size == (height-1)*padded+rowSize) && zeroPadding(payload, height, rowSize, padded) {
+			return fmt.Errorf("rows seem padded to %d bytes (%d bytes of pixel data for %d rows of %d bytes): set DecodeOptions.RowAlignment to accept it",
+				alignment, size, height, rowSize)
+		}
+	}
+	return nil
+}
+
+// zeroPadding indique si les octets qui suivent chaque ligne de rowSize octets, jusqu'à padded, sont nuls.
+func zeroPadding(payload []byte, height, rowSize, padded int) bool {
+	for y := 0; y < height; y++ {
+		for i := y*padded + rowSize; i < min((y+1)*padded, len(payload)); i++ {
+			if payload[i] != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sampleReader renvoie une fonction qui lit l'échantillon i de la ligne y, ramené sur 0-255 s'il est sur
+// 16 bits. Elle vérifie au préalable que tous les échantillons respectent la valeur maximale, et nomme
+// l'ordre des octets qui l'aurait respectée si ce n'est pas celui attendu.
+func sampleReader(payload []byte, info Info, stride int, littleEndian bool) (func(y, i int) uint8, error) {
+	if info.Max <= 255 {
+		for y := 0; y < info.Height; y++ {
+			for _, v := range payload[y*stride : y*stride+info.RowSize()] {
+				if int(v) > info.Max {
+					return nil, fmt.Errorf("sample %d at row %d exceeds the max value %d", v, y, info.Max)
+				}
+			}
+		}
+		return func(y, i int) uint8 { return payload[y*stride+i] }, nil
+	}
+
+	read := func(y, i int, little bool) int {
+		hi, lo := payload[y*stride+2*i], payload[y*stride+2*i+1]
+		if little {
+			hi, lo = lo, hi
+		}
+		return int(hi)<<8 | int(lo)
+	}
+	fits := func(little bool) bool {
+		for y := 0; y < info.Height; y++ {
+			for i := 0; i < info.RowSize()/2; i++ {
+				if read(y, i, little) > info.Max {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if !fits(littleEndian) {
+		if fits(!littleEndian) {
+			order := func(little bool) string {
+				if little {
+					return "little-endian"
+				}
+				return "big-endian"
+			}
+			return nil, fmt.Errorf("16-bit samples exceed the max value %d as %s but fit as %s: set DecodeOptions.LittleEndian to %v",
+				info.Max, order(littleEndian), order(!littleEndian), !littleEndian)
+		}
+		return nil, fmt.Errorf("16-bit samples exceed the max value %d", info.Max)
+	}
+	return func(y, i int) uint8 {
+		return uint8((read(y, i, littleEndian)*255 + info.Max/2) / info.Max)
+	}, nil
+}
@@ -0,0 +1,86 @@
+package Netpbm // 🧪 Test écarts tolérés à la lecture
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// samples16 renvoie les échantillons sur deux octets, dans l'ordre demandé.
+func samples16(little bool, values ...int) []byte {
+	var b []byte
+	for _, v := range values {
+		if little {
+			b = append(b, byte(v), byte(v>>8))
+		} else {
+			b = append(b, byte(v>>8), byte(v))
+		}
+	}
+	return b
+}
+
+func TestDecodeBinary16Bit(t *testing.T) {
+	header := "P5\n3 1\n1000\n"
+	values := []int{0, 500, 1000}
+	img, err := DecodeBinary(bytes.NewReader(append([]byte(header), samples16(false, values...)...)), DecodeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm := img.(*PGM); pgm.max != 255 || pgm.At(1, 0) != 128 || pgm.At(2, 0) != 255 {
+		t.Errorf("Unexpected scaled samples %v", pgm.data)
+	}
+
+	little := append([]byte(header), samples16(true, values...)...)
+	_, err = DecodeBinary(bytes.NewReader(little), DecodeOptions{})
+	if err == nil || !strings.Contains(err.Error(), "set DecodeOptions.LittleEndian to true") {
+		t.Errorf("Expected the little-endian deviation to be named, got %v", err)
+	}
+	img, err = DecodeBinary(bytes.NewReader(little), DecodeOptions{LittleEndian: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.(*PGM).At(1, 0) != 128 {
+		t.Error("Little-endian samples should decode to the same image")
+	}
+}
+
+func TestDecodeBinaryRowPadding(t *testing.T) {
+	// Deux lignes de 3 pixels RGB (9 octets), complétées à 12 octets
+	padded := []byte("P6\n3 2\n255\n")
+	for y := 0; y < 2; y++ {
+		padded = append(padded, 10, 20, 30, 40, 50, 60, 70, 80, byte(90+y), 0, 0, 0)
+	}
+	_, err := DecodeBinary(bytes.NewReader(padded), DecodeOptions{})
+	if err == nil || !strings.Contains(err.Error(), "rows seem padded to 4 bytes") {
+		t.Errorf("Expected the padding to be named, got %v", err)
+	}
+	img, err := DecodeBinary(bytes.NewReader(padded), DecodeOptions{RowAlignment: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ppm := img.(*PPM); ppm.At(2, 1) != (Pixel{70, 80, 91}) || ppm.At(0, 1) != (Pixel{10, 20, 30}) {
+		t.Errorf("Unexpected pixels %v", ppm.data)
+	}
+
+	// Sans remplissage de la dernière ligne
+	img, err = DecodeBinary(bytes.NewReader(padded[:len(padded)-3]), DecodeOptions{RowAlignment: 4})
+	if err != nil || img.(*PPM).At(2, 1) != (Pixel{70, 80, 91}) {
+		t.Errorf("The last row may be unpadded: %v", err)
+	}
+	if _, err := DecodeBinary(bytes.NewReader(padded[:len(padded)-4]), DecodeOptions{RowAlignment: 4}); err == nil {
+		t.Error("Expected an error for truncated pixel data")
+	}
+}
+
+func TestDecodeBinaryStrict(t *testing.T) {
+	if _, err := DecodeBinary(strings.NewReader("P5\n2 1\n100\n\x05\xc8"), DecodeOptions{}); err == nil {
+		t.Error("Expected an error for a sample above the max value")
+	}
+	if _, err := DecodeBinary(strings.NewReader("P2\n1 1\n255\n0\n"), DecodeOptions{}); err == nil {
+		t.Error("Expected an error for a text format")
+	}
+	img, err := DecodeBinary(strings.NewReader("P5\n2 1\n255\n\x05\xc8P5"), DecodeOptions{})
+	if err != nil || img.(*PGM).At(1, 0) != 200 {
+		t.Errorf("Trailing data that is not padding should be ignored: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package Netpbm // ✨ Dématriçage des images de capteur
+
+// BayerPattern donne la disposition des filtres colorés d'un capteur (matrice de Bayer), nommée d'après
+// les quatre pixels du coin supérieur gauche, ligne par ligne.
+type BayerPattern int
+
+const (
+	BayerRGGB BayerPattern = iota
+	BayerBGGR
+	BayerGRBG
+	BayerGBRG
+)
+
+// DemosaicMethod choisit comment Demosaic retrouve les deux couleurs que chaque pixel du capteur n'a
+// pas mesurées.
+type DemosaicMethod int
+
+const (
+	DemosaicBilinear DemosaicMethod = iota // Moyenne des voisins de la couleur manquante : rapide, mais bords flous et franges colorées
+	DemosaicMalvar                         // Interpolation corrigée par le gradient (Malvar, He et Cutler, 2004) : bords nets, peu de franges
+)
+
+// bayerColors donne la couleur (0 rouge, 1 vert, 2 bleu) des quatre pixels du motif de chaque disposition.
+var bayerColors = map[BayerPattern][4]int{
+	BayerRGGB: {0, 1, 1, 2},
+	BayerBGGR: {2, 1, 1, 0},
+	BayerGRBG: {1, 0, 2, 1},
+	BayerGBRG: {1, 2, 0, 1},
+}
+
+// Noyaux de Malvar, He et Cutler, à diviser par 8 : décalages (dx, dy) et poids.
+type demosaicTap struct {
+	dx, dy int
+	weight float64
+}
+
+var (
+	// Vert sur un pixel rouge ou bleu
+	malvarGreen = []demosaicTap{
+		{0, -2, -1}, {0, -1, 2}, {-2, 0, -1}, {-1, 0, 2}, {0, 0, 4}, {1, 0, 2}, {2, 0, -1}, {0, 1, 2}, {0, 2, -1},
+	}
+	// Couleur des voisins de gauche et de droite, sur un pixel vert
+	malvarRow = []demosaicTap{
+		{0, -2, 0.5}, {-1, -1, -1}, {1, -1, -1}, {-2, 0, -1}, {-1, 0, 4}, {0, 0, 5}, {1, 0, 4}, {2, 0, -1},
+		{-1, 1, -1}, {1, 1, -1}, {0, 2, 0.5},
+	}
+	// Couleur des voisins du haut et du bas, sur un pixel vert
+	malvarColumn = []demosaicTap{
+		{0, -2, -1}, {-1, -1, -1}, {0, -1, 4}, {1, -1, -1}, {-2, 0, 0.5}, {0, 0, 5}, {2, 0, 0.5},
+		{-1, 1, -1}, {0, 1, 4}, {1, 1, -1}, {0, 2, -1},
+	}
+	// Rouge sur un pixel bleu, ou bleu sur un pixel rouge
+	malvarDiagonal = []demosaicTap{
+		{0, -2, -1.5}, {-1, -1, 2}, {1, -1, 2}, {-2, 0, -1.5}, {0, 0, 6}, {2, 0, -1.5}, {-1, 1, 2}, {1, 1, 2}, {0, 2, -1.5},
+	}
+)
+
+// Demosaic convertit l'image PGM brute d'un capteur à matrice de Bayer, où chaque pixel n'a mesuré que
+// la couleur de son filtre selon pattern, en image PPM de même taille et de même valeur maximale. Les
+// deux couleurs manquantes de chaque pixel sont interpolées selon method ; au bord, l'image est prolongée
+// par symétrie, ce qui respecte la disposition des couleurs.
+func (pgm *PGM) Demosaic(pattern BayerPattern, method DemosaicMethod) *PPM {
+	colors, ok := bayerColors[pattern]
+	if !ok {
+		colors = bayerColors[BayerRGGB]
+	}
+	color := func(x, y int) int { return colors[(y&1)*2+(x&1)] }
+	// at lit la valeur brute en (x, y), l'image étant prolongée par symétrie autour de ses bords
+	at := func(x, y int) float64 {
+		return float64(pgm.data[mirrorIndex(y, pgm.height)][mirrorIndex(x, pgm.width)])
+	}
+	apply := func(x, y int, kernel []demosaicTap) float64 {
+		v := 0.0
+		for _, t := range kernel {
+			v += t.weight * at(x+t.dx, y+t.dy)
+		}
+		return v / 8
+	}
+
+	ppm := NewPPM(pgm.width, pgm.height, pgm.max)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			var rgb [3]float64
+			own := color(x, y)
+			rgb[own] = at(x, y)
+			switch method {
+			case DemosaicMalvar:
+				switch own {
+				case 1:
+					// Le rouge et le bleu sont, selon la ligne, à gauche et à droite ou en haut et en bas
+					horizontal := color(x+1, y)
+					rgb[horizontal] = apply(x, y, malvarRow)
+					rgb[2-horizontal] = apply(x, y, malvarColumn)
+				default:
+					rgb[1] = apply(x, y, malvarGreen)
+					rgb[2-own] = apply(x, y, malvarDiagonal)
+				}
+			default:
+				// Moyenne, pour chaque couleur manquante, des voisins 3×3 qui l'ont mesurée
+				var sum, count [3]float64
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						c := color(x+dx, y+dy)
+						sum[c] += at(x+dx, y+dy)
+						count[c]++
+					}
+				}
+				for c := range rgb {
+					if c != own {
+						rgb[c] = sum[c] / count[c]
+					}
+				}
+			}
+			ppm.data[y][x] = Pixel{clampChannel(rgb[0], pgm.max), clampChannel(rgb[1], pgm.max), clampChannel(rgb[2], pgm.max)}
+		}
+	}
+	return ppm
+}
+
+// mirrorIndex ramène i dans [0, n) par symétrie autour des bords, sans répéter le pixel du bord
+// (-1 devient 1, n devient n-2), ce qui conserve la parité de i.
+func mirrorIndex(i, n int) int {
+	if n == 1 {
+		return 0
+	}
+	for i < 0 || i >= n {
+		if i < 0 {
+			i = -i
+		}
+		if i >= n {
+			i = 2*(n-1) - i
+		}
+	}
+	return i
+}
@@ -0,0 +1,67 @@
+package Netpbm // 🧪 Test Dématriçage des images de capteur
+
+import "testing"
+
+// mosaic renvoie l'image brute que mesurerait un capteur de disposition pattern photographiant ppm.
+func mosaic(ppm *PPM, pattern BayerPattern) *PGM {
+	colors := bayerColors[pattern]
+	raw := NewPGM(ppm.width, ppm.height, ppm.max)
+	for y := 0; y < ppm.height; y++ {
+		for x, p := range ppm.data[y] {
+			raw.data[y][x] = [3]uint8{p.R, p.G, p.B}[colors[(y&1)*2+(x&1)]]
+		}
+	}
+	return raw
+}
+
+func TestDemosaicUniform(t *testing.T) {
+	color := Pixel{200, 120, 40}
+	scene := newUniformPPM(7, 6, color)
+	for _, pattern := range []BayerPattern{BayerRGGB, BayerBGGR, BayerGRBG, BayerGBRG} {
+		for _, method := range []DemosaicMethod{DemosaicBilinear, DemosaicMalvar} {
+			ppm := mosaic(scene, pattern).Demosaic(pattern, method)
+			if ppm.width != 7 || ppm.height != 6 || ppm.max != 255 {
+				t.Fatalf("taille %dx%d (max %d)", ppm.width, ppm.height, ppm.max)
+			}
+			if got := countColor(ppm, color); got != 7*6 {
+				t.Errorf("motif %d, méthode %d : %d pixels de la bonne couleur sur %d", pattern, method, got, 7*6)
+			}
+		}
+	}
+}
+
+func TestDemosaicMalvarSharperEdges(t *testing.T) {
+	// Bord vertical gris : le vert, mesuré deux fois plus souvent, guide l'interpolation de Malvar
+	scene := NewPPM(16, 8, 255)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			v := uint8(40)
+			if x >= 8 {
+				v = 220
+			}
+			scene.data[y][x] = Pixel{v, v, v}
+		}
+	}
+	raw := mosaic(scene, BayerRGGB)
+	errorOf := func(ppm *PPM) int {
+		total := 0
+		for y := 2; y < 6; y++ {
+			for x := 2; x < 14; x++ {
+				total += colorDistance(ppm.data[y][x], scene.data[y][x])
+			}
+		}
+		return total
+	}
+	bilinear, malvar := errorOf(raw.Demosaic(BayerRGGB, DemosaicBilinear)), errorOf(raw.Demosaic(BayerRGGB, DemosaicMalvar))
+	if malvar >= bilinear {
+		t.Errorf("écart de Malvar %d, pas plus faible que l'écart bilinéaire %d", malvar, bilinear)
+	}
+}
+
+func TestMirrorIndex(t *testing.T) {
+	for _, test := range [][3]int{{-1, 5, 1}, {-2, 5, 2}, {5, 5, 3}, {6, 5, 2}, {3, 5, 3}, {-3, 2, 1}, {4, 1, 0}} {
+		if got := mirrorIndex(test[0], test[1]); got != test[2] {
+			t.Errorf("mirrorIndex(%d, %d) = %d, attendu %d", test[0], test[1], got, test[2])
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package Netpbm // ✨ Changement de valeur maximale
+
+import "fmt"
+
+// scaleSample ramène la valeur v, entre 0 et from, sur l'intervalle 0-to, arrondie au plus proche.
+func scaleSample(v, from, to int) int {
+	from = max(from, 1)
+	return (v*to + from/2) / from
+}
+
+// checkDepth vérifie qu'une nouvelle valeur maximale tient dans les échantillons d'un octet des images.
+func checkDepth(newMax int) error {
+	if newMax < 1 || newMax > 255 {
+		return fmt.Errorf("maximum value %d out of range [1, 255] for 8-bit samples", newMax)
+	}
+	return nil
+}
+
+// ConvertDepth change la valeur maximale de l'image PGM en newMax, entre 1 et 255, chaque valeur étant
+// remise à l'échelle et arrondie au plus proche : le noir et le blanc sont conservés, et convertir vers
+// une valeur maximale plus grande puis revenir rend l'image d'origine. Les images gardent leurs
+// échantillons sur un octet : les valeurs maximales sur 16 bits, comme 1023 ou 65535, sont refusées.
+func (pgm *PGM) ConvertDepth(newMax int) error {
+	if err := checkDepth(newMax); err != nil {
+		return err
+	}
+	pgm.own()
+	for y := 0; y < pgm.height; y++ {
+		for x, v := range pgm.data[y] {
+			pgm.data[y][x] = uint8(scaleSample(int(v), pgm.max, newMax))
+		}
+	}
+	pgm.max = newMax
+	return nil
+}
+
+// ConvertDepth change la valeur maximale de l'image PPM en newMax, entre 1 et 255, comme ConvertDepth
+// pour une image PGM.
+func (ppm *PPM) ConvertDepth(newMax int) error {
+	if err := checkDepth(newMax); err != nil {
+		return err
+	}
+	ppm.own()
+	scale := func(v uint8) uint8 { return uint8(scaleSample(int(v), ppm.max, newMax)) }
+	for y := 0; y < ppm.height; y++ {
+		for x, p := range ppm.data[y] {
+			ppm.data[y][x] = Pixel{scale(p.R), scale(p.G), scale(p.B)}
+		}
+	}
+	ppm.max = newMax
+	return nil
+}
@@ -0,0 +1,84 @@
+package Netpbm // 🧪 Test Changement de valeur maximale
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPGMConvertDepth(t *testing.T) {
+	pgm := NewPGM(4, 1, 255)
+	for x, v := range []uint8{0, 1, 128, 255} {
+		pgm.Set(x, 0, v)
+	}
+	if err := pgm.ConvertDepth(15); err != nil {
+		t.Fatal(err)
+	}
+	// 128 × 15 / 255 = 7,53 : arrondi à 8 et non tronqué à 7
+	if pgm.max != 15 || pgm.At(0, 0) != 0 || pgm.At(1, 0) != 0 || pgm.At(2, 0) != 8 || pgm.At(3, 0) != 15 {
+		t.Errorf("valeurs %v (max %d)", pgm.data[0], pgm.max)
+	}
+
+	// Aller-retour vers une valeur maximale plus grande : image inchangée
+	for v := 0; v <= 15; v++ {
+		pgm.Set(0, 0, uint8(v))
+		if err := pgm.ConvertDepth(255); err != nil {
+			t.Fatal(err)
+		}
+		if err := pgm.ConvertDepth(15); err != nil {
+			t.Fatal(err)
+		}
+		if pgm.At(0, 0) != uint8(v) {
+			t.Errorf("aller-retour de %d : %d", v, pgm.At(0, 0))
+		}
+	}
+
+	for _, newMax := range []int{0, 256, 65535} {
+		if err := pgm.ConvertDepth(newMax); err == nil {
+			t.Errorf("ConvertDepth(%d) doit être refusé", newMax)
+		}
+	}
+	if pgm.max != 15 {
+		t.Errorf("une conversion refusée a changé la valeur maximale : %d", pgm.max)
+	}
+}
+
+func TestPPMConvertDepth(t *testing.T) {
+	ppm := newUniformPPM(2, 2, Pixel{255, 128, 1})
+	clone := ppm.Clone()
+	if err := ppm.ConvertDepth(3); err != nil {
+		t.Fatal(err)
+	}
+	if ppm.max != 3 || ppm.data[1][1] != (Pixel{3, 2, 0}) {
+		t.Errorf("pixel %v (max %d), attendu {3 2 0} (max 3)", ppm.data[1][1], ppm.max)
+	}
+	if clone.max != 255 || clone.data[1][1] != (Pixel{255, 128, 1}) {
+		t.Error("le clone a été modifié")
+	}
+}
+
+func TestRead16BitRejected(t *testing.T) {
+	dir := t.TempDir()
+	// 2×1 pixels de 16 bits : 1023 et 10, pour une valeur maximale de 1023
+	samples := []byte{0x03, 0xFF, 0x00, 0x0A}
+	pgmFile, ppmFile := filepath.Join(dir, "deep.pgm"), filepath.Join(dir, "deep.ppm")
+	os.WriteFile(pgmFile, append([]byte("P5\n2 1\n1023\n"), samples...), 0644)
+	os.WriteFile(ppmFile, append([]byte("P6\n1 1\n65535\n"), 0xFF, 0xFF, 0, 0, 0, 0), 0644)
+	if _, err := ReadPGM(pgmFile); err == nil {
+		t.Error("ReadPGM should reject 16-bit samples instead of reading them as bytes")
+	}
+	if _, err := ReadPPM(ppmFile); err == nil {
+		t.Error("ReadPPM should reject 16-bit samples instead of reading them as bytes")
+	}
+
+	// DecodeBinary les ramène sur 0-255
+	file, _ := os.Open(pgmFile)
+	defer file.Close()
+	img, err := DecodeBinary(file, DecodeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm := img.(*PGM); pgm.max != 255 || pgm.At(0, 0) != 255 || pgm.At(1, 0) != 2 {
+		t.Errorf("Unexpected scaled samples %v (max %d)", pgm.data[0], pgm.max)
+	}
+}
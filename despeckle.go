@@ -0,0 +1,41 @@
+package Netpbm // ✨ Nettoyage des taches
+
+// Despeckle blanchit les groupes de pixels noirs voisins (en comptant les diagonales) qui comptent
+// au plus maxBlobSize pixels, pour nettoyer une page binarisée avant la reconnaissance de texte ou la vectorisation.
+func (pbm *PBM) Despeckle(maxBlobSize int) {
+	if maxBlobSize <= 0 {
+		return
+	}
+	visited := make([][]bool, pbm.height)
+	for y := range visited {
+		visited[y] = make([]bool, pbm.width)
+	}
+	var blob, stack []Point
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if !pbm.data[y][x] || visited[y][x] {
+				continue
+			}
+			// Parcours du groupe à partir de (x, y)
+			blob, stack = blob[:0], append(stack[:0], Point{x, y})
+			visited[y][x] = true
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				blob = append(blob, p)
+				for _, n := range neighbours {
+					q := Point{p.X + n.X, p.Y + n.Y}
+					if q.X >= 0 && q.Y >= 0 && q.X < pbm.width && q.Y < pbm.height && pbm.data[q.Y][q.X] && !visited[q.Y][q.X] {
+						visited[q.Y][q.X] = true
+						stack = append(stack, q)
+					}
+				}
+			}
+			if len(blob) <= maxBlobSize {
+				for _, p := range blob {
+					pbm.data[p.Y][p.X] = false
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package Netpbm // 🧪 Test nettoyage des taches
+
+import "testing"
+
+func TestDespeckle(t *testing.T) {
+	pbm := NewPBM(12, 8)
+	pbm.data[0][0] = true                       // Point isolé
+	pbm.data[3][3], pbm.data[4][4] = true, true // Tache de deux pixels reliés en diagonale
+	fillRectPBM(pbm, Rect{6, 1, 4, 4})          // Forme à garder
+	pbm.data[7][11] = true
+
+	pbm.Despeckle(2)
+	if pbm.At(0, 0) || pbm.At(3, 3) || pbm.At(4, 4) || pbm.At(11, 7) {
+		t.Error("Small blobs should be removed")
+	}
+	if countBlack(pbm) != 16 {
+		t.Errorf("The large shape should be kept, got %d black pixels", countBlack(pbm))
+	}
+
+	pbm.Despeckle(16)
+	if countBlack(pbm) != 0 {
+		t.Error("A blob of exactly maxBlobSize pixels should be removed")
+	}
+}
+
+func TestDespeckleDisabled(t *testing.T) {
+	pbm := NewPBM(3, 3)
+	pbm.data[1][1] = true
+	pbm.Despeckle(0)
+	if !pbm.At(1, 1) {
+		t.Error("maxBlobSize 0 should keep every pixel")
+	}
+}
@@ -0,0 +1,51 @@
+package Netpbm // 🧪 Test cartes de distances
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestDistanceTransform(t *testing.T) {
+	pbm := NewPBM(9, 9)
+	pbm.data[4][4] = true
+	pgm := pbm.DistanceTransform()
+	if pgm.At(4, 4) != 0 || pgm.At(7, 4) != 3 || pgm.At(4, 0) != 4 {
+		t.Errorf("Unexpected straight distances %d %d %d", pgm.At(4, 4), pgm.At(7, 4), pgm.At(4, 0))
+	}
+	// Diagonale de 4 pixels : 4√2 ≈ 5.66, approché par 16/3
+	if pgm.At(0, 0) != 5 {
+		t.Errorf("Unexpected diagonal distance %d", pgm.At(0, 0))
+	}
+	if d := NewPBM(3, 3).DistanceTransform(); d.At(1, 1) != 255 {
+		t.Error("Without black pixels, every distance should be the maximum")
+	}
+}
+
+func TestSignedDistanceField(t *testing.T) {
+	pbm := NewPBM(20, 20)
+	fillRectPBM(pbm, Rect{X: 5, Y: 5, Width: 10, Height: 10})
+	field := pbm.SignedDistanceField()
+	if field[5][0] != 5 || field[0][0] != math.Sqrt(50) {
+		t.Errorf("Unexpected outside distances %v %v", field[5][0], field[0][0])
+	}
+	if field[10][10] != -5 || field[5][5] != -1 {
+		t.Errorf("Unexpected inside distances %v %v", field[10][10], field[5][5])
+	}
+
+	// Le champ exact reste sous l'approximation du chanfrein
+	chamfer := pbm.DistanceTransform()
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if d := math.Max(field[y][x], 0); math.Abs(d-float64(chamfer.At(x, y))) > 1 {
+				t.Fatalf("Distances differ too much at (%d, %d): %v and %d", x, y, d, chamfer.At(x, y))
+			}
+		}
+	}
+
+	err := chamfer.Save("./testImages/pgm/distance.pgm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/pgm/distance.pgm")
+}
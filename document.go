@@ -0,0 +1,167 @@
+package Netpbm // ✨ Documents à calques
+
+import (
+	"fmt"
+	"math"
+)
+
+// BlendMode indique comment les pixels d'un calque se combinent avec ceux des calques du dessous.
+type BlendMode int
+
+const (
+	BlendNormal     BlendMode = iota // Le calque recouvre le dessous
+	BlendMultiply                    // Produit des couleurs : assombrit
+	BlendScreen                      // Inverse du produit des inverses : éclaircit
+	BlendOverlay                     // Multiply dans les tons sombres du dessous, Screen dans les clairs
+	BlendDarken                      // Plus sombre des deux couleurs, canal par canal
+	BlendLighten                     // Plus claire des deux couleurs, canal par canal
+	BlendDifference                  // Valeur absolue de la différence
+	BlendAdd                         // Somme, limitée au blanc
+)
+
+// mix combine une valeur du dessous et une valeur du calque, toutes deux entre 0 et 1.
+func (mode BlendMode) mix(below, layer float64) float64 {
+	switch mode {
+	case BlendMultiply:
+		return below * layer
+	case BlendScreen:
+		return 1 - (1-below)*(1-layer)
+	case BlendOverlay:
+		if below < 0.5 {
+			return 2 * below * layer
+		}
+		return 1 - 2*(1-below)*(1-layer)
+	case BlendDarken:
+		return math.Min(below, layer)
+	case BlendLighten:
+		return math.Max(below, layer)
+	case BlendDifference:
+		return math.Abs(below - layer)
+	case BlendAdd:
+		return math.Min(below+layer, 1)
+	}
+	return layer
+}
+
+// Layer est un calque d'un document : une image PBM, PGM ou PPM placée à Offset dans le document.
+// Ses champs peuvent être modifiés directement entre deux appels à Flatten.
+type Layer struct {
+	Name    string
+	Image   Image     // *PBM, *PGM ou *PPM
+	Offset  Point     // Position du coin supérieur gauche du calque dans le document
+	Opacity float64   // Opacité du calque, entre 0 (invisible) et 1
+	Mode    BlendMode // Mode de fusion avec les calques du dessous
+	Hidden  bool      // Calque masqué, ignoré par Flatten
+}
+
+// Document est une pile ordonnée de calques posés sur un fond uni, du plus bas au plus haut, à
+// aplatir en une seule image avec Flatten.
+type Document struct {
+	width, height int
+	background    Pixel
+	layers        []*Layer
+}
+
+// NewDocument crée un document vide de width×height pixels, sur un fond de couleur background.
+func NewDocument(width, height int, background Pixel) *Document {
+	return &Document{width: max(width, 0), height: max(height, 0), background: background}
+}
+
+// Size renvoie la largeur et la hauteur du document.
+func (d *Document) Size() (int, int) {
+	return d.width, d.height
+}
+
+// AddLayer ajoute au sommet du document un calque opaque, en mode normal, avec l'image img placée à
+// offset. Il renvoie le calque pour en régler les autres champs.
+func (d *Document) AddLayer(name string, img Image, offset Point) *Layer {
+	layer := &Layer{Name: name, Image: img, Offset: offset, Opacity: 1}
+	d.layers = append(d.layers, layer)
+	return layer
+}
+
+// Layers renvoie les calques du document, du plus bas au plus haut.
+func (d *Document) Layers() []*Layer {
+	return append([]*Layer(nil), d.layers...)
+}
+
+// RemoveLayer enlève le calque d'indice index.
+func (d *Document) RemoveLayer(index int) error {
+	if index < 0 || index >= len(d.layers) {
+		return fmt.Errorf("layer %d does not exist (%d layers)", index, len(d.layers))
+	}
+	d.layers = append(d.layers[:index], d.layers[index+1:]...)
+	return nil
+}
+
+// MoveLayer déplace le calque d'indice from à l'indice to, les autres gardant leur ordre.
+func (d *Document) MoveLayer(from, to int) error {
+	if from < 0 || from >= len(d.layers) || to < 0 || to >= len(d.layers) {
+		return fmt.Errorf("cannot move layer %d to %d (%d layers)", from, to, len(d.layers))
+	}
+	layer := d.layers[from]
+	d.layers = append(d.layers[:from], d.layers[from+1:]...)
+	d.layers = append(d.layers[:to], append([]*Layer{layer}, d.layers[to:]...)...)
+	return nil
+}
+
+// Flatten compose les calques visibles du bas vers le haut et renvoie l'image obtenue, de valeur
+// maximale 255. Les parties des calques qui dépassent du document sont ignorées.
+func (d *Document) Flatten() *PPM {
+	// Composition en flottants pour ne pas accumuler d'arrondis d'un calque à l'autre
+	canvas := make([][3]float64, d.width*d.height)
+	for i := range canvas {
+		canvas[i] = [3]float64{float64(d.background.R) / 255, float64(d.background.G) / 255, float64(d.background.B) / 255}
+	}
+	for _, layer := range d.layers {
+		opacity := math.Min(math.Max(layer.Opacity, 0), 1)
+		if layer.Hidden || layer.Image == nil || opacity == 0 {
+			continue
+		}
+		width, height := layer.Image.Size()
+		for y := max(0, -layer.Offset.Y); y < height && layer.Offset.Y+y < d.height; y++ {
+			for x := max(0, -layer.Offset.X); x < width && layer.Offset.X+x < d.width; x++ {
+				color, ok := layerColor(layer.Image, x, y)
+				if !ok {
+					continue
+				}
+				below := &canvas[(layer.Offset.Y+y)*d.width+layer.Offset.X+x]
+				for c := range below {
+					below[c] += (layer.Mode.mix(below[c], color[c]) - below[c]) * opacity
+				}
+			}
+		}
+	}
+
+	flat := NewPPM(d.width, d.height, 255)
+	for y := 0; y < d.height; y++ {
+		for x := 0; x < d.width; x++ {
+			color := canvas[y*d.width+x]
+			flat.data[y][x] = Pixel{unitToByte(color[0]), unitToByte(color[1]), unitToByte(color[2])}
+		}
+	}
+	return flat
+}
+
+// layerColor renvoie la couleur du pixel (x, y) d'une image de calque, chaque canal entre 0 et 1.
+func layerColor(img Image, x, y int) ([3]float64, bool) {
+	switch img := img.(type) {
+	case *PPM:
+		p, scale := img.data[y][x], float64(max(img.max, 1))
+		return [3]float64{float64(p.R) / scale, float64(p.G) / scale, float64(p.B) / scale}, true
+	case *PGM:
+		v := float64(img.data[y][x]) / float64(max(img.max, 1))
+		return [3]float64{v, v, v}, true
+	case *PBM:
+		if img.data[y][x] {
+			return [3]float64{}, true
+		}
+		return [3]float64{1, 1, 1}, true
+	}
+	return [3]float64{}, false
+}
+
+// unitToByte convertit une valeur entre 0 et 1 en octet.
+func unitToByte(v float64) uint8 {
+	return uint8(math.Round(math.Min(math.Max(v, 0), 1) * 255))
+}
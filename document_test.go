@@ -0,0 +1,81 @@
+package Netpbm // 🧪 Test Documents à calques
+
+import "testing"
+
+func TestDocumentFlattenNormal(t *testing.T) {
+	doc := NewDocument(10, 10, Pixel{255, 255, 255})
+	doc.AddLayer("rouge", newUniformPPM(4, 4, Pixel{255, 0, 0}), Point{2, 2})
+	half := doc.AddLayer("bleu", newUniformPPM(4, 4, Pixel{0, 0, 255}), Point{8, 4})
+	half.Opacity = 0.5
+
+	flat := doc.Flatten()
+	if w, h := flat.Size(); w != 10 || h != 10 {
+		t.Fatalf("taille %dx%d, attendu 10x10", w, h)
+	}
+	if flat.At(0, 0) != (Pixel{255, 255, 255}) {
+		t.Errorf("fond = %v", flat.At(0, 0))
+	}
+	if flat.At(3, 3) != (Pixel{255, 0, 0}) {
+		t.Errorf("calque opaque = %v", flat.At(3, 3))
+	}
+	// Le calque à moitié transparent dépasse à droite : seules deux colonnes restent
+	if flat.At(9, 5) != (Pixel{128, 128, 255}) || flat.At(7, 5) != (Pixel{255, 255, 255}) {
+		t.Errorf("calque à 50 %% = %v, %v", flat.At(9, 5), flat.At(7, 5))
+	}
+
+	half.Hidden = true
+	if doc.Flatten().At(9, 5) != (Pixel{255, 255, 255}) {
+		t.Error("un calque masqué ne doit pas être dessiné")
+	}
+}
+
+func TestDocumentBlendModes(t *testing.T) {
+	below, layer := Pixel{200, 100, 50}, Pixel{100, 100, 100}
+	tests := []struct {
+		mode BlendMode
+		want Pixel
+	}{
+		{BlendNormal, Pixel{100, 100, 100}},
+		{BlendMultiply, Pixel{78, 39, 20}},
+		{BlendScreen, Pixel{222, 161, 130}},
+		{BlendDarken, Pixel{100, 100, 50}},
+		{BlendLighten, Pixel{200, 100, 100}},
+		{BlendDifference, Pixel{100, 0, 50}},
+		{BlendAdd, Pixel{255, 200, 150}},
+	}
+	for _, test := range tests {
+		doc := NewDocument(1, 1, below)
+		doc.AddLayer("", newUniformPPM(1, 1, layer), Point{}).Mode = test.mode
+		if got := doc.Flatten().At(0, 0); got != test.want {
+			t.Errorf("mode %d : %v, attendu %v", test.mode, got, test.want)
+		}
+	}
+}
+
+func TestDocumentGrayLayersAndOrder(t *testing.T) {
+	doc := NewDocument(2, 1, Pixel{0, 0, 0})
+	pgm := NewPGM(2, 1, 100)
+	pgm.Set(0, 0, 100)
+	pgm.Set(1, 0, 50)
+	doc.AddLayer("gris", pgm, Point{})
+	pbm := NewPBM(1, 1)
+	pbm.Set(0, 0, true)
+	doc.AddLayer("noir", pbm, Point{})
+
+	flat := doc.Flatten()
+	if flat.At(0, 0) != (Pixel{0, 0, 0}) || flat.At(1, 0) != (Pixel{128, 128, 128}) {
+		t.Errorf("pixels %v, %v", flat.At(0, 0), flat.At(1, 0))
+	}
+	if err := doc.MoveLayer(1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Layers()[0].Name != "noir" || doc.Flatten().At(0, 0) != (Pixel{255, 255, 255}) {
+		t.Error("MoveLayer ne change pas l'ordre de composition")
+	}
+	if err := doc.RemoveLayer(2); err == nil {
+		t.Error("RemoveLayer accepte un indice inexistant")
+	}
+	if err := doc.RemoveLayer(1); err != nil || len(doc.Layers()) != 1 {
+		t.Errorf("RemoveLayer : %v, %d calques", err, len(doc.Layers()))
+	}
+}
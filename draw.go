@@ -0,0 +1,114 @@
+package Netpbm // ✨ Outils de dessin
+
+import (
+	"math"
+	"sort"
+)
+
+// pointF représente un point à coordonnées réelles, utilisé pour les calculs de géométrie avant l'arrondi.
+type pointF struct {
+	X, Y float64
+}
+
+// toPoint arrondit le point au pixel le plus proche.
+func (p pointF) toPoint() Point {
+	return Point{int(math.Round(p.X)), int(math.Round(p.Y))}
+}
+
+// rotateAround fait tourner p de angle degrés autour de center (sens horaire à l'écran).
+func (p pointF) rotateAround(center pointF, angle float64) pointF {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	dx, dy := p.X-center.X, p.Y-center.Y
+	return pointF{center.X + dx*cos - dy*sin, center.Y + dx*sin + dy*cos}
+}
+
+// bresenham appelle visit pour chaque pixel du segment [p1, p2], dans l'ordre.
+func bresenham(p1, p2 Point, visit func(p Point)) {
+	x1, y1 := p1.X, p1.Y
+	x2, y2 := p2.X, p2.Y
+
+	dx := abs(x2 - x1)
+	dy := abs(y2 - y1)
+
+	sx, sy := 1, 1
+	if x1 >= x2 {
+		sx = -1
+	}
+	if y1 >= y2 {
+		sy = -1
+	}
+
+	err := dx - dy
+	for {
+		visit(Point{x1, y1})
+		if x1 == x2 && y1 == y2 {
+			return
+		}
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x1 += sx
+		}
+		if e2 < dx {
+			err += dx
+			y1 += sy
+		}
+	}
+}
+
+// stamp colore un carré de côté thickness centré sur p.
+func (ppm *PPM) stamp(p Point, thickness int, color Pixel) {
+	if thickness <= 1 {
+		ppm.SetPixel(p, color)
+		return
+	}
+	for dy := -(thickness - 1) / 2; dy <= thickness/2; dy++ {
+		for dx := -(thickness - 1) / 2; dx <= thickness/2; dx++ {
+			ppm.SetPixel(Point{p.X + dx, p.Y + dy}, color)
+		}
+	}
+}
+
+// drawThickLine trace une ligne d'épaisseur thickness entre deux points.
+func (ppm *PPM) drawThickLine(p1, p2 Point, thickness int, color Pixel) {
+	bresenham(p1, p2, func(p Point) {
+		ppm.stamp(p, thickness, color)
+	})
+}
+
+// fillPolygonF remplit un polygone quelconque par balayage de lignes (règle pair-impair).
+// Un pixel est rempli si son centre est à l'intérieur du polygone ; les bords droit et bas sont exclus,
+// si bien que deux polygones voisins ne se recouvrent pas.
+func (ppm *PPM) fillPolygonF(points []pointF, color Pixel) {
+	if len(points) < 3 {
+		return
+	}
+	minY, maxY := points[0].Y, points[0].Y
+	for _, p := range points {
+		minY = math.Min(minY, p.Y)
+		maxY = math.Max(maxY, p.Y)
+	}
+
+	var crossings []float64
+	for y := max(int(math.Ceil(minY)), 0); y <= min(int(math.Floor(maxY)), ppm.height-1); y++ {
+		crossings = scanlineCrossings(points, float64(y), crossings[:0])
+		for i := 0; i+1 < len(crossings); i += 2 {
+			for x := max(int(math.Ceil(crossings[i])), 0); x < min(int(math.Ceil(crossings[i+1])), ppm.width); x++ {
+				ppm.data[y][x] = color
+			}
+		}
+	}
+}
+
+// scanlineCrossings ajoute à crossings les abscisses, triées, où les côtés du polygone coupent la ligne y.
+// Un pixel de la ligne est à l'intérieur s'il se trouve à droite d'un nombre impair de ces abscisses.
+func scanlineCrossings(points []pointF, y float64, crossings []float64) []float64 {
+	for i, a := range points {
+		b := points[(i+1)%len(points)]
+		if (a.Y <= y && b.Y > y) || (b.Y <= y && a.Y > y) {
+			crossings = append(crossings, a.X+(y-a.Y)/(b.Y-a.Y)*(b.X-a.X))
+		}
+	}
+	sort.Float64s(crossings)
+	return crossings
+}
@@ -0,0 +1,177 @@
+package Netpbm // ✨ Effets de style
+
+import (
+	"sort"
+)
+
+// brightness renvoie la luminosité moyenne d'un pixel.
+func (p Pixel) brightness() int {
+	return (int(p.R) + int(p.G) + int(p.B)) / 3
+}
+
+// ChromaticAberration décale le canal rouge de offset et le canal bleu de -offset, le vert restant en place.
+// Les pixels lus hors de l'image sont pris sur le bord le plus proche.
+func (ppm *PPM) ChromaticAberration(offset Point) {
+	ppm.own()
+	source := make([][]Pixel, ppm.height)
+	for y := range source {
+		source[y] = append([]Pixel(nil), ppm.data[y]...)
+	}
+	at := func(x, y int) Pixel {
+		return source[min(max(y, 0), ppm.height-1)][min(max(x, 0), ppm.width-1)]
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			ppm.data[y][x].R = at(x-offset.X, y-offset.Y).R
+			ppm.data[y][x].B = at(x+offset.X, y+offset.Y).B
+		}
+	}
+}
+
+// PixelSort trie, dans chaque ligne, les suites de pixels plus lumineux que threshold par luminosité croissante.
+func (ppm *PPM) PixelSort(threshold uint8) {
+	ppm.own()
+	for y := 0; y < ppm.height; y++ {
+		row := ppm.data[y]
+		for start := 0; start < ppm.width; {
+			if row[start].brightness() <= int(threshold) {
+				start++
+				continue
+			}
+			end := start
+			for end < ppm.width && row[end].brightness() > int(threshold) {
+				end++
+			}
+			run := row[start:end]
+			sort.SliceStable(run, func(i, j int) bool {
+				return run[i].brightness() < run[j].brightness()
+			})
+			start = end
+		}
+	}
+}
+
+// ScanlineGlitch décale horizontalement des bandes de lignes tirées au hasard, en boucle,
+// et intervertit parfois leurs canaux rouge et bleu. Une même graine donne toujours le même résultat.
+func (ppm *PPM) ScanlineGlitch(seed int64) {
+	ppm.own()
+	if ppm.width == 0 || ppm.height == 0 {
+		return
+	}
+	random := newRandom(seed)
+	bands := 1 + random.Intn(max(ppm.height/8, 1))
+	for i := 0; i < bands; i++ {
+		top := random.Intn(ppm.height)
+		height := 1 + random.Intn(max(ppm.height/10, 1))
+		shift := random.Intn(ppm.width/4+1) - ppm.width/8
+		swap := random.Intn(3) == 0
+		for y := top; y < min(top+height, ppm.height); y++ {
+			shifted := make([]Pixel, ppm.width)
+			for x := 0; x < ppm.width; x++ {
+				pixel := ppm.data[y][((x-shift)%ppm.width+ppm.width)%ppm.width]
+				if swap {
+					pixel.R, pixel.B = pixel.B, pixel.R
+				}
+				shifted[x] = pixel
+			}
+			ppm.data[y] = shifted
+		}
+	}
+}
+
+// Emboss donne à l'image PPM un aspect de relief éclairé depuis le coin supérieur gauche.
+// Les zones uniformes deviennent gris moyen.
+func (ppm *PPM) Emboss() {
+	ppm.own()
+	kernel := [][]float64{{-1, -1, 0}, {-1, 0, 1}, {0, 1, 1}}
+	source := make([][]Pixel, ppm.height)
+	for y := range source {
+		source[y] = append([]Pixel(nil), ppm.data[y]...)
+	}
+	red := func(x, y int) float64 { return float64(source[y][x].R) }
+	green := func(x, y int) float64 { return float64(source[y][x].G) }
+	blue := func(x, y int) float64 { return float64(source[y][x].B) }
+	bias := float64(ppm.max) / 2
+
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			ppm.data[y][x] = Pixel{
+				R: clampChannel(bias+convolveAt(kernel, x, y, ppm.width, ppm.height, red), ppm.max),
+				G: clampChannel(bias+convolveAt(kernel, x, y, ppm.width, ppm.height, green), ppm.max),
+				B: clampChannel(bias+convolveAt(kernel, x, y, ppm.width, ppm.height, blue), ppm.max),
+			}
+		}
+	}
+}
+
+// OilPaint donne à l'image PPM un aspect de peinture à l'huile : chaque pixel prend la couleur moyenne
+// du niveau de luminosité le plus fréquent dans son voisinage de rayon radius, réparti en levels niveaux.
+func (ppm *PPM) OilPaint(radius, levels int) {
+	ppm.own()
+	if radius < 1 || levels < 1 {
+		return
+	}
+	source := make([][]Pixel, ppm.height)
+	for y := range source {
+		source[y] = append([]Pixel(nil), ppm.data[y]...)
+	}
+	counts := make([]int, levels)
+	sums := make([][3]int, levels)
+
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			for i := range counts {
+				counts[i] = 0
+				sums[i] = [3]int{}
+			}
+			for ny := max(y-radius, 0); ny <= min(y+radius, ppm.height-1); ny++ {
+				for nx := max(x-radius, 0); nx <= min(x+radius, ppm.width-1); nx++ {
+					pixel := source[ny][nx]
+					// Les échantillons au-dessus de la valeur maximale tombent dans le dernier niveau
+					level := min(pixel.brightness(), ppm.max) * levels / (ppm.max + 1)
+					counts[level]++
+					sums[level][0] += int(pixel.R)
+					sums[level][1] += int(pixel.G)
+					sums[level][2] += int(pixel.B)
+				}
+			}
+			best := 0
+			for i, count := range counts {
+				if count > counts[best] {
+					best = i
+				}
+			}
+			n := counts[best]
+			ppm.data[y][x] = Pixel{uint8(sums[best][0] / n), uint8(sums[best][1] / n), uint8(sums[best][2] / n)}
+		}
+	}
+}
+
+// Pixelate remplace chaque bloc blockSize×blockSize de l'image PPM par sa couleur moyenne.
+func (ppm *PPM) Pixelate(blockSize int) {
+	ppm.own()
+	if blockSize < 2 {
+		return
+	}
+	for by := 0; by < ppm.height; by += blockSize {
+		for bx := 0; bx < ppm.width; bx += blockSize {
+			// Les blocs du bord droit et du bas peuvent être plus petits
+			y1, x1 := min(by+blockSize, ppm.height), min(bx+blockSize, ppm.width)
+			var r, g, b int
+			for y := by; y < y1; y++ {
+				for x := bx; x < x1; x++ {
+					r += int(ppm.data[y][x].R)
+					g += int(ppm.data[y][x].G)
+					b += int(ppm.data[y][x].B)
+				}
+			}
+			n := (y1 - by) * (x1 - bx)
+			average := Pixel{uint8(r / n), uint8(g / n), uint8(b / n)}
+			for y := by; y < y1; y++ {
+				for x := bx; x < x1; x++ {
+					ppm.data[y][x] = average
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package Netpbm // 🧪 Test effets
+
+import "testing"
+
+func TestChromaticAberration(t *testing.T) {
+	ppm := NewPPM(5, 1, 255)
+	ppm.Set(2, 0, Pixel{200, 150, 100})
+	ppm.ChromaticAberration(Point{X: 1, Y: 0})
+	if ppm.At(3, 0).R != 200 || ppm.At(2, 0).R != 0 {
+		t.Error("Red channel not shifted right")
+	}
+	if ppm.At(1, 0).B != 100 || ppm.At(2, 0).B != 0 {
+		t.Error("Blue channel not shifted left")
+	}
+	if ppm.At(2, 0).G != 150 {
+		t.Error("Green channel should stay in place")
+	}
+}
+
+func TestPixelSort(t *testing.T) {
+	ppm := NewPPM(6, 1, 255)
+	values := []uint8{10, 200, 120, 180, 5, 90}
+	for x, v := range values {
+		ppm.Set(x, 0, Pixel{v, v, v})
+	}
+	ppm.PixelSort(50)
+	expected := []uint8{10, 120, 180, 200, 5, 90}
+	for x, v := range expected {
+		if ppm.At(x, 0).R != v {
+			t.Errorf("Pixel at (%d, 0) wanted %d got %d", x, v, ppm.At(x, 0).R)
+		}
+	}
+}
+
+func TestScanlineGlitch(t *testing.T) {
+	a, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	b, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	a.ScanlineGlitch(42)
+	b.ScanlineGlitch(42)
+	if a.Hash() != b.Hash() {
+		t.Error("Same seed should give the same glitch")
+	}
+	w, h := a.Size()
+	if w != imagePPMWidth || h != imagePPMHeight {
+		t.Error("Glitch should not change the size")
+	}
+}
+
+func TestEmboss(t *testing.T) {
+	ppm := NewPPM(4, 4, 255)
+	ppm.Emboss()
+	if ppm.At(1, 1) != (Pixel{128, 128, 128}) {
+		t.Errorf("Flat area should become mid gray, got %v", ppm.At(1, 1))
+	}
+	// Bord clair à gauche, sombre à droite : l'arête passe dans l'ombre
+	ppm = NewPPM(6, 6, 255)
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 3; x++ {
+			ppm.Set(x, y, Pixel{255, 255, 255})
+		}
+	}
+	ppm.Emboss()
+	if ppm.At(3, 3).R >= 128 || ppm.At(0, 3).R != 128 || ppm.At(5, 3).R != 128 {
+		t.Errorf("Edge not embossed: %v %v %v", ppm.At(0, 3), ppm.At(3, 3), ppm.At(5, 3))
+	}
+}
+
+func TestOilPaint(t *testing.T) {
+	ppm := NewPPM(5, 5, 255)
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			ppm.Set(x, y, Pixel{200, 100, 50})
+		}
+	}
+	ppm.Set(2, 2, Pixel{0, 0, 0})
+	ppm.OilPaint(1, 8)
+	if ppm.At(2, 2) != (Pixel{200, 100, 50}) {
+		t.Errorf("Isolated pixel should take the dominant color, got %v", ppm.At(2, 2))
+	}
+
+	// Échantillons au-dessus de la valeur maximale, acceptés par ReadPPM
+	bright := newUniformPPM(3, 3, Pixel{255, 255, 255})
+	bright.max = 100
+	bright.OilPaint(1, 8)
+	if bright.At(1, 1) != (Pixel{255, 255, 255}) {
+		t.Errorf("Samples above the maximum value should stay in the top level, got %v", bright.At(1, 1))
+	}
+}
+
+func TestPixelate(t *testing.T) {
+	ppm := NewPPM(3, 2, 255)
+	ppm.Set(0, 0, Pixel{100, 0, 0})
+	ppm.Set(1, 1, Pixel{0, 200, 0})
+	ppm.Set(2, 0, Pixel{0, 0, 90})
+	ppm.Set(2, 1, Pixel{0, 0, 30})
+	ppm.Pixelate(2)
+	if ppm.At(0, 0) != (Pixel{25, 50, 0}) || ppm.At(1, 1) != (Pixel{25, 50, 0}) {
+		t.Errorf("Block not averaged: %v", ppm.At(0, 0))
+	}
+	if ppm.At(2, 0) != (Pixel{0, 0, 60}) {
+		t.Errorf("Edge block not averaged: %v", ppm.At(2, 0))
+	}
+}
@@ -0,0 +1,150 @@
+package Netpbm // ✨ Préparation pour écrans à encre électronique
+
+import (
+	"math"
+	"sync"
+)
+
+// DitherMethod choisit comment une image en niveaux de gris est réduite à des pixels noirs et blancs.
+type DitherMethod int
+
+const (
+	DitherThreshold      DitherMethod = iota // Simple seuil à mi-luminosité, sans tramage
+	DitherFloydSteinberg                     // Diffusion d'erreur de Floyd-Steinberg
+	DitherAtkinson                           // Diffusion d'erreur d'Atkinson : plus contrastée, prisée sur encre électronique
+	DitherBayer                              // Tramage ordonné par la matrice de Bayer 8×8
+	DitherBlueNoise                          // Tramage par un masque de bruit bleu 64×64, sans motif visible
+)
+
+// einkBlueNoise est le masque de bruit bleu de DitherBlueNoise, calculé au premier usage.
+var einkBlueNoise = sync.OnceValue(func() *PGM {
+	return BlueNoiseMask(64, 1)
+})
+
+// srgbToLinear convertit une valeur sRGB entre 0 et 1 en luminance linéaire.
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// PrepareForEInk prépare l'image PPM pour un écran noir et blanc (encre électronique, afficheur 1 bit)
+// de width×height pixels : l'image est réduite pour tenir dans l'écran en gardant ses proportions,
+// centrée sur un fond blanc, puis tramée selon dither. Réduction et tramage se font en lumière linéaire
+// (sRGB décodé), si bien que la proportion de pixels blancs d'une zone reproduit sa luminosité réelle,
+// là où un tramage des valeurs sRGB assombrit les tons moyens.
+func (ppm *PPM) PrepareForEInk(width, height int, dither DitherMethod) *PBM {
+	scale := 1 / float64(max(ppm.max, 1))
+	return prepareForEInk(ppm.width, ppm.height, func(x, y int) float64 {
+		p := ppm.data[y][x]
+		// Luminance relative (coefficients de la recommandation BT.709, ceux de sRGB)
+		return 0.2126*srgbToLinear(float64(p.R)*scale) + 0.7152*srgbToLinear(float64(p.G)*scale) +
+			0.0722*srgbToLinear(float64(p.B)*scale)
+	}, width, height, dither)
+}
+
+// PrepareForEInk prépare l'image PGM pour un écran noir et blanc de width×height pixels, comme
+// PrepareForEInk pour une image PPM.
+func (pgm *PGM) PrepareForEInk(width, height int, dither DitherMethod) *PBM {
+	scale := 1 / float64(max(pgm.max, 1))
+	return prepareForEInk(pgm.width, pgm.height, func(x, y int) float64 {
+		return srgbToLinear(float64(pgm.data[y][x]) * scale)
+	}, width, height, dither)
+}
+
+// prepareForEInk réduit l'image srcWidth×srcHeight dont luminance donne la luminance linéaire de chaque
+// pixel, la centre dans width×height pixels blancs et la trame selon dither.
+func prepareForEInk(srcWidth, srcHeight int, luminance func(x, y int) float64, width, height int, dither DitherMethod) *PBM {
+	width, height = max(width, 0), max(height, 0)
+	screen := make([][]float64, height)
+	for y := range screen {
+		screen[y] = make([]float64, width)
+		for x := range screen[y] {
+			screen[y][x] = 1
+		}
+	}
+
+	if srcWidth > 0 && srcHeight > 0 && width > 0 && height > 0 {
+		scale := math.Min(float64(width)/float64(srcWidth), float64(height)/float64(srcHeight))
+		fitWidth := min(max(int(math.Round(float64(srcWidth)*scale)), 1), width)
+		fitHeight := min(max(int(math.Round(float64(srcHeight)*scale)), 1), height)
+		origin := GravityCenter.place(width, height, fitWidth, fitHeight)
+
+		// Réduction séparable : d'abord les lignes, puis les colonnes
+		xTaps, yTaps := axisTaps(srcWidth, fitWidth, Triangle), axisTaps(srcHeight, fitHeight, Triangle)
+		rows := make([][]float64, srcHeight)
+		for y := range rows {
+			rows[y] = make([]float64, fitWidth)
+			for x, taps := range xTaps {
+				for _, t := range taps {
+					rows[y][x] += t.weight * luminance(t.index, y)
+				}
+			}
+		}
+		for y, taps := range yTaps {
+			for x := 0; x < fitWidth; x++ {
+				v := 0.0
+				for _, t := range taps {
+					v += t.weight * rows[t.index][x]
+				}
+				screen[origin.Y+y][origin.X+x] = v
+			}
+		}
+	}
+
+	return ditherLinear(screen, dither)
+}
+
+// ditherLinear trame la luminance linéaire (entre 0 et 1) de chaque pixel, indexée par [y][x], en une
+// image PBM. Les diffusions d'erreur modifient screen.
+func ditherLinear(screen [][]float64, dither DitherMethod) *PBM {
+	height, width := len(screen), 0
+	if height > 0 {
+		width = len(screen[0])
+	}
+	pbm := NewPBM(width, height)
+
+	// Répartition de l'erreur de chaque pixel sur ses voisins : décalages et poids
+	type spread struct {
+		dx, dy int
+		weight float64
+	}
+	var diffusion []spread
+	switch dither {
+	case DitherFloydSteinberg:
+		diffusion = []spread{{1, 0, 7.0 / 16}, {-1, 1, 3.0 / 16}, {0, 1, 5.0 / 16}, {1, 1, 1.0 / 16}}
+	case DitherAtkinson:
+		// Seuls les trois quarts de l'erreur sont répartis : les aplats très clairs ou très sombres
+		// restent unis
+		diffusion = []spread{{1, 0, 1.0 / 8}, {2, 0, 1.0 / 8}, {-1, 1, 1.0 / 8}, {0, 1, 1.0 / 8}, {1, 1, 1.0 / 8}, {0, 2, 1.0 / 8}}
+	}
+	bayer, noise := BayerMatrix(8), einkBlueNoise
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v, threshold := screen[y][x], 0.5
+			switch dither {
+			case DitherBayer:
+				threshold = (float64(bayer[y%8][x%8]) + 0.5) / 64
+			case DitherBlueNoise:
+				mask := noise()
+				threshold = (float64(mask.data[y%mask.height][x%mask.width]) + 0.5) / 256
+			}
+			black := v < threshold
+			pbm.data[y][x] = black
+			if diffusion == nil {
+				continue
+			}
+			err := v
+			if !black {
+				err = v - 1
+			}
+			for _, s := range diffusion {
+				if nx, ny := x+s.dx, y+s.dy; nx >= 0 && nx < width && ny < height {
+					screen[ny][nx] += err * s.weight
+				}
+			}
+		}
+	}
+	return pbm
+}
@@ -0,0 +1,41 @@
+package Netpbm // 🧪 Test Préparation pour écrans à encre électronique
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrepareForEInkGamma(t *testing.T) {
+	// Un gris sRGB de 128 ne renvoie que 21,6 % de la lumière : environ 78 % des pixels doivent être noirs
+	gray := newUniformPGM(128, 128, 128)
+	want := 1 - srgbToLinear(128.0/255)
+	for _, method := range []DitherMethod{DitherFloydSteinberg, DitherAtkinson, DitherBayer, DitherBlueNoise} {
+		pbm := gray.PrepareForEInk(64, 64, method)
+		got := float64(countBlack(pbm)) / (64 * 64)
+		tolerance := 0.02
+		if method == DitherAtkinson {
+			tolerance = 0.08 // L'erreur n'est diffusée qu'aux trois quarts : les tons sombres noircissent
+		}
+		if math.Abs(got-want) > tolerance {
+			t.Errorf("méthode %d : %.3f de pixels noirs, attendu %.3f", method, got, want)
+		}
+	}
+	if pbm := gray.PrepareForEInk(8, 8, DitherThreshold); countBlack(pbm) != 64 {
+		t.Errorf("seuil : %d pixels noirs, attendu 64", countBlack(pbm))
+	}
+}
+
+func TestPrepareForEInkFit(t *testing.T) {
+	ppm := newUniformPPM(100, 50, Pixel{0, 0, 0})
+	pbm := ppm.PrepareForEInk(40, 40, DitherFloydSteinberg)
+	if w, h := pbm.Size(); w != 40 || h != 40 {
+		t.Fatalf("taille %dx%d, attendu 40x40", w, h)
+	}
+	// L'image réduite à 40×20 est centrée verticalement sur du blanc
+	if countBlack(pbm) != 40*20 || pbm.At(0, 9) || !pbm.At(0, 10) || !pbm.At(39, 29) || pbm.At(39, 30) {
+		t.Errorf("%d pixels noirs, bandes blanches mal placées", countBlack(pbm))
+	}
+	if empty := NewPPM(0, 0, 255).PrepareForEInk(4, 4, DitherBayer); countBlack(empty) != 0 {
+		t.Error("une image vide doit donner un écran blanc")
+	}
+}
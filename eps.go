@@ -0,0 +1,55 @@
+package Netpbm // ✨ Export PostScript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ToEPS écrit l'image PBM sous forme de document PostScript encapsulé (EPS), imprimée à dpi pixels par pouce,
+// par exemple pour l'inclure dans un document LaTeX. Les pixels sont codés en hexadécimal pour l'opérateur image.
+func (pbm *PBM) ToEPS(w io.Writer, dpi int) error {
+	if dpi <= 0 {
+		return fmt.Errorf("invalid resolution %d dpi", dpi)
+	}
+	// Taille en points PostScript (1/72 de pouce)
+	width := float64(pbm.width) * 72 / float64(dpi)
+	height := float64(pbm.height) * 72 / float64(dpi)
+	rowBytes := (pbm.width + 7) / 8
+
+	writer := bufio.NewWriter(w)
+	fmt.Fprintln(writer, "%!PS-Adobe-3.0 EPSF-3.0")
+	fmt.Fprintln(writer, "%%Creator: Netpbm")
+	fmt.Fprintf(writer, "%%%%BoundingBox: 0 0 %d %d\n", int(math.Ceil(width)), int(math.Ceil(height)))
+	fmt.Fprintf(writer, "%%%%HiResBoundingBox: 0 0 %g %g\n", width, height)
+	writer.WriteString("%%EndComments\n")
+	fmt.Fprintln(writer, "gsave")
+	fmt.Fprintf(writer, "/picstr %d string def\n", rowBytes)
+	fmt.Fprintf(writer, "%g %g scale\n", width, height)
+	fmt.Fprintf(writer, "%d %d 1 [%[1]d 0 0 -%[2]d 0 %[2]d] {currentfile picstr readhexstring pop} image\n", pbm.width, pbm.height)
+
+	// En PostScript, un bit à 1 est blanc : les bits de PBM sont donc inversés
+	column := 0
+	for _, row := range pbm.data {
+		bytes := make([]byte, rowBytes)
+		for x, pixel := range row {
+			if !pixel {
+				bytes[x/8] |= 1 << (7 - uint(x%8))
+			}
+		}
+		for _, b := range bytes {
+			fmt.Fprintf(writer, "%02x", b)
+			if column += 2; column >= 72 {
+				fmt.Fprintln(writer)
+				column = 0
+			}
+		}
+	}
+	if column > 0 {
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprintln(writer, "grestore")
+	writer.WriteString("%%EOF\n")
+	return writer.Flush()
+}
@@ -0,0 +1,47 @@
+package Netpbm // 🧪 Test export PostScript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToEPS(t *testing.T) {
+	pbm := NewPBM(10, 2)
+	pbm.data[0][0] = true
+	pbm.data[1][9] = true
+
+	var eps strings.Builder
+	if err := pbm.ToEPS(&eps, 144); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"%!PS-Adobe-3.0 EPSF-3.0\n",
+		"%%BoundingBox: 0 0 5 1\n",
+		"%%HiResBoundingBox: 0 0 5 1\n",
+		"/picstr 2 string def\n",
+		"5 1 scale\n",
+		"10 2 1 [10 0 0 -2 0 2] {currentfile picstr readhexstring pop} image\n",
+		// Ligne 0 : 0111 1111 1100 0000 ; ligne 1 : 1111 1111 1000 0000 (bits de remplissage à 0)
+		"7fc0ff80\n",
+		"%%EOF\n",
+	} {
+		if !strings.Contains(eps.String(), want) {
+			t.Errorf("EPS should contain %q:\n%s", want, eps.String())
+		}
+	}
+}
+
+func TestToEPSLongRows(t *testing.T) {
+	pbm := NewPBM(400, 1)
+	var eps strings.Builder
+	if err := pbm.ToEPS(&eps, 72); err != nil {
+		t.Fatal(err)
+	}
+	// 50 octets, soit 100 chiffres hexadécimaux répartis sur deux lignes
+	if !strings.Contains(eps.String(), strings.Repeat("ff", 36)+"\n"+strings.Repeat("ff", 14)+"\ngrestore") {
+		t.Errorf("Hex data should be wrapped at 72 characters:\n%s", eps.String())
+	}
+	if err := pbm.ToEPS(&eps, 0); err == nil {
+		t.Error("Expected an error for a zero resolution")
+	}
+}
@@ -0,0 +1,124 @@
+package Netpbm // ✨ Fusion d'expositions
+
+import (
+	"fmt"
+	"math"
+)
+
+// ExposureWeights règle l'importance des trois critères de la fusion d'expositions de Mertens.
+// Chaque valeur est un exposant : 0 ignore le critère, 1 lui donne son poids normal.
+type ExposureWeights struct {
+	Contrast    float64 // Favorise les zones détaillées (réponse d'un filtre laplacien)
+	Saturation  float64 // Favorise les couleurs vives (écart type des trois canaux)
+	Exposedness float64 // Favorise les valeurs proches du gris moyen
+}
+
+// DefaultExposureWeights donne le même poids aux trois critères.
+var DefaultExposureWeights = ExposureWeights{Contrast: 1, Saturation: 1, Exposedness: 1}
+
+// MergeExposures fusionne une série d'images PPM de même taille prises avec des expositions différentes
+// (fusion d'expositions de Mertens) et renvoie une seule image correctement exposée.
+func MergeExposures(frames []*PPM, weights ExposureWeights) (*PPM, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames to merge")
+	}
+	width, height := frames[0].width, frames[0].height
+	for i, frame := range frames {
+		if frame.width != width || frame.height != height {
+			return nil, fmt.Errorf("frame %d has size %dx%d, expected %dx%d", i, frame.width, frame.height, width, height)
+		}
+	}
+
+	// Poids de chaque image, normalisés pour que leur somme vaille 1 en chaque pixel
+	maps := make([]*rgbPlane, len(frames))
+	for i, frame := range frames {
+		maps[i] = exposureWeightMap(frame, weights)
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			total := 0.0
+			for _, m := range maps {
+				total += m.data[y][x][0]
+			}
+			for _, m := range maps {
+				w := 1 / float64(len(maps))
+				if total > 0 {
+					w = m.data[y][x][0] / total
+				}
+				m.data[y][x] = [3]float64{w, w, w}
+			}
+		}
+	}
+
+	// Mélange bande par bande pour éviter les halos
+	levels := int(math.Log2(float64(min(width, height))))
+	var merged *LaplacianPyramid
+	for i, frame := range frames {
+		pyramid := frame.BuildLaplacianPyramid(levels)
+		weightPyramid := gaussianPlanes(maps[i], levels)
+		if merged == nil {
+			merged = &LaplacianPyramid{max: frame.max}
+			for _, band := range pyramid.levels {
+				merged.levels = append(merged.levels, newRGBPlane(band.width, band.height))
+			}
+		}
+		for l, band := range pyramid.levels {
+			for y := range band.data {
+				for x := range band.data[y] {
+					for c := 0; c < 3; c++ {
+						merged.levels[l].data[y][x][c] += weightPyramid[l].data[y][x][c] * band.data[y][x][c]
+					}
+				}
+			}
+		}
+	}
+	return merged.Reconstruct(), nil
+}
+
+// MergeExposuresPGM fusionne une série d'images PGM de même taille prises avec des expositions différentes.
+// Le critère de saturation n'a pas de sens en niveaux de gris et il est ignoré.
+func MergeExposuresPGM(frames []*PGM, weights ExposureWeights) (*PGM, error) {
+	colored := make([]*PPM, len(frames))
+	for i, frame := range frames {
+		colored[i] = frame.ToPPM()
+	}
+	weights.Saturation = 0
+	merged, err := MergeExposures(colored, weights)
+	if err != nil {
+		return nil, err
+	}
+	pgm := merged.ToPGM()
+	pgm.max = merged.max
+	return pgm, nil
+}
+
+// exposureWeightMap calcule le poids de Mertens de chaque pixel de l'image (identique sur les trois canaux).
+func exposureWeightMap(ppm *PPM, weights ExposureWeights) *rgbPlane {
+	plane := newRGBPlane(ppm.width, ppm.height)
+	scale := float64(ppm.max)
+	gray := func(x, y int) float64 {
+		x, y = min(max(x, 0), ppm.width-1), min(max(y, 0), ppm.height-1)
+		return float64(ppm.data[y][x].brightness()) / scale
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			pixel := ppm.data[y][x]
+			r, g, b := float64(pixel.R)/scale, float64(pixel.G)/scale, float64(pixel.B)/scale
+
+			contrast := math.Abs(gray(x-1, y) + gray(x+1, y) + gray(x, y-1) + gray(x, y+1) - 4*gray(x, y))
+			mean := (r + g + b) / 3
+			saturation := math.Sqrt(((r-mean)*(r-mean) + (g-mean)*(g-mean) + (b-mean)*(b-mean)) / 3)
+			exposedness := 1.0
+			for _, v := range []float64{r, g, b} {
+				exposedness *= math.Exp(-(v - 0.5) * (v - 0.5) / (2 * 0.2 * 0.2))
+			}
+
+			// Un petit terme évite qu'un critère nul n'annule complètement le poids
+			w := math.Pow(contrast+1e-3, weights.Contrast) *
+				math.Pow(saturation+1e-3, weights.Saturation) *
+				math.Pow(exposedness+1e-3, weights.Exposedness)
+			plane.data[y][x] = [3]float64{w, w, w}
+		}
+	}
+	return plane
+}
@@ -0,0 +1,69 @@
+package Netpbm // 🧪 Test fusion d'expositions
+
+import "testing"
+
+func TestMergeExposures(t *testing.T) {
+	frames := []*PPM{
+		newUniformPPM(16, 16, Pixel{10, 10, 10}),
+		newUniformPPM(16, 16, Pixel{128, 128, 128}),
+		newUniformPPM(16, 16, Pixel{250, 250, 250}),
+	}
+	merged, err := MergeExposures(frames, DefaultExposureWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	width, height := merged.Size()
+	if width != 16 || height != 16 {
+		t.Fatalf("Wrong size: %dx%d", width, height)
+	}
+	// L'image bien exposée domine le résultat
+	if r := merged.At(8, 8).R; r < 110 || r > 145 {
+		t.Errorf("Merged pixel should be close to mid-gray, got %d", r)
+	}
+}
+
+func TestMergeExposuresPerRegion(t *testing.T) {
+	// Chaque image n'est bien exposée que sur une moitié
+	dark := newUniformPPM(32, 32, Pixel{5, 5, 5})
+	bright := newUniformPPM(32, 32, Pixel{250, 250, 250})
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 16; x++ {
+			dark.data[y][x] = Pixel{120, 120, 120}
+		}
+		for x := 16; x < 32; x++ {
+			bright.data[y][x] = Pixel{140, 140, 140}
+		}
+	}
+	merged, err := MergeExposures([]*PPM{dark, bright}, DefaultExposureWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sur une si petite image, les basses fréquences sont mélangées : on vérifie seulement
+	// que les deux moitiés échappent au noir et au blanc
+	for _, x := range []int{2, 29} {
+		if r := merged.At(x, 16).R; r < 40 || r > 215 {
+			t.Errorf("Pixel at x=%d should be well exposed, got %d", x, r)
+		}
+	}
+}
+
+func TestMergeExposuresErrors(t *testing.T) {
+	if _, err := MergeExposures(nil, DefaultExposureWeights); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+	frames := []*PPM{newUniformPPM(8, 8, Pixel{}), newUniformPPM(8, 4, Pixel{})}
+	if _, err := MergeExposures(frames, DefaultExposureWeights); err == nil {
+		t.Error("Expected an error for mismatched sizes")
+	}
+}
+
+func TestMergeExposuresPGM(t *testing.T) {
+	frames := []*PGM{newUniformPGM(16, 16, 15), newUniformPGM(16, 16, 130), newUniformPGM(16, 16, 245)}
+	merged, err := MergeExposuresPGM(frames, DefaultExposureWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := merged.At(8, 8); v < 110 || v > 150 {
+		t.Errorf("Merged pixel should be close to mid-gray, got %d", v)
+	}
+}
@@ -0,0 +1,28 @@
+package Netpbm // ✨ Boucles optimisées
+
+// thresholdTable renvoie la table de seuillage : 0 sous level, maxValue à partir de level.
+func thresholdTable(level, maxValue uint8) *[256]uint8 {
+	var table [256]uint8
+	for v := int(level); v < 256; v++ {
+		table[v] = maxValue
+	}
+	return &table
+}
+
+// mapBytes remplace chaque octet de b par son image dans la table, sans branchement.
+func mapBytes(b []uint8, table *[256]uint8) {
+	for i, v := range b {
+		b[i] = table[v]
+	}
+}
+
+// grayRow écrit dans dst la moyenne des trois canaux de chaque pixel de src, arrondie à l'entier
+// inférieur. La division par 3 est remplacée par une multiplication et un décalage, exacts pour
+// toute somme de trois octets (au plus 765).
+func grayRow(dst []uint8, src []Pixel) {
+	dst = dst[:len(src)]
+	for i, p := range src {
+		sum := uint32(p.R) + uint32(p.G) + uint32(p.B)
+		dst[i] = uint8(sum * 0xAAAB >> 17)
+	}
+}
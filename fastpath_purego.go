@@ -0,0 +1,24 @@
+//go:build purego
+
+package Netpbm // ✨ Boucles portables, sans unsafe
+
+// invertBytes remplace chaque octet v de b par maxValue - v.
+func invertBytes(b []uint8, maxValue uint8) {
+	for i, v := range b {
+		b[i] = maxValue - v
+	}
+}
+
+// invertPixels inverse les trois canaux de chaque pixel.
+func invertPixels(row []Pixel, maxValue uint8) {
+	for i, p := range row {
+		row[i] = Pixel{maxValue - p.R, maxValue - p.G, maxValue - p.B}
+	}
+}
+
+// thresholdPixels applique la table de seuillage aux trois canaux de chaque pixel.
+func thresholdPixels(row []Pixel, table *[256]uint8) {
+	for i, p := range row {
+		row[i] = Pixel{table[p.R], table[p.G], table[p.B]}
+	}
+}
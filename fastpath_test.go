@@ -0,0 +1,113 @@
+package Netpbm // 🧪 Test boucles optimisées
+
+import "testing"
+
+func TestFastPathsMatchNaive(t *testing.T) {
+	row := make([]uint8, 37)
+	for i := range row {
+		row[i] = uint8(i * 7)
+	}
+	for _, maxValue := range []uint8{255, 200} {
+		b := append([]uint8(nil), row...)
+		invertBytes(b, maxValue)
+		for i, v := range row {
+			if b[i] != maxValue-v {
+				t.Fatalf("max %d: invertBytes(%d) = %d", maxValue, v, b[i])
+			}
+		}
+	}
+
+	pixels := make([]Pixel, 19)
+	for i := range pixels {
+		pixels[i] = Pixel{uint8(i * 13), uint8(255 - i), uint8(i * i)}
+	}
+	inverted := append([]Pixel(nil), pixels...)
+	invertPixels(inverted, 255)
+	thresholded := append([]Pixel(nil), pixels...)
+	thresholdPixels(thresholded, thresholdTable(100, 255))
+	gray := make([]uint8, len(pixels))
+	grayRow(gray, pixels)
+	for i, p := range pixels {
+		if inverted[i] != (Pixel{255 - p.R, 255 - p.G, 255 - p.B}) {
+			t.Errorf("Unexpected inverted pixel %v", inverted[i])
+		}
+		if (thresholded[i].R == 255) != (p.R >= 100) || (thresholded[i].B == 0) != (p.B < 100) {
+			t.Errorf("Unexpected thresholded pixel %v for %v", thresholded[i], p)
+		}
+		if gray[i] != uint8((int(p.R)+int(p.G)+int(p.B))/3) {
+			t.Errorf("Unexpected gray %d for %v", gray[i], p)
+		}
+	}
+
+	// La division par multiplication est exacte pour toutes les sommes possibles
+	for sum := uint32(0); sum <= 765; sum++ {
+		if sum*0xAAAB>>17 != sum/3 {
+			t.Fatalf("Wrong division for %d", sum)
+		}
+	}
+}
+
+// Images 4K (3840×2160) pour les mesures de performance
+const benchWidth, benchHeight = 3840, 2160
+
+func BenchmarkInvertPGM4K(b *testing.B) {
+	pgm := newGradientPGM(benchWidth, benchHeight)
+	b.SetBytes(benchWidth * benchHeight)
+	for i := 0; i < b.N; i++ {
+		pgm.Invert()
+	}
+}
+
+func BenchmarkInvertPGM4KNaive(b *testing.B) {
+	pgm := newGradientPGM(benchWidth, benchHeight)
+	b.SetBytes(benchWidth * benchHeight)
+	for i := 0; i < b.N; i++ {
+		for _, row := range pgm.data {
+			for x, v := range row {
+				row[x] = uint8(pgm.max) - v
+			}
+		}
+	}
+}
+
+func BenchmarkInvertPPM4K(b *testing.B) {
+	ppm := newPatternPPM(benchWidth, benchHeight)
+	b.SetBytes(3 * benchWidth * benchHeight)
+	for i := 0; i < b.N; i++ {
+		ppm.Invert()
+	}
+}
+
+func BenchmarkThresholdPPM4K(b *testing.B) {
+	ppm := newPatternPPM(benchWidth, benchHeight)
+	b.SetBytes(3 * benchWidth * benchHeight)
+	for i := 0; i < b.N; i++ {
+		ppm.Threshold(128)
+	}
+}
+
+func BenchmarkThresholdPPM4KNaive(b *testing.B) {
+	ppm := newPatternPPM(benchWidth, benchHeight)
+	b.SetBytes(3 * benchWidth * benchHeight)
+	threshold := func(v uint8) uint8 {
+		if v < 128 {
+			return 0
+		}
+		return 255
+	}
+	for i := 0; i < b.N; i++ {
+		for _, row := range ppm.data {
+			for x, p := range row {
+				row[x] = Pixel{threshold(p.R), threshold(p.G), threshold(p.B)}
+			}
+		}
+	}
+}
+
+func BenchmarkToPGM4K(b *testing.B) {
+	ppm := newPatternPPM(benchWidth, benchHeight)
+	b.SetBytes(3 * benchWidth * benchHeight)
+	for i := 0; i < b.N; i++ {
+		ppm.ToPGM()
+	}
+}
@@ -0,0 +1,46 @@
+//go:build !purego
+
+package Netpbm // ✨ Boucles optimisées par mots de 64 bits
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// invertBytes remplace chaque octet v de b par maxValue - v. Pour maxValue = 255, cela revient à
+// inverser tous les bits : les octets sont alors traités par mots de 64 bits.
+func invertBytes(b []uint8, maxValue uint8) {
+	if maxValue != 255 {
+		for i, v := range b {
+			b[i] = maxValue - v
+		}
+		return
+	}
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		word := b[i : i+8 : i+8]
+		binary.NativeEndian.PutUint64(word, ^binary.NativeEndian.Uint64(word))
+	}
+	for ; i < len(b); i++ {
+		b[i] = ^b[i]
+	}
+}
+
+// pixelBytes renvoie les octets des canaux des pixels, sans copie : Pixel est formé de trois octets
+// consécutifs, sans remplissage.
+func pixelBytes(row []Pixel) []uint8 {
+	if len(row) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*uint8)(unsafe.Pointer(&row[0])), 3*len(row))
+}
+
+// invertPixels inverse les trois canaux de chaque pixel.
+func invertPixels(row []Pixel, maxValue uint8) {
+	invertBytes(pixelBytes(row), maxValue)
+}
+
+// thresholdPixels applique la table de seuillage aux trois canaux de chaque pixel.
+func thresholdPixels(row []Pixel, table *[256]uint8) {
+	mapBytes(pixelBytes(row), table)
+}
@@ -0,0 +1,250 @@
+package Netpbm // ✨ Transformée de Fourier et filtrage fréquentiel
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// Spectrum est la transformée de Fourier discrète d'une image PGM : un coefficient complexe par
+// fréquence, indexé comme l'image. Ses filtres le modifient sur place ; Inverse reconstruit l'image.
+// Les fréquences sont comptées en cycles par image, positives ou négatives, comme dans l'image Magnitude
+// où la fréquence nulle est au centre.
+type Spectrum struct {
+	data          [][]complex128
+	width, height int
+	max           int // Valeur maximale de l'image d'origine
+}
+
+// FFT renvoie la transformée de Fourier discrète de l'image PGM, calculée par transformée rapide
+// (algorithme de Bluestein quand une dimension n'est pas une puissance de 2).
+func (pgm *PGM) FFT() *Spectrum {
+	s := &Spectrum{data: make([][]complex128, pgm.height), width: pgm.width, height: pgm.height, max: pgm.max}
+	for y := range s.data {
+		s.data[y] = make([]complex128, pgm.width)
+		for x, v := range pgm.data[y] {
+			s.data[y][x] = complex(float64(v), 0)
+		}
+	}
+	s.transform(false)
+	return s
+}
+
+// Inverse reconstruit l'image PGM à partir du spectre, avec la valeur maximale de l'image d'origine.
+func (s *Spectrum) Inverse() *PGM {
+	inverse := &Spectrum{data: make([][]complex128, s.height), width: s.width, height: s.height}
+	for y := range inverse.data {
+		inverse.data[y] = append([]complex128(nil), s.data[y]...)
+	}
+	inverse.transform(true)
+	pgm := NewPGM(s.width, s.height, s.max)
+	for y := range pgm.data {
+		for x := range pgm.data[y] {
+			pgm.data[y][x] = clampChannel(real(inverse.data[y][x]), s.max)
+		}
+	}
+	return pgm
+}
+
+// Magnitude renvoie l'amplitude du spectre en image PGM 8 bits, sur une échelle logarithmique
+// (log(1 + |F|)) et avec la fréquence nulle au centre : les bruits périodiques y forment des points
+// brillants symétriques, à retirer avec Notch.
+func (s *Spectrum) Magnitude() *PGM {
+	values := s.centered(func(c complex128) float64 { return math.Log1p(cmplx.Abs(c)) })
+	highest := 0.0
+	for y := range values {
+		for _, v := range values[y] {
+			highest = math.Max(highest, v)
+		}
+	}
+	pgm := NewPGM(s.width, s.height, 255)
+	for y := range values {
+		for x, v := range values[y] {
+			if highest > 0 {
+				pgm.data[y][x] = clampChannel(v/highest*255, 255)
+			}
+		}
+	}
+	return pgm
+}
+
+// Phase renvoie la phase du spectre en image PGM 8 bits, de -π (noir) à π (blanc), avec la fréquence
+// nulle au centre.
+func (s *Spectrum) Phase() *PGM {
+	values := s.centered(cmplx.Phase)
+	pgm := NewPGM(s.width, s.height, 255)
+	for y := range values {
+		for x, v := range values[y] {
+			pgm.data[y][x] = clampChannel((v+math.Pi)/(2*math.Pi)*255, 255)
+		}
+	}
+	return pgm
+}
+
+// LowPass atténue les hautes fréquences par un filtre gaussien : les fréquences de cutoff cycles par
+// pixel (entre 0 et 0,5) sont atténuées d'environ 40 %, les plus hautes bien davantage. L'image devient
+// plus floue, sans les oscillations d'une coupure nette.
+func (s *Spectrum) LowPass(cutoff float64) {
+	s.filter(func(fx, fy float64) float64 {
+		return math.Exp(-(fx*fx + fy*fy) / (2 * cutoff * cutoff))
+	})
+}
+
+// HighPass atténue les basses fréquences, en complément de LowPass avec la même coupure : seuls les bords
+// et les détails fins restent. La fréquence nulle est conservée, si bien que la luminosité moyenne de
+// l'image ne change pas.
+func (s *Spectrum) HighPass(cutoff float64) {
+	s.filter(func(fx, fy float64) float64 {
+		if fx == 0 && fy == 0 {
+			return 1
+		}
+		return 1 - math.Exp(-(fx*fx+fy*fy)/(2*cutoff*cutoff))
+	})
+}
+
+// Notch retire la fréquence de u cycles par largeur d'image et v cycles par hauteur d'image, et sa
+// symétrique (-u, -v), par un creux gaussien de radius cycles : c'est la fréquence placée en (u, v) par
+// rapport au centre de l'image Magnitude. Retirer les points brillants d'un bruit périodique (trame,
+// rayures de scanner) le fait disparaître sans flouter le reste de l'image.
+func (s *Spectrum) Notch(u, v int, radius float64) {
+	radius = math.Max(radius, 1e-9)
+	s.filter(func(fx, fy float64) float64 {
+		x, y := fx*float64(s.width), fy*float64(s.height) // En cycles par image
+		gain := 1.0
+		for _, sign := range [2]float64{1, -1} {
+			dx, dy := x-sign*float64(u), y-sign*float64(v)
+			gain *= 1 - math.Exp(-(dx*dx+dy*dy)/(2*radius*radius))
+		}
+		return gain
+	})
+}
+
+// filter multiplie chaque coefficient du spectre par gain(fx, fy), les fréquences étant en cycles par
+// pixel, entre -0,5 et 0,5.
+func (s *Spectrum) filter(gain func(fx, fy float64) float64) {
+	for y := range s.data {
+		fy := float64(signedFrequency(y, s.height)) / float64(s.height)
+		for x := range s.data[y] {
+			fx := float64(signedFrequency(x, s.width)) / float64(s.width)
+			s.data[y][x] *= complex(gain(fx, fy), 0)
+		}
+	}
+}
+
+// centered renvoie value de chaque coefficient, disposé avec la fréquence nulle au centre de l'image.
+func (s *Spectrum) centered(value func(complex128) float64) [][]float64 {
+	values := make([][]float64, s.height)
+	for y := range values {
+		values[y] = make([]float64, s.width)
+		for x := range values[y] {
+			values[y][x] = value(s.data[(y+s.height-s.height/2)%s.height][(x+s.width-s.width/2)%s.width])
+		}
+	}
+	return values
+}
+
+// signedFrequency renvoie la fréquence, en cycles par image, du coefficient d'indice k sur n : les
+// indices de la seconde moitié sont les fréquences négatives.
+func signedFrequency(k, n int) int {
+	if k > n/2 {
+		return k - n
+	}
+	return k
+}
+
+// transform calcule sur place la transformée (ou la transformée inverse) du spectre, lignes puis colonnes.
+func (s *Spectrum) transform(inverse bool) {
+	for _, row := range s.data {
+		fft(row, inverse)
+	}
+	column := make([]complex128, s.height)
+	for x := 0; x < s.width; x++ {
+		for y := range column {
+			column[y] = s.data[y][x]
+		}
+		fft(column, inverse)
+		for y, c := range column {
+			s.data[y][x] = c
+		}
+	}
+}
+
+// fft calcule sur place la transformée de Fourier discrète de a, de longueur quelconque ; la transformée
+// inverse est divisée par la longueur.
+func fft(a []complex128, inverse bool) {
+	n := len(a)
+	if n <= 1 {
+		return
+	}
+	if inverse {
+		// Transformée inverse par conjugaison : conj(FFT(conj(a))) / n
+		for i := range a {
+			a[i] = cmplx.Conj(a[i])
+		}
+		fft(a, false)
+		for i := range a {
+			a[i] = cmplx.Conj(a[i]) / complex(float64(n), 0)
+		}
+		return
+	}
+	if n&(n-1) == 0 {
+		fftRadix2(a)
+		return
+	}
+
+	// Algorithme de Bluestein : la transformée devient une convolution, calculée par des transformées de
+	// longueur m, puissance de 2
+	m := 1 << bits.Len(uint(2*n-1))
+	chirp := make([]complex128, n)
+	for k := range chirp {
+		// k² est réduit modulo 2n avant la multiplication par π/n, pour garder la précision
+		angle := math.Pi * float64(k*k%(2*n)) / float64(n)
+		chirp[k] = cmplx.Rect(1, -angle)
+	}
+	x, y := make([]complex128, m), make([]complex128, m)
+	for k := 0; k < n; k++ {
+		x[k] = a[k] * chirp[k]
+	}
+	y[0] = cmplx.Conj(chirp[0])
+	for k := 1; k < n; k++ {
+		y[k] = cmplx.Conj(chirp[k])
+		y[m-k] = y[k]
+	}
+	fftRadix2(x)
+	fftRadix2(y)
+	for i := range x {
+		x[i] *= y[i]
+	}
+	fft(x, true)
+	for k := range a {
+		a[k] = x[k] * chirp[k]
+	}
+}
+
+// fftRadix2 calcule sur place la transformée de Fourier de a, dont la longueur est une puissance de 2
+// (algorithme de Cooley-Tukey itératif).
+func fftRadix2(a []complex128) {
+	n := len(a)
+	// Permutation par inversion des bits des indices
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, -2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even, odd := a[start+k], a[start+k+size/2]*w
+				a[start+k], a[start+k+size/2] = even+odd, even-odd
+				w *= step
+			}
+		}
+	}
+}
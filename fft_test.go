@@ -0,0 +1,117 @@
+package Netpbm // 🧪 Test Transformée de Fourier et filtrage fréquentiel
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestFFTMatchesDFT(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 8, 12} {
+		a := make([]complex128, n)
+		for i := range a {
+			a[i] = complex(float64(i*i%7), float64(i%3))
+		}
+		want := make([]complex128, n)
+		for k := range want {
+			for j, v := range a {
+				want[k] += v * cmplx.Rect(1, -2*math.Pi*float64(j*k)/float64(n))
+			}
+		}
+		got := append([]complex128(nil), a...)
+		fft(got, false)
+		for k := range want {
+			if cmplx.Abs(got[k]-want[k]) > 1e-9 {
+				t.Errorf("n = %d, coefficient %d = %v, attendu %v", n, k, got[k], want[k])
+			}
+		}
+		fft(got, true)
+		for k := range a {
+			if cmplx.Abs(got[k]-a[k]) > 1e-9 {
+				t.Errorf("n = %d, aller-retour %d = %v, attendu %v", n, k, got[k], a[k])
+			}
+		}
+	}
+}
+
+func TestFFTRoundTrip(t *testing.T) {
+	pgm := NewPGM(10, 7, 255)
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 10; x++ {
+			pgm.Set(x, y, uint8((x*37+y*91)%256))
+		}
+	}
+	restored := pgm.FFT().Inverse()
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 10; x++ {
+			if restored.At(x, y) != pgm.At(x, y) {
+				t.Fatalf("pixel (%d, %d) = %d, attendu %d", x, y, restored.At(x, y), pgm.At(x, y))
+			}
+		}
+	}
+}
+
+// newStripedPGM renvoie une image 32×32 grise à 128 barrée de rayures verticales de 4 cycles par image.
+func newStripedPGM() *PGM {
+	pgm := NewPGM(32, 32, 255)
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			pgm.Set(x, y, uint8(128+60*math.Cos(2*math.Pi*4*float64(x)/32)))
+		}
+	}
+	return pgm
+}
+
+func TestSpectrumMagnitude(t *testing.T) {
+	magnitude := newStripedPGM().FFT().Magnitude()
+	// Fréquence nulle au centre, rayures en (±4, 0)
+	if magnitude.At(16, 16) != 255 {
+		t.Errorf("centre = %d, attendu 255", magnitude.At(16, 16))
+	}
+	if magnitude.At(20, 16) < 200 || magnitude.At(12, 16) < 200 || magnitude.At(16, 20) > 50 {
+		t.Errorf("pics = %d et %d, hors pic = %d", magnitude.At(20, 16), magnitude.At(12, 16), magnitude.At(16, 20))
+	}
+	if phase := newStripedPGM().FFT().Phase(); phase.At(16, 16) < 120 || phase.At(16, 16) > 135 {
+		t.Errorf("phase de la fréquence nulle = %d, attendu environ 128", phase.At(16, 16))
+	}
+}
+
+func TestSpectrumNotch(t *testing.T) {
+	spectrum := newStripedPGM().FFT()
+	spectrum.Notch(4, 0, 1)
+	clean := spectrum.Inverse()
+	for x := 0; x < 32; x++ {
+		if v := clean.At(x, 5); v < 126 || v > 130 {
+			t.Fatalf("pixel (%d, 5) = %d, attendu environ 128 une fois les rayures retirées", x, v)
+		}
+	}
+}
+
+func TestSpectrumLowHighPass(t *testing.T) {
+	// Les rayures (0,125 cycle par pixel) passent un passe-bas large et sont coupées par un passe-bas étroit
+	spread := func(pgm *PGM) int {
+		low, high := 255, 0
+		for x := 0; x < 32; x++ {
+			low, high = min(low, int(pgm.At(x, 0))), max(high, int(pgm.At(x, 0)))
+		}
+		return high - low
+	}
+	wide, narrow := newStripedPGM().FFT(), newStripedPGM().FFT()
+	wide.LowPass(0.4)
+	narrow.LowPass(0.02)
+	if a, b := spread(wide.Inverse()), spread(narrow.Inverse()); a < 100 || b > 5 {
+		t.Errorf("amplitude après passe-bas large %d, étroit %d", a, b)
+	}
+
+	// Un passe-haut garde les rayures et la luminosité moyenne
+	high := newStripedPGM().FFT()
+	high.HighPass(0.02)
+	result := high.Inverse()
+	sum := 0
+	for x := 0; x < 32; x++ {
+		sum += int(result.At(x, 0))
+	}
+	if spread(result) < 100 || sum/32 < 124 || sum/32 > 132 {
+		t.Errorf("passe-haut : amplitude %d, moyenne %d", spread(result), sum/32)
+	}
+}
@@ -0,0 +1,180 @@
+package Netpbm // ✨ Filtres
+
+import "math"
+
+// BoxKernel renvoie le noyau de convolution d'un flou moyen de rayon radius (0 si radius est négatif).
+func BoxKernel(radius int) [][]float64 {
+	radius = max(radius, 0)
+	size := 2*radius + 1
+	weight := 1 / float64(size*size)
+	kernel := make([][]float64, size)
+	for i := range kernel {
+		kernel[i] = make([]float64, size)
+		for j := range kernel[i] {
+			kernel[i][j] = weight
+		}
+	}
+	return kernel
+}
+
+// UnsharpKernel renvoie le noyau d'un masque flou (netteté) : l'image plus amount fois sa différence
+// avec son flou moyen de rayon radius (0 si radius est négatif).
+func UnsharpKernel(radius int, amount float64) [][]float64 {
+	radius = max(radius, 0)
+	kernel := BoxKernel(radius)
+	for i := range kernel {
+		for j := range kernel[i] {
+			kernel[i][j] *= -amount
+		}
+	}
+	kernel[radius][radius] += 1 + amount
+	return kernel
+}
+
+// convolveAt calcule la convolution du noyau centré en (x, y) sur un canal.
+// Les pixels hors de l'image sont remplacés par le pixel du bord le plus proche.
+func convolveAt(kernel [][]float64, x, y, width, height int, value func(x, y int) float64) float64 {
+	cy, cx := len(kernel)/2, len(kernel[0])/2
+	sum := 0.0
+	for ky, row := range kernel {
+		sy := min(max(y+ky-cy, 0), height-1)
+		for kx, weight := range row {
+			if weight == 0 {
+				continue
+			}
+			sx := min(max(x+kx-cx, 0), width-1)
+			sum += weight * value(sx, sy)
+		}
+	}
+	return sum
+}
+
+// clampChannel arrondit v et le limite à l'intervalle [0, max].
+func clampChannel(v float64, max int) uint8 {
+	return uint8(math.Min(math.Max(math.Round(v), 0), float64(max)))
+}
+
+// Threshold remplace chaque pixel de l'image PGM par 0 s'il est inférieur à level, et par la valeur maximale sinon.
+func (pgm *PGM) Threshold(level uint8) {
+	pgm.ThresholdRegion(level, Rect{0, 0, pgm.width, pgm.height})
+}
+
+// ThresholdRegion applique Threshold dans la zone r uniquement.
+func (pgm *PGM) ThresholdRegion(level uint8, r Rect) {
+	pgm.own()
+	r = r.clip(pgm.width, pgm.height)
+	table := thresholdTable(level, uint8(pgm.max))
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		mapBytes(pgm.data[y][r.X:r.X+r.Width], table)
+	}
+}
+
+// Convolve applique un noyau de convolution (de dimensions impaires) à l'image PGM.
+func (pgm *PGM) Convolve(kernel [][]float64) {
+	pgm.ConvolveRegion(kernel, Rect{0, 0, pgm.width, pgm.height})
+}
+
+// ConvolveRegion applique Convolve dans la zone r uniquement.
+// Les pixels autour de la zone sont lus mais jamais modifiés.
+func (pgm *PGM) ConvolveRegion(kernel [][]float64, r Rect) {
+	pgm.own()
+	r = r.clip(pgm.width, pgm.height)
+	if r.Empty() || len(kernel) == 0 || len(kernel[0]) == 0 {
+		return
+	}
+
+	// Copier la zone lue par le noyau pour ne pas relire des pixels déjà modifiés
+	source := make([][]uint8, pgm.height)
+	for y := max(r.Y-len(kernel)/2, 0); y < min(r.Y+r.Height+len(kernel)/2, pgm.height); y++ {
+		source[y] = pgm.buffers.getBytes(pgm.width)
+		copy(source[y], pgm.data[y])
+	}
+	value := func(x, y int) float64 { return float64(source[y][x]) }
+
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		for x := r.X; x < r.X+r.Width; x++ {
+			pgm.data[y][x] = clampChannel(convolveAt(kernel, x, y, pgm.width, pgm.height, value), pgm.max)
+		}
+	}
+	for _, row := range source {
+		pgm.buffers.putBytes(row)
+	}
+}
+
+// Blur applique un flou moyen de rayon radius à l'image PGM.
+func (pgm *PGM) Blur(radius int) {
+	pgm.Convolve(BoxKernel(radius))
+}
+
+// BlurRegion applique Blur dans la zone r uniquement.
+func (pgm *PGM) BlurRegion(radius int, r Rect) {
+	pgm.ConvolveRegion(BoxKernel(radius), r)
+}
+
+// Threshold remplace chaque canal de l'image PPM par 0 s'il est inférieur à level, et par la valeur maximale sinon.
+func (ppm *PPM) Threshold(level uint8) {
+	ppm.ThresholdRegion(level, Rect{0, 0, ppm.width, ppm.height})
+}
+
+// ThresholdRegion applique Threshold dans la zone r uniquement.
+func (ppm *PPM) ThresholdRegion(level uint8, r Rect) {
+	ppm.own()
+	r = r.clip(ppm.width, ppm.height)
+	table := thresholdTable(level, uint8(ppm.max))
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		thresholdPixels(ppm.data[y][r.X:r.X+r.Width], table)
+	}
+}
+
+// Convolve applique un noyau de convolution (de dimensions impaires) à chaque canal de l'image PPM.
+func (ppm *PPM) Convolve(kernel [][]float64) {
+	ppm.ConvolveRegion(kernel, Rect{0, 0, ppm.width, ppm.height})
+}
+
+// ConvolveRegion applique Convolve dans la zone r uniquement.
+// Les pixels autour de la zone sont lus mais jamais modifiés.
+func (ppm *PPM) ConvolveRegion(kernel [][]float64, r Rect) {
+	ppm.own()
+	r = r.clip(ppm.width, ppm.height)
+	if r.Empty() || len(kernel) == 0 || len(kernel[0]) == 0 {
+		return
+	}
+
+	// Copier la zone lue par le noyau pour ne pas relire des pixels déjà modifiés
+	source := make([][]Pixel, ppm.height)
+	for y := max(r.Y-len(kernel)/2, 0); y < min(r.Y+r.Height+len(kernel)/2, ppm.height); y++ {
+		source[y] = ppm.buffers.getPixels(ppm.width)
+		copy(source[y], ppm.data[y])
+	}
+	red := func(x, y int) float64 { return float64(source[y][x].R) }
+	green := func(x, y int) float64 { return float64(source[y][x].G) }
+	blue := func(x, y int) float64 { return float64(source[y][x].B) }
+
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		for x := r.X; x < r.X+r.Width; x++ {
+			ppm.data[y][x] = Pixel{
+				R: clampChannel(convolveAt(kernel, x, y, ppm.width, ppm.height, red), ppm.max),
+				G: clampChannel(convolveAt(kernel, x, y, ppm.width, ppm.height, green), ppm.max),
+				B: clampChannel(convolveAt(kernel, x, y, ppm.width, ppm.height, blue), ppm.max),
+			}
+		}
+	}
+	for _, row := range source {
+		ppm.buffers.putPixels(row)
+	}
+}
+
+// Blur applique un flou moyen de rayon radius à l'image PPM.
+func (ppm *PPM) Blur(radius int) {
+	ppm.Convolve(BoxKernel(radius))
+}
+
+// UnsharpMask renforce la netteté de l'image PPM avec le noyau UnsharpKernel(radius, amount).
+func (ppm *PPM) UnsharpMask(radius int, amount float64) {
+	ppm.Convolve(UnsharpKernel(radius, amount))
+}
+
+// BlurRegion applique Blur dans la zone r uniquement.
+func (ppm *PPM) BlurRegion(radius int, r Rect) {
+	ppm.ConvolveRegion(BoxKernel(radius), r)
+}
@@ -0,0 +1,112 @@
+package Netpbm // 🧪 Test filtres
+
+import "testing"
+
+func TestRectIntersect(t *testing.T) {
+	r := Rect{2, 2, 5, 5}.Intersect(Rect{4, 0, 10, 4})
+	if r != (Rect{4, 2, 3, 2}) {
+		t.Errorf("Wrong intersection %v", r)
+	}
+	if !(Rect{0, 0, 2, 2}).Intersect(Rect{5, 5, 1, 1}).Empty() {
+		t.Error("Disjoint rectangles should give an empty intersection")
+	}
+	if !r.Contains(Point{4, 3}) || r.Contains(Point{7, 3}) {
+		t.Error("Contains is wrong")
+	}
+}
+
+func TestInvertRegion(t *testing.T) {
+	ppm, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	r := Rect{2, 3, 4, 5}
+	ppm.InvertRegion(r)
+	for i := 0; i < imagePPMWidth*imagePPMHeight; i++ {
+		x := i % imagePPMWidth
+		y := i / imagePPMWidth
+		expected := imagePPMData[i]
+		if r.Contains(Point{x, y}) {
+			expected = Pixel{uint8(ppm.max) - expected.R, uint8(ppm.max) - expected.G, uint8(ppm.max) - expected.B}
+		}
+		if ppm.data[y][x] != expected {
+			t.Errorf("Pixel at (%d, %d) wanted %v got %v", x, y, expected, ppm.data[y][x])
+		}
+	}
+
+	pbm, err := ReadPBM("./testImages/pbm/testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	pbm.InvertRegion(Rect{-5, -5, 100, 100})
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		if pbm.data[i/imageWidth][i%imageWidth] != imageDataInvert[i] {
+			t.Error("Wrong data")
+		}
+	}
+}
+
+func TestThresholdPGM(t *testing.T) {
+	pgm := newGradientPGM(8, 2)
+	pgm.ThresholdRegion(128, Rect{0, 0, 8, 1})
+	for x := 0; x < 8; x++ {
+		expected := uint8(0)
+		if x >= 4 {
+			expected = 255
+		}
+		if pgm.At(x, 0) != expected {
+			t.Errorf("Pixel at (%d, 0) wanted %d got %d", x, expected, pgm.At(x, 0))
+		}
+		if pgm.At(x, 1) != uint8(x*255/7) {
+			t.Errorf("Pixel at (%d, 1) outside the region was modified", x)
+		}
+	}
+}
+
+func TestConvolveRegion(t *testing.T) {
+	pgm := NewPGM(5, 5, 255)
+	pgm.Set(2, 2, 90)
+	pgm.BlurRegion(1, Rect{1, 1, 2, 2})
+	if pgm.At(1, 1) != 10 || pgm.At(2, 2) != 10 {
+		t.Errorf("Region not blurred: %d %d", pgm.At(1, 1), pgm.At(2, 2))
+	}
+	if pgm.At(3, 3) != 0 || pgm.At(3, 2) != 0 {
+		t.Error("Pixels outside the region were modified")
+	}
+
+	ppm := NewPPM(3, 3, 255)
+	ppm.Set(1, 1, Pixel{90, 180, 9})
+	ppm.Blur(1)
+	if ppm.At(0, 0) != (Pixel{10, 20, 1}) {
+		t.Errorf("Wrong blurred value %v", ppm.At(0, 0))
+	}
+
+	// Un rayon négatif compte comme 0 : l'image est inchangée
+	ppm.BlurRegion(-2, Rect{0, 0, 3, 3})
+	if ppm.At(0, 0) != (Pixel{10, 20, 1}) {
+		t.Errorf("Negative radius changed the image: %v", ppm.At(0, 0))
+	}
+	if kernel := UnsharpKernel(-1, 1); len(kernel) != 1 || kernel[0][0] != 1 {
+		t.Errorf("Unexpected kernel for a negative radius %v", kernel)
+	}
+}
+
+// newGradientPGM crée une image PGM dont les pixels croissent de gauche à droite.
+func newGradientPGM(width, height int) *PGM {
+	pgm := NewPGM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pgm.data[y][x] = uint8(x * 255 / (width - 1))
+		}
+	}
+	return pgm
+}
+
+func TestUnsharpMask(t *testing.T) {
+	ppm := newUniformPPM(5, 1, Pixel{100, 100, 100})
+	ppm.data[0][2] = Pixel{160, 160, 160}
+	ppm.UnsharpMask(1, 1)
+	if ppm.At(2, 0).R <= 160 || ppm.At(1, 0).R >= 100 {
+		t.Errorf("Sharpening should increase the local contrast, got %v", ppm.data[0])
+	}
+}
@@ -0,0 +1,44 @@
+package Netpbm // ✨ Redimensionnement à proportions conservées
+
+import "math"
+
+// Pad renvoie une image width×height remplie de fill, au milieu de laquelle l'image PPM est placée selon
+// gravity. Si l'image est plus grande que la nouvelle taille, elle est coupée.
+func (ppm *PPM) Pad(width, height int, gravity Gravity, fill Pixel) *PPM {
+	padded := NewPPM(max(width, 0), max(height, 0), ppm.max)
+	padded.magicNumber = ppm.magicNumber
+	for y := range padded.data {
+		for x := range padded.data[y] {
+			padded.data[y][x] = fill
+		}
+	}
+	padded.Paste(ppm, gravity.place(width, height, ppm.width, ppm.height))
+	return padded
+}
+
+// ResizeFit renvoie une image width×height où l'image PPM, réduite ou agrandie pour tenir entièrement
+// dans ce cadre sans être déformée, est centrée sur des bandes de couleur fill (object-fit: contain en CSS).
+func (ppm *PPM) ResizeFit(width, height int, fill Pixel) *PPM {
+	if ppm.width == 0 || ppm.height == 0 {
+		return NewPPM(0, 0, ppm.max).Pad(width, height, GravityCenter, fill)
+	}
+	scale := math.Min(float64(width)/float64(ppm.width), float64(height)/float64(ppm.height))
+	w := min(max(int(math.Round(float64(ppm.width)*scale)), 1), width)
+	h := min(max(int(math.Round(float64(ppm.height)*scale)), 1), height)
+	return ppm.Resize(w, h, Bilinear).Pad(width, height, GravityCenter, fill)
+}
+
+// ResizeFill renvoie une image width×height entièrement couverte par l'image PPM, agrandie ou réduite
+// sans être déformée, dont on ne garde que la partie choisie par gravity (object-fit: cover en CSS).
+func (ppm *PPM) ResizeFill(width, height int, gravity Gravity) *PPM {
+	if ppm.width == 0 || ppm.height == 0 || width <= 0 || height <= 0 {
+		return NewPPM(max(width, 0), max(height, 0), ppm.max)
+	}
+	scale := math.Max(float64(width)/float64(ppm.width), float64(height)/float64(ppm.height))
+	w := max(int(math.Round(float64(ppm.width)*scale)), width)
+	h := max(int(math.Round(float64(ppm.height)*scale)), height)
+	resized := ppm.Resize(w, h, Bilinear)
+	offset := gravity.place(width, height, w, h)
+	resized.Crop(Rect{-offset.X, -offset.Y, width, height})
+	return resized
+}
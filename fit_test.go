@@ -0,0 +1,42 @@
+package Netpbm // 🧪 Test redimensionnement à proportions conservées
+
+import "testing"
+
+func TestPad(t *testing.T) {
+	red := Pixel{255, 0, 0}
+	padded := newUniformPPM(2, 2, Pixel{1, 1, 1}).Pad(4, 3, GravitySouthWest, red)
+	if padded.width != 4 || padded.height != 3 || padded.At(0, 2) != (Pixel{1, 1, 1}) || padded.At(0, 0) != red {
+		t.Errorf("Unexpected padding %v", padded.data)
+	}
+}
+
+func TestResizeFit(t *testing.T) {
+	blue, black := Pixel{0, 0, 255}, Pixel{}
+	fit := newUniformPPM(40, 20, blue).ResizeFit(30, 30, black)
+	if fit.width != 30 || fit.height != 30 {
+		t.Fatalf("Unexpected size %dx%d", fit.width, fit.height)
+	}
+	// Image de 30×15 centrée : bandes noires en haut et en bas
+	if countColor(fit, blue) != 30*15 || fit.At(15, 7) != blue || fit.At(15, 6) != black || fit.At(15, 21) != blue || fit.At(15, 22) != black {
+		t.Errorf("Unexpected letterbox with %d blue pixels", countColor(fit, blue))
+	}
+}
+
+func TestResizeFill(t *testing.T) {
+	// Moitié gauche rouge, moitié droite verte
+	ppm := newUniformPPM(40, 20, Pixel{255, 0, 0})
+	for y := 0; y < 20; y++ {
+		for x := 20; x < 40; x++ {
+			ppm.data[y][x] = Pixel{0, 255, 0}
+		}
+	}
+	// Seule la dernière colonne gardée touche la limite des deux couleurs
+	fill := ppm.ResizeFill(10, 10, GravityWest)
+	if fill.width != 10 || fill.height != 10 || countColor(fill, Pixel{255, 0, 0}) != 90 {
+		t.Errorf("Expected only the red left side, got %dx%d", fill.width, fill.height)
+	}
+	fill = ppm.ResizeFill(10, 10, GravityEast)
+	if countColor(fill, Pixel{0, 255, 0}) != 90 {
+		t.Error("Expected only the green right side")
+	}
+}
@@ -0,0 +1,161 @@
+package Netpbm // ✨ Export G-code pour graveurs et traceurs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// GcodeMode choisit comment ToGcode parcourt les pixels noirs.
+type GcodeMode int
+
+const (
+	GcodeRaster  GcodeMode = iota // Balayage ligne par ligne, en aller-retour, des suites de pixels noirs
+	GcodeContour                  // Suivi des contours des formes noires
+)
+
+// GcodeOptions règle l'export de ToGcode.
+type GcodeOptions struct {
+	Mode        GcodeMode
+	PixelSize   float64 // Taille d'un pixel, en millimètres
+	FeedRate    float64 // Vitesse de gravure ou de tracé, en mm/min
+	TravelRate  float64 // Vitesse des déplacements outil levé, en mm/min
+	ToolOn      string  // Commande qui allume le laser ou baisse le stylo
+	ToolOff     string  // Commande qui éteint le laser ou lève le stylo
+	Tolerance   float64 // Écart maximal, en pixels, toléré en simplifiant les contours (mode GcodeContour)
+	FillSpacing float64 // Écart, en pixels, entre contours concentriques remplissant les formes (0 : bords seuls)
+}
+
+// DefaultGcodeOptions convient à un petit graveur laser à diode piloté par GRBL, à 10 pixels par mm.
+var DefaultGcodeOptions = GcodeOptions{
+	Mode:       GcodeRaster,
+	PixelSize:  0.1,
+	FeedRate:   1000,
+	TravelRate: 3000,
+	ToolOn:     "M3 S1000",
+	ToolOff:    "M5",
+	Tolerance:  0.5,
+}
+
+// ToGcode écrit le G-code qui grave (laser) ou trace (traceur à stylo) les pixels noirs de l'image PBM
+// selon options. Le coin inférieur gauche de l'image est à l'origine de la machine, l'axe Y montant vers
+// le haut de l'image ; les coordonnées sont en millimètres absolus (G21, G90).
+// En mode GcodeContour, le bord de chaque forme (et de ses trous) est suivi puis simplifié ; si
+// FillSpacing est positif, des contours concentriques, obtenus par la carte des distances au bord,
+// remplissent l'intérieur des formes.
+func (pbm *PBM) ToGcode(w io.Writer, options GcodeOptions) error {
+	if options.PixelSize <= 0 || options.FeedRate <= 0 || options.TravelRate <= 0 {
+		return fmt.Errorf("invalid G-code options: pixel size, feed rate and travel rate must be positive")
+	}
+	if options.Mode != GcodeRaster && options.Mode != GcodeContour {
+		return fmt.Errorf("unknown G-code mode %d", options.Mode)
+	}
+	g := gcodeWriter{Writer: bufio.NewWriter(w), options: options, height: pbm.height}
+	fmt.Fprintf(g, "; %dx%d pixels, %s mm per pixel\n", pbm.width, pbm.height, gcodeNumber(options.PixelSize))
+	fmt.Fprintln(g, "G21\nG90")
+	fmt.Fprintln(g, options.ToolOff)
+
+	switch options.Mode {
+	case GcodeRaster:
+		for y := 0; y < pbm.height; y++ {
+			// Aller-retour : les lignes impaires sont parcourues de droite à gauche
+			var runs [][2]int
+			for x := 0; x < pbm.width; x++ {
+				if !pbm.data[y][x] {
+					continue
+				}
+				start := x
+				for x < pbm.width && pbm.data[y][x] {
+					x++
+				}
+				runs = append(runs, [2]int{start, x})
+			}
+			for i := range runs {
+				run := runs[i]
+				if y%2 == 1 {
+					run = runs[len(runs)-1-i]
+					run[0], run[1] = run[1], run[0]
+				}
+				g.stroke([]pointF{{float64(run[0]), float64(y) + 0.5}, {float64(run[1]), float64(y) + 0.5}})
+			}
+		}
+	case GcodeContour:
+		shapes := [][]Point{}
+		field := [][]float64(nil)
+		for level := 0.0; ; level += options.FillSpacing {
+			mask := pbm
+			if level > 0 {
+				// Pixels à plus de level pixels du bord, à l'intérieur des formes
+				if field == nil {
+					field = pbm.SignedDistanceField()
+				}
+				mask = NewPBM(pbm.width, pbm.height)
+				for y := range field {
+					for x, d := range field[y] {
+						mask.data[y][x] = d <= -1-level
+					}
+				}
+			}
+			contours := mask.Contours()
+			if len(contours) == 0 {
+				break
+			}
+			shapes = append(shapes, contours...)
+			if options.FillSpacing <= 0 {
+				break
+			}
+		}
+		for _, contour := range shapes {
+			simplified := contour
+			if options.Tolerance > 0 {
+				simplified = SimplifyPolygon(contour, options.Tolerance)
+			}
+			path := make([]pointF, 0, len(simplified)+1)
+			for _, p := range simplified {
+				path = append(path, pointF{float64(p.X) + 0.5, float64(p.Y) + 0.5})
+			}
+			g.stroke(append(path, path[0]))
+		}
+	}
+
+	fmt.Fprintf(g, "G0 X0 Y0 F%s\n", gcodeNumber(options.TravelRate))
+	fmt.Fprintln(g, "M2")
+	return g.Flush()
+}
+
+// gcodeWriter écrit les déplacements de ToGcode, en coordonnées de pixels converties en millimètres.
+type gcodeWriter struct {
+	*bufio.Writer
+	options GcodeOptions
+	height  int
+}
+
+// stroke écrit un tracé : déplacement outil levé jusqu'au premier point, puis outil en marche le long
+// des suivants.
+func (g gcodeWriter) stroke(path []pointF) {
+	if len(path) < 2 {
+		return
+	}
+	fmt.Fprintf(g, "G0 %s F%s\n", g.position(path[0]), gcodeNumber(g.options.TravelRate))
+	fmt.Fprintln(g, g.options.ToolOn)
+	for i, p := range path[1:] {
+		if i == 0 {
+			fmt.Fprintf(g, "G1 %s F%s\n", g.position(p), gcodeNumber(g.options.FeedRate))
+		} else {
+			fmt.Fprintf(g, "G1 %s\n", g.position(p))
+		}
+	}
+	fmt.Fprintln(g, g.options.ToolOff)
+}
+
+// position renvoie les coordonnées X et Y, en millimètres, d'un point de l'image.
+func (g gcodeWriter) position(p pointF) string {
+	return "X" + gcodeNumber(p.X*g.options.PixelSize) + " Y" + gcodeNumber((float64(g.height)-p.Y)*g.options.PixelSize)
+}
+
+// gcodeNumber formate un nombre avec au plus trois décimales, sans zéros inutiles.
+func gcodeNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64)
+}
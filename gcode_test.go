@@ -0,0 +1,74 @@
+package Netpbm // 🧪 Test Export G-code pour graveurs et traceurs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToGcodeRaster(t *testing.T) {
+	pbm := NewPBM(4, 2)
+	pbm.Set(0, 0, true)
+	pbm.Set(1, 0, true)
+	pbm.Set(3, 0, true)
+	pbm.Set(2, 1, true)
+
+	var out strings.Builder
+	if err := pbm.ToGcode(&out, DefaultGcodeOptions); err != nil {
+		t.Fatal(err)
+	}
+	// Première ligne de gauche à droite, seconde de droite à gauche ; Y monte vers le haut de l'image
+	want := "; 4x2 pixels, 0.1 mm per pixel\nG21\nG90\nM5\n" +
+		"G0 X0 Y0.15 F3000\nM3 S1000\nG1 X0.2 Y0.15 F1000\nM5\n" +
+		"G0 X0.3 Y0.15 F3000\nM3 S1000\nG1 X0.4 Y0.15 F1000\nM5\n" +
+		"G0 X0.3 Y0.05 F3000\nM3 S1000\nG1 X0.2 Y0.05 F1000\nM5\n" +
+		"G0 X0 Y0 F3000\nM2\n"
+	if out.String() != want {
+		t.Errorf("ToGcode =\n%s\nattendu\n%s", out.String(), want)
+	}
+}
+
+func TestToGcodeContour(t *testing.T) {
+	pbm := NewPBM(8, 8)
+	for y := 1; y < 7; y++ {
+		for x := 1; x < 7; x++ {
+			pbm.Set(x, y, true)
+		}
+	}
+	options := DefaultGcodeOptions
+	options.Mode, options.ToolOn, options.ToolOff = GcodeContour, "G1 Z0", "G0 Z5"
+
+	var out strings.Builder
+	if err := pbm.ToGcode(&out, options); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "G1 Z0"); n != 1 {
+		t.Errorf("%d tracés, attendu le seul bord du carré", n)
+	}
+	if !strings.Contains(out.String(), "G0 X0.15 Y0.65 F3000\nG1 Z0\nG1 X0.15 Y0.15 F1000\nG1 X0.65 Y0.15\nG1 X0.65 Y0.65\nG1 X0.15 Y0.65\nG0 Z5\n") {
+		t.Errorf("bord du carré absent :\n%s", out.String())
+	}
+
+	// Remplissage : un second carré concentrique, deux pixels plus à l'intérieur
+	out.Reset()
+	options.FillSpacing = 2
+	if err := pbm.ToGcode(&out, options); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "G1 Z0"); n != 2 || !strings.Contains(out.String(), "G0 X0.35 Y0.45 F3000") {
+		t.Errorf("%d tracés :\n%s", n, out.String())
+	}
+}
+
+func TestToGcodeInvalidOptions(t *testing.T) {
+	var out strings.Builder
+	options := DefaultGcodeOptions
+	options.PixelSize = 0
+	if err := NewPBM(2, 2).ToGcode(&out, options); err == nil {
+		t.Error("une taille de pixel nulle doit être refusée")
+	}
+	options = DefaultGcodeOptions
+	options.Mode = 7
+	if err := NewPBM(2, 2).ToGcode(&out, options); err == nil {
+		t.Error("un mode inconnu doit être refusé")
+	}
+}
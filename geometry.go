@@ -0,0 +1,110 @@
+package Netpbm // ✨ Géométrie des polygones
+
+import (
+	"math"
+	"sort"
+)
+
+// SimplifyPolygon renvoie le polygone fermé points avec moins de sommets (algorithme de Douglas-Peucker) :
+// aucun point supprimé n'est à plus de epsilon pixels du polygone simplifié.
+func SimplifyPolygon(points []Point, epsilon float64) []Point {
+	if len(points) <= 3 {
+		return append([]Point(nil), points...)
+	}
+	// Le polygone est coupé en deux chaînes, entre le premier sommet et le sommet le plus éloigné de lui
+	far, farDistance := 0, -1.0
+	for i, p := range points {
+		dx, dy := float64(p.X-points[0].X), float64(p.Y-points[0].Y)
+		if d := dx*dx + dy*dy; d > farDistance {
+			far, farDistance = i, d
+		}
+	}
+	first := simplifyChain(points[:far+1], epsilon)
+	second := simplifyChain(append(append([]Point(nil), points[far:]...), points[0]), epsilon)
+	return append(first[:len(first)-1], second[:len(second)-1]...)
+}
+
+// SimplifyPolyline renvoie la ligne brisée ouverte points avec moins de sommets, comme SimplifyPolygon.
+// Le premier et le dernier point sont toujours conservés.
+func SimplifyPolyline(points []Point, epsilon float64) []Point {
+	if len(points) <= 2 {
+		return append([]Point(nil), points...)
+	}
+	return simplifyChain(points, epsilon)
+}
+
+// simplifyChain applique récursivement Douglas-Peucker à une chaîne ouverte d'au moins deux points.
+func simplifyChain(points []Point, epsilon float64) []Point {
+	last := len(points) - 1
+	index, distance := 0, -1.0
+	for i := 1; i < last; i++ {
+		if d := segmentDistance(points[i], points[0], points[last]); d > distance {
+			index, distance = i, d
+		}
+	}
+	if distance <= epsilon {
+		return []Point{points[0], points[last]}
+	}
+	left := simplifyChain(points[:index+1], epsilon)
+	right := simplifyChain(points[index:], epsilon)
+	return append(left[:len(left)-1], right...)
+}
+
+// segmentDistance renvoie la distance entre le point p et le segment [a, b].
+func segmentDistance(p, a, b Point) float64 {
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	px, py := float64(p.X-a.X), float64(p.Y-a.Y)
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return math.Hypot(px, py)
+	}
+	t := math.Max(0, math.Min(1, (px*dx+py*dy)/length))
+	return math.Hypot(px-t*dx, py-t*dy)
+}
+
+// cross renvoie le produit vectoriel (a - o) × (b - o), positif lorsque o, a, b tournent dans le sens
+// des aiguilles d'une montre à l'écran (l'axe y étant dirigé vers le bas).
+func cross(o, a, b Point) int {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// ConvexHull renvoie l'enveloppe convexe des points (algorithme de la chaîne monotone d'Andrew).
+// Les sommets sont donnés dans le sens des aiguilles d'une montre à l'écran, à partir du point le plus
+// à gauche (puis le plus haut) ; les points alignés sur un côté ne sont pas gardés.
+func ConvexHull(points []Point) []Point {
+	sorted := append([]Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	// Suppression des doublons
+	unique := sorted[:0]
+	for i, p := range sorted {
+		if i == 0 || p != sorted[i-1] {
+			unique = append(unique, p)
+		}
+	}
+	if len(unique) < 3 {
+		return unique
+	}
+
+	hull := make([]Point, 0, 2*len(unique))
+	// Chaîne du haut, de gauche à droite, puis chaîne du bas, de droite à gauche
+	for _, p := range unique {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	upper := len(hull) + 1
+	for i := len(unique) - 2; i >= 0; i-- {
+		p := unique[i]
+		for len(hull) >= upper && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
@@ -0,0 +1,59 @@
+package Netpbm // 🧪 Test géométrie des polygones
+
+import "testing"
+
+// samePoints indique si deux listes de points sont identiques.
+func samePoints(a, b []Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSimplifyPolygon(t *testing.T) {
+	// Contour d'un carré, avec tous les pixels de ses côtés
+	pbm := NewPBM(12, 12)
+	fillRectPBM(pbm, Rect{1, 1, 10, 10})
+	contour := pbm.Contours()[0]
+	simplified := SimplifyPolygon(contour, 0.5)
+	if len(simplified) != 4 {
+		t.Fatalf("Expected the 4 corners, got %v", simplified)
+	}
+	for _, p := range simplified {
+		if (p.X != 1 && p.X != 10) || (p.Y != 1 && p.Y != 10) {
+			t.Errorf("Unexpected vertex %v", p)
+		}
+	}
+	if got := SimplifyPolygon(contour, 0); len(got) != 4 {
+		t.Errorf("Collinear points should always be removed, got %d vertices", len(got))
+	}
+}
+
+func TestSimplifyPolyline(t *testing.T) {
+	line := []Point{{0, 0}, {1, 0}, {2, 1}, {3, 0}, {6, 0}, {6, 6}}
+	if got := SimplifyPolyline(line, 2); !samePoints(got, []Point{{0, 0}, {6, 0}, {6, 6}}) {
+		t.Errorf("Unexpected simplification: %v", got)
+	}
+	if got := SimplifyPolyline(line, 0.5); !samePoints(got, []Point{{0, 0}, {2, 1}, {3, 0}, {6, 0}, {6, 6}}) {
+		t.Errorf("Small epsilon should keep details: %v", got)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	points := []Point{{5, 5}, {0, 0}, {10, 10}, {0, 10}, {10, 0}, {5, 0}, {3, 7}, {0, 0}}
+	expected := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	if got := ConvexHull(points); !samePoints(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := ConvexHull([]Point{{1, 1}, {1, 1}}); !samePoints(got, []Point{{1, 1}}) {
+		t.Errorf("Duplicates should collapse, got %v", got)
+	}
+	if got := ConvexHull(nil); len(got) != 0 {
+		t.Errorf("Empty input should give an empty hull, got %v", got)
+	}
+}
@@ -0,0 +1,55 @@
+package Netpbm // ✨ Dégradés de couleurs
+
+import "math"
+
+// ColorStop est une couleur d'un dégradé, placée à Position entre 0 (noir) et 1 (blanc).
+type ColorStop struct {
+	Position float64
+	Color    Pixel
+}
+
+// GradientMap colore l'image PGM selon un dégradé : chaque niveau de gris prend la couleur du dégradé
+// à la même position. Avant le premier arrêt et après le dernier, la couleur est constante.
+func (pgm *PGM) GradientMap(stops []ColorStop) *PPM {
+	ppm := NewPPM(pgm.width, pgm.height, 255)
+	if len(stops) == 0 {
+		return ppm
+	}
+	colormap := NewColormap(stops...)
+
+	// Une couleur par niveau de gris possible
+	lut := make([]Pixel, pgm.max+1)
+	for level := range lut {
+		lut[level] = gradientAt(colormap, float64(level)/float64(max(pgm.max, 1)))
+	}
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			ppm.data[y][x] = lut[min(int(pgm.data[y][x]), pgm.max)]
+		}
+	}
+	return ppm
+}
+
+// Duotone colore l'image PGM en deux tons : le noir devient dark, le blanc devient light.
+func (pgm *PGM) Duotone(dark, light Pixel) *PPM {
+	return pgm.GradientMap([]ColorStop{{0, dark}, {1, light}})
+}
+
+// gradientAt renvoie la couleur du dégradé, dont les arrêts sont triés, à la position t.
+func gradientAt(stops []ColorStop, t float64) Pixel {
+	if t <= stops[0].Position {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		a, b := stops[i-1], stops[i]
+		if t > b.Position {
+			continue
+		}
+		w := (t - a.Position) / (b.Position - a.Position)
+		mix := func(u, v uint8) uint8 {
+			return uint8(math.Round(float64(u)*(1-w) + float64(v)*w))
+		}
+		return Pixel{mix(a.Color.R, b.Color.R), mix(a.Color.G, b.Color.G), mix(a.Color.B, b.Color.B)}
+	}
+	return stops[len(stops)-1].Color
+}
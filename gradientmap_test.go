@@ -0,0 +1,35 @@
+package Netpbm // 🧪 Test dégradés de couleurs
+
+import "testing"
+
+func TestGradientMap(t *testing.T) {
+	pgm := NewPGM(5, 1, 100)
+	pgm.data[0] = []uint8{0, 10, 25, 50, 100}
+	stops := []ColorStop{
+		{0.5, Pixel{255, 255, 0}},
+		{0.2, Pixel{0, 0, 255}},
+		{1, Pixel{255, 255, 255}},
+	}
+	ppm := pgm.GradientMap(stops)
+	expected := []Pixel{{0, 0, 255}, {0, 0, 255}, {42, 42, 213}, {255, 255, 0}, {255, 255, 255}}
+	for x, e := range expected {
+		if ppm.At(x, 0) != e {
+			t.Errorf("Pixel %d: expected %v, got %v", x, e, ppm.At(x, 0))
+		}
+	}
+	if empty := pgm.GradientMap(nil); empty.At(4, 0) != (Pixel{}) {
+		t.Error("Empty gradient should give a black image")
+	}
+}
+
+func TestDuotone(t *testing.T) {
+	pgm := NewPGM(3, 1, 255)
+	pgm.data[0] = []uint8{0, 128, 255}
+	ppm := pgm.Duotone(Pixel{20, 0, 80}, Pixel{255, 200, 120})
+	if ppm.At(0, 0) != (Pixel{20, 0, 80}) || ppm.At(2, 0) != (Pixel{255, 200, 120}) {
+		t.Errorf("Black and white should map to the two tones, got %v %v", ppm.At(0, 0), ppm.At(2, 0))
+	}
+	if mid := ppm.At(1, 0); mid != (Pixel{138, 100, 100}) {
+		t.Errorf("Mid-gray should mix the tones, got %v", mid)
+	}
+}
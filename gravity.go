@@ -0,0 +1,36 @@
+package Netpbm // ✨ Placement par gravité
+
+// Gravity indique où placer une image dans une zone plus grande (ou quelle partie garder d'une image
+// plus grande que la zone) : au centre, contre un bord ou dans un coin.
+type Gravity int
+
+const (
+	GravityCenter Gravity = iota
+	GravityNorth
+	GravitySouth
+	GravityEast
+	GravityWest
+	GravityNorthEast
+	GravityNorthWest
+	GravitySouthEast
+	GravitySouthWest
+)
+
+// place renvoie le coin supérieur gauche d'un rectangle innerWidth×innerHeight placé selon la gravité
+// dans un rectangle outerWidth×outerHeight. Il peut être négatif si le premier dépasse du second.
+func (g Gravity) place(outerWidth, outerHeight, innerWidth, innerHeight int) Point {
+	x, y := (outerWidth-innerWidth)/2, (outerHeight-innerHeight)/2
+	switch g {
+	case GravityWest, GravityNorthWest, GravitySouthWest:
+		x = 0
+	case GravityEast, GravityNorthEast, GravitySouthEast:
+		x = outerWidth - innerWidth
+	}
+	switch g {
+	case GravityNorth, GravityNorthEast, GravityNorthWest:
+		y = 0
+	case GravitySouth, GravitySouthEast, GravitySouthWest:
+		y = outerHeight - innerHeight
+	}
+	return Point{x, y}
+}
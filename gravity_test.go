@@ -0,0 +1,21 @@
+package Netpbm // 🧪 Test placement par gravité
+
+import "testing"
+
+func TestGravityPlace(t *testing.T) {
+	cases := map[Gravity]Point{
+		GravityCenter:    {3, 2},
+		GravityNorth:     {3, 0},
+		GravitySouthEast: {6, 4},
+		GravityWest:      {0, 2},
+		GravityNorthEast: {6, 0},
+	}
+	for g, expected := range cases {
+		if p := g.place(10, 8, 4, 4); p != expected {
+			t.Errorf("Gravity %d: expected %v, got %v", g, expected, p)
+		}
+	}
+	if p := GravityCenter.place(4, 4, 10, 8); p != (Point{-3, -2}) {
+		t.Errorf("A larger image should be centered with a negative offset, got %v", p)
+	}
+}
@@ -0,0 +1,375 @@
+package Netpbm // ✨ Détection de visages par cascade de Haar
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HaarCascade est un détecteur de Viola-Jones : une suite d'étages de classifieurs faibles, chacun
+// comparant une caractéristique de Haar (somme pondérée de rectangles) à un seuil. Une fenêtre n'est
+// retenue que si elle passe tous les étages.
+type HaarCascade struct {
+	width, height int // Taille de la fenêtre de détection
+	stages        []haarStage
+	features      []haarFeature
+}
+
+// haarStage est un étage de la cascade : la somme des réponses de ses arbres doit atteindre threshold.
+type haarStage struct {
+	threshold float64
+	trees     []haarTree
+}
+
+// haarTree est un classifieur faible : un arbre de décision (le plus souvent un seul nœud) dont les
+// feuilles donnent la réponse.
+type haarTree struct {
+	nodes  []haarNode
+	leaves []float64
+}
+
+// haarNode compare la caractéristique feature à threshold et passe au nœud left ou right ; un indice
+// négatif ou nul -i désigne la feuille i.
+type haarNode struct {
+	left, right, feature int
+	threshold            float64
+}
+
+// haarFeature est une caractéristique de Haar : des rectangles de la fenêtre et leurs poids.
+type haarFeature struct {
+	rects []haarRect
+}
+
+// haarRect est un rectangle pondéré d'une caractéristique, en pixels de la fenêtre.
+type haarRect struct {
+	x, y, width, height int
+	weight              float64
+}
+
+// haarXML est la structure d'un fichier de cascade OpenCV (format produit par opencv_traincascade).
+type haarXML struct {
+	Cascade struct {
+		StageType   string `xml:"stageType"`
+		FeatureType string `xml:"featureType"`
+		Width       int    `xml:"width"`
+		Height      int    `xml:"height"`
+		Stages      []struct {
+			Threshold float64 `xml:"stageThreshold"`
+			Trees     []struct {
+				Nodes  string `xml:"internalNodes"`
+				Leaves string `xml:"leafValues"`
+			} `xml:"weakClassifiers>_"`
+		} `xml:"stages>_"`
+		Features []struct {
+			Rects  []string `xml:"rects>_"`
+			Tilted int      `xml:"tilted"`
+		} `xml:"features>_"`
+	} `xml:"cascade"`
+}
+
+// ReadHaarCascade lit une cascade de Haar au format XML d'OpenCV, comme les fichiers
+// haarcascade_frontalface_default.xml fournis avec OpenCV. Seul le format récent (opencv_traincascade)
+// est pris en charge, sans caractéristiques inclinées à 45°.
+func ReadHaarCascade(filename string) (*HaarCascade, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+	return DecodeHaarCascade(file)
+}
+
+// DecodeHaarCascade lit une cascade de Haar au format XML d'OpenCV depuis r, comme ReadHaarCascade.
+func DecodeHaarCascade(r io.Reader) (*HaarCascade, error) {
+	var doc haarXML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error reading cascade: %v", err)
+	}
+	c := doc.Cascade
+	if c.FeatureType != "HAAR" || c.StageType != "BOOST" {
+		return nil, fmt.Errorf("unsupported cascade: stage type %q, feature type %q (want BOOST and HAAR in the opencv_traincascade format)", c.StageType, c.FeatureType)
+	}
+	if c.Width <= 2 || c.Height <= 2 {
+		return nil, fmt.Errorf("invalid window size %dx%d", c.Width, c.Height)
+	}
+	cascade := &HaarCascade{width: c.Width, height: c.Height}
+
+	for i, f := range c.Features {
+		if f.Tilted != 0 {
+			return nil, fmt.Errorf("feature %d: tilted features are not supported", i)
+		}
+		var feature haarFeature
+		for _, text := range f.Rects {
+			values, err := parseFloats(text)
+			if err != nil || len(values) != 5 {
+				return nil, fmt.Errorf("feature %d: invalid rectangle %q", i, strings.TrimSpace(text))
+			}
+			rect := haarRect{int(values[0]), int(values[1]), int(values[2]), int(values[3]), values[4]}
+			if rect.x < 0 || rect.y < 0 || rect.width <= 0 || rect.height <= 0 ||
+				rect.x+rect.width > c.Width || rect.y+rect.height > c.Height {
+				return nil, fmt.Errorf("feature %d: rectangle %q outside the window", i, strings.TrimSpace(text))
+			}
+			feature.rects = append(feature.rects, rect)
+		}
+		cascade.features = append(cascade.features, feature)
+	}
+
+	for s, st := range c.Stages {
+		stage := haarStage{threshold: st.Threshold}
+		for t, tr := range st.Trees {
+			values, err := parseFloats(tr.Nodes)
+			if err != nil || len(values) == 0 || len(values)%4 != 0 {
+				return nil, fmt.Errorf("stage %d, tree %d: invalid nodes", s, t)
+			}
+			var tree haarTree
+			if tree.leaves, err = parseFloats(tr.Leaves); err != nil {
+				return nil, fmt.Errorf("stage %d, tree %d: invalid leaves: %v", s, t, err)
+			}
+			for n := 0; n < len(values); n += 4 {
+				node := haarNode{int(values[n]), int(values[n+1]), int(values[n+2]), values[n+3]}
+				if node.feature < 0 || node.feature >= len(cascade.features) {
+					return nil, fmt.Errorf("stage %d, tree %d: unknown feature %d", s, t, node.feature)
+				}
+				// Un nœud ne mène qu'à un nœud suivant, pour que le parcours de l'arbre se termine toujours
+				for _, next := range []int{node.left, node.right} {
+					if next >= len(values)/4 || (next > 0 && next <= n/4) || -next >= len(tree.leaves) {
+						return nil, fmt.Errorf("stage %d, tree %d: invalid node or leaf %d", s, t, next)
+					}
+				}
+				tree.nodes = append(tree.nodes, node)
+			}
+			stage.trees = append(stage.trees, tree)
+		}
+		cascade.stages = append(cascade.stages, stage)
+	}
+	if len(cascade.stages) == 0 {
+		return nil, fmt.Errorf("cascade has no stages")
+	}
+	return cascade, nil
+}
+
+// parseFloats lit une suite de nombres séparés par des blancs.
+func parseFloats(text string) ([]float64, error) {
+	var values []float64
+	for _, field := range strings.Fields(text) {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Size renvoie la taille de la fenêtre de détection de la cascade, qui est aussi la plus petite taille
+// d'objet détectable.
+func (c *HaarCascade) Size() (int, int) {
+	return c.width, c.height
+}
+
+// HaarOptions règle la recherche de DetectOptions.
+type HaarOptions struct {
+	ScaleFactor  float64 // Rapport entre deux tailles de fenêtre successives (plus de 1)
+	MinNeighbors int     // Nombre de détections voisines à dépasser pour garder un objet (0 : détections brutes)
+	MinSize      int     // Largeur minimale des objets cherchés, en pixels (0 : taille de la fenêtre)
+	MaxSize      int     // Largeur maximale des objets cherchés, en pixels (0 : pas de limite)
+}
+
+// DefaultHaarOptions reprend les réglages par défaut d'OpenCV.
+var DefaultHaarOptions = HaarOptions{ScaleFactor: 1.1, MinNeighbors: 3}
+
+// Detect cherche les objets de la cascade dans l'image PGM, avec les options par défaut, et renvoie
+// le rectangle qui entoure chacun.
+func (c *HaarCascade) Detect(pgm *PGM) []Rect {
+	return c.DetectOptions(pgm, DefaultHaarOptions)
+}
+
+// DetectOptions cherche les objets de la cascade dans l'image PGM selon options. Comme OpenCV, l'image
+// est réduite pas à pas et la fenêtre de détection la parcourt à chaque taille ; les détections proches
+// sont ensuite regroupées en un seul rectangle.
+func (c *HaarCascade) DetectOptions(pgm *PGM, options HaarOptions) []Rect {
+	scaleFactor := math.Max(options.ScaleFactor, 1.01)
+	var found []Rect
+	for factor := 1.0; ; factor *= scaleFactor {
+		width, height := int(float64(pgm.width)/factor), int(float64(pgm.height)/factor)
+		windowWidth := int(math.Round(float64(c.width) * factor))
+		if width < c.width || height < c.height || (options.MaxSize > 0 && windowWidth > options.MaxSize) {
+			break
+		}
+		if windowWidth < options.MinSize {
+			continue
+		}
+		scaled := pgm
+		if factor > 1 {
+			scaled = pgm.Resize(width, height, Bilinear)
+		}
+		sums := newIntegralImage(scaled)
+		step := 2
+		if factor > 2 {
+			step = 1
+		}
+		for y := 0; y+c.height <= height; y += step {
+			for x := 0; x+c.width <= width; x += step {
+				if c.accepts(sums, x, y) {
+					found = append(found, Rect{
+						int(math.Round(float64(x) * factor)), int(math.Round(float64(y) * factor)),
+						windowWidth, int(math.Round(float64(c.height) * factor)),
+					})
+				}
+			}
+		}
+	}
+	if options.MinNeighbors <= 0 {
+		return found
+	}
+	return groupRectangles(found, options.MinNeighbors)
+}
+
+// integralImage contient les sommes cumulées des niveaux de gris d'une image et de leurs carrés.
+type integralImage struct {
+	sums, squares [][]int64
+}
+
+// newIntegralImage calcule les sommes cumulées de l'image PGM.
+func newIntegralImage(pgm *PGM) integralImage {
+	ii := integralImage{make([][]int64, pgm.height+1), make([][]int64, pgm.height+1)}
+	ii.sums[0], ii.squares[0] = make([]int64, pgm.width+1), make([]int64, pgm.width+1)
+	for y := 0; y < pgm.height; y++ {
+		ii.sums[y+1], ii.squares[y+1] = make([]int64, pgm.width+1), make([]int64, pgm.width+1)
+		for x := 0; x < pgm.width; x++ {
+			v := int64(pgm.data[y][x])
+			ii.sums[y+1][x+1] = v + ii.sums[y][x+1] + ii.sums[y+1][x] - ii.sums[y][x]
+			ii.squares[y+1][x+1] = v*v + ii.squares[y][x+1] + ii.squares[y+1][x] - ii.squares[y][x]
+		}
+	}
+	return ii
+}
+
+// area renvoie la somme de table sur le rectangle de coin (x, y) et de taille width×height.
+func area(table [][]int64, x, y, width, height int) int64 {
+	return table[y+height][x+width] - table[y][x+width] - table[y+height][x] + table[y][x]
+}
+
+// accepts indique si la fenêtre de coin (x, y) passe tous les étages de la cascade.
+func (c *HaarCascade) accepts(ii integralImage, x, y int) bool {
+	// Normalisation par l'écart type de la fenêtre (privée de son bord), comme OpenCV, pour que les
+	// seuils ne dépendent ni de la luminosité ni du contraste
+	count := int64((c.width - 2) * (c.height - 2))
+	sum := area(ii.sums, x+1, y+1, c.width-2, c.height-2)
+	squares := area(ii.squares, x+1, y+1, c.width-2, c.height-2)
+	norm := float64(count)*float64(squares) - float64(sum)*float64(sum)
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+	} else {
+		norm = 1
+	}
+
+	for _, stage := range c.stages {
+		total := 0.0
+		for _, tree := range stage.trees {
+			index := 0
+			for {
+				node := tree.nodes[index]
+				value := 0.0
+				for _, r := range c.features[node.feature].rects {
+					value += r.weight * float64(area(ii.sums, x+r.x, y+r.y, r.width, r.height))
+				}
+				if value/norm < node.threshold {
+					index = node.left
+				} else {
+					index = node.right
+				}
+				if index <= 0 {
+					break
+				}
+			}
+			total += tree.leaves[-index]
+		}
+		if total < stage.threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// groupRectangles regroupe les rectangles proches (comme groupRectangles d'OpenCV) et renvoie la moyenne
+// des groupes de plus de minNeighbors rectangles, sans ceux qui sont contenus dans un groupe plus sûr.
+func groupRectangles(rects []Rect, minNeighbors int) []Rect {
+	const eps = 0.2
+	similar := func(a, b Rect) bool {
+		delta := eps * float64(min(a.Width, b.Width)+min(a.Height, b.Height)) / 2
+		return math.Abs(float64(a.X-b.X)) <= delta && math.Abs(float64(a.Y-b.Y)) <= delta &&
+			math.Abs(float64(a.X+a.Width-b.X-b.Width)) <= delta && math.Abs(float64(a.Y+a.Height-b.Y-b.Height)) <= delta
+	}
+
+	// Partition par union-find
+	parent := make([]int, len(rects))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			if similar(rects[i], rects[j]) {
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+
+	type group struct {
+		x, y, width, height, count int
+	}
+	groups := map[int]*group{}
+	var order []int
+	for i, r := range rects {
+		root := find(i)
+		g, ok := groups[root]
+		if !ok {
+			g = &group{}
+			groups[root] = g
+			order = append(order, root)
+		}
+		g.x, g.y, g.width, g.height, g.count = g.x+r.X, g.y+r.Y, g.width+r.Width, g.height+r.Height, g.count+1
+	}
+	var averaged []Rect
+	var counts []int
+	for _, root := range order {
+		g := groups[root]
+		if g.count <= minNeighbors {
+			continue
+		}
+		n := float64(g.count)
+		averaged = append(averaged, Rect{
+			int(math.Round(float64(g.x) / n)), int(math.Round(float64(g.y) / n)),
+			int(math.Round(float64(g.width) / n)), int(math.Round(float64(g.height) / n)),
+		})
+		counts = append(counts, g.count)
+	}
+
+	var result []Rect
+	for i, a := range averaged {
+		inside := false
+		for j, b := range averaged {
+			dx, dy := int(float64(b.Width)*eps), int(float64(b.Height)*eps)
+			if i != j && a.X >= b.X-dx && a.Y >= b.Y-dy && a.X+a.Width <= b.X+b.Width+dx && a.Y+a.Height <= b.Y+b.Height+dy &&
+				(counts[j] > max(3, counts[i]) || counts[i] < 3) {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			result = append(result, a)
+		}
+	}
+	return result
+}
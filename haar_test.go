@@ -0,0 +1,135 @@
+package Netpbm // 🧪 Test Détection de visages par cascade de Haar
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// testCascade est une cascade d'un seul étage et d'un seul arbre qui reconnaît, dans une fenêtre de
+// 6×6 pixels, une moitié haute sombre au-dessus d'une moitié basse claire.
+const testCascade = `<?xml version="1.0"?>
+<opencv_storage>
+<cascade type_id="opencv-cascade-classifier">
+  <stageType>BOOST</stageType>
+  <featureType>HAAR</featureType>
+  <height>6</height>
+  <width>6</width>
+  <stageNum>1</stageNum>
+  <stages>
+    <_>
+      <maxWeakCount>1</maxWeakCount>
+      <stageThreshold>0.</stageThreshold>
+      <weakClassifiers>
+        <_>
+          <internalNodes>
+            0 -1 0 1.5</internalNodes>
+          <leafValues>
+            -1. 1.</leafValues></_></weakClassifiers></_></stages>
+  <features>
+    <_>
+      <rects>
+        <_>
+          0 0 6 3 -1.</_>
+        <_>
+          0 3 6 3 1.</_></rects></_></features></cascade>
+</opencv_storage>
+`
+
+// drawHaarTarget dessine l'objet de testCascade, de size pixels de côté, en (x, y).
+func drawHaarTarget(pgm *PGM, x, y, size int) {
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			if dy < size/2 {
+				pgm.data[y+dy][x+dx] = 0
+			} else {
+				pgm.data[y+dy][x+dx] = 255
+			}
+		}
+	}
+}
+
+func TestHaarCascadeDetect(t *testing.T) {
+	cascade, err := DecodeHaarCascade(strings.NewReader(testCascade))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := cascade.Size(); w != 6 || h != 6 {
+		t.Fatalf("fenêtre %dx%d", w, h)
+	}
+	pgm := newUniformPGM(80, 40, 128)
+	drawHaarTarget(pgm, 50, 10, 18)
+	drawHaarTarget(pgm, 6, 20, 6)
+
+	// Toute fenêtre à cheval sur la limite sombre/claire ressemble à l'objet : les détections doivent
+	// au moins toutes tomber sur les objets, et chaque objet être trouvé à sa taille
+	targets := []Rect{{50, 10, 18, 18}, {6, 20, 6, 6}}
+	overlaps := func(a, b Rect) bool {
+		return a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height
+	}
+	found := cascade.Detect(pgm)
+	for _, r := range found {
+		if !overlaps(r, targets[0]) && !overlaps(r, targets[1]) {
+			t.Errorf("fausse détection %v", r)
+		}
+	}
+	for _, target := range targets {
+		matched := false
+		for _, r := range found {
+			if overlaps(r, target) && r.Width >= target.Width*3/4 && r.Width <= target.Width*4/3 {
+				matched = true
+			}
+		}
+		if !matched {
+			t.Errorf("objet %v non trouvé dans %v", target, found)
+		}
+	}
+
+	options := DefaultHaarOptions
+	options.MinSize = 12
+	for _, r := range cascade.DetectOptions(pgm, options) {
+		if r.Width < 12 || !overlaps(r, targets[0]) {
+			t.Errorf("MinSize doit écarter le petit objet : %v", r)
+		}
+	}
+}
+
+func TestGroupRectangles(t *testing.T) {
+	rects := []Rect{
+		{10, 10, 20, 20}, {11, 10, 20, 20}, {10, 11, 21, 21}, {9, 9, 20, 20}, // Quatre détections voisines
+		{60, 60, 20, 20}, {61, 61, 20, 20}, // Deux seulement : écartées
+		{14, 14, 8, 8}, // Seule, dans le premier groupe
+	}
+	got := groupRectangles(rects, 3)
+	if len(got) != 1 || got[0] != (Rect{10, 10, 20, 20}) {
+		t.Errorf("groupRectangles = %v", got)
+	}
+	if got := groupRectangles(rects, 1); len(got) != 2 {
+		t.Errorf("avec MinNeighbors 1 : %v", got)
+	}
+}
+
+func TestReadHaarCascade(t *testing.T) {
+	filename := t.TempDir() + "/cascade.xml"
+	if err := os.WriteFile(filename, []byte(testCascade), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadHaarCascade(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, cascade := range map[string]string{
+		"LBP":      strings.Replace(testCascade, "<featureType>HAAR", "<featureType>LBP", 1),
+		"inclinée": strings.Replace(testCascade, "1.</_></rects>", "1.</_></rects><tilted>1</tilted>", 1),
+		"feuille":  strings.Replace(testCascade, "0 -1 0 1.5", "0 -2 0 1.5", 1),
+		"hors":     strings.Replace(testCascade, "0 3 6 3 1.", "0 4 6 3 1.", 1),
+		"largeur":  strings.Replace(testCascade, "0 3 6 3 1.", "6 3 -6 3 1.", 1),
+		"hauteur":  strings.Replace(testCascade, "0 3 6 3 1.", "0 6 6 -3 1.", 1),
+		"boucle":   strings.Replace(testCascade, "0 -1 0 1.5", "0 1 0 1.5 0 1 -1 1.5", 1),
+		"retour":   strings.Replace(testCascade, "0 -1 0 1.5", "0 1 0 1.5 0 2 -1 1.5 0 1 0 1.5", 1),
+	} {
+		if _, err := DecodeHaarCascade(strings.NewReader(cascade)); err == nil {
+			t.Errorf("cascade %s acceptée", name)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package Netpbm // ✨ Tramage
+
+import (
+	"math"
+	"sort"
+)
+
+// BayerMatrix renvoie la matrice de Bayer n×n (valeurs de 0 à n²-1) utilisée pour le tramage ordonné.
+// n doit être une puissance de deux ; sinon la fonction renvoie nil.
+func BayerMatrix(n int) [][]int {
+	if n < 2 || n&(n-1) != 0 {
+		return nil
+	}
+	matrix := [][]int{{0, 2}, {3, 1}}
+	for size := 2; size < n; size *= 2 {
+		// Chaque étape quadruple la matrice précédente
+		next := make([][]int, size*2)
+		for y := range next {
+			next[y] = make([]int, size*2)
+		}
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				v := 4 * matrix[y][x]
+				next[y][x] = v
+				next[y][x+size] = v + 2
+				next[y+size][x] = v + 3
+				next[y+size][x+size] = v + 1
+			}
+		}
+		matrix = next
+	}
+	return matrix
+}
+
+// ClusteredDotMatrix renvoie une matrice n×n de tramage à points groupés :
+// les seuils croissent du centre de la cellule vers ses bords, comme une trame d'imprimerie.
+func ClusteredDotMatrix(n int) [][]int {
+	if n < 2 {
+		return nil
+	}
+	type cell struct {
+		x, y int
+		spot float64
+	}
+	cells := make([]cell, 0, n*n)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			u := (float64(x)+0.5)/float64(n) - 0.5
+			v := (float64(y)+0.5)/float64(n) - 0.5
+			cells = append(cells, cell{x, y, spotFunction(u, v)})
+		}
+	}
+	// Le point le plus proche du centre reçoit le seuil le plus bas
+	sort.SliceStable(cells, func(i, j int) bool {
+		return cells[i].spot > cells[j].spot
+	})
+	matrix := make([][]int, n)
+	for y := range matrix {
+		matrix[y] = make([]int, n)
+	}
+	for rank, c := range cells {
+		matrix[c.y][c.x] = rank
+	}
+	return matrix
+}
+
+// spotFunction renvoie une valeur entre -1 et 1, maximale au centre (0, 0) d'une cellule de trame.
+// u et v sont les coordonnées dans la cellule, entre -0.5 et 0.5.
+func spotFunction(u, v float64) float64 {
+	return (math.Cos(2*math.Pi*u) + math.Cos(2*math.Pi*v)) / 2
+}
+
+// ToPBMOrdered convertit l'image PGM en PBM par tramage ordonné avec la matrice de seuils donnée
+// (par exemple BayerMatrix(4) ou ClusteredDotMatrix(8)).
+// Une matrice vide, ou dont les lignes n'ont pas toutes la même longueur, revient à un simple seuillage
+// avec ToPBM.
+func (pgm *PGM) ToPBMOrdered(matrix [][]int) *PBM {
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return pgm.ToPBM()
+	}
+	for _, row := range matrix {
+		if len(row) != len(matrix[0]) {
+			return pgm.ToPBM()
+		}
+	}
+	n := len(matrix) * len(matrix[0])
+	pbm := NewPBM(pgm.width, pgm.height)
+	for y := 0; y < pgm.height; y++ {
+		row := matrix[y%len(matrix)]
+		for x := 0; x < pgm.width; x++ {
+			threshold := (float64(row[x%len(row)]) + 0.5) / float64(n)
+			pbm.data[y][x] = float64(pgm.data[y][x])/float64(pgm.max) < threshold
+		}
+	}
+	return pbm
+}
+
+// ToPBMScreen convertit l'image PGM en PBM avec une trame de journal.
+// Angle est l'inclinaison de la trame en degrés (45 est l'angle classique).
+// Frequency est le nombre de lignes de trame par pixel (0.125 donne des cellules de 8 pixels).
+func (pgm *PGM) ToPBMScreen(angle, frequency float64) *PBM {
+	pbm := NewPBM(pgm.width, pgm.height)
+	if frequency <= 0 {
+		return pbm
+	}
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			// Coordonnées du pixel dans le repère tourné de la trame
+			u := (float64(x)*cos + float64(y)*sin) * frequency
+			v := (-float64(x)*sin + float64(y)*cos) * frequency
+			u -= math.Floor(u) + 0.5
+			v -= math.Floor(v) + 0.5
+
+			// Le point noir grossit depuis le centre de la cellule quand l'image s'assombrit
+			threshold := (1 - spotFunction(u, v)) / 2
+			darkness := 1 - float64(pgm.data[y][x])/float64(pgm.max)
+			pbm.data[y][x] = darkness > threshold
+		}
+	}
+	return pbm
+}
@@ -0,0 +1,88 @@
+package Netpbm // 🧪 Test tramage
+
+import "testing"
+
+// countBlack compte les pixels à true de l'image PBM.
+func countBlack(pbm *PBM) int {
+	count := 0
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// newUniformPGM crée une image PGM dont tous les pixels valent value.
+func newUniformPGM(width, height int, value uint8) *PGM {
+	pgm := NewPGM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pgm.data[y][x] = value
+		}
+	}
+	return pgm
+}
+
+func TestBayerMatrix(t *testing.T) {
+	expected := [][]int{{0, 8, 2, 10}, {12, 4, 14, 6}, {3, 11, 1, 9}, {15, 7, 13, 5}}
+	matrix := BayerMatrix(4)
+	for y := range expected {
+		for x := range expected[y] {
+			if matrix[y][x] != expected[y][x] {
+				t.Errorf("Wrong value at (%d, %d): wanted %d got %d", x, y, expected[y][x], matrix[y][x])
+			}
+		}
+	}
+	if BayerMatrix(6) != nil {
+		t.Error("Expected nil for a size that is not a power of two")
+	}
+}
+
+func TestClusteredDotMatrix(t *testing.T) {
+	matrix := ClusteredDotMatrix(4)
+	seen := make(map[int]bool)
+	for _, row := range matrix {
+		for _, v := range row {
+			seen[v] = true
+		}
+	}
+	if len(seen) != 16 {
+		t.Error("Thresholds are not a permutation")
+	}
+	if matrix[0][0] != 15 && matrix[0][0] != 14 && matrix[0][0] != 13 && matrix[0][0] != 12 {
+		t.Errorf("Corner should hold one of the highest thresholds, got %d", matrix[0][0])
+	}
+}
+
+func TestToPBMOrdered(t *testing.T) {
+	pbm := newUniformPGM(16, 16, 128).ToPBMOrdered(BayerMatrix(8))
+	if countBlack(pbm) != 128 {
+		t.Errorf("Wrong number of black pixels: %d", countBlack(pbm))
+	}
+	if countBlack(newUniformPGM(8, 8, 0).ToPBMOrdered(BayerMatrix(4))) != 64 {
+		t.Error("Black image should stay black")
+	}
+	if countBlack(newUniformPGM(8, 8, 255).ToPBMOrdered(ClusteredDotMatrix(4))) != 0 {
+		t.Error("White image should stay white")
+	}
+	gradient := newGradientPGM(8, 8)
+	for _, matrix := range [][][]int{{{0, 1}, {}}, {{0, 1}, {2}}} {
+		if gradient.ToPBMOrdered(matrix).Hash() != gradient.ToPBM().Hash() {
+			t.Errorf("A ragged matrix %v should fall back to ToPBM", matrix)
+		}
+	}
+}
+
+func TestToPBMScreen(t *testing.T) {
+	light := countBlack(newUniformPGM(32, 32, 200).ToPBMScreen(45, 0.125))
+	dark := countBlack(newUniformPGM(32, 32, 50).ToPBMScreen(45, 0.125))
+	if light >= dark {
+		t.Errorf("Darker input should produce more black pixels (%d >= %d)", light, dark)
+	}
+	if countBlack(newUniformPGM(32, 32, 255).ToPBMScreen(15, 0.1)) != 0 {
+		t.Error("White image should stay white")
+	}
+}
@@ -0,0 +1,194 @@
+package Netpbm // ✨ Empreintes
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// Hash renvoie l'empreinte SHA-256 (en hexadécimal) des pixels de l'image PBM.
+// L'empreinte ne dépend que des dimensions et des pixels, pas du format ASCII ou binaire.
+func (pbm *PBM) Hash() string {
+	h := sha256.New()
+	binary.Write(h, binary.BigEndian, [3]uint32{1, uint32(pbm.width), uint32(pbm.height)})
+	for _, row := range pbm.data {
+		bytes := make([]byte, (pbm.width+7)/8)
+		for x, pixel := range row {
+			if pixel {
+				bytes[x/8] |= 1 << (7 - uint(x%8))
+			}
+		}
+		h.Write(bytes)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Hash renvoie l'empreinte SHA-256 (en hexadécimal) des pixels de l'image PGM.
+// L'empreinte ne dépend que des dimensions, de la valeur maximale et des pixels, pas du format ASCII ou binaire.
+func (pgm *PGM) Hash() string {
+	h := sha256.New()
+	binary.Write(h, binary.BigEndian, [4]uint32{2, uint32(pgm.width), uint32(pgm.height), uint32(pgm.max)})
+	for _, row := range pgm.data {
+		h.Write(row)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Hash renvoie l'empreinte SHA-256 (en hexadécimal) des pixels de l'image PPM.
+// L'empreinte ne dépend que des dimensions, de la valeur maximale et des pixels, pas du format ASCII ou binaire.
+func (ppm *PPM) Hash() string {
+	h := sha256.New()
+	binary.Write(h, binary.BigEndian, [4]uint32{3, uint32(ppm.width), uint32(ppm.height), uint32(ppm.max)})
+	row := make([]byte, 3*ppm.width)
+	for y := 0; y < ppm.height; y++ {
+		for x, pixel := range ppm.data[y] {
+			row[3*x], row[3*x+1], row[3*x+2] = pixel.R, pixel.G, pixel.B
+		}
+		h.Write(row)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// HammingDistance renvoie le nombre de bits qui diffèrent entre deux empreintes perceptuelles.
+// Deux images proches ont une distance faible (en général moins de 10).
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// downsample réduit l'image PGM à width×height par moyenne des zones couvertes, en niveaux normalisés entre 0 et 1.
+func (pgm *PGM) downsample(width, height int) [][]float64 {
+	result := make([][]float64, height)
+	for j := range result {
+		result[j] = make([]float64, width)
+		y0 := j * pgm.height / height
+		y1 := max(y0+1, (j+1)*pgm.height/height)
+		for i := range result[j] {
+			x0 := i * pgm.width / width
+			x1 := max(x0+1, (i+1)*pgm.width/width)
+			sum := 0.0
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					sum += float64(pgm.data[y][x])
+				}
+			}
+			result[j][i] = sum / float64((y1-y0)*(x1-x0)) / float64(pgm.max)
+		}
+	}
+	return result
+}
+
+// AverageHash renvoie l'empreinte perceptuelle aHash de l'image PGM :
+// chaque bit indique si une case de la vignette 8×8 est plus claire que la moyenne.
+func (pgm *PGM) AverageHash() uint64 {
+	if pgm.width == 0 || pgm.height == 0 {
+		return 0
+	}
+	small := pgm.downsample(8, 8)
+	mean := 0.0
+	for _, row := range small {
+		for _, v := range row {
+			mean += v
+		}
+	}
+	mean /= 64
+
+	var hash uint64
+	for _, row := range small {
+		for _, v := range row {
+			hash <<= 1
+			if v > mean {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// DifferenceHash renvoie l'empreinte perceptuelle dHash de l'image PGM :
+// chaque bit indique si une case de la vignette 9×8 est plus claire que sa voisine de droite.
+func (pgm *PGM) DifferenceHash() uint64 {
+	if pgm.width == 0 || pgm.height == 0 {
+		return 0
+	}
+	small := pgm.downsample(9, 8)
+	var hash uint64
+	for _, row := range small {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if row[x] > row[x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// PerceptualHash renvoie l'empreinte perceptuelle pHash de l'image PGM :
+// les basses fréquences de la transformée en cosinus d'une vignette 32×32 sont comparées à leur médiane.
+func (pgm *PGM) PerceptualHash() uint64 {
+	if pgm.width == 0 || pgm.height == 0 {
+		return 0
+	}
+	const size, keep = 32, 8
+	small := pgm.downsample(size, size)
+
+	// DCT-II séparable, limitée aux coefficients conservés
+	cosines := make([][]float64, keep)
+	for u := range cosines {
+		cosines[u] = make([]float64, size)
+		for x := range cosines[u] {
+			cosines[u][x] = math.Cos(float64((2*x+1)*u) * math.Pi / (2 * size))
+		}
+	}
+	rows := make([][]float64, size)
+	for y := range rows {
+		rows[y] = make([]float64, keep)
+		for u := 0; u < keep; u++ {
+			for x := 0; x < size; x++ {
+				rows[y][u] += small[y][x] * cosines[u][x]
+			}
+		}
+	}
+	coefficients := make([]float64, 0, keep*keep)
+	for v := 0; v < keep; v++ {
+		for u := 0; u < keep; u++ {
+			sum := 0.0
+			for y := 0; y < size; y++ {
+				sum += rows[y][u] * cosines[v][y]
+			}
+			coefficients = append(coefficients, sum)
+		}
+	}
+
+	// La composante continue est exclue du calcul de la médiane
+	sorted := append([]float64(nil), coefficients[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var hash uint64
+	for _, c := range coefficients {
+		hash <<= 1
+		if c > median {
+			hash |= 1
+		}
+	}
+	return hash
+}
+
+// AverageHash renvoie l'empreinte perceptuelle aHash de l'image PPM, calculée sur ses niveaux de gris.
+func (ppm *PPM) AverageHash() uint64 {
+	return ppm.ToPGM().AverageHash()
+}
+
+// DifferenceHash renvoie l'empreinte perceptuelle dHash de l'image PPM, calculée sur ses niveaux de gris.
+func (ppm *PPM) DifferenceHash() uint64 {
+	return ppm.ToPGM().DifferenceHash()
+}
+
+// PerceptualHash renvoie l'empreinte perceptuelle pHash de l'image PPM, calculée sur ses niveaux de gris.
+func (ppm *PPM) PerceptualHash() uint64 {
+	return ppm.ToPGM().PerceptualHash()
+}
@@ -0,0 +1,89 @@
+package Netpbm // 🧪 Test empreintes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	p3, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	p6, err := ReadPPM("./testImages/ppm/testP6.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	if p3.Hash() != p6.Hash() {
+		t.Error("Hash should not depend on the encoding")
+	}
+	if len(p3.Hash()) != 64 {
+		t.Errorf("Wrong hash length %d", len(p3.Hash()))
+	}
+	p3.Set(0, 0, Pixel{1, 2, 3})
+	if p3.Hash() == p6.Hash() {
+		t.Error("Hash should change with the pixels")
+	}
+
+	p1, err := ReadPBM("./testImages/pbm/testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	p4, err := ReadPBM("./testImages/pbm/testP4.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	if p1.Hash() != p4.Hash() {
+		t.Error("PBM hash should not depend on the encoding")
+	}
+
+	p2, err := ReadPGM("./testImages/pgm/testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	p5, err := ReadPGM("./testImages/pgm/testP5.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	if p2.Hash() != p5.Hash() {
+		t.Error("PGM hash should not depend on the encoding")
+	}
+}
+
+// newWavePGM crée une image PGM ondulée dans les deux directions.
+func newWavePGM(width, height int) *PGM {
+	pgm := NewPGM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pgm.data[y][x] = uint8(128 + 60*math.Sin(float64(x)/7)*math.Cos(float64(y)/5) + 40*math.Sin(float64(x+2*y)/9))
+		}
+	}
+	return pgm
+}
+
+func TestPerceptualHashes(t *testing.T) {
+	hashes := []func(*PGM) uint64{(*PGM).AverageHash, (*PGM).DifferenceHash, (*PGM).PerceptualHash}
+	for i, hash := range hashes {
+		original := newWavePGM(64, 64)
+		noisy := newWavePGM(64, 64)
+		noisy.data[10][10] = 0
+		noisy.data[40][50] = 255
+		inverted := newWavePGM(64, 64)
+		inverted.Invert()
+
+		if d := HammingDistance(hash(original), hash(noisy)); d > 4 {
+			t.Errorf("Hash %d: similar images too far apart (%d)", i, d)
+		}
+		if d := HammingDistance(hash(original), hash(inverted)); d < 20 {
+			t.Errorf("Hash %d: different images too close (%d)", i, d)
+		}
+	}
+
+	ppm, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	if ppm.AverageHash() != ppm.ToPGM().AverageHash() {
+		t.Error("PPM hash should match the grayscale hash")
+	}
+}
@@ -0,0 +1,83 @@
+package Netpbm // ✨ Cartes de chaleur
+
+import "math"
+
+// HeatmapOptions regroupe les options de RenderHeatmapOptions.
+type HeatmapOptions struct {
+	Min, Max    float64 // Valeurs associées aux deux bouts de la carte de couleurs (calculées si égales)
+	CellSize    int     // Côté en pixels de chaque case
+	Legend      bool    // Ajouter à droite une barre montrant la carte de couleurs, Max en haut
+	LegendWidth int     // Largeur en pixels de la barre de légende
+}
+
+// DefaultHeatmapOptions sont les options utilisées par RenderHeatmap.
+var DefaultHeatmapOptions = HeatmapOptions{CellSize: 1, LegendWidth: 16}
+
+// RenderHeatmap transforme une grille de valeurs (data[y][x]) en image, chaque valeur étant colorée par
+// la carte de couleurs selon sa place entre le minimum et le maximum de la grille.
+// Les valeurs NaN restent noires.
+func RenderHeatmap(data [][]float64, colormap Colormap) *PPM {
+	return RenderHeatmapOptions(data, colormap, DefaultHeatmapOptions)
+}
+
+// RenderHeatmapOptions fait comme RenderHeatmap avec des options choisies.
+func RenderHeatmapOptions(data [][]float64, colormap Colormap, options HeatmapOptions) *PPM {
+	rows, cols := len(data), 0
+	for _, row := range data {
+		cols = max(cols, len(row))
+	}
+	cell := max(options.CellSize, 1)
+
+	low, high := options.Min, options.Max
+	if low == high {
+		low, high = math.Inf(1), math.Inf(-1)
+		for _, row := range data {
+			for _, v := range row {
+				if !math.IsNaN(v) {
+					low, high = math.Min(low, v), math.Max(high, v)
+				}
+			}
+		}
+	}
+	normalize := func(v float64) float64 {
+		if high <= low {
+			return 0.5
+		}
+		return (v - low) / (high - low)
+	}
+
+	width, height := cols*cell, rows*cell
+	legendX := width
+	if options.Legend {
+		// La barre est séparée de la grille par une marge de la largeur d'une demi-barre
+		legendWidth := max(options.LegendWidth, 1)
+		legendX = width + (legendWidth+1)/2
+		width = legendX + legendWidth
+		height = max(height, 2)
+	}
+
+	ppm := NewPPM(width, height, 255)
+	ppm.magicNumber = "P6"
+	for y, row := range data {
+		for x, v := range row {
+			if math.IsNaN(v) {
+				continue
+			}
+			color := colormap.Lookup(normalize(v))
+			for dy := 0; dy < cell; dy++ {
+				for dx := 0; dx < cell; dx++ {
+					ppm.data[y*cell+dy][x*cell+dx] = color
+				}
+			}
+		}
+	}
+	if options.Legend {
+		for y := 0; y < height; y++ {
+			color := colormap.Lookup(1 - float64(y)/float64(height-1))
+			for x := legendX; x < width; x++ {
+				ppm.data[y][x] = color
+			}
+		}
+	}
+	return ppm
+}
@@ -0,0 +1,45 @@
+package Netpbm // 🧪 Test cartes de chaleur
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	data := [][]float64{{0, 5}, {10, math.NaN()}}
+	ppm := RenderHeatmap(data, Grayscale)
+	if ppm.width != 2 || ppm.height != 2 {
+		t.Fatalf("Unexpected size %dx%d", ppm.width, ppm.height)
+	}
+	if ppm.At(0, 0) != (Pixel{}) || ppm.At(1, 0) != (Pixel{128, 128, 128}) || ppm.At(0, 1) != (Pixel{255, 255, 255}) {
+		t.Errorf("Unexpected colors %v", ppm.data)
+	}
+	if ppm.At(1, 1) != (Pixel{}) {
+		t.Error("NaN cells should stay black")
+	}
+}
+
+func TestRenderHeatmapOptions(t *testing.T) {
+	data := [][]float64{{1, 2, 3}}
+	options := HeatmapOptions{Min: 0, Max: 4, CellSize: 4, Legend: true, LegendWidth: 6}
+	ppm := RenderHeatmapOptions(data, Magma, options)
+	if ppm.width != 3*4+3+6 || ppm.height != 4 {
+		t.Fatalf("Unexpected size %dx%d", ppm.width, ppm.height)
+	}
+	if ppm.At(5, 3) != Magma.Lookup(0.5) {
+		t.Error("Cells should use the fixed range")
+	}
+	if ppm.At(13, 0) != (Pixel{}) {
+		t.Error("The gap before the legend should stay black")
+	}
+	if ppm.At(20, 0) != Magma.Lookup(1) || ppm.At(15, 3) != Magma.Lookup(0) {
+		t.Error("The legend should go from the maximum at the top to the minimum at the bottom")
+	}
+
+	err := ppm.Save("./testImages/ppm/heatmap.ppm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/ppm/heatmap.ppm")
+}
@@ -0,0 +1,157 @@
+package Netpbm // ✨ Historique d'annulation
+
+// History enregistre les modifications successives d'une image PPM pour pouvoir les annuler et les
+// rétablir, comme le ferait un éditeur interactif. Chaque opération ne garde que le rectangle de
+// pixels qu'elle a modifié, avant et après, et les plus anciennes sont oubliées dès que la mémoire
+// occupée dépasse la limite donnée à NewHistory.
+type History struct {
+	ppm        *PPM
+	undo, redo []historyStep
+	limit      int // Nombre maximal d'octets gardés (0 : pas de limite).
+	size       int // Nombre d'octets occupés par les étapes d'undo et de redo.
+}
+
+// historyStep décrit une opération : la zone modifiée et son contenu avant et après. Une opération
+// qui change la taille ou l'en-tête de l'image garde l'image entière.
+type historyStep struct {
+	at            Point
+	before, after *PPM
+	whole         bool
+}
+
+// bytes renvoie la mémoire occupée par les pixels gardés pour l'étape.
+func (step historyStep) bytes() int {
+	return 3 * (step.before.width*step.before.height + step.after.width*step.after.height)
+}
+
+// NewHistory renvoie un historique vide pour l'image PPM, qui ne gardera pas plus de limit octets de
+// pixels (limit <= 0 : pas de limite). Une opération plus grosse que la limite à elle seule ne peut
+// pas être annulée.
+func NewHistory(ppm *PPM, limit int) *History {
+	return &History{ppm: ppm, limit: max(limit, 0)}
+}
+
+// Image renvoie l'image suivie par l'historique.
+func (h *History) Image() *PPM {
+	return h.ppm
+}
+
+// Do applique op à l'image et l'enregistre dans l'historique, en oubliant les opérations annulées
+// qui pouvaient encore être rétablies. Il renvoie faux si op n'a rien changé, auquel cas rien n'est
+// enregistré.
+func (h *History) Do(op func(ppm *PPM)) bool {
+	before := h.ppm.Clone()
+	op(h.ppm)
+	step, changed := diffStep(before, h.ppm)
+	// Le clone n'est jamais modifié : le lâcher évite une copie à la prochaine écriture dans l'image
+	if before.shared != nil {
+		release(&before.shared)
+	}
+	if !changed {
+		return false
+	}
+	for _, s := range h.redo {
+		h.size -= s.bytes()
+	}
+	h.redo = nil
+	h.undo = append(h.undo, step)
+	h.size += step.bytes()
+	for h.limit > 0 && h.size > h.limit && len(h.undo) > 0 {
+		h.size -= h.undo[0].bytes()
+		h.undo = h.undo[1:]
+	}
+	return true
+}
+
+// Undo annule la dernière opération enregistrée et renvoie faux s'il n'y en a aucune.
+func (h *History) Undo() bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+	step := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.apply(step, step.before)
+	h.redo = append(h.redo, step)
+	return true
+}
+
+// Redo rétablit la dernière opération annulée et renvoie faux s'il n'y en a aucune.
+func (h *History) Redo() bool {
+	if len(h.redo) == 0 {
+		return false
+	}
+	step := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.apply(step, step.after)
+	h.undo = append(h.undo, step)
+	return true
+}
+
+// CanUndo indique s'il reste une opération à annuler.
+func (h *History) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+// CanRedo indique s'il reste une opération annulée à rétablir.
+func (h *History) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+// Bytes renvoie la mémoire occupée par les pixels gardés dans l'historique.
+func (h *History) Bytes() int {
+	return h.size
+}
+
+// apply remet dans l'image l'état state de l'étape, avant ou après l'opération.
+func (h *History) apply(step historyStep, state *PPM) {
+	ppm := h.ppm
+	if step.whole {
+		// L'image garde son adresse, ses métadonnées et sa réserve de tampons
+		clone := state.Clone()
+		if ppm.shared != nil {
+			release(&ppm.shared)
+		}
+		ppm.data, ppm.width, ppm.height = clone.data, clone.width, clone.height
+		ppm.magicNumber, ppm.max, ppm.shared = clone.magicNumber, clone.max, clone.shared
+		return
+	}
+	ppm.own()
+	for y := 0; y < state.height; y++ {
+		copy(ppm.data[step.at.Y+y][step.at.X:], state.data[y])
+	}
+}
+
+// diffStep compare l'image avant et après une opération et renvoie l'étape correspondante, ou faux
+// si aucun pixel n'a changé.
+func diffStep(before, after *PPM) (historyStep, bool) {
+	if before.width != after.width || before.height != after.height ||
+		before.magicNumber != after.magicNumber || before.max != after.max {
+		return historyStep{before: before.Clone(), after: after.Clone(), whole: true}, true
+	}
+	minX, minY, maxX, maxY := after.width, after.height, -1, -1
+	for y := 0; y < after.height; y++ {
+		rowBefore, rowAfter := before.data[y], after.data[y]
+		if len(rowAfter) == 0 || &rowBefore[0] == &rowAfter[0] {
+			continue // Ligne encore partagée, donc inchangée
+		}
+		for x := range rowAfter {
+			if rowBefore[x] != rowAfter[x] {
+				minX, minY, maxX, maxY = min(minX, x), min(minY, y), max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		return historyStep{}, false
+	}
+	r := Rect{minX, minY, maxX - minX + 1, maxY - minY + 1}
+	return historyStep{at: Point{r.X, r.Y}, before: cropRows(before, r), after: cropRows(after, r)}, true
+}
+
+// cropRows renvoie une copie de la zone r de l'image PPM.
+func cropRows(ppm *PPM, r Rect) *PPM {
+	cropped := NewPPM(r.Width, r.Height, ppm.max)
+	for y := range cropped.data {
+		copy(cropped.data[y], ppm.data[r.Y+y][r.X:r.X+r.Width])
+	}
+	return cropped
+}
@@ -0,0 +1,98 @@
+package Netpbm // 🧪 Test Historique d'annulation
+
+import "testing"
+
+func TestHistoryUndoRedo(t *testing.T) {
+	ppm := newPatternPPM(20, 10)
+	original := ppm.Clone()
+	history := NewHistory(ppm, 0)
+
+	if !history.Do(func(ppm *PPM) { ppm.DrawFilledRectangle(Point{2, 3}, 4, 2, Pixel{255, 0, 0}) }) {
+		t.Fatal("Do ne détecte pas le rectangle dessiné")
+	}
+	drawn := ppm.Clone()
+	history.Do(func(ppm *PPM) { ppm.Crop(Rect{5, 2, 8, 6}) })
+	if ppm.width != 8 || ppm.height != 6 {
+		t.Fatalf("taille après Crop : %dx%d", ppm.width, ppm.height)
+	}
+
+	if !history.Undo() || ppm.width != 20 || !samePixels(ppm, drawn) {
+		t.Fatal("Undo ne rend pas l'image d'avant le recadrage")
+	}
+	if !history.Undo() || !samePixels(ppm, original) {
+		t.Fatal("Undo ne rend pas l'image d'origine")
+	}
+	if history.Undo() || history.CanUndo() {
+		t.Error("Undo sur un historique vide")
+	}
+	if !history.Redo() || !samePixels(ppm, drawn) {
+		t.Fatal("Redo ne rétablit pas le rectangle")
+	}
+	if !history.Redo() || ppm.width != 8 || ppm.At(0, 0) != drawn.At(5, 2) {
+		t.Fatal("Redo ne rétablit pas le recadrage")
+	}
+	if history.Redo() || history.CanRedo() {
+		t.Error("Redo sans opération annulée")
+	}
+	if history.Image() != ppm {
+		t.Error("l'historique ne suit plus la même image")
+	}
+}
+
+func TestHistoryNewOperationClearsRedo(t *testing.T) {
+	ppm := newUniformPPM(8, 8, Pixel{0, 0, 0})
+	history := NewHistory(ppm, 0)
+	history.Do(func(ppm *PPM) { ppm.Set(1, 1, Pixel{255, 255, 255}) })
+	history.Undo()
+	history.Do(func(ppm *PPM) { ppm.Set(2, 2, Pixel{255, 255, 255}) })
+	if history.CanRedo() {
+		t.Error("une nouvelle opération doit oublier les opérations annulées")
+	}
+	if history.Bytes() != 2*3 {
+		t.Errorf("Bytes = %d, attendu %d", history.Bytes(), 2*3)
+	}
+	if history.Do(func(ppm *PPM) { ppm.Set(2, 2, Pixel{255, 255, 255}) }) {
+		t.Error("une opération sans effet ne doit pas être enregistrée")
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	ppm := newUniformPPM(10, 10, Pixel{0, 0, 0})
+	// Chaque ligne remplie garde 2 × 10 pixels, soit 60 octets
+	history := NewHistory(ppm, 150)
+	for y := 0; y < 5; y++ {
+		history.Do(func(ppm *PPM) {
+			for x := 0; x < 10; x++ {
+				ppm.Set(x, y, Pixel{255, 255, 255})
+			}
+		})
+	}
+	if history.Bytes() > 150 {
+		t.Errorf("Bytes = %d dépasse la limite", history.Bytes())
+	}
+	undone := 0
+	for history.Undo() {
+		undone++
+	}
+	if undone != 2 {
+		t.Errorf("%d opérations annulées, attendu 2", undone)
+	}
+	if ppm.At(0, 2) != (Pixel{255, 255, 255}) || ppm.At(0, 3) != (Pixel{0, 0, 0}) {
+		t.Error("les opérations les plus anciennes doivent rester appliquées")
+	}
+}
+
+// samePixels indique si deux images PPM ont la même taille et les mêmes pixels.
+func samePixels(a, b *PPM) bool {
+	if a.width != b.width || a.height != b.height {
+		return false
+	}
+	for y := range a.data {
+		for x := range a.data[y] {
+			if a.data[y][x] != b.data[y][x] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package Netpbm // ✨ Jeux d'icônes
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"os"
+)
+
+// IconFormat choisit le format des fichiers écrits par GenerateIconSet.
+type IconFormat int
+
+const (
+	IconPPM IconFormat = iota // PPM binaire (.ppm)
+	IconTGA                   // TGA 24 bits compressé par plages (.tga)
+	IconPCX                   // PCX 24 bits (.pcx)
+	IconXPM                   // XPM (.xpm)
+)
+
+// GenerateIconSet écrit l'image PPM en icônes carrées de chacune des tailles sizes, au format format, dans
+// les fichiers prefix-16x16.ppm, prefix-32x32.ppm... Le carré gardé est la partie la plus détaillée de
+// l'image (comme SmartCrop). Les réductions passent par Thumbnail, qui renforce la netteté d'autant plus
+// que la réduction est forte, pour que les petites icônes restent lisibles ; les agrandissements utilisent
+// le noyau CatmullRom. Si ico est vrai, les icônes de 256 pixels au plus sont aussi réunies dans
+// prefix.ico. Les noms des fichiers écrits sont renvoyés.
+func (ppm *PPM) GenerateIconSet(prefix string, sizes []int, format IconFormat, ico bool) ([]string, error) {
+	extensions := map[IconFormat]string{IconPPM: ".ppm", IconTGA: ".tga", IconPCX: ".pcx", IconXPM: ".xpm"}
+	extension, ok := extensions[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown icon format %d", format)
+	}
+	if ppm.width == 0 || ppm.height == 0 {
+		return nil, fmt.Errorf("cannot make icons from an empty image")
+	}
+	for _, size := range sizes {
+		if size <= 0 {
+			return nil, fmt.Errorf("invalid icon size %d", size)
+		}
+	}
+
+	r := ppm.smartCropRect(1, 1)
+	square := NewPPM(r.Width, r.Height, ppm.max)
+	square.magicNumber = "P6"
+	for y := 0; y < r.Height; y++ {
+		copy(square.data[y], ppm.data[r.Y+y][r.X:r.X+r.Width])
+	}
+
+	var files []string
+	var icons []*PPM
+	for _, size := range sizes {
+		icon := square.Thumbnail(size, size)
+		if size > square.width {
+			icon = square.Resize(size, size, CatmullRom)
+		}
+		icon.magicNumber = "P6"
+		filename := fmt.Sprintf("%s-%dx%d%s", prefix, size, size, extension)
+		var err error
+		switch format {
+		case IconTGA:
+			err = icon.SaveTGA(filename, true)
+		case IconPCX:
+			err = icon.SavePCX(filename)
+		case IconXPM:
+			err = icon.SaveXPM(filename)
+		default:
+			err = icon.Save(filename)
+		}
+		if err != nil {
+			return files, err
+		}
+		files = append(files, filename)
+		if size <= 256 {
+			icons = append(icons, icon)
+		}
+	}
+
+	if ico {
+		if len(icons) == 0 {
+			return files, fmt.Errorf("no icon size of 256 pixels or less for the ICO file")
+		}
+		if err := SaveICO(prefix+".ico", icons); err != nil {
+			return files, err
+		}
+		files = append(files, prefix+".ico")
+	}
+	return files, nil
+}
+
+// SaveICO enregistre les images PPM, de 256×256 pixels au plus, dans un même fichier d'icônes Windows
+// (.ico), chacune en bitmap 32 bits opaque.
+func SaveICO(filename string, images []*PPM) error {
+	if len(images) == 0 || len(images) > 0xFFFF {
+		return fmt.Errorf("invalid number of icons: %d", len(images))
+	}
+	for _, img := range images {
+		if img.width < 1 || img.height < 1 || img.width > 256 || img.height > 256 {
+			return fmt.Errorf("icon size %dx%d out of range 1-256", img.width, img.height)
+		}
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	le := binary.LittleEndian
+
+	// En-tête puis répertoire : une entrée de 16 octets par image
+	header := make([]byte, 6, 6+16*len(images))
+	le.PutUint16(header[2:], 1) // Type : icône
+	le.PutUint16(header[4:], uint16(len(images)))
+	offset := len(header) + 16*len(images)
+	for _, img := range images {
+		size := icoBitmapSize(img)
+		entry := make([]byte, 16)
+		entry[0], entry[1] = byte(img.width), byte(img.height) // 256 s'écrit 0
+		le.PutUint16(entry[4:], 1)                             // Plans
+		le.PutUint16(entry[6:], 32)                            // Bits par pixel
+		le.PutUint32(entry[8:], uint32(size))
+		le.PutUint32(entry[12:], uint32(offset))
+		header = append(header, entry...)
+		offset += size
+	}
+	writer.Write(header)
+
+	for _, img := range images {
+		// En-tête BITMAPINFOHEADER : la hauteur compte l'image et son masque de transparence
+		info := make([]byte, 40)
+		le.PutUint32(info[0:], 40)
+		le.PutUint32(info[4:], uint32(img.width))
+		le.PutUint32(info[8:], uint32(2*img.height))
+		le.PutUint16(info[12:], 1)
+		le.PutUint16(info[14:], 32)
+		le.PutUint32(info[20:], uint32(icoBitmapSize(img)-40))
+		writer.Write(info)
+
+		// Pixels bleu, vert, rouge, alpha, lignes de bas en haut
+		scale := func(v uint8) byte { return byte(int(v) * 255 / max(img.max, 1)) }
+		for y := img.height - 1; y >= 0; y-- {
+			for _, p := range img.data[y] {
+				writer.Write([]byte{scale(p.B), scale(p.G), scale(p.R), 255})
+			}
+		}
+		// Masque de transparence à zéro (tout est opaque), lignes alignées sur 4 octets
+		writer.Write(make([]byte, icoMaskRowSize(img.width)*img.height))
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing file: %v", err)
+	}
+	return file.Close()
+}
+
+// icoMaskRowSize renvoie la taille en octets d'une ligne du masque 1 bit d'une icône de width pixels.
+func icoMaskRowSize(width int) int {
+	return (width + 31) / 32 * 4
+}
+
+// icoBitmapSize renvoie la taille en octets du bitmap d'une icône : en-tête, pixels et masque.
+func icoBitmapSize(img *PPM) int {
+	return 40 + 4*img.width*img.height + icoMaskRowSize(img.width)*img.height
+}
@@ -0,0 +1,90 @@
+package Netpbm // 🧪 Test jeux d'icônes
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateIconSet(t *testing.T) {
+	ppm := newPatternPPM(60, 40)
+	prefix := filepath.Join(t.TempDir(), "app")
+	files, err := ppm.GenerateIconSet(prefix, []int{16, 32, 64}, IconTGA, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 || files[0] != prefix+"-16x16.tga" || files[2] != prefix+"-64x64.tga" {
+		t.Fatalf("Unexpected files %v", files)
+	}
+	for i, size := range []int{16, 32, 64} {
+		icon, err := ReadTGA(files[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if icon.width != size || icon.height != size {
+			t.Errorf("Icon %s is %dx%d, want %dx%d", files[i], icon.width, icon.height, size, size)
+		}
+	}
+	if ppm.width != 60 || ppm.height != 40 {
+		t.Errorf("GenerateIconSet changed the source image")
+	}
+}
+
+func TestGenerateIconSetICO(t *testing.T) {
+	ppm := newUniformPPM(20, 20, Pixel{10, 20, 30})
+	prefix := filepath.Join(t.TempDir(), "app")
+	files, err := ppm.GenerateIconSet(prefix, []int{16, 256, 300}, IconPPM, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 4 || files[3] != prefix+".ico" {
+		t.Fatalf("Unexpected files %v", files)
+	}
+	data, err := os.ReadFile(prefix + ".ico")
+	if err != nil {
+		t.Fatal(err)
+	}
+	le := binary.LittleEndian
+	if le.Uint16(data[2:]) != 1 || le.Uint16(data[4:]) != 2 {
+		t.Fatalf("Bad ICO header % x", data[:6])
+	}
+	// Première entrée : 16×16, deuxième : 256×256 écrit 0
+	if data[6] != 16 || data[7] != 16 || data[22] != 0 || data[23] != 0 {
+		t.Errorf("Bad directory sizes %d %d %d %d", data[6], data[7], data[22], data[23])
+	}
+	size16 := 40 + 4*16*16 + 4*16
+	if le.Uint32(data[6+8:]) != uint32(size16) || le.Uint32(data[6+12:]) != 6+32 {
+		t.Errorf("Bad first entry size or offset")
+	}
+	if le.Uint32(data[22+12:]) != uint32(6+32+size16) {
+		t.Errorf("Bad second entry offset")
+	}
+	if len(data) != 6+32+size16+40+4*256*256+32*256 {
+		t.Errorf("Unexpected ICO length %d", len(data))
+	}
+	bitmap := data[6+32:]
+	if le.Uint32(bitmap[8:]) != 32 || le.Uint16(bitmap[14:]) != 32 {
+		t.Errorf("Bad bitmap header")
+	}
+	if px := bitmap[40:44]; px[0] != 30 || px[1] != 20 || px[2] != 10 || px[3] != 255 {
+		t.Errorf("First pixel is % x, want 1e 14 0a ff", px)
+	}
+}
+
+func TestGenerateIconSetErrors(t *testing.T) {
+	ppm := newPatternPPM(8, 8)
+	prefix := filepath.Join(t.TempDir(), "app")
+	if _, err := ppm.GenerateIconSet(prefix, []int{0}, IconPPM, false); err == nil {
+		t.Errorf("Size 0 should fail")
+	}
+	if _, err := ppm.GenerateIconSet(prefix, []int{16}, IconFormat(9), false); err == nil {
+		t.Errorf("Unknown format should fail")
+	}
+	if _, err := ppm.GenerateIconSet(prefix, []int{512}, IconPPM, true); err == nil {
+		t.Errorf("ICO without a size of 256 or less should fail")
+	}
+	if err := SaveICO(prefix+".ico", []*PPM{NewPPM(300, 300, 255)}); err == nil {
+		t.Errorf("SaveICO should reject 300x300")
+	}
+}
@@ -0,0 +1,112 @@
+package Netpbm // ✨ Accès direct aux lignes des fichiers binaires
+
+import (
+	"fmt"
+	"os"
+)
+
+// IndexedFile donne accès aux lignes d'un fichier PGM (P5) ou PPM (P6) sans le lire en entier : chaque
+// ligne est lue directement à sa position dans le fichier. Les méthodes peuvent être appelées depuis
+// plusieurs goroutines à la fois.
+type IndexedFile struct {
+	file *os.File
+	info Info
+}
+
+// OpenIndexed ouvre le fichier binaire filename et lit son en-tête. Seuls les formats P5 et P6 à 8 bits
+// par échantillon sont acceptés, les autres n'ayant pas de lignes de taille fixe exploitables ici.
+func OpenIndexed(filename string) (*IndexedFile, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	f, err := newIndexedFile(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// newIndexedFile vérifie l'en-tête et la taille du fichier.
+func newIndexedFile(file *os.File) (*IndexedFile, error) {
+	info, err := ProbeHeader(file)
+	if err != nil {
+		return nil, err
+	}
+	if info.MagicNumber != "P5" && info.MagicNumber != "P6" {
+		return nil, fmt.Errorf("row access needs a P5 or P6 file, got %s", info.MagicNumber)
+	}
+	if info.Max > 255 {
+		return nil, fmt.Errorf("16-bit samples are not supported (max value %d)", info.Max)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error reading file size: %v", err)
+	}
+	if size := info.Offset + int64(info.Height)*int64(info.RowSize()); stat.Size() < size {
+		return nil, fmt.Errorf("file truncated: %d bytes, expected %d", stat.Size(), size)
+	}
+	return &IndexedFile{file, info}, nil
+}
+
+// Info renvoie l'en-tête du fichier.
+func (f *IndexedFile) Info() Info {
+	return f.info
+}
+
+// ReadRow renvoie les échantillons bruts de la ligne y : un octet par pixel pour P5, trois (R, G, B)
+// pour P6.
+func (f *IndexedFile) ReadRow(y int) ([]byte, error) {
+	if y < 0 || y >= f.info.Height {
+		return nil, fmt.Errorf("row %d out of range [0, %d)", y, f.info.Height)
+	}
+	row := make([]byte, f.info.RowSize())
+	// ReadAt peut renvoyer io.EOF avec une ligne complète quand elle termine le fichier
+	if n, err := f.file.ReadAt(row, f.info.Offset+int64(y)*int64(len(row))); n < len(row) {
+		return nil, fmt.Errorf("error reading row %d: %v", y, err)
+	}
+	return row, nil
+}
+
+// Region lit la zone r du fichier, limitée à l'image, dans une nouvelle image *PGM (P5) ou *PPM (P6).
+// Seules les lignes de la zone sont lues, et de chacune seulement les pixels de la zone.
+func (f *IndexedFile) Region(r Rect) (Image, error) {
+	r = r.clip(f.info.Width, f.info.Height)
+	if r.Empty() {
+		return nil, fmt.Errorf("region outside the image")
+	}
+	channels := f.info.Channels()
+	samples := make([]byte, r.Width*channels)
+	var pgm *PGM
+	var ppm *PPM
+	if channels == 1 {
+		pgm = NewPGM(r.Width, r.Height, f.info.Max)
+		pgm.magicNumber = "P5"
+	} else {
+		ppm = NewPPM(r.Width, r.Height, f.info.Max)
+		ppm.magicNumber = "P6"
+	}
+	for y := 0; y < r.Height; y++ {
+		offset := f.info.Offset + int64(r.Y+y)*int64(f.info.RowSize()) + int64(r.X*channels)
+		if n, err := f.file.ReadAt(samples, offset); n < len(samples) {
+			return nil, fmt.Errorf("error reading row %d: %v", r.Y+y, err)
+		}
+		if pgm != nil {
+			copy(pgm.data[y], samples)
+			continue
+		}
+		for x := range ppm.data[y] {
+			ppm.data[y][x] = Pixel{samples[3*x], samples[3*x+1], samples[3*x+2]}
+		}
+	}
+	if pgm != nil {
+		return pgm, nil
+	}
+	return ppm, nil
+}
+
+// Close ferme le fichier.
+func (f *IndexedFile) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,75 @@
+package Netpbm // 🧪 Test accès direct aux lignes des fichiers binaires
+
+import (
+	"os"
+	"testing"
+)
+
+func TestOpenIndexedPPM(t *testing.T) {
+	ppm := newPatternPPM(30, 20)
+	ppm.SetMagicNumber("P6")
+	ppm.SetMetadata(MetadataCreator, "test")
+	filename := "./testImages/ppm/indexed.ppm"
+	if err := ppm.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+
+	f, err := OpenIndexed(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for _, y := range []int{19, 0, 7} {
+		row, err := f.ReadRow(y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p := ppm.At(5, y); row[15] != p.R || row[16] != p.G || row[17] != p.B {
+			t.Errorf("Row %d: unexpected pixel %v, expected %v", y, row[15:18], p)
+		}
+	}
+	if _, err := f.ReadRow(20); err == nil {
+		t.Error("Expected an error for a row out of range")
+	}
+
+	region, err := f.Region(Rect{X: 25, Y: 15, Width: 10, Height: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region.(*PPM).Hash() != cropForTest(ppm, Rect{X: 25, Y: 15, Width: 5, Height: 5}).Hash() {
+		t.Error("The region should match the cropped image")
+	}
+}
+
+func TestOpenIndexedPGM(t *testing.T) {
+	pgm := newGradientPGM(16, 9)
+	pgm.SetMagicNumber("P5")
+	filename := "./testImages/pgm/indexed.pgm"
+	if err := pgm.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	f, err := OpenIndexed(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	region, err := f.Region(Rect{X: 3, Y: 2, Width: 4, Height: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := region.(*PGM); got.At(0, 0) != pgm.At(3, 2) || got.At(3, 2) != pgm.At(6, 4) {
+		t.Error("Unexpected region pixels")
+	}
+	f.Close()
+
+	// Fichier tronqué et format texte refusés
+	content, _ := os.ReadFile(filename)
+	os.WriteFile(filename, content[:len(content)-1], 0644)
+	if _, err := OpenIndexed(filename); err == nil {
+		t.Error("Expected an error for a truncated file")
+	}
+	if _, err := OpenIndexed("./testImages/ppm/testP3.ppm"); err == nil {
+		t.Error("Expected an error for a P3 file")
+	}
+}
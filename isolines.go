@@ -0,0 +1,176 @@
+package Netpbm // ✨ Courbes de niveau
+
+// marchingEdges donne, pour chaque configuration d'une case de marching squares, les paires de côtés
+// reliés par un segment. Les côtés sont numérotés 0 (haut), 1 (droite), 2 (bas) et 3 (gauche), et
+// la configuration vaut 8 pour le coin haut gauche, 4 pour le haut droit, 2 pour le bas droit et 1 pour
+// le bas gauche, quand ce coin atteint le niveau. Les cas ambigus 5 et 10 sont traités à part.
+var marchingEdges = [16][][2]int{
+	{}, {{3, 2}}, {{2, 1}}, {{3, 1}},
+	{{0, 1}}, nil, {{0, 2}}, {{3, 0}},
+	{{3, 0}}, {{0, 2}}, nil, {{0, 1}},
+	{{3, 1}}, {{2, 1}}, {{3, 2}}, {},
+}
+
+// Polyline est une ligne brisée. Elle est fermée quand son dernier point est égal au premier.
+type Polyline []Point
+
+// isoSegment est un segment de courbe de niveau. Chaque extrémité est repérée par le côté de la grille
+// qu'elle coupe, ce qui permet de raccorder les segments des cases voisines sans comparer des réels.
+type isoSegment struct {
+	ends [2]pointF
+	keys [2]int
+}
+
+// isoSegments renvoie les segments de la courbe de niveau level de l'image PGM, calculés par marching
+// squares sur la grille des centres de pixels. Les extrémités sont placées par interpolation linéaire.
+func (pgm *PGM) isoSegments(level float64) []isoSegment {
+	var segments []isoSegment
+	for y := 0; y+1 < pgm.height; y++ {
+		for x := 0; x+1 < pgm.width; x++ {
+			corners := [4]float64{
+				float64(pgm.data[y][x]), float64(pgm.data[y][x+1]),
+				float64(pgm.data[y+1][x+1]), float64(pgm.data[y+1][x]),
+			}
+			config := 0
+			for i, v := range corners {
+				if v >= level {
+					config |= 8 >> uint(i)
+				}
+			}
+
+			edges := marchingEdges[config]
+			if config == 5 || config == 10 {
+				// Point selle : la moyenne des coins décide si les coins atteignant le niveau sont reliés
+				center := (corners[0]+corners[1]+corners[2]+corners[3])/4 >= level
+				if (config == 5) == center {
+					edges = [][2]int{{3, 0}, {2, 1}}
+				} else {
+					edges = [][2]int{{3, 2}, {0, 1}}
+				}
+			}
+
+			// Point de passage du niveau sur chaque côté, entre ses deux coins
+			crossing := func(edge int) pointF {
+				a, b := corners[edge], corners[(edge+1)%4]
+				t := (level - a) / (b - a)
+				fx, fy := float64(x), float64(y)
+				switch edge {
+				case 0:
+					return pointF{fx + t, fy}
+				case 1:
+					return pointF{fx + 1, fy + t}
+				case 2:
+					return pointF{fx + 1 - t, fy + 1}
+				default:
+					return pointF{fx, fy + 1 - t}
+				}
+			}
+			// Les côtés horizontaux ont une clé paire, les verticaux une clé impaire
+			key := func(edge int) int {
+				switch edge {
+				case 0:
+					return 2 * (y*pgm.width + x)
+				case 1:
+					return 2*(y*pgm.width+x+1) + 1
+				case 2:
+					return 2 * ((y+1)*pgm.width + x)
+				default:
+					return 2*(y*pgm.width+x) + 1
+				}
+			}
+			for _, pair := range edges {
+				segments = append(segments, isoSegment{
+					[2]pointF{crossing(pair[0]), crossing(pair[1])},
+					[2]int{key(pair[0]), key(pair[1])},
+				})
+			}
+		}
+	}
+	return segments
+}
+
+// DrawContours renvoie une copie en couleurs de l'image PGM sur laquelle sont tracées, de la couleur
+// donnée, les courbes de niveau correspondant à chacune des valeurs de levels. Cela permet de visualiser
+// un relief ou un champ d'intensité, par exemple généré par bruit.
+func (pgm *PGM) DrawContours(levels []uint8, color Pixel) *PPM {
+	ppm := pgm.ToPPM()
+	for _, level := range levels {
+		for _, segment := range pgm.isoSegments(float64(level)) {
+			ppm.DrawLine(segment.ends[0].toPoint(), segment.ends[1].toPoint(), color)
+		}
+	}
+	return ppm
+}
+
+// IsoContours renvoie les courbes de niveau level de l'image PGM sous forme de lignes brisées, pour un
+// traceur ou une machine à commande numérique par exemple. Les courbes qui touchent le bord de l'image
+// sont ouvertes, les autres sont fermées. Les sommets sont arrondis au pixel le plus proche.
+func (pgm *PGM) IsoContours(level uint8) []Polyline {
+	segments := pgm.isoSegments(float64(level))
+
+	// Chaque côté coupé est partagé par au plus deux segments
+	byKey := make(map[int][]int)
+	for i, s := range segments {
+		for _, k := range s.keys {
+			byKey[k] = append(byKey[k], i)
+		}
+	}
+	used := make([]bool, len(segments))
+
+	// follow parcourt la courbe à partir du segment start, entré par son extrémité end
+	follow := func(start, end int) Polyline {
+		var points []pointF
+		i := start
+		for !used[i] {
+			used[i] = true
+			s := segments[i]
+			points = append(points, s.ends[end])
+			k := s.keys[1-end]
+			next := -1
+			for _, j := range byKey[k] {
+				if j != i {
+					next = j
+				}
+			}
+			if next < 0 || used[next] {
+				points = append(points, s.ends[1-end])
+				break
+			}
+			i = next
+			end = 0
+			if segments[i].keys[1] == k {
+				end = 1
+			}
+		}
+		return roundPolyline(points)
+	}
+
+	var contours []Polyline
+	// D'abord les courbes ouvertes, qui partent d'un côté utilisé par un seul segment
+	for i, s := range segments {
+		for end, k := range s.keys {
+			if !used[i] && len(byKey[k]) == 1 {
+				contours = append(contours, follow(i, end))
+			}
+		}
+	}
+	// Puis les courbes fermées
+	for i := range segments {
+		if !used[i] {
+			contours = append(contours, follow(i, 0))
+		}
+	}
+	return contours
+}
+
+// roundPolyline arrondit les points au pixel le plus proche, en supprimant les répétitions consécutives.
+func roundPolyline(points []pointF) Polyline {
+	var polyline Polyline
+	for _, p := range points {
+		q := p.toPoint()
+		if len(polyline) == 0 || polyline[len(polyline)-1] != q {
+			polyline = append(polyline, q)
+		}
+	}
+	return polyline
+}
@@ -0,0 +1,92 @@
+package Netpbm // 🧪 Test courbes de niveau
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDrawContours(t *testing.T) {
+	pgm := NewPGM(6, 4, 255)
+	for y := 0; y < 4; y++ {
+		for x := 3; x < 6; x++ {
+			pgm.data[y][x] = 200
+		}
+	}
+	red, blue := Pixel{255, 0, 0}, Pixel{0, 0, 255}
+	ppm := pgm.DrawContours([]uint8{50}, red)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 6; x++ {
+			if (ppm.At(x, y) == red) != (x == 2) {
+				t.Errorf("Unexpected pixel %v at (%d, %d)", ppm.At(x, y), x, y)
+			}
+		}
+	}
+	if ppm := pgm.DrawContours([]uint8{150}, blue); ppm.At(3, 1) != blue || ppm.At(2, 1) == blue {
+		t.Error("A higher level should cross closer to the bright side")
+	}
+	if ppm := pgm.DrawContours([]uint8{250}, blue); countColor(ppm, blue) != 0 {
+		t.Error("A level above every value should draw nothing")
+	}
+}
+
+func TestIsoSegmentsSaddle(t *testing.T) {
+	pgm := NewPGM(2, 2, 255)
+	pgm.data[0] = []uint8{200, 0}
+	pgm.data[1] = []uint8{0, 200}
+	// La moyenne (100) atteint le niveau 80 : les coins clairs sont reliés, les coins sombres isolés
+	segments := pgm.isoSegments(80)
+	if len(segments) != 2 {
+		t.Fatalf("Expected 2 segments, got %d", len(segments))
+	}
+	for _, s := range segments {
+		if s.ends[0].X < s.ends[0].Y != (s.ends[1].X < s.ends[1].Y) {
+			t.Errorf("Segment %v should not cross the bright diagonal", s)
+		}
+	}
+
+	noise := newWavePGM(32, 32)
+	err := noise.DrawContours([]uint8{64, 128, 192}, Pixel{255, 0, 0}).Save("./testImages/ppm/contours.ppm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/ppm/contours.ppm")
+}
+
+func TestIsoContours(t *testing.T) {
+	pgm := NewPGM(7, 7, 255)
+	for y := 2; y < 5; y++ {
+		for x := 2; x < 5; x++ {
+			pgm.data[y][x] = 255
+		}
+	}
+	contours := pgm.IsoContours(128)
+	if len(contours) != 1 {
+		t.Fatalf("Expected 1 contour, got %d", len(contours))
+	}
+	ring := contours[0]
+	if len(ring) < 5 || ring[0] != ring[len(ring)-1] {
+		t.Errorf("Expected a closed ring, got %v", ring)
+	}
+	for _, p := range ring {
+		if p.X < 1 || p.X > 5 || p.Y < 1 || p.Y > 5 {
+			t.Errorf("Point %v is outside the square", p)
+		}
+	}
+
+	// Une marche verticale donne une seule courbe ouverte, d'un bord à l'autre
+	step := NewPGM(6, 4, 255)
+	for y := 0; y < 4; y++ {
+		step.data[y][4], step.data[y][5] = 200, 200
+	}
+	contours = step.IsoContours(100)
+	if len(contours) != 1 {
+		t.Fatalf("Expected 1 contour, got %d", len(contours))
+	}
+	line := contours[0]
+	if len(line) != 4 || line[0].X != 4 || min(line[0].Y, line[3].Y) != 0 || max(line[0].Y, line[3].Y) != 3 {
+		t.Errorf("Unexpected open contour %v", line)
+	}
+	if len(NewPGM(5, 5, 255).IsoContours(10)) != 0 {
+		t.Error("A flat image has no contour")
+	}
+}
@@ -0,0 +1,117 @@
+package Netpbm // ✨ Noyaux de rééchantillonnage
+
+import "math"
+
+// ResampleKernel est un noyau de rééchantillonnage, utilisé par Resize, DrawImageTransformed et
+// RotateWith. Weight(x) est le poids d'un pixel source situé à x pixels du point calculé ; il doit être
+// nul au-delà de Support(). Les poids sont normalisés à l'usage, leur somme n'a donc pas à valoir 1.
+// Lors d'une réduction, le noyau est élargi d'autant pour couvrir tous les pixels sources. Un noyau nil
+// équivaut à NearestNeighbor.
+type ResampleKernel interface {
+	Support() float64
+	Weight(x float64) float64
+}
+
+// Noyaux prédéfinis.
+var (
+	NearestNeighbor ResampleKernel = nearestKernel{}             // Pixel source le plus proche, sans mélange ni élargissement
+	Box             ResampleKernel = boxKernel{}                 // Moyenne des pixels couverts : idéal pour réduire d'un facteur entier
+	Triangle        ResampleKernel = triangleKernel{}            // Interpolation linéaire
+	Bilinear                       = Triangle                    // Moyenne pondérée des pixels voisins, élargie lors d'une réduction
+	CatmullRom      ResampleKernel = CubicKernel{0, 0.5}         // Cubique nette, qui passe par les pixels sources
+	Mitchell        ResampleKernel = CubicKernel{1. / 3, 1. / 3} // Cubique équilibrée entre netteté et halos
+	Lanczos         ResampleKernel = LanczosKernel{3}            // Sinus cardinal fenêtré sur trois lobes : le plus net
+)
+
+// nearestKernel prend le pixel le plus proche ; les fonctions de rééchantillonnage le reconnaissent pour
+// ne jamais mélanger de pixels, même lors d'une réduction.
+type nearestKernel struct{}
+
+func (nearestKernel) Support() float64 { return 0.5 }
+
+func (nearestKernel) Weight(x float64) float64 { return boxKernel{}.Weight(x) }
+
+type boxKernel struct{}
+
+func (boxKernel) Support() float64 { return 0.5 }
+
+func (boxKernel) Weight(x float64) float64 {
+	if x >= -0.5 && x < 0.5 {
+		return 1
+	}
+	return 0
+}
+
+type triangleKernel struct{}
+
+func (triangleKernel) Support() float64 { return 1 }
+
+func (triangleKernel) Weight(x float64) float64 {
+	return math.Max(1-math.Abs(x), 0)
+}
+
+// CubicKernel est un noyau cubique de la famille de Mitchell et Netravali, de paramètres B et C.
+// B = 0, C = 0.5 donne Catmull-Rom ; B = C = 1/3 donne le filtre de Mitchell.
+type CubicKernel struct {
+	B, C float64
+}
+
+func (k CubicKernel) Support() float64 { return 2 }
+
+func (k CubicKernel) Weight(x float64) float64 {
+	b, c := k.B, k.C
+	x = math.Abs(x)
+	switch {
+	case x < 1:
+		return ((12-9*b-6*c)*x*x*x + (-18+12*b+6*c)*x*x + (6 - 2*b)) / 6
+	case x < 2:
+		return ((-b-6*c)*x*x*x + (6*b+30*c)*x*x + (-12*b-48*c)*x + (8*b + 24*c)) / 6
+	}
+	return 0
+}
+
+// LanczosKernel est un sinus cardinal fenêtré par un sinus cardinal A fois plus large.
+type LanczosKernel struct {
+	A int
+}
+
+func (k LanczosKernel) Support() float64 { return float64(k.A) }
+
+func (k LanczosKernel) Weight(x float64) float64 {
+	a := float64(k.A)
+	if x == 0 {
+		return 1
+	}
+	if math.Abs(x) >= a {
+		return 0
+	}
+	px := math.Pi * x
+	return a * math.Sin(px) * math.Sin(px/a) / (px * px)
+}
+
+// pointTaps renvoie les pixels d'un axe qui contribuent à la valeur au point continu pos (le centre du
+// pixel i étant en i + 0.5), avec leurs poids normalisés. Les indices peuvent sortir de l'image.
+func pointTaps(pos float64, kernel ResampleKernel) []tap {
+	if _, ok := kernel.(nearestKernel); ok || kernel == nil {
+		return []tap{{int(math.Floor(pos)), 1}}
+	}
+	center := pos - 0.5
+	support := kernel.Support()
+	var taps []tap
+	total := 0.0
+	for j := int(math.Ceil(center - support)); j <= int(math.Floor(center+support)); j++ {
+		weight := kernel.Weight(float64(j) - center)
+		if weight == 0 {
+			continue
+		}
+		taps = append(taps, tap{j, weight})
+		total += weight
+	}
+	if total == 0 {
+		return []tap{{int(math.Floor(pos)), 1}}
+	}
+	for k := range taps {
+		taps[k].weight /= total
+	}
+	return taps
+}
@@ -0,0 +1,126 @@
+package Netpbm // 🧪 Test noyaux de rééchantillonnage
+
+import (
+	"math"
+	"testing"
+)
+
+// gaussianKernel est un noyau personnalisé, pour vérifier qu'un utilisateur peut en fournir un.
+type gaussianKernel struct {
+	sigma float64
+}
+
+func (k gaussianKernel) Support() float64 { return 3 * k.sigma }
+
+func (k gaussianKernel) Weight(x float64) float64 {
+	if math.Abs(x) >= k.Support() {
+		return 0
+	}
+	return math.Exp(-x * x / (2 * k.sigma * k.sigma))
+}
+
+func TestResampleKernels(t *testing.T) {
+	for name, kernel := range map[string]ResampleKernel{"Triangle": Triangle, "CatmullRom": CatmullRom, "Lanczos": Lanczos} {
+		// Noyaux interpolants : 1 au centre, 0 sur les autres pixels
+		if kernel.Weight(0) != 1 || math.Abs(kernel.Weight(1)) > 1e-12 || math.Abs(kernel.Weight(-2)) > 1e-12 {
+			t.Errorf("%s should interpolate: %v %v %v", name, kernel.Weight(0), kernel.Weight(1), kernel.Weight(-2))
+		}
+		if kernel.Weight(kernel.Support()+0.1) != 0 {
+			t.Errorf("%s should vanish beyond its support", name)
+		}
+	}
+	// Mitchell lisse : un peu moins de 1 au centre, mais la somme des poids vaut toujours 1
+	for _, offset := range []float64{0, 0.25, 0.5} {
+		sum := 0.0
+		for j := -2.0; j <= 2; j++ {
+			sum += Mitchell.Weight(j - offset)
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("Mitchell weights should sum to 1 at offset %v, got %v", offset, sum)
+		}
+	}
+	if Mitchell.Weight(0) >= 1 {
+		t.Error("Mitchell should not interpolate exactly")
+	}
+}
+
+func TestResizeWithKernels(t *testing.T) {
+	uniform := newUniformPGM(9, 7, 100)
+	edge := newGradientPGM(16, 16)
+	for name, kernel := range map[string]ResampleKernel{
+		"Box": Box, "Triangle": Triangle, "CatmullRom": CatmullRom, "Mitchell": Mitchell, "Lanczos": Lanczos, "Gaussian": gaussianKernel{0.6},
+	} {
+		for _, size := range [][2]int{{4, 3}, {20, 15}} {
+			resized := uniform.Resize(size[0], size[1], kernel)
+			for y := 0; y < size[1]; y++ {
+				for x := 0; x < size[0]; x++ {
+					if resized.At(x, y) != 100 {
+						t.Fatalf("%s: a uniform image should stay uniform, got %d at (%d, %d)", name, resized.At(x, y), x, y)
+					}
+				}
+			}
+		}
+		if same := edge.Resize(16, 16, kernel); name != "Gaussian" && name != "Mitchell" && same.Hash() != edge.Hash() {
+			t.Errorf("%s: resizing to the same size should keep the image", name)
+		}
+	}
+
+	// Une réduction par 2 avec Box donne la moyenne exacte de chaque bloc
+	pgm := NewPGM(4, 2, 255)
+	pgm.data[0] = []uint8{0, 100, 200, 200}
+	pgm.data[1] = []uint8{100, 200, 0, 40}
+	reduced := pgm.Resize(2, 1, Box)
+	if reduced.At(0, 0) != 100 || reduced.At(1, 0) != 110 {
+		t.Errorf("Unexpected box averages %v", reduced.data)
+	}
+}
+
+func TestRotateWith(t *testing.T) {
+	pgm := newGradientPGM(12, 10)
+	for _, kernel := range []ResampleKernel{NearestNeighbor, Bilinear, Lanczos} {
+		rotated := newGradientPGM(12, 10)
+		rotated.RotateWith(0, 0, kernel)
+		if rotated.Hash() != pgm.Hash() {
+			t.Errorf("%T: a rotation by 0 should keep the image", kernel)
+		}
+	}
+
+	// Un quart de tour au plus proche voisin déplace les pixels sans les mélanger
+	square := newGradientPGM(8, 8)
+	square.RotateWith(90, 0, NearestNeighbor)
+	expected := newGradientPGM(8, 8)
+	expected.Rotate90CW()
+	if square.Hash() != expected.Hash() {
+		t.Error("A quarter turn with NearestNeighbor should match Rotate90CW")
+	}
+}
+
+func TestDrawImageTransformedKernel(t *testing.T) {
+	src := newPatternPPM(6, 6)
+	for _, kernel := range []ResampleKernel{NearestNeighbor, CatmullRom} {
+		dst := NewPPM(6, 6, 255)
+		dst.DrawImageTransformed(src, IdentityTransform(), kernel, nil)
+		if dst.Hash() != src.Hash() {
+			t.Errorf("%T: the identity transform should copy the image", kernel)
+		}
+	}
+}
+
+func TestNilKernelIsNearestNeighbor(t *testing.T) {
+	ppm := newPatternPPM(7, 5)
+	if ppm.Resize(15, 4, nil).Hash() != ppm.Resize(15, 4, NearestNeighbor).Hash() {
+		t.Error("Resize with a nil kernel should match NearestNeighbor")
+	}
+	if Lazy(ppm).Resize(15, 4, nil).ToPPM().Hash() != ppm.Resize(15, 4, NearestNeighbor).Hash() {
+		t.Error("LazyPPM.Resize with a nil kernel should match NearestNeighbor")
+	}
+	if len(ppm.BuildPyramid(2, nil)) != 3 {
+		t.Error("BuildPyramid with a nil kernel should build every level")
+	}
+	rotated, expected := newGradientPGM(8, 8), newGradientPGM(8, 8)
+	rotated.RotateWith(90, 0, nil)
+	expected.RotateWith(90, 0, NearestNeighbor)
+	if rotated.Hash() != expected.Hash() {
+		t.Error("RotateWith with a nil kernel should match NearestNeighbor")
+	}
+}
@@ -0,0 +1,99 @@
+package Netpbm // ✨ Segmentation par k-moyennes
+
+import "math"
+
+// kMeansIterations est le nombre maximal de passes de l'algorithme des k-moyennes.
+const kMeansIterations = 50
+
+// SegmentKMeans regroupe les couleurs de l'image PPM en k groupes (entre 1 et 256) par l'algorithme des
+// k-moyennes, les centres initiaux étant tirés selon la graine seed (méthode k-means++). Il renvoie la
+// carte des groupes, une image PGM de valeur maximale k-1 où chaque pixel vaut le numéro de son groupe,
+// et la couleur moyenne de chaque groupe. MapToPalette(palette, false) donne ensuite l'image postérisée ;
+// la carte, seuillée groupe par groupe, sert de segmentation grossière avant d'extraire des contours.
+// S'il y a moins de couleurs distinctes que k, certains groupes restent vides.
+func (ppm *PPM) SegmentKMeans(k int, seed int64) (*PGM, []Pixel) {
+	k = min(max(k, 1), 256)
+	labels := NewPGM(ppm.width, ppm.height, k-1)
+	if ppm.width == 0 || ppm.height == 0 {
+		return labels, make([]Pixel, k)
+	}
+	points := make([][3]float64, 0, ppm.width*ppm.height)
+	for y := 0; y < ppm.height; y++ {
+		for _, p := range ppm.data[y] {
+			points = append(points, [3]float64{float64(p.R), float64(p.G), float64(p.B)})
+		}
+	}
+	distance := func(a, b [3]float64) float64 {
+		dr, dg, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+		return dr*dr + dg*dg + db*db
+	}
+
+	// k-means++ : chaque centre est tiré avec une probabilité proportionnelle au carré de sa distance
+	// au centre déjà choisi le plus proche
+	random := newRandom(seed)
+	centers := [][3]float64{points[random.Intn(len(points))]}
+	nearest := make([]float64, len(points))
+	for i, p := range points {
+		nearest[i] = distance(p, centers[0])
+	}
+	for len(centers) < k {
+		total := 0.0
+		for _, d := range nearest {
+			total += d
+		}
+		if total == 0 {
+			break // Plus de couleurs distinctes que de centres
+		}
+		target, chosen := random.Float64()*total, len(points)-1
+		for i, d := range nearest {
+			if target -= d; target < 0 {
+				chosen = i
+				break
+			}
+		}
+		centers = append(centers, points[chosen])
+		for i, p := range points {
+			nearest[i] = math.Min(nearest[i], distance(p, points[chosen]))
+		}
+	}
+
+	// Algorithme de Lloyd : affectation au centre le plus proche puis déplacement des centres
+	assignment := make([]int, len(points))
+	for iteration := 0; iteration < kMeansIterations; iteration++ {
+		changed := false
+		for i, p := range points {
+			best := 0
+			for c := 1; c < len(centers); c++ {
+				if distance(p, centers[c]) < distance(p, centers[best]) {
+					best = c
+				}
+			}
+			if best != assignment[i] {
+				changed = true
+				assignment[i] = best
+			}
+		}
+		if !changed && iteration > 0 {
+			break // Les centres ont déjà été calculés pour ces affectations
+		}
+		sums := make([][4]float64, len(centers))
+		for i, p := range points {
+			s := &sums[assignment[i]]
+			s[0], s[1], s[2], s[3] = s[0]+p[0], s[1]+p[1], s[2]+p[2], s[3]+1
+		}
+		for c, s := range sums {
+			if s[3] > 0 {
+				centers[c] = [3]float64{s[0] / s[3], s[1] / s[3], s[2] / s[3]}
+			}
+		}
+	}
+
+	palette := make([]Pixel, k)
+	for c, center := range centers {
+		palette[c] = Pixel{uint8(math.Round(center[0])), uint8(math.Round(center[1])), uint8(math.Round(center[2]))}
+	}
+	for i, label := range assignment {
+		labels.data[i/ppm.width][i%ppm.width] = uint8(label)
+	}
+	return labels, palette
+}
@@ -0,0 +1,71 @@
+package Netpbm // 🧪 Test Segmentation par k-moyennes
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newThreeColorPPM renvoie une image en trois bandes verticales de couleurs bruitées autour de
+// rouge, vert et bleu.
+func newThreeColorPPM() *PPM {
+	ppm := NewPPM(30, 10, 255)
+	bases := []Pixel{{200, 30, 30}, {30, 200, 30}, {30, 30, 200}}
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 30; x++ {
+			base, noise := bases[x/10], uint8((x*7+y*13)%9)
+			ppm.data[y][x] = Pixel{base.R + noise, base.G + noise, base.B + noise}
+		}
+	}
+	return ppm
+}
+
+func TestSegmentKMeans(t *testing.T) {
+	ppm := newThreeColorPPM()
+	labels, palette := ppm.SegmentKMeans(3, 1)
+	if len(palette) != 3 || labels.max != 2 {
+		t.Fatalf("%d couleurs, valeur maximale %d", len(palette), labels.max)
+	}
+	// Chaque bande forme un seul groupe, distinct des deux autres
+	seen := map[uint8]bool{}
+	for band := 0; band < 3; band++ {
+		label := labels.At(band*10, 0)
+		seen[label] = true
+		for y := 0; y < 10; y++ {
+			for x := band * 10; x < band*10+10; x++ {
+				if labels.At(x, y) != label {
+					t.Fatalf("bande %d coupée en (%d, %d)", band, x, y)
+				}
+			}
+		}
+		if c := palette[label]; colorDistance(c, ppm.At(band*10, 0)) > 8 {
+			t.Errorf("couleur du groupe de la bande %d : %v", band, c)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("%d groupes distincts, attendu 3", len(seen))
+	}
+
+	again, _ := ppm.SegmentKMeans(3, 1)
+	if fmt.Sprint(again.data) != fmt.Sprint(labels.data) {
+		t.Error("une même graine doit donner la même segmentation")
+	}
+}
+
+func TestSegmentKMeansPosterize(t *testing.T) {
+	ppm := newThreeColorPPM()
+	_, palette := ppm.SegmentKMeans(3, 7)
+	ppm.MapToPalette(palette, false)
+	for _, c := range palette {
+		if countColor(ppm, c) != 100 {
+			t.Errorf("couleur %v sur %d pixels, attendu 100", c, countColor(ppm, c))
+		}
+	}
+}
+
+func TestSegmentKMeansFewColors(t *testing.T) {
+	ppm := newUniformPPM(4, 4, Pixel{10, 20, 30})
+	labels, palette := ppm.SegmentKMeans(5, 3)
+	if len(palette) != 5 || palette[0] != (Pixel{10, 20, 30}) || labels.At(3, 3) != 0 {
+		t.Errorf("palette %v, groupe %d", palette, labels.At(3, 3))
+	}
+}
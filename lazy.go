@@ -0,0 +1,87 @@
+package Netpbm // ✨ Évaluation paresseuse
+
+// LazyPPM est une image PPM dont les pixels ne sont calculés qu'à la demande. Crop, Resize et Invert
+// ne touchent à aucun pixel : ils ajoutent une étape au graphe d'opérations, et chaque pixel demandé
+// par At ou ToPPM ne lit que les pixels sources dont il dépend. Une suite Resize puis Crop ne calcule
+// ainsi que la partie gardée de l'image agrandie.
+type LazyPPM struct {
+	width, height, max int
+	at                 func(x, y int) Pixel
+}
+
+// Lazy renvoie une vue paresseuse de l'image PPM. L'image ne doit pas être modifiée tant que la vue sert.
+func Lazy(ppm *PPM) *LazyPPM {
+	return &LazyPPM{ppm.width, ppm.height, ppm.max, func(x, y int) Pixel { return ppm.data[y][x] }}
+}
+
+// LazyFunc renvoie une image paresseuse width×height dont chaque pixel est donné par at.
+func LazyFunc(width, height, maxValue int, at func(x, y int) Pixel) *LazyPPM {
+	return &LazyPPM{width, height, maxValue, at}
+}
+
+// Size renvoie la largeur et la hauteur de l'image.
+func (l *LazyPPM) Size() (int, int) {
+	return l.width, l.height
+}
+
+// At calcule le pixel (x, y), ou renvoie du noir hors de l'image.
+func (l *LazyPPM) At(x, y int) Pixel {
+	if x < 0 || x >= l.width || y < 0 || y >= l.height {
+		return Pixel{}
+	}
+	return l.at(x, y)
+}
+
+// Crop renvoie l'image réduite à la zone r (limitée aux bords de l'image), comme (*PPM).Crop.
+func (l *LazyPPM) Crop(r Rect) *LazyPPM {
+	r = r.clip(l.width, l.height)
+	return &LazyPPM{r.Width, r.Height, l.max, func(x, y int) Pixel { return l.at(r.X+x, r.Y+y) }}
+}
+
+// Resize renvoie l'image redimensionnée à width×height, avec le même résultat que (*PPM).Resize.
+// Seuls les poids de chaque axe sont calculés tout de suite.
+func (l *LazyPPM) Resize(width, height int, filter Interpolation) *LazyPPM {
+	width, height = max(width, 0), max(height, 0)
+	if width == 0 || height == 0 || l.width == 0 || l.height == 0 {
+		return &LazyPPM{width, height, l.max, func(x, y int) Pixel { return Pixel{} }}
+	}
+	xTaps := axisTaps(l.width, width, filter)
+	yTaps := axisTaps(l.height, height, filter)
+	return &LazyPPM{width, height, l.max, func(x, y int) Pixel {
+		var r, g, b float64
+		for _, ty := range yTaps[y] {
+			for _, tx := range xTaps[x] {
+				w := ty.weight * tx.weight
+				p := l.at(tx.index, ty.index)
+				r += w * float64(p.R)
+				g += w * float64(p.G)
+				b += w * float64(p.B)
+			}
+		}
+		return Pixel{clampChannel(r, l.max), clampChannel(g, l.max), clampChannel(b, l.max)}
+	}}
+}
+
+// Invert renvoie l'image aux couleurs inversées, comme (*PPM).Invert.
+func (l *LazyPPM) Invert() *LazyPPM {
+	return &LazyPPM{l.width, l.height, l.max, func(x, y int) Pixel {
+		p := l.at(x, y)
+		return Pixel{uint8(l.max) - p.R, uint8(l.max) - p.G, uint8(l.max) - p.B}
+	}}
+}
+
+// ToPPM calcule tous les pixels et renvoie l'image obtenue.
+func (l *LazyPPM) ToPPM() *PPM {
+	ppm := NewPPM(l.width, l.height, l.max)
+	for y := 0; y < l.height; y++ {
+		for x := 0; x < l.width; x++ {
+			ppm.data[y][x] = l.at(x, y)
+		}
+	}
+	return ppm
+}
+
+// Save calcule l'image et l'enregistre dans un fichier.
+func (l *LazyPPM) Save(filename string) error {
+	return l.ToPPM().Save(filename)
+}
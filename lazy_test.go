@@ -0,0 +1,39 @@
+package Netpbm // 🧪 Test évaluation paresseuse
+
+import "testing"
+
+func TestLazyMatchesEager(t *testing.T) {
+	ppm := newPatternPPM(30, 20)
+	r := Rect{3, 4, 12, 9}
+	lazy := Lazy(ppm).Resize(45, 31, Bilinear).Crop(r).Invert().ToPPM()
+
+	eager := ppm.Resize(45, 31, Bilinear)
+	eager.Crop(r)
+	eager.Invert()
+	if lazy.Hash() != eager.Hash() {
+		t.Error("Lazy evaluation should give the same pixels as the eager operations")
+	}
+	if w, h := Lazy(ppm).Crop(Rect{25, 15, 10, 10}).Size(); w != 5 || h != 5 {
+		t.Errorf("Crop should be clipped like (*PPM).Crop, got %dx%d", w, h)
+	}
+}
+
+func TestLazySkipsDiscardedPixels(t *testing.T) {
+	reads := 0
+	source := LazyFunc(1000, 1000, 255, func(x, y int) Pixel {
+		reads++
+		return Pixel{uint8(x), uint8(y), 0}
+	})
+	// Agrandir 1000×1000 en 4000×4000 puis n'en garder que 2×2 pixels
+	view := source.Resize(4000, 4000, NearestNeighbor).Crop(Rect{400, 800, 2, 2})
+	if reads != 0 {
+		t.Error("Building the graph should not read any pixel")
+	}
+	ppm := view.ToPPM()
+	if reads != 4 {
+		t.Errorf("Expected 4 source reads, got %d", reads)
+	}
+	if ppm.At(1, 1) != (Pixel{100, 200, 0}) || view.At(5, 5) != (Pixel{}) {
+		t.Errorf("Unexpected pixels %v", ppm.data)
+	}
+}
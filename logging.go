@@ -0,0 +1,98 @@
+package Netpbm // ✨ Journalisation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// packageLogger est le journal installé par SetLogger, nil tant qu'aucun ne l'est.
+var packageLogger atomic.Pointer[slog.Logger]
+
+// SetLogger installe le journal qui reçoit les événements du paquet : durées de décodage, d'écriture et
+// des étapes d'une Pipeline au niveau Debug, fichiers tronqués ou échantillons au-dessus de la valeur
+// maximale au niveau Warn. Un journal nil (par défaut) désactive la journalisation.
+func SetLogger(logger *slog.Logger) {
+	packageLogger.Store(logger)
+}
+
+// loggerKey est la clé du journal dans un contexte.
+type loggerKey struct{}
+
+// WithLogger renvoie un contexte qui porte logger. Les opérations qui reçoivent un contexte, comme
+// Watcher.Run, l'utilisent à la place du journal installé par SetLogger, et le passent au gestionnaire
+// pour qu'il puisse y lire ses propres valeurs (identifiant de requête, trace...).
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// eventLogger renvoie le journal du contexte, ou à défaut celui du paquet, s'il accepte le niveau level.
+// Sinon elle renvoie nil, ce qui évite de calculer les attributs d'un événement ignoré.
+func eventLogger(ctx context.Context, level slog.Level) *slog.Logger {
+	logger, _ := ctx.Value(loggerKey{}).(*slog.Logger)
+	if logger == nil {
+		logger = packageLogger.Load()
+	}
+	if logger == nil || !logger.Enabled(ctx, level) {
+		return nil
+	}
+	return logger
+}
+
+// logDecode journalise la lecture du fichier filename commencée à start.
+func logDecode(filename string, start time.Time, img Image, err error) {
+	ctx := context.Background()
+	var truncated *TruncatedError
+	if errors.As(err, &truncated) {
+		if logger := eventLogger(ctx, slog.LevelWarn); logger != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "truncated image", slog.String("file", filename),
+				slog.Int("row", truncated.Row), slog.Int("height", truncated.Height))
+		}
+		return
+	}
+	if err != nil {
+		if logger := eventLogger(ctx, slog.LevelDebug); logger != nil {
+			logger.LogAttrs(ctx, slog.LevelDebug, "decode failed", slog.String("file", filename), slog.Any("error", err))
+		}
+		return
+	}
+
+	if logger := eventLogger(ctx, slog.LevelDebug); logger != nil {
+		width, height := img.Size()
+		logger.LogAttrs(ctx, slog.LevelDebug, "decode", slog.String("file", filename),
+			slog.Duration("duration", time.Since(start)), slog.Int("width", width), slog.Int("height", height),
+			slog.Int("pixels", width*height))
+	}
+	if logger := eventLogger(ctx, slog.LevelWarn); logger != nil {
+		if count := samplesAboveMax(img); count > 0 {
+			logger.LogAttrs(ctx, slog.LevelWarn, "samples above max value", slog.String("file", filename),
+				slog.Int("count", count))
+		}
+	}
+}
+
+// samplesAboveMax compte les échantillons qui dépassent la valeur maximale annoncée par l'en-tête.
+func samplesAboveMax(img Image) int {
+	count := 0
+	switch img := img.(type) {
+	case *PGM:
+		for _, row := range img.data {
+			for _, v := range row {
+				if int(v) > img.max {
+					count++
+				}
+			}
+		}
+	case *PPM:
+		for _, row := range img.data {
+			for _, p := range row {
+				if int(max(p.R, p.G, p.B)) > img.max {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,67 @@
+package Netpbm // 🧪 Test journalisation
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	filename := "./testImages/pgm/overflow.pgm"
+	if err := os.WriteFile(filename, []byte("P2\n2 2\n100\n0 50\n150 200\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	if _, err := ReadPGM(filename); err != nil {
+		t.Fatal(err)
+	}
+	logs := buf.String()
+	if !strings.Contains(logs, "msg=decode") || !strings.Contains(logs, "pixels=4") {
+		t.Errorf("Expected a decode event with the pixel count, got %q", logs)
+	}
+	if !strings.Contains(logs, `level=WARN msg="samples above max value"`) || !strings.Contains(logs, "count=2") {
+		t.Errorf("Expected a warning for the two samples above the max value, got %q", logs)
+	}
+
+	buf.Reset()
+	os.WriteFile(filename, []byte("P5\n4 4\n255\n\x01\x02"), 0644)
+	if _, err := ReadPGMRecover(filename, 0); err == nil {
+		t.Fatal("Expected a truncated error")
+	}
+	if !strings.Contains(buf.String(), `msg="truncated image"`) {
+		t.Errorf("Expected a truncated image warning, got %q", buf.String())
+	}
+
+	// Au niveau Warn, une lecture correcte ne laisse aucune trace
+	buf.Reset()
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	ReadPPM("./testImages/ppm/testP3.ppm")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no event at the info level, got %q", buf.String())
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "in.pgm")
+	if err := newGradientPGM(8, 8).Save(input); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	ctx := WithLogger(context.Background(), slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	pipeline := Pipeline{func(img Image) (Image, error) { img.(*PGM).Invert(); return img, nil }}
+	if err := pipeline.process(ctx, input, filepath.Join(dir, "out.pgm")); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `msg="pipeline step" step=0`) || !strings.Contains(buf.String(), "msg=encode") {
+		t.Errorf("Expected step and encode events in the context logger, got %q", buf.String())
+	}
+}
@@ -0,0 +1,24 @@
+package Netpbm // ✨ Remplissage à travers un masque
+
+import "math"
+
+// FillMask peint la couleur color sur l'image PPM à travers le masque placé en origin : chaque pixel est
+// mélangé à color selon l'opacité du pixel correspondant du masque. Un glyphe rasterisé en niveaux de gris
+// (couverture anticrénelée) ou en noir et blanc s'affiche ainsi dans la couleur voulue.
+func (ppm *PPM) FillMask(mask Mask, origin Point, color Pixel) {
+	ppm.own()
+	width, height := mask.Size()
+	for y := max(0, -origin.Y); y < height && origin.Y+y < ppm.height; y++ {
+		for x := max(0, -origin.X); x < width && origin.X+x < ppm.width; x++ {
+			alpha := mask.opacity(x, y)
+			if alpha <= 0 {
+				continue
+			}
+			p := &ppm.data[origin.Y+y][origin.X+x]
+			mix := func(u, v uint8) uint8 {
+				return uint8(math.Round(float64(u)*(1-alpha) + float64(v)*alpha))
+			}
+			*p = Pixel{mix(p.R, color.R), mix(p.G, color.G), mix(p.B, color.B)}
+		}
+	}
+}
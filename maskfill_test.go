@@ -0,0 +1,23 @@
+package Netpbm // 🧪 Test remplissage à travers un masque
+
+import "testing"
+
+func TestFillMask(t *testing.T) {
+	ppm := newUniformPPM(4, 4, Pixel{0, 0, 0})
+	glyph := NewPGM(2, 2, 255)
+	glyph.data[0] = []uint8{255, 128}
+	glyph.data[1] = []uint8{0, 255}
+	red := Pixel{255, 0, 0}
+	ppm.FillMask(glyph, Point{1, 1}, red)
+	if ppm.At(1, 1) != red || ppm.At(2, 1) != (Pixel{128, 0, 0}) || ppm.At(1, 2) != (Pixel{}) {
+		t.Errorf("Unexpected blending %v", ppm.data)
+	}
+
+	// Un masque PBM qui dépasse de l'image est découpé
+	bitmap := NewPBM(3, 3)
+	bitmap.data[0][0], bitmap.data[2][2] = true, true
+	ppm.FillMask(bitmap, Point{-2, -2}, Pixel{0, 255, 0})
+	if ppm.At(0, 0) != (Pixel{0, 255, 0}) || countColor(ppm, Pixel{0, 255, 0}) != 1 {
+		t.Error("Only the visible part of the mask should be drawn")
+	}
+}
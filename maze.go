@@ -0,0 +1,189 @@
+package Netpbm // ✨ Labyrinthes
+
+// MazeAlgo est l'algorithme utilisé par GenerateMaze.
+type MazeAlgo int
+
+const (
+	// MazeBacktracker creuse par exploration en profondeur : de longs couloirs sinueux.
+	MazeBacktracker MazeAlgo = iota
+	// MazePrim fait grandir le labyrinthe depuis une case : beaucoup de courtes impasses.
+	MazePrim
+	// MazeKruskal abat les murs dans un ordre aléatoire en reliant des ensembles disjoints.
+	MazeKruskal
+)
+
+// mazeWall est le mur entre deux cases voisines, données par leur indice.
+type mazeWall struct {
+	a, b int
+}
+
+// GenerateMaze génère un labyrinthe parfait (un seul chemin entre deux cases) de width×height cases.
+// L'image PBM renvoyée mesure 2·width+1 × 2·height+1 pixels : les murs sont noirs, les couloirs blancs,
+// et la case (cx, cy) est le pixel (2·cx+1, 2·cy+1). Seed initialise le tirage : une même graine donne
+// toujours le même labyrinthe.
+func GenerateMaze(width, height int, algo MazeAlgo, seed int64) *PBM {
+	width, height = max(width, 1), max(height, 1)
+	pbm := NewPBM(2*width+1, 2*height+1)
+	for y := range pbm.data {
+		for x := range pbm.data[y] {
+			pbm.data[y][x] = true
+		}
+	}
+	open := func(i int) {
+		pbm.data[2*(i/width)+1][2*(i%width)+1] = false
+	}
+	carve := func(w mazeWall) {
+		open(w.a)
+		open(w.b)
+		// Le mur est à mi-chemin entre les pixels des deux cases
+		pbm.data[w.a/width+w.b/width+1][w.a%width+w.b%width+1] = false
+	}
+	// walls renvoie les murs entre la case i et ses voisines
+	walls := func(i int) []mazeWall {
+		x, y := i%width, i/width
+		var result []mazeWall
+		if x > 0 {
+			result = append(result, mazeWall{i, i - 1})
+		}
+		if x < width-1 {
+			result = append(result, mazeWall{i, i + 1})
+		}
+		if y > 0 {
+			result = append(result, mazeWall{i, i - width})
+		}
+		if y < height-1 {
+			result = append(result, mazeWall{i, i + width})
+		}
+		return result
+	}
+
+	random := newRandom(seed)
+	total := width * height
+	visited := make([]bool, total)
+	switch algo {
+	case MazePrim:
+		visited[0] = true
+		open(0)
+		frontier := walls(0)
+		for len(frontier) > 0 {
+			k := random.Intn(len(frontier))
+			w := frontier[k]
+			frontier[k] = frontier[len(frontier)-1]
+			frontier = frontier[:len(frontier)-1]
+			if visited[w.b] {
+				continue
+			}
+			visited[w.b] = true
+			carve(w)
+			frontier = append(frontier, walls(w.b)...)
+		}
+
+	case MazeKruskal:
+		var all []mazeWall
+		for i := 0; i < total; i++ {
+			for _, w := range walls(i) {
+				if w.a < w.b {
+					all = append(all, w)
+				}
+			}
+		}
+		random.Shuffle(len(all), func(i, j int) { all[i], all[j] = all[j], all[i] })
+		parent := make([]int, total)
+		for i := range parent {
+			parent[i] = i
+		}
+		var find func(int) int
+		find = func(i int) int {
+			if parent[i] != i {
+				parent[i] = find(parent[i])
+			}
+			return parent[i]
+		}
+		open(0)
+		for _, w := range all {
+			if ra, rb := find(w.a), find(w.b); ra != rb {
+				parent[ra] = rb
+				carve(w)
+			}
+		}
+
+	default:
+		visited[0] = true
+		open(0)
+		stack := []int{0}
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			var candidates []mazeWall
+			for _, w := range walls(i) {
+				if !visited[w.b] {
+					candidates = append(candidates, w)
+				}
+			}
+			if len(candidates) == 0 {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			w := candidates[random.Intn(len(candidates))]
+			visited[w.b] = true
+			carve(w)
+			stack = append(stack, w.b)
+		}
+	}
+	return pbm
+}
+
+// SolveMaze renvoie le plus court chemin entre start et end à travers les pixels blancs du labyrinthe,
+// en se déplaçant horizontalement ou verticalement, extrémités comprises. Elle renvoie nil s'il n'y a pas
+// de chemin ou si start ou end est sur un mur.
+func SolveMaze(maze *PBM, start, end Point) []Point {
+	free := func(p Point) bool {
+		return p.X >= 0 && p.X < maze.width && p.Y >= 0 && p.Y < maze.height && !maze.data[p.Y][p.X]
+	}
+	if !free(start) || !free(end) {
+		return nil
+	}
+
+	// Parcours en largeur, en retenant d'où vient chaque pixel atteint
+	from := make(map[Point]Point)
+	from[start] = start
+	queue := []Point{start}
+	for len(queue) > 0 && queue[0] != end {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range []Point{{1, 0}, {0, 1}, {-1, 0}, {0, -1}} {
+			q := Point{p.X + d.X, p.Y + d.Y}
+			if _, seen := from[q]; !seen && free(q) {
+				from[q] = p
+				queue = append(queue, q)
+			}
+		}
+	}
+	if _, found := from[end]; !found {
+		return nil
+	}
+
+	var path []Point
+	for p := end; p != start; p = from[p] {
+		path = append(path, p)
+	}
+	path = append(path, start)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// RenderMaze renvoie le labyrinthe agrandi (chaque pixel devient un bloc scale×scale), le chemin
+// donné étant peint de la couleur pathColor, par exemple la solution renvoyée par SolveMaze.
+func RenderMaze(maze *PBM, path []Point, scale int, pathColor Pixel) *PPM {
+	scale = max(scale, 1)
+	ppm := maze.RenderZoomed(scale, nil)
+	for _, p := range path {
+		for y := p.Y * scale; y < (p.Y+1)*scale; y++ {
+			for x := p.X * scale; x < (p.X+1)*scale; x++ {
+				ppm.SetPixel(Point{x, y}, pathColor)
+			}
+		}
+	}
+	return ppm
+}
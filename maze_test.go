@@ -0,0 +1,74 @@
+package Netpbm // 🧪 Test labyrinthes
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGenerateMaze(t *testing.T) {
+	for _, algo := range []MazeAlgo{MazeBacktracker, MazePrim, MazeKruskal} {
+		maze := GenerateMaze(8, 6, algo, 3)
+		if maze.width != 17 || maze.height != 13 {
+			t.Fatalf("algo %d: unexpected size %dx%d", algo, maze.width, maze.height)
+		}
+		// Un labyrinthe parfait de n cases a exactement n-1 passages ouverts entre cases
+		cells, passages := 0, 0
+		for y := 1; y < maze.height-1; y++ {
+			for x := 1; x < maze.width-1; x++ {
+				if maze.data[y][x] {
+					continue
+				}
+				if x%2 == 1 && y%2 == 1 {
+					cells++
+				} else {
+					passages++
+				}
+			}
+		}
+		if cells != 48 || passages != 47 {
+			t.Errorf("algo %d: expected 48 cells and 47 passages, got %d and %d", algo, cells, passages)
+		}
+		// Toutes les cases sont reliées
+		if SolveMaze(maze, Point{1, 1}, Point{15, 11}) == nil {
+			t.Errorf("algo %d: opposite corners should be connected", algo)
+		}
+		if GenerateMaze(8, 6, algo, 3).Hash() != maze.Hash() {
+			t.Errorf("algo %d: the same seed should give the same maze", algo)
+		}
+	}
+}
+
+func TestSolveMaze(t *testing.T) {
+	maze := newPBMFromRows("#####", "#...#", "###.#", "#...#", "#####")
+	path := SolveMaze(maze, Point{1, 1}, Point{1, 3})
+	expected := []Point{{1, 1}, {2, 1}, {3, 1}, {3, 2}, {3, 3}, {2, 3}, {1, 3}}
+	if !samePoints(path, expected) {
+		t.Errorf("Unexpected path %v", path)
+	}
+	if SolveMaze(maze, Point{0, 0}, Point{1, 3}) != nil {
+		t.Error("Starting on a wall should give no path")
+	}
+
+	red := Pixel{255, 0, 0}
+	ppm := RenderMaze(maze, path, 3, red)
+	if countColor(ppm, red) != 7*9 || ppm.At(3, 3) != red || ppm.At(2, 3) == red {
+		t.Errorf("Unexpected rendering with %d red pixels", countColor(ppm, red))
+	}
+
+	err := RenderMaze(GenerateMaze(10, 10, MazePrim, 1), nil, 4, red).Save("./testImages/ppm/maze.ppm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/ppm/maze.ppm")
+}
+
+// newPBMFromRows crée une image PBM à partir de lignes de texte où '#' représente un pixel noir.
+func newPBMFromRows(rows ...string) *PBM {
+	pbm := NewPBM(len(rows[0]), len(rows))
+	for y, row := range rows {
+		for x, c := range row {
+			pbm.data[y][x] = c == '#'
+		}
+	}
+	return pbm
+}
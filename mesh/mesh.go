@@ -0,0 +1,154 @@
+// Package mesh lit des maillages de triangles (OBJ, STL) et les rend en images PPM, en fil de fer ou
+// ombrés, vus par une caméra du paquet raytrace.
+package mesh // ✨ Rendu de maillages 3D
+
+import (
+	"math"
+
+	"github.com/YOYOPX15/Netpbm"
+	"github.com/YOYOPX15/Netpbm/raytrace"
+)
+
+// Vec3 et Camera sont ceux du lancer de rayons.
+type (
+	Vec3   = raytrace.Vec3
+	Camera = raytrace.Camera
+)
+
+// nearEpsilon est la profondeur minimale d'un sommet visible, devant la caméra.
+const nearEpsilon = 1e-6
+
+// Mesh est un maillage de triangles : chaque face donne les indices de ses trois sommets dans Vertices.
+type Mesh struct {
+	Vertices []Vec3
+	Faces    [][3]int
+}
+
+// MeshMode est le mode de rendu d'un maillage.
+type MeshMode int
+
+const (
+	// Wireframe trace les arêtes des faces.
+	Wireframe MeshMode = iota
+	// FlatShaded remplit chaque face d'une couleur unie éclairée selon son orientation, les faces cachées
+	// étant éliminées par un tampon de profondeur.
+	FlatShaded
+)
+
+// MeshOptions regroupe les options de RenderMesh. Light est la direction dans laquelle va la lumière et
+// Ambient la part de lumière reçue par les faces qui ne lui font pas face.
+type MeshOptions struct {
+	Mode       MeshMode
+	Color      Netpbm.Pixel
+	Background Netpbm.Pixel
+	Light      Vec3
+	Ambient    float64
+}
+
+// DefaultMeshOptions sont des options de rendu ombré, gris clair sur fond noir, éclairé d'en haut à gauche.
+var DefaultMeshOptions = MeshOptions{
+	Mode:    FlatShaded,
+	Color:   Netpbm.Pixel{R: 220, G: 220, B: 220},
+	Light:   Vec3{X: 1, Y: -1, Z: 1},
+	Ambient: 0.15,
+}
+
+// RenderMesh rend le maillage vu par la caméra dans une nouvelle image PPM de width×height pixels.
+func RenderMesh(mesh Mesh, camera Camera, width, height int, options MeshOptions) *Netpbm.PPM {
+	ppm := Netpbm.NewPPM(width, height, 255)
+	ppm.SetMagicNumber("P6")
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			ppm.Set(x, y, options.Background)
+		}
+	}
+
+	// Projection perspective de chaque sommet : position dans l'image et profondeur
+	forward, right, up, halfWidth, halfHeight := camera.Basis(width, height)
+	projected := make([]Vec3, len(mesh.Vertices))
+	for i, v := range mesh.Vertices {
+		d := v.Sub(camera.Position)
+		z := d.Dot(forward)
+		if z <= nearEpsilon {
+			projected[i] = Vec3{Z: -1}
+			continue
+		}
+		sx, sy := d.Dot(right)/(z*halfWidth), d.Dot(up)/(z*halfHeight)
+		projected[i] = Vec3{X: (sx + 1) / 2 * float64(width), Y: (1 - sy) / 2 * float64(height), Z: z}
+	}
+	visible := func(face [3]int) bool {
+		for _, i := range face {
+			if i < 0 || i >= len(projected) || projected[i].Z <= 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	if options.Mode == Wireframe {
+		toPoint := func(p Vec3) Netpbm.Point {
+			return Netpbm.Point{X: int(math.Round(p.X - 0.5)), Y: int(math.Round(p.Y - 0.5))}
+		}
+		for _, face := range mesh.Faces {
+			if !visible(face) {
+				continue
+			}
+			for k := 0; k < 3; k++ {
+				ppm.DrawLine(toPoint(projected[face[k]]), toPoint(projected[face[(k+1)%3]]), options.Color)
+			}
+		}
+		return ppm
+	}
+
+	light := options.Light.Normalize().Scale(-1)
+	depth := make([]float64, width*height)
+	for _, face := range mesh.Faces {
+		if !visible(face) {
+			continue
+		}
+		// Éclairage des deux côtés de la face, pour ne pas dépendre du sens de ses sommets
+		a, b, c := mesh.Vertices[face[0]], mesh.Vertices[face[1]], mesh.Vertices[face[2]]
+		normal := b.Sub(a).Cross(c.Sub(a)).Normalize()
+		intensity := options.Ambient + (1-options.Ambient)*math.Abs(normal.Dot(light))
+		shade := func(v uint8) uint8 { return uint8(math.Round(float64(v) * min(intensity, 1))) }
+		color := Netpbm.Pixel{R: shade(options.Color.R), G: shade(options.Color.G), B: shade(options.Color.B)}
+		rasterizeTriangle(projected[face[0]], projected[face[1]], projected[face[2]], width, height, depth, func(x, y int) {
+			ppm.Set(x, y, color)
+		})
+	}
+	return ppm
+}
+
+// rasterizeTriangle appelle plot pour chaque pixel dont le centre est dans le triangle projeté p0 p1 p2
+// (Z étant la profondeur) et qui est plus proche que ce que contient déjà le tampon depth.
+// Le tampon stocke l'inverse de la profondeur, qui varie linéairement dans l'image (0 : rien de dessiné).
+func rasterizeTriangle(p0, p1, p2 Vec3, width, height int, depth []float64, plot func(x, y int)) {
+	area := (p1.X-p0.X)*(p2.Y-p0.Y) - (p1.Y-p0.Y)*(p2.X-p0.X)
+	if area == 0 {
+		return
+	}
+	minX := max(int(math.Floor(min(p0.X, p1.X, p2.X))), 0)
+	maxX := min(int(math.Ceil(max(p0.X, p1.X, p2.X))), width-1)
+	minY := max(int(math.Floor(min(p0.Y, p1.Y, p2.Y))), 0)
+	maxY := min(int(math.Ceil(max(p0.Y, p1.Y, p2.Y))), height-1)
+	edge := func(a, b Vec3, x, y float64) float64 {
+		return (b.X-a.X)*(y-a.Y) - (b.Y-a.Y)*(x-a.X)
+	}
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			cx, cy := float64(x)+0.5, float64(y)+0.5
+			// Coordonnées barycentriques, positives à l'intérieur quel que soit le sens du triangle
+			w0 := edge(p1, p2, cx, cy) / area
+			w1 := edge(p2, p0, cx, cy) / area
+			w2 := edge(p0, p1, cx, cy) / area
+			if w0 < 0 || w1 < 0 || w2 < 0 {
+				continue
+			}
+			inverse := w0/p0.Z + w1/p1.Z + w2/p2.Z
+			if inverse > depth[y*width+x] {
+				depth[y*width+x] = inverse
+				plot(x, y)
+			}
+		}
+	}
+}
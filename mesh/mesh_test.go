@@ -0,0 +1,82 @@
+package mesh // 🧪 Test rendu de maillages 3D
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/YOYOPX15/Netpbm"
+)
+
+// countColor compte les pixels de l'image PPM qui ont la couleur donnée.
+func countColor(ppm *Netpbm.PPM, color Netpbm.Pixel) int {
+	count := 0
+	width, height := ppm.Size()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if ppm.At(x, y) == color {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// newCubeMesh renvoie un cube de côté 2 centré sur l'origine.
+func newCubeMesh() Mesh {
+	mesh := Mesh{}
+	for i := 0; i < 8; i++ {
+		mesh.Vertices = append(mesh.Vertices, Vec3{X: float64(i&1*2 - 1), Y: float64(i>>1&1*2 - 1), Z: float64(i>>2&1*2 - 1)})
+	}
+	for _, q := range [][4]int{{0, 1, 3, 2}, {4, 6, 7, 5}, {0, 4, 5, 1}, {2, 3, 7, 6}, {0, 2, 6, 4}, {1, 5, 7, 3}} {
+		mesh.Faces = append(mesh.Faces, [3]int{q[0], q[1], q[2]}, [3]int{q[0], q[2], q[3]})
+	}
+	return mesh
+}
+
+func TestRasterizeTriangleDepth(t *testing.T) {
+	depth := make([]float64, 10*10)
+	drawn := map[Netpbm.Point]int{}
+	far := [3]Vec3{{X: 0, Y: 0, Z: 10}, {X: 10, Y: 0, Z: 10}, {X: 0, Y: 10, Z: 10}}
+	near := [3]Vec3{{X: 0, Y: 0, Z: 2}, {X: 5, Y: 0, Z: 2}, {X: 0, Y: 5, Z: 2}}
+	for i, tri := range [][3]Vec3{far, near, far} {
+		rasterizeTriangle(tri[0], tri[1], tri[2], 10, 10, depth, func(x, y int) { drawn[Netpbm.Point{X: x, Y: y}] = i })
+	}
+	if drawn[Netpbm.Point{X: 1, Y: 1}] != 1 || drawn[Netpbm.Point{X: 7, Y: 1}] != 0 {
+		t.Errorf("The near triangle should stay in front, got %d and %d", drawn[Netpbm.Point{X: 1, Y: 1}], drawn[Netpbm.Point{X: 7, Y: 1}])
+	}
+	if _, ok := drawn[Netpbm.Point{X: 9, Y: 9}]; ok {
+		t.Error("Pixels outside both triangles should not be drawn")
+	}
+}
+
+func TestRenderMesh(t *testing.T) {
+	camera := Camera{Position: Vec3{X: 4, Y: 3, Z: -5}, LookAt: Vec3{X: 0, Y: 0, Z: 0}, Up: Vec3{X: 0, Y: 1, Z: 0}, FOV: 40}
+	options := DefaultMeshOptions
+	options.Light = Vec3{X: -1, Y: -2, Z: 3}
+	ppm := RenderMesh(newCubeMesh(), camera, 80, 60, options)
+
+	// Trois faces visibles, chacune d'une teinte différente
+	shades := map[Netpbm.Pixel]int{}
+	for y := 0; y < 60; y++ {
+		for x := 0; x < 80; x++ {
+			shades[ppm.At(x, y)]++
+		}
+	}
+	if len(shades) != 4 {
+		t.Errorf("Expected the background and three shades, got %v", shades)
+	}
+	if ppm.At(0, 0) != (Netpbm.Pixel{}) || ppm.At(40, 30) == (Netpbm.Pixel{}) {
+		t.Error("The cube should be centered on a black background")
+	}
+
+	options.Mode = Wireframe
+	options.Color = Netpbm.Pixel{G: 255}
+	wire := RenderMesh(newCubeMesh(), camera, 80, 60, options)
+	if countColor(wire, Netpbm.Pixel{G: 255}) == 0 || countColor(wire, Netpbm.Pixel{}) < 80*60/2 {
+		t.Error("The wireframe should only draw edges")
+	}
+
+	if err := ppm.Save(filepath.Join(t.TempDir(), "mesh.ppm")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,114 @@
+package mesh // ✨ Lecture de maillages OBJ et STL
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadOBJ lit les sommets (v) et les faces (f) d'un fichier Wavefront OBJ. Les faces de plus de trois
+// sommets sont découpées en triangles en éventail ; les textures, normales et matériaux sont ignorés.
+func ReadOBJ(filename string) (*Mesh, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+
+	mesh := &Mesh{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "v":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("line %d: vertex needs three coordinates", line)
+			}
+			var coords [3]float64
+			for i := range coords {
+				if coords[i], err = strconv.ParseFloat(fields[i+1], 64); err != nil {
+					return nil, fmt.Errorf("line %d: invalid coordinate: %v", line, err)
+				}
+			}
+			mesh.Vertices = append(mesh.Vertices, Vec3{X: coords[0], Y: coords[1], Z: coords[2]})
+
+		case "f":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("line %d: face needs at least three vertices", line)
+			}
+			indices := make([]int, len(fields)-1)
+			for i, field := range fields[1:] {
+				// Forme v, v/vt, v//vn ou v/vt/vn : seul l'indice du sommet compte
+				index, err := strconv.Atoi(strings.SplitN(field, "/", 2)[0])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid vertex index: %v", line, err)
+				}
+				// Les indices commencent à 1 ; un indice négatif compte depuis le dernier sommet lu
+				if index < 0 {
+					index += len(mesh.Vertices) + 1
+				}
+				if index < 1 || index > len(mesh.Vertices) {
+					return nil, fmt.Errorf("line %d: vertex index %s out of range", line, field)
+				}
+				indices[i] = index - 1
+			}
+			for i := 1; i+1 < len(indices); i++ {
+				mesh.Faces = append(mesh.Faces, [3]int{indices[0], indices[i], indices[i+1]})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %v", err)
+	}
+	return mesh, nil
+}
+
+// ReadSTL lit un fichier STL binaire. Les sommets partagés par plusieurs triangles ne sont stockés qu'une fois.
+func ReadSTL(filename string) (*Mesh, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	if len(content) < 84 {
+		return nil, fmt.Errorf("file too short for a binary STL header")
+	}
+	count := int(binary.LittleEndian.Uint32(content[80:84]))
+	if len(content) < 84+50*count {
+		if strings.HasPrefix(string(content), "solid") {
+			return nil, fmt.Errorf("ASCII STL files are not supported")
+		}
+		return nil, fmt.Errorf("file too short for %d triangles", count)
+	}
+
+	mesh := &Mesh{}
+	indices := make(map[Vec3]int)
+	// Chaque triangle : normale (ignorée), trois sommets et deux octets d'attributs
+	for t := 0; t < count; t++ {
+		record := content[84+50*t:]
+		var face [3]int
+		for k := range face {
+			var coords [3]float64
+			for i := range coords {
+				bits := binary.LittleEndian.Uint32(record[12+12*k+4*i:])
+				coords[i] = float64(math.Float32frombits(bits))
+			}
+			v := Vec3{X: coords[0], Y: coords[1], Z: coords[2]}
+			index, ok := indices[v]
+			if !ok {
+				index = len(mesh.Vertices)
+				indices[v] = index
+				mesh.Vertices = append(mesh.Vertices, v)
+			}
+			face[k] = index
+		}
+		mesh.Faces = append(mesh.Faces, face)
+	}
+	return mesh, nil
+}
@@ -0,0 +1,77 @@
+package mesh // 🧪 Test lecture de maillages OBJ et STL
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadOBJ(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "quad.obj")
+	content := "# Un carré et un triangle\nv 0 0 0\nv 1 0 0\nv 1 1 0\nv 0 1 0\nvn 0 0 1\n" +
+		"f 1/1/1 2/2/1 3/3/1 4/4/1\nv 0 0 1\nf -1 1//1 2\n"
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mesh, err := ReadOBJ(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mesh.Vertices) != 5 || mesh.Vertices[2] != (Vec3{X: 1, Y: 1, Z: 0}) {
+		t.Errorf("Unexpected vertices %v", mesh.Vertices)
+	}
+	expected := [][3]int{{0, 1, 2}, {0, 2, 3}, {4, 0, 1}}
+	if len(mesh.Faces) != len(expected) {
+		t.Fatalf("Unexpected faces %v", mesh.Faces)
+	}
+	for i := range expected {
+		if mesh.Faces[i] != expected[i] {
+			t.Errorf("Unexpected face %d: %v", i, mesh.Faces[i])
+		}
+	}
+
+	os.WriteFile(filename, []byte("v 0 0 0\nf 1 2 3\n"), 0644)
+	if _, err := ReadOBJ(filename); err == nil {
+		t.Error("Expected an error for an index out of range")
+	}
+}
+
+func TestReadSTL(t *testing.T) {
+	// Deux triangles partageant une arête
+	triangles := [][3]Vec3{
+		{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}},
+		{{X: 1, Y: 0, Z: 0}, {X: 1, Y: 1, Z: 0}, {X: 0, Y: 1, Z: 0}},
+	}
+	content := make([]byte, 84+50*len(triangles))
+	binary.LittleEndian.PutUint32(content[80:], uint32(len(triangles)))
+	for t, tri := range triangles {
+		for k, v := range tri {
+			for i, c := range []float64{v.X, v.Y, v.Z} {
+				binary.LittleEndian.PutUint32(content[84+50*t+12+12*k+4*i:], math.Float32bits(float32(c)))
+			}
+		}
+	}
+	filename := filepath.Join(t.TempDir(), "square.stl")
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mesh, err := ReadSTL(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mesh.Vertices) != 4 || len(mesh.Faces) != 2 {
+		t.Fatalf("Expected 4 shared vertices and 2 faces, got %d and %d", len(mesh.Vertices), len(mesh.Faces))
+	}
+	if mesh.Faces[1] != [3]int{1, 3, 2} {
+		t.Errorf("Unexpected second face %v", mesh.Faces[1])
+	}
+
+	os.WriteFile(filename, content[:100], 0644)
+	if _, err := ReadSTL(filename); err == nil {
+		t.Error("Expected an error for a truncated file")
+	}
+}
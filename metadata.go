@@ -0,0 +1,96 @@
+package Netpbm // ✨ Métadonnées
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Clés de métadonnées courantes.
+const (
+	MetadataCreator   = "creator"   // Programme ou personne à l'origine de l'image
+	MetadataTimestamp = "timestamp" // Date de création, de préférence au format RFC 3339
+)
+
+// readHeaderLine lit la prochaine ligne utile de l'en-tête.
+// Les lignes vides sont ignorées et les commentaires de la forme "# clé: valeur" sont ajoutés à metadata.
+func readHeaderLine(reader *bufio.Reader, metadata map[string]string) (string, error) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return line, err
+		}
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			key, value, found := strings.Cut(strings.TrimPrefix(trimmed, "#"), ":")
+			key = strings.TrimSpace(key)
+			if found && key != "" {
+				metadata[key] = strings.TrimSpace(value)
+			}
+			continue
+		}
+		if trimmed != "" {
+			return line, nil
+		}
+	}
+}
+
+// writeMetadata écrit les métadonnées sous forme de commentaires "# clé: valeur", triés par clé.
+func writeMetadata(w io.Writer, metadata map[string]string) error {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Les retours à la ligne casseraient l'en-tête, et un deux-points dans la clé la couperait en deux
+	cleanValue := strings.NewReplacer("\r", " ", "\n", " ")
+	cleanKey := strings.NewReplacer("\r", " ", "\n", " ", ":", " ")
+	for _, key := range keys {
+		_, err := fmt.Fprintf(w, "# %s: %s\n", cleanKey.Replace(key), cleanValue.Replace(metadata[key]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setMetadata ajoute une entrée à metadata, en créant la table si besoin.
+func setMetadata(metadata *map[string]string, key, value string) {
+	if *metadata == nil {
+		*metadata = make(map[string]string)
+	}
+	(*metadata)[key] = value
+}
+
+// Metadata renvoie les métadonnées de l'image PBM.
+func (pbm *PBM) Metadata() map[string]string {
+	return pbm.metadata
+}
+
+// SetMetadata définit une métadonnée de l'image PBM, écrite en commentaire lors de l'enregistrement.
+func (pbm *PBM) SetMetadata(key, value string) {
+	setMetadata(&pbm.metadata, key, value)
+}
+
+// Metadata renvoie les métadonnées de l'image PGM.
+func (pgm *PGM) Metadata() map[string]string {
+	return pgm.metadata
+}
+
+// SetMetadata définit une métadonnée de l'image PGM, écrite en commentaire lors de l'enregistrement.
+func (pgm *PGM) SetMetadata(key, value string) {
+	setMetadata(&pgm.metadata, key, value)
+}
+
+// Metadata renvoie les métadonnées de l'image PPM.
+func (ppm *PPM) Metadata() map[string]string {
+	return ppm.metadata
+}
+
+// SetMetadata définit une métadonnée de l'image PPM, écrite en commentaire lors de l'enregistrement.
+func (ppm *PPM) SetMetadata(key, value string) {
+	setMetadata(&ppm.metadata, key, value)
+}
@@ -0,0 +1,76 @@
+package Netpbm // 🧪 Test métadonnées
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMetadataRoundTrip(t *testing.T) {
+	ppm, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	ppm.SetMetadata(MetadataCreator, "Netpbm")
+	ppm.SetMetadata(MetadataTimestamp, "2024-01-02T03:04:05Z")
+	ppm.SetMetadata("note", "two\nlines")
+	ppm.SetMagicNumber("P6")
+	err = ppm.Save("./testImages/ppm/testMetadata.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.Remove("./testImages/ppm/testMetadata.ppm")
+
+	ppm, err = ReadPPM("./testImages/ppm/testMetadata.ppm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := ppm.Metadata()
+	if metadata[MetadataCreator] != "Netpbm" || metadata[MetadataTimestamp] != "2024-01-02T03:04:05Z" {
+		t.Errorf("Metadata not read back: %v", metadata)
+	}
+	if metadata["note"] != "two lines" {
+		t.Errorf("Multi-line value not sanitized: %q", metadata["note"])
+	}
+	for i := 0; i < imagePPMWidth*imagePPMHeight; i++ {
+		x := i % imagePPMWidth
+		y := i / imagePPMWidth
+		if ppm.data[y][x] != imagePPMData[i] {
+			t.Errorf("Pixel at (%d, %d) not read correctly", x, y)
+		}
+	}
+}
+
+func TestMetadataPBMPGM(t *testing.T) {
+	pbm, err := ReadPBM("./testImages/pbm/testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	pbm.SetMetadata("source", "scanner")
+	err = pbm.Save("./testImages/pbm/testMetadata.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.Remove("./testImages/pbm/testMetadata.pbm")
+	pbm, err = ReadPBM("./testImages/pbm/testMetadata.pbm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pbm.Metadata()["source"] != "scanner" {
+		t.Error("PBM metadata not read back")
+	}
+
+	pgm := NewPGM(2, 1, 255)
+	pgm.SetMetadata("a:b", "c: d")
+	err = pgm.Save("./testImages/pgm/testMetadata.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.Remove("./testImages/pgm/testMetadata.pgm")
+	pgm, err = ReadPGM("./testImages/pgm/testMetadata.pgm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm.Metadata()["a b"] != "c: d" {
+		t.Errorf("PGM metadata not read back: %v", pgm.Metadata())
+	}
+}
@@ -0,0 +1,209 @@
+package Netpbm // ✨ Métriques
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Noms des métriques relevées par le paquet, suivis de leurs étiquettes.
+const (
+	MetricImagesDecoded  = "netpbm_images_decoded_total"           // Compteur : format, result (ok, truncated, error)
+	MetricDecodeSeconds  = "netpbm_decode_duration_seconds"        // Histogramme : format
+	MetricEncodeSeconds  = "netpbm_encode_duration_seconds"        // Histogramme, écritures d'une Pipeline
+	MetricBytesWritten   = "netpbm_bytes_written_total"            // Compteur, écritures d'une Pipeline
+	MetricStepSeconds    = "netpbm_pipeline_step_duration_seconds" // Histogramme : step
+	MetricFilesProcessed = "netpbm_watcher_files_total"            // Compteur : result (ok, error)
+)
+
+// Label est une étiquette nommée d'une métrique.
+type Label struct {
+	Name, Value string
+}
+
+// Metrics reçoit les mesures du paquet. Add incrémente un compteur, Observe ajoute une valeur à un
+// histogramme ; les durées sont en secondes. Une implémentation peut relayer les mesures vers
+// Prometheus ou un autre système, ou utiliser MetricsRegistry.
+type Metrics interface {
+	Add(name string, value float64, labels ...Label)
+	Observe(name string, value float64, labels ...Label)
+}
+
+// packageMetrics contient les métriques installées par SetMetrics, nil tant qu'aucune ne l'est.
+var packageMetrics atomic.Pointer[Metrics]
+
+// SetMetrics installe le destinataire des mesures du paquet. nil (par défaut) désactive les mesures.
+func SetMetrics(metrics Metrics) {
+	if metrics == nil {
+		packageMetrics.Store(nil)
+		return
+	}
+	packageMetrics.Store(&metrics)
+}
+
+// currentMetrics renvoie les métriques installées, ou nil.
+func currentMetrics() Metrics {
+	if metrics := packageMetrics.Load(); metrics != nil {
+		return *metrics
+	}
+	return nil
+}
+
+// traceDecode journalise et mesure la lecture, au format format, du fichier filename commencée à start.
+func traceDecode(format, filename string, start time.Time, img Image, err error) {
+	if metrics := currentMetrics(); metrics != nil {
+		result := "ok"
+		var truncated *TruncatedError
+		if errors.As(err, &truncated) {
+			result = "truncated"
+		} else if err != nil {
+			result = "error"
+		}
+		metrics.Add(MetricImagesDecoded, 1, Label{"format", format}, Label{"result", result})
+		if err == nil {
+			metrics.Observe(MetricDecodeSeconds, time.Since(start).Seconds(), Label{"format", format})
+		}
+	}
+	logDecode(filename, start, img, err)
+}
+
+// DefaultMetricsBuckets sont les bornes des histogrammes d'un MetricsRegistry, en secondes.
+var DefaultMetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MetricsRegistry est une implémentation de Metrics qui garde les mesures en mémoire et les publie au
+// format texte de Prometheus. Elle peut être utilisée directement comme gestionnaire HTTP (/metrics).
+type MetricsRegistry struct {
+	mu         sync.Mutex
+	buckets    []float64
+	counters   map[string]map[string]float64    // Valeur de chaque série, par nom puis étiquettes
+	histograms map[string]map[string]*histogram // Histogramme de chaque série, par nom puis étiquettes
+}
+
+// histogram compte les valeurs observées sous chaque borne.
+type histogram struct {
+	counts []uint64 // Nombre de valeurs inférieures ou égales à chaque borne (non cumulé)
+	count  uint64
+	sum    float64
+}
+
+// NewMetricsRegistry crée un registre vide dont les histogrammes utilisent DefaultMetricsBuckets.
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{
+		buckets:    DefaultMetricsBuckets,
+		counters:   make(map[string]map[string]float64),
+		histograms: make(map[string]map[string]*histogram),
+	}
+}
+
+// formatLabels écrit les étiquettes au format Prometheus, triées par nom, sans les accolades.
+func formatLabels(labels []Label) string {
+	sorted := append([]Label(nil), labels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	parts := make([]string, len(sorted))
+	for i, label := range sorted {
+		parts[i] = label.Name + "=" + strconv.Quote(label.Value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Add ajoute value au compteur name.
+func (r *MetricsRegistry) Add(name string, value float64, labels ...Label) {
+	key := formatLabels(labels)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.counters[name] == nil {
+		r.counters[name] = make(map[string]float64)
+	}
+	r.counters[name][key] += value
+}
+
+// Observe ajoute value à l'histogramme name.
+func (r *MetricsRegistry) Observe(name string, value float64, labels ...Label) {
+	key := formatLabels(labels)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.histograms[name] == nil {
+		r.histograms[name] = make(map[string]*histogram)
+	}
+	h := r.histograms[name][key]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		r.histograms[name][key] = h
+	}
+	if i := sort.SearchFloat64s(r.buckets, value); i < len(r.buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// Counter renvoie la valeur du compteur name pour ces étiquettes (0 s'il n'existe pas).
+func (r *MetricsRegistry) Counter(name string, labels ...Label) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counters[name][formatLabels(labels)]
+}
+
+// WriteTo écrit toutes les séries au format texte de Prometheus, triées par nom puis par étiquettes.
+func (r *MetricsRegistry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+	series := func(name, labels, extra string) string {
+		all := strings.Trim(labels+","+extra, ",")
+		if all == "" {
+			return name
+		}
+		return name + "{" + all + "}"
+	}
+	for _, name := range sortedKeys(r.counters) {
+		fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+		for _, labels := range sortedKeys(r.counters[name]) {
+			fmt.Fprintf(&b, "%s %s\n", series(name, labels, ""), formatFloat(r.counters[name][labels]))
+		}
+	}
+	for _, name := range sortedKeys(r.histograms) {
+		fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+		for _, labels := range sortedKeys(r.histograms[name]) {
+			h := r.histograms[name][labels]
+			cumulative := uint64(0)
+			for i, bound := range r.buckets {
+				cumulative += h.counts[i]
+				fmt.Fprintf(&b, "%s %d\n", series(name+"_bucket", labels, `le="`+formatFloat(bound)+`"`), cumulative)
+			}
+			fmt.Fprintf(&b, "%s %d\n", series(name+"_bucket", labels, `le="+Inf"`), h.count)
+			fmt.Fprintf(&b, "%s %s\n", series(name+"_sum", labels, ""), formatFloat(h.sum))
+			fmt.Fprintf(&b, "%s %d\n", series(name+"_count", labels, ""), h.count)
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP publie les métriques, pour exposer le registre à un serveur Prometheus.
+func (r *MetricsRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// sortedKeys renvoie les clés de la table, triées.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatFloat écrit un nombre sous sa forme la plus courte.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,86 @@
+package Netpbm // 🧪 Test métriques
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetricsRegistry(t *testing.T) {
+	registry := NewMetricsRegistry()
+	registry.Add("jobs_total", 1, Label{"result", "ok"})
+	registry.Add("jobs_total", 2, Label{"result", "ok"})
+	registry.Add("jobs_total", 1, Label{"result", "error"})
+	registry.Observe("job_seconds", 0.02)
+	registry.Observe("job_seconds", 3)
+	if registry.Counter("jobs_total", Label{"result", "ok"}) != 3 {
+		t.Errorf("Unexpected counter %v", registry.Counter("jobs_total", Label{"result", "ok"}))
+	}
+
+	var b strings.Builder
+	registry.WriteTo(&b)
+	for _, line := range []string{
+		"# TYPE jobs_total counter",
+		`jobs_total{result="error"} 1`,
+		`jobs_total{result="ok"} 3`,
+		"# TYPE job_seconds histogram",
+		`job_seconds_bucket{le="0.01"} 0`,
+		`job_seconds_bucket{le="0.025"} 1`,
+		`job_seconds_bucket{le="5"} 2`,
+		`job_seconds_bucket{le="+Inf"} 2`,
+		"job_seconds_sum 3.02",
+		"job_seconds_count 2",
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("Missing line %q in:\n%s", line, b.String())
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if recorder.Body.String() != b.String() {
+		t.Error("The HTTP handler should serve the same text")
+	}
+}
+
+func TestSetMetrics(t *testing.T) {
+	registry := NewMetricsRegistry()
+	SetMetrics(registry)
+	defer SetMetrics(nil)
+
+	dir := t.TempDir()
+	input, output := filepath.Join(dir, "in"), filepath.Join(dir, "out")
+	os.Mkdir(input, 0755)
+	os.Mkdir(output, 0755)
+	if err := newGradientPGM(8, 8).Save(filepath.Join(input, "a.pgm")); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(input, "b.pgm"), []byte("P2\n"), 0644)
+
+	watcher := NewWatcher(input, output, Pipeline{func(img Image) (Image, error) { return img, nil }})
+	watcher.Poll()
+	watcher.Poll()
+
+	if registry.Counter(MetricImagesDecoded, Label{"format", "pgm"}, Label{"result", "ok"}) != 1 ||
+		registry.Counter(MetricImagesDecoded, Label{"format", "pgm"}, Label{"result", "error"}) != 1 {
+		t.Error("Expected one decoded image and one decoding error")
+	}
+	if registry.Counter(MetricFilesProcessed, Label{"result", "ok"}) != 1 ||
+		registry.Counter(MetricFilesProcessed, Label{"result", "error"}) != 1 {
+		t.Error("Expected one processed file and one failure")
+	}
+	info, err := os.Stat(filepath.Join(output, "a.pgm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registry.Counter(MetricBytesWritten) != float64(info.Size()) {
+		t.Errorf("Expected %d bytes written, got %v", info.Size(), registry.Counter(MetricBytesWritten))
+	}
+	var b strings.Builder
+	registry.WriteTo(&b)
+	if !strings.Contains(b.String(), MetricStepSeconds+`_count{step="0"} 1`) {
+		t.Errorf("Expected one observed step in:\n%s", b.String())
+	}
+}
@@ -0,0 +1,86 @@
+package Netpbm // ✨ Moments d'image
+
+import "math"
+
+// Moments regroupe les moments d'une image jusqu'à l'ordre 3, indexés par [p][q] pour x^p·y^q.
+type Moments struct {
+	Raw        [4][4]float64 // Moments bruts : somme de x^p·y^q·I(x, y)
+	Central    [4][4]float64 // Moments centrés sur le centre de masse
+	Normalized [4][4]float64 // Moments centrés normalisés, invariants par changement d'échelle (p+q >= 2)
+
+	CentroidX, CentroidY float64 // Centre de masse
+	Orientation          float64 // Angle de l'axe principal, en radians, dans ]-π/2, π/2]
+}
+
+// Moments calcule les moments de la forme formée par les pixels noirs de l'image PBM.
+func (pbm *PBM) Moments() Moments {
+	return computeMoments(pbm.width, pbm.height, func(x, y int) float64 {
+		if pbm.data[y][x] {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Moments calcule les moments de l'image PGM, chaque pixel pesant son intensité.
+func (pgm *PGM) Moments() Moments {
+	return computeMoments(pgm.width, pgm.height, func(x, y int) float64 {
+		return float64(pgm.data[y][x])
+	})
+}
+
+// computeMoments calcule les moments d'une image width×height dont value donne le poids de chaque pixel.
+func computeMoments(width, height int, value func(x, y int) float64) Moments {
+	var m Moments
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := value(x, y)
+			if v == 0 {
+				continue
+			}
+			xp := 1.0
+			for p := 0; p < 4; p++ {
+				yq := 1.0
+				for q := 0; p+q < 4; q++ {
+					m.Raw[p][q] += v * xp * yq
+					yq *= float64(y)
+				}
+				xp *= float64(x)
+			}
+		}
+	}
+	if m.Raw[0][0] == 0 {
+		return m
+	}
+	m.CentroidX = m.Raw[1][0] / m.Raw[0][0]
+	m.CentroidY = m.Raw[0][1] / m.Raw[0][0]
+
+	// Seconde passe : plus précise que de développer les moments bruts
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := value(x, y)
+			if v == 0 {
+				continue
+			}
+			dx, dy := float64(x)-m.CentroidX, float64(y)-m.CentroidY
+			xp := 1.0
+			for p := 0; p < 4; p++ {
+				yq := 1.0
+				for q := 0; p+q < 4; q++ {
+					m.Central[p][q] += v * xp * yq
+					yq *= dy
+				}
+				xp *= dx
+			}
+		}
+	}
+	for p := 0; p < 4; p++ {
+		for q := 0; p+q < 4; q++ {
+			if p+q >= 2 {
+				m.Normalized[p][q] = m.Central[p][q] / math.Pow(m.Raw[0][0], 1+float64(p+q)/2)
+			}
+		}
+	}
+	m.Orientation = 0.5 * math.Atan2(2*m.Central[1][1], m.Central[2][0]-m.Central[0][2])
+	return m
+}
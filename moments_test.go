@@ -0,0 +1,73 @@
+package Netpbm // 🧪 Test moments d'image
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPBMMoments(t *testing.T) {
+	// Rectangle noir de 6×2 pixels dont le coin supérieur gauche est en (2, 3)
+	pbm := NewPBM(10, 10)
+	for y := 3; y < 5; y++ {
+		for x := 2; x < 8; x++ {
+			pbm.data[y][x] = true
+		}
+	}
+	m := pbm.Moments()
+	if m.Raw[0][0] != 12 {
+		t.Errorf("Expected area 12, got %v", m.Raw[0][0])
+	}
+	if m.CentroidX != 4.5 || m.CentroidY != 3.5 {
+		t.Errorf("Wrong centroid: (%v, %v)", m.CentroidX, m.CentroidY)
+	}
+	if math.Abs(m.Orientation) > 1e-9 {
+		t.Errorf("Horizontal shape should have orientation 0, got %v", m.Orientation)
+	}
+	if math.Abs(m.Central[1][0]) > 1e-9 || math.Abs(m.Central[0][1]) > 1e-9 {
+		t.Error("First-order central moments should be 0")
+	}
+	if m.Central[2][0] <= m.Central[0][2] {
+		t.Error("Horizontal shape should spread more along x")
+	}
+}
+
+func TestPBMMomentsOrientation(t *testing.T) {
+	// Diagonale descendante : axe principal à +45° (y vers le bas)
+	pbm := NewPBM(10, 10)
+	for i := 0; i < 10; i++ {
+		pbm.data[i][i] = true
+	}
+	m := pbm.Moments()
+	if math.Abs(m.Orientation-math.Pi/4) > 1e-9 {
+		t.Errorf("Expected orientation π/4, got %v", m.Orientation)
+	}
+}
+
+func TestMomentsScaleInvariance(t *testing.T) {
+	square := func(size int) *PBM {
+		pbm := NewPBM(40, 40)
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				pbm.data[y][x] = true
+			}
+		}
+		return pbm
+	}
+	small, large := square(10).Moments(), square(30).Moments()
+	if math.Abs(small.Normalized[2][0]-large.Normalized[2][0]) > 0.01 {
+		t.Errorf("Normalized moments should not depend on scale: %v %v", small.Normalized[2][0], large.Normalized[2][0])
+	}
+}
+
+func TestPGMMoments(t *testing.T) {
+	pgm := NewPGM(5, 1, 255)
+	pgm.data[0][1] = 100
+	pgm.data[0][4] = 100
+	m := pgm.Moments()
+	if m.Raw[0][0] != 200 || m.CentroidX != 2.5 || m.CentroidY != 0 {
+		t.Errorf("Wrong moments: area %v, centroid (%v, %v)", m.Raw[0][0], m.CentroidX, m.CentroidY)
+	}
+	if empty := NewPGM(3, 3, 255).Moments(); empty.Raw[0][0] != 0 || empty.CentroidX != 0 {
+		t.Error("Empty image should have zero moments")
+	}
+}
@@ -0,0 +1,58 @@
+package Netpbm // ✨ Mise à l'échelle en neuf parties
+
+import "fmt"
+
+// Insets donne la largeur des bords d'une image découpée en neuf parties.
+type Insets struct {
+	Left, Top, Right, Bottom int
+}
+
+// NinePatchScale redimensionne l'image PPM à width×height en la découpant en neuf parties selon insets :
+// les coins sont gardés tels quels, les bords ne sont étirés que dans leur longueur et le centre dans
+// les deux sens. Pratique pour fabriquer des cadres et des boutons de toutes tailles.
+func NinePatchScale(src *PPM, insets Insets, width, height int) (*PPM, error) {
+	if src.width == 0 || src.height == 0 {
+		return nil, fmt.Errorf("cannot scale an empty image")
+	}
+	if insets.Left < 0 || insets.Top < 0 || insets.Right < 0 || insets.Bottom < 0 {
+		return nil, fmt.Errorf("negative insets %+v", insets)
+	}
+	if insets.Left+insets.Right > src.width || insets.Top+insets.Bottom > src.height {
+		return nil, fmt.Errorf("insets %+v do not fit in a %dx%d image", insets, src.width, src.height)
+	}
+	if insets.Left+insets.Right > width || insets.Top+insets.Bottom > height {
+		return nil, fmt.Errorf("size %dx%d is too small for insets %+v", width, height, insets)
+	}
+
+	columns := ninePatchAxis(src.width, width, insets.Left, insets.Right)
+	rows := ninePatchAxis(src.height, height, insets.Top, insets.Bottom)
+	scaled := NewPPM(width, height, src.max)
+	scaled.magicNumber = src.magicNumber
+	for y, sy := range rows {
+		for x, sx := range columns {
+			scaled.data[y][x] = src.data[sy][sx]
+		}
+	}
+	return scaled, nil
+}
+
+// ninePatchAxis renvoie, pour chaque coordonnée de destination, la coordonnée source correspondante sur un axe :
+// les start premiers et les end derniers pixels sont recopiés, ceux du milieu sont étirés.
+func ninePatchAxis(src, dst, start, end int) []int {
+	mapping := make([]int, dst)
+	middle, stretched := src-start-end, dst-start-end
+	for i := range mapping {
+		switch {
+		case i < start:
+			mapping[i] = i
+		case i >= dst-end:
+			mapping[i] = src - (dst - i)
+		case middle == 0:
+			// Centre vide : on prolonge le bord de départ
+			mapping[i] = max(start-1, 0)
+		default:
+			mapping[i] = start + (i-start)*middle/stretched
+		}
+	}
+	return mapping
+}
@@ -0,0 +1,53 @@
+package Netpbm // 🧪 Test mise à l'échelle en neuf parties
+
+import "testing"
+
+func TestNinePatchScale(t *testing.T) {
+	src := newPatternPPM(6, 5)
+	insets := Insets{Left: 2, Top: 1, Right: 1, Bottom: 2}
+	scaled, err := NinePatchScale(src, insets, 20, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	width, height := scaled.Size()
+	if width != 20 || height != 12 {
+		t.Fatalf("Wrong size: %dx%d", width, height)
+	}
+	// Les quatre coins sont recopiés tels quels
+	corners := []struct{ dst, src Point }{
+		{Point{0, 0}, Point{0, 0}}, {Point{1, 0}, Point{1, 0}},
+		{Point{19, 0}, Point{5, 0}},
+		{Point{0, 11}, Point{0, 4}}, {Point{1, 10}, Point{1, 3}},
+		{Point{19, 11}, Point{5, 4}},
+	}
+	for _, c := range corners {
+		if scaled.At(c.dst.X, c.dst.Y) != src.At(c.src.X, c.src.Y) {
+			t.Errorf("Corner pixel %v should come from %v", c.dst, c.src)
+		}
+	}
+	// Le bord supérieur n'est étiré qu'horizontalement
+	for x := 2; x < 19; x++ {
+		if got := scaled.At(x, 0); got.G != src.At(2, 0).G {
+			t.Errorf("Top edge pixel %d should come from row 0, got %v", x, got)
+		}
+	}
+}
+
+func TestNinePatchScaleErrors(t *testing.T) {
+	src := newPatternPPM(6, 6)
+	if _, err := NinePatchScale(src, Insets{Left: 4, Right: 4}, 20, 20); err == nil {
+		t.Error("Expected an error for insets wider than the source")
+	}
+	if _, err := NinePatchScale(src, Insets{Left: 2, Right: 2}, 3, 20); err == nil {
+		t.Error("Expected an error for a target smaller than the insets")
+	}
+	if _, err := NinePatchScale(src, Insets{Top: -1}, 8, 8); err == nil {
+		t.Error("Expected an error for negative insets")
+	}
+	if _, err := NinePatchScale(NewPPM(0, 0, 255), Insets{}, 8, 8); err == nil {
+		t.Error("Expected an error for an empty source")
+	}
+	if shrunk, err := NinePatchScale(src, Insets{2, 2, 2, 2}, 4, 4); err != nil || shrunk.At(3, 3) != src.At(5, 5) {
+		t.Error("Shrinking to the corners only should keep them")
+	}
+}
@@ -0,0 +1,122 @@
+package Netpbm // ✨ Bruit
+
+import (
+	"math"
+	"math/rand"
+)
+
+// poisson tire un nombre selon une loi de Poisson de moyenne lambda.
+func poisson(random *rand.Rand, lambda float64) float64 {
+	if lambda <= 0 {
+		return 0
+	}
+	if lambda > 30 {
+		// Approximation normale, suffisante pour de grandes moyennes
+		return math.Max(math.Round(lambda+math.Sqrt(lambda)*random.NormFloat64()), 0)
+	}
+	// Méthode de Knuth
+	limit := math.Exp(-lambda)
+	k, p := 0.0, 1.0
+	for {
+		p *= random.Float64()
+		if p <= limit {
+			return k
+		}
+		k++
+	}
+}
+
+// AddGaussianNoise ajoute à chaque pixel de l'image PGM un bruit gaussien d'écart type sigma.
+// Une même graine donne toujours le même bruit.
+func (pgm *PGM) AddGaussianNoise(sigma float64, seed int64) {
+	pgm.own()
+	random := newRandom(seed)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = clampChannel(float64(pgm.data[y][x])+sigma*random.NormFloat64(), pgm.max)
+		}
+	}
+}
+
+// AddSaltPepperNoise remplace une proportion density des pixels de l'image PGM par du noir ou du blanc.
+// Une même graine donne toujours le même bruit.
+func (pgm *PGM) AddSaltPepperNoise(density float64, seed int64) {
+	pgm.own()
+	random := newRandom(seed)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			if random.Float64() >= density {
+				continue
+			}
+			if random.Intn(2) == 0 {
+				pgm.data[y][x] = 0
+			} else {
+				pgm.data[y][x] = uint8(pgm.max)
+			}
+		}
+	}
+}
+
+// AddPoissonNoise remplace chaque pixel de l'image PGM par un tirage de Poisson de même moyenne,
+// comme le bruit de photons d'un capteur. Une même graine donne toujours le même bruit.
+func (pgm *PGM) AddPoissonNoise(seed int64) {
+	pgm.own()
+	random := newRandom(seed)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = clampChannel(poisson(random, float64(pgm.data[y][x])), pgm.max)
+		}
+	}
+}
+
+// AddGaussianNoise ajoute à chaque canal de l'image PPM un bruit gaussien d'écart type sigma.
+// Une même graine donne toujours le même bruit.
+func (ppm *PPM) AddGaussianNoise(sigma float64, seed int64) {
+	ppm.own()
+	random := newRandom(seed)
+	noisy := func(v uint8) uint8 {
+		return clampChannel(float64(v)+sigma*random.NormFloat64(), ppm.max)
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			pixel := &ppm.data[y][x]
+			pixel.R, pixel.G, pixel.B = noisy(pixel.R), noisy(pixel.G), noisy(pixel.B)
+		}
+	}
+}
+
+// AddSaltPepperNoise remplace une proportion density des pixels de l'image PPM par du noir ou du blanc.
+// Une même graine donne toujours le même bruit.
+func (ppm *PPM) AddSaltPepperNoise(density float64, seed int64) {
+	ppm.own()
+	random := newRandom(seed)
+	white := Pixel{uint8(ppm.max), uint8(ppm.max), uint8(ppm.max)}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			if random.Float64() >= density {
+				continue
+			}
+			if random.Intn(2) == 0 {
+				ppm.data[y][x] = Pixel{}
+			} else {
+				ppm.data[y][x] = white
+			}
+		}
+	}
+}
+
+// AddPoissonNoise remplace chaque canal de l'image PPM par un tirage de Poisson de même moyenne.
+// Une même graine donne toujours le même bruit.
+func (ppm *PPM) AddPoissonNoise(seed int64) {
+	ppm.own()
+	random := newRandom(seed)
+	noisy := func(v uint8) uint8 {
+		return clampChannel(poisson(random, float64(v)), ppm.max)
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			pixel := &ppm.data[y][x]
+			pixel.R, pixel.G, pixel.B = noisy(pixel.R), noisy(pixel.G), noisy(pixel.B)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package Netpbm // 🧪 Test bruit
+
+import (
+	"math"
+	"testing"
+)
+
+// meanAndStdDev renvoie la moyenne et l'écart type des pixels de l'image PGM.
+func meanAndStdDev(pgm *PGM) (float64, float64) {
+	sum, sumSquares := 0.0, 0.0
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			v := float64(pgm.data[y][x])
+			sum += v
+			sumSquares += v * v
+		}
+	}
+	n := float64(pgm.width * pgm.height)
+	mean := sum / n
+	return mean, math.Sqrt(sumSquares/n - mean*mean)
+}
+
+func TestAddGaussianNoise(t *testing.T) {
+	pgm := newUniformPGM(100, 100, 128)
+	pgm.AddGaussianNoise(10, 1)
+	mean, stdDev := meanAndStdDev(pgm)
+	if math.Abs(mean-128) > 1 || math.Abs(stdDev-10) > 1 {
+		t.Errorf("Wrong noise statistics: mean %.2f, std dev %.2f", mean, stdDev)
+	}
+	other := newUniformPGM(100, 100, 128)
+	other.AddGaussianNoise(10, 1)
+	if other.Hash() != pgm.Hash() {
+		t.Error("Same seed should give the same noise")
+	}
+
+	ppm := NewPPM(10, 10, 255)
+	ppm.AddGaussianNoise(0, 3)
+	if countColor(ppm, Pixel{}) != 100 {
+		t.Error("Zero sigma should not change the image")
+	}
+}
+
+func TestAddSaltPepperNoise(t *testing.T) {
+	pgm := newUniformPGM(100, 100, 128)
+	pgm.AddSaltPepperNoise(0.1, 2)
+	changed := 0
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			switch pgm.data[y][x] {
+			case 0, 255:
+				changed++
+			case 128:
+			default:
+				t.Fatalf("Unexpected value %d", pgm.data[y][x])
+			}
+		}
+	}
+	if changed < 900 || changed > 1100 {
+		t.Errorf("Wrong number of corrupted pixels: %d", changed)
+	}
+
+	ppm := NewPPM(100, 100, 255)
+	ppm.AddSaltPepperNoise(1, 2)
+	if countColor(ppm, Pixel{})+countColor(ppm, Pixel{255, 255, 255}) != 10000 {
+		t.Error("Every pixel should be salt or pepper")
+	}
+}
+
+func TestAddPoissonNoise(t *testing.T) {
+	for _, level := range []uint8{5, 100} {
+		pgm := newUniformPGM(100, 100, level)
+		pgm.AddPoissonNoise(3)
+		mean, stdDev := meanAndStdDev(pgm)
+		expected := math.Sqrt(float64(level))
+		if math.Abs(mean-float64(level)) > 0.5 || math.Abs(stdDev-expected) > expected/10 {
+			t.Errorf("Wrong noise statistics for %d: mean %.2f, std dev %.2f", level, mean, stdDev)
+		}
+	}
+	ppm := NewPPM(4, 4, 255)
+	ppm.AddPoissonNoise(1)
+	if countColor(ppm, Pixel{}) != 16 {
+		t.Error("Black stays black with Poisson noise")
+	}
+}
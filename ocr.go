@@ -0,0 +1,280 @@
+package Netpbm // ✨ Préparation à la reconnaissance de texte
+
+import "math"
+
+// SkewAngle estime l'inclinaison, en degrés dans [-maxAngle, maxAngle], des lignes de texte sombre
+// de l'image PGM : c'est l'angle pour lequel la projection des pixels sombres sur l'axe vertical
+// forme les pics les plus nets. Un angle positif veut dire que les lignes descendent vers la droite.
+func (pgm *PGM) SkewAngle(maxAngle float64) float64 {
+	var dark []pointF
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			if pgm.data[y][x] < uint8(pgm.max/2) {
+				dark = append(dark, pointF{float64(x), float64(y)})
+			}
+		}
+	}
+	if len(dark) == 0 {
+		return 0
+	}
+
+	size := pgm.width + pgm.height
+	histogram := make([]int, 2*size+1)
+	score := func(angle float64) float64 {
+		sin, cos := math.Sincos(angle * math.Pi / 180)
+		for i := range histogram {
+			histogram[i] = 0
+		}
+		for _, p := range dark {
+			histogram[size+int(math.Round(p.Y*cos-p.X*sin))]++
+		}
+		sum := 0.0
+		for _, count := range histogram {
+			sum += float64(count * count)
+		}
+		return sum
+	}
+
+	// Recherche grossière, puis affinage autour du meilleur angle
+	best, bestScore := 0.0, score(0)
+	for angle := -maxAngle; angle <= maxAngle; angle += 0.5 {
+		if s := score(angle); s > bestScore {
+			best, bestScore = angle, s
+		}
+	}
+	center := best
+	for angle := center - 0.5; angle <= center+0.5; angle += 0.1 {
+		if s := score(angle); s > bestScore && math.Abs(angle) <= maxAngle {
+			best, bestScore = angle, s
+		}
+	}
+	return best
+}
+
+// Deskew redresse les lignes de texte de l'image PGM, inclinées d'au plus maxAngle degrés, et renvoie
+// l'inclinaison corrigée. Les coins découverts par la rotation sont blancs.
+func (pgm *PGM) Deskew(maxAngle float64) float64 {
+	pgm.own()
+	angle := pgm.SkewAngle(maxAngle)
+	if angle != 0 {
+		pgm.Rotate(-angle, uint8(pgm.max))
+	}
+	return angle
+}
+
+// AdaptiveThreshold convertit l'image PGM en PBM en comparant chaque pixel à la moyenne de son voisinage
+// de rayon radius : il devient noir s'il est plus sombre que cette moyenne moins offset.
+// Contrairement à un seuil fixe, le résultat résiste aux ombres et aux éclairages inégaux.
+func (pgm *PGM) AdaptiveThreshold(radius, offset int) *PBM {
+	return pgm.localThreshold(radius, func(mean, _ float64) float64 {
+		return mean - float64(offset)
+	})
+}
+
+// NiblackThreshold convertit l'image PGM en PBM avec la méthode de Niblack : un pixel devient noir s'il
+// est plus sombre que mean + k × deviation, la moyenne et l'écart type de son voisinage de rayon radius.
+// K est négatif, autour de -0,2 : le seuil descend sous la moyenne là où le contraste local est fort.
+// Le fond uni devient bruité, ce que corrige SauvolaThreshold.
+func (pgm *PGM) NiblackThreshold(radius int, k float64) *PBM {
+	return pgm.localThreshold(radius, func(mean, deviation float64) float64 {
+		return mean + k*deviation
+	})
+}
+
+// SauvolaThreshold convertit l'image PGM en PBM avec la méthode de Sauvola : un pixel devient noir s'il
+// est plus sombre que mean × (1 + k × (deviation / R - 1)), où R est la moitié de la valeur maximale.
+// Un voisinage peu contrasté (fond, zone mal éclairée) a un seuil nettement sous sa moyenne et reste
+// blanc, ce qui convient aux codes-barres et aux étiquettes photographiés. K vaut habituellement 0,2 à 0,5.
+func (pgm *PGM) SauvolaThreshold(radius int, k float64) *PBM {
+	r := float64(pgm.max) / 2
+	return pgm.localThreshold(radius, func(mean, deviation float64) float64 {
+		return mean * (1 + k*(deviation/r-1))
+	})
+}
+
+// localThreshold convertit l'image PGM en PBM : un pixel devient noir s'il est plus sombre que le seuil
+// calculé par threshold d'après la moyenne et l'écart type de son voisinage de rayon radius.
+func (pgm *PGM) localThreshold(radius int, threshold func(mean, deviation float64) float64) *PBM {
+	// Sommes cumulées des niveaux de gris et de leurs carrés, pour calculer chaque voisinage en temps constant
+	sums := make([][]int, pgm.height+1)
+	squares := make([][]int, pgm.height+1)
+	sums[0], squares[0] = make([]int, pgm.width+1), make([]int, pgm.width+1)
+	for y := 0; y < pgm.height; y++ {
+		sums[y+1], squares[y+1] = make([]int, pgm.width+1), make([]int, pgm.width+1)
+		for x := 0; x < pgm.width; x++ {
+			v := int(pgm.data[y][x])
+			sums[y+1][x+1] = v + sums[y][x+1] + sums[y+1][x] - sums[y][x]
+			squares[y+1][x+1] = v*v + squares[y][x+1] + squares[y+1][x] - squares[y][x]
+		}
+	}
+	area := func(table [][]int, x0, y0, x1, y1 int) float64 {
+		return float64(table[y1][x1] - table[y0][x1] - table[y1][x0] + table[y0][x0])
+	}
+
+	pbm := NewPBM(pgm.width, pgm.height)
+	for y := 0; y < pgm.height; y++ {
+		y0, y1 := max(y-radius, 0), min(y+radius+1, pgm.height)
+		for x := 0; x < pgm.width; x++ {
+			x0, x1 := max(x-radius, 0), min(x+radius+1, pgm.width)
+			count := float64((y1 - y0) * (x1 - x0))
+			mean := area(sums, x0, y0, x1, y1) / count
+			variance := area(squares, x0, y0, x1, y1)/count - mean*mean
+			pbm.data[y][x] = float64(pgm.data[y][x]) < threshold(mean, math.Sqrt(max(variance, 0)))
+		}
+	}
+	return pbm
+}
+
+// OCROptions règle les étapes de PrepareForOCROptions.
+type OCROptions struct {
+	MaxSkew         float64 // Inclinaison maximale corrigée, en degrés (0 pour ne pas redresser)
+	ThresholdRadius int     // Rayon du voisinage du seuil adaptatif
+	ThresholdOffset int     // Écart sous la moyenne locale à partir duquel un pixel devient noir
+	DespeckleSize   int     // Taille maximale des taches supprimées, en pixels (0 pour les garder)
+}
+
+// DefaultOCROptions convient à une page de texte scannée à 200-300 dpi.
+var DefaultOCROptions = OCROptions{MaxSkew: 10, ThresholdRadius: 15, ThresholdOffset: 10, DespeckleSize: 4}
+
+// PrepareForOCR prépare une page scannée pour la reconnaissance de texte, avec les options par défaut :
+// passage en niveaux de gris, redressement, seuil adaptatif et nettoyage des petites taches.
+func PrepareForOCR(ppm *PPM) *PBM {
+	return PrepareForOCROptions(ppm, DefaultOCROptions)
+}
+
+// PrepareForOCROptions prépare une page scannée pour la reconnaissance de texte, selon options.
+func PrepareForOCROptions(ppm *PPM, options OCROptions) *PBM {
+	gray := ppm.ToPGM()
+	if options.MaxSkew > 0 {
+		gray.Deskew(options.MaxSkew)
+	}
+	pbm := gray.AdaptiveThreshold(options.ThresholdRadius, options.ThresholdOffset)
+	pbm.Despeckle(options.DespeckleSize)
+	return pbm
+}
+
+// DetectOrientation estime de combien de degrés, dans le sens des aiguilles d'une montre, le texte sombre
+// de l'image PGM a tourné : 0, 90, 180 ou 270. Les lignes de texte sont horizontales si les blancs entre
+// deux pixels sombres sont plus courts en moyenne le long des lignes de l'image (espaces entre lettres)
+// que le long de ses colonnes (interlignes). Le haut des lignes est ensuite le côté où dépassent le plus
+// de lettres, les hampes (b, d, h, l, majuscules…) étant plus fréquentes que les jambages (g, p, q, y…)
+// dans les écritures latines.
+func (pgm *PGM) DetectOrientation() int {
+	vertical := pgm.meanGap(true) > pgm.meanGap(false)
+	work := pgm
+	if vertical {
+		// Texte vertical : une rotation d'un quart de tour le remet à 0° ou à 180°
+		work = pgm.Clone()
+		work.Rotate90CW()
+	}
+	upright := ascenderBalance(work.darkProfile()) >= 0
+	switch {
+	case !vertical && upright:
+		return 0
+	case !vertical:
+		return 180
+	case upright:
+		return 270
+	}
+	return 90
+}
+
+// AutoRotate remet droit le texte de l'image PGM d'après DetectOrientation et renvoie l'angle corrigé.
+func (pgm *PGM) AutoRotate() int {
+	pgm.own()
+	angle := pgm.DetectOrientation()
+	for turn := angle; turn > 0 && turn < 360; turn += 90 {
+		pgm.Rotate90CW()
+	}
+	return angle
+}
+
+// AutoRotate remet droit le texte de l'image PPM, comme AutoRotate pour une image PGM.
+func (ppm *PPM) AutoRotate() int {
+	ppm.own()
+	angle := ppm.ToPGM().DetectOrientation()
+	for turn := angle; turn > 0 && turn < 360; turn += 90 {
+		ppm.Rotate90CW()
+	}
+	return angle
+}
+
+// meanGap renvoie la longueur moyenne des suites de pixels clairs comprises entre deux pixels sombres,
+// le long des lignes de l'image PGM (rows) ou de ses colonnes.
+func (pgm *PGM) meanGap(rows bool) float64 {
+	outer, inner := pgm.width, pgm.height
+	if rows {
+		outer, inner = inner, outer
+	}
+	total, count := 0, 0
+	for i := 0; i < outer; i++ {
+		last := -1
+		for j := 0; j < inner; j++ {
+			var value uint8
+			if rows {
+				value = pgm.data[i][j]
+			} else {
+				value = pgm.data[j][i]
+			}
+			if value >= uint8(pgm.max/2) {
+				continue
+			}
+			if last >= 0 && j > last+1 {
+				total += j - last - 1
+				count++
+			}
+			last = j
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return float64(total) / float64(count)
+}
+
+// darkProfile compte les pixels sombres de chaque ligne de l'image PGM.
+func (pgm *PGM) darkProfile() []int {
+	profile := make([]int, pgm.height)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			if pgm.data[y][x] < uint8(pgm.max/2) {
+				profile[y]++
+			}
+		}
+	}
+	return profile
+}
+
+// ascenderBalance renvoie, pour toutes les lignes de texte du profil horizontal, la différence entre les
+// pixels sombres au-dessus et au-dessous du corps des lettres (les lignes où le profil dépasse la moitié
+// de son maximum). Elle est positive pour un texte latin à l'endroit.
+func ascenderBalance(profile []int) int {
+	balance := 0
+	for start := 0; start < len(profile); {
+		if profile[start] == 0 {
+			start++
+			continue
+		}
+		end := start
+		peak := 0
+		for end < len(profile) && profile[end] > 0 {
+			peak = max(peak, profile[end])
+			end++
+		}
+		top, bottom := start, end-1
+		for 2*profile[top] < peak {
+			top++
+		}
+		for 2*profile[bottom] < peak {
+			bottom--
+		}
+		for y := start; y < top; y++ {
+			balance += profile[y]
+		}
+		for y := bottom + 1; y < end; y++ {
+			balance -= profile[y]
+		}
+		start = end
+	}
+	return balance
+}
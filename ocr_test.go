@@ -0,0 +1,188 @@
+package Netpbm // 🧪 Test préparation à la reconnaissance de texte
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// newTextPGM dessine sur fond blanc des « lignes de texte » sombres inclinées de angle degrés.
+func newTextPGM(width, height int, angle float64) *PGM {
+	pgm := newUniformPGM(width, height, 255)
+	slope := math.Tan(angle * math.Pi / 180)
+	for line := 10; line < height-10; line += 12 {
+		for x := 5; x < width-5; x++ {
+			if x%9 == 8 {
+				continue // Espace entre les « mots »
+			}
+			y := line + int(math.Round(float64(x-width/2)*slope))
+			for dy := 0; dy < 3; dy++ {
+				if y+dy >= 0 && y+dy < height {
+					pgm.data[y+dy][x] = 30
+				}
+			}
+		}
+	}
+	return pgm
+}
+
+func TestSkewAngle(t *testing.T) {
+	for _, angle := range []float64{0, 3, -4.5} {
+		if got := newTextPGM(200, 80, angle).SkewAngle(10); math.Abs(got-angle) > 0.35 {
+			t.Errorf("Expected skew %v, got %v", angle, got)
+		}
+	}
+	if got := newUniformPGM(10, 10, 255).SkewAngle(10); got != 0 {
+		t.Errorf("Blank page should have no skew, got %v", got)
+	}
+}
+
+func TestDeskew(t *testing.T) {
+	pgm := newTextPGM(120, 80, 4)
+	if angle := pgm.Deskew(10); math.Abs(angle-4) > 0.35 {
+		t.Errorf("Expected a 4° correction, got %v", angle)
+	}
+	if residual := pgm.SkewAngle(10); math.Abs(residual) > 0.35 {
+		t.Errorf("Deskewed page should be straight, got %v", residual)
+	}
+}
+
+func TestAdaptiveThreshold(t *testing.T) {
+	// Texte sombre sur un fond dont l'éclairage baisse de gauche à droite
+	pgm := NewPGM(40, 10, 255)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 40; x++ {
+			pgm.data[y][x] = uint8(250 - 4*x)
+			if y == 5 {
+				pgm.data[y][x] -= 60
+			}
+		}
+	}
+	pbm := pgm.AdaptiveThreshold(3, 10)
+	for x := 0; x < 40; x++ {
+		if !pbm.At(x, 5) || pbm.At(x, 2) {
+			t.Fatalf("Column %d: only the text line should be black", x)
+		}
+	}
+}
+
+func TestPrepareForOCR(t *testing.T) {
+	page := newTextPGM(120, 80, 3)
+	page.data[2][2], page.data[2][3], page.data[3][3] = 0, 0, 0 // Poussière
+	pbm := PrepareForOCR(page.ToPPM())
+	if width, height := pbm.Size(); width != 120 || height != 80 {
+		t.Fatalf("Wrong size %dx%d", width, height)
+	}
+	if pbm.At(2, 2) || pbm.At(3, 3) {
+		t.Error("Specks should be removed")
+	}
+	if countBlack(pbm) < 1000 {
+		t.Errorf("Text should be kept, only %d black pixels", countBlack(pbm))
+	}
+	// Les lignes redressées sont horizontales
+	if angle := PrepareForOCROptions(page.ToPPM(), OCROptions{ThresholdRadius: 15, ThresholdOffset: 10}).toPGMForTest().SkewAngle(10); math.Abs(angle-3) > 0.35 {
+		t.Errorf("Without deskew the skew should remain, got %v", angle)
+	}
+}
+
+// toPGMForTest convertit l'image PBM en PGM noir et blanc.
+func (pbm *PBM) toPGMForTest() *PGM {
+	pgm := newUniformPGM(pbm.width, pbm.height, 255)
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				pgm.data[y][x] = 0
+			}
+		}
+	}
+	return pgm
+}
+
+// newLettersPGM dessine sur fond blanc des lignes de « lettres » : un corps de 5 pixels, des hampes
+// de 3 pixels sur une lettre sur trois et des jambages sur une lettre sur sept.
+func newLettersPGM() *PGM {
+	pgm := newUniformPGM(120, 60, 255)
+	for line := 5; line+12 < 60; line += 14 {
+		for letter := 0; letter*4+6 < 120; letter++ {
+			top, bottom := line+3, line+8
+			if letter%3 == 0 {
+				top -= 3
+			}
+			if letter%7 == 3 {
+				bottom += 3
+			}
+			for y := top; y < bottom; y++ {
+				for x := letter*4 + 4; x < letter*4+6; x++ {
+					pgm.data[y][x] = 20
+				}
+			}
+		}
+	}
+	return pgm
+}
+
+func TestDetectOrientation(t *testing.T) {
+	for _, angle := range []int{0, 90, 180, 270} {
+		pgm := newLettersPGM()
+		for turn := 0; turn < angle; turn += 90 {
+			pgm.Rotate90CW()
+		}
+		if got := pgm.DetectOrientation(); got != angle {
+			t.Errorf("texte tourné de %d° : DetectOrientation = %d", angle, got)
+		}
+		original := newLettersPGM()
+		if pgm.AutoRotate() != angle || pgm.width != original.width || fmt.Sprint(pgm.data) != fmt.Sprint(original.data) {
+			t.Errorf("AutoRotate ne remet pas droit un texte tourné de %d°", angle)
+		}
+	}
+}
+
+func TestAutoRotatePPM(t *testing.T) {
+	ppm := newLettersPGM().ToPPM()
+	ppm.Rotate90CW()
+	if got := ppm.AutoRotate(); got != 90 || ppm.width != 120 {
+		t.Errorf("AutoRotate = %d, largeur %d", got, ppm.width)
+	}
+}
+
+// newBarcodePGM dessine un code-barres sous un éclairage qui baisse de gauche à droite, avec une marge
+// unie (et un léger grain) à droite, où un seuil local ne doit rien trouver.
+func newBarcodePGM() *PGM {
+	pgm := NewPGM(80, 12, 255)
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 80; x++ {
+			light := 230 - 2*x
+			if x < 40 && x%4 < 2 {
+				light = light * 3 / 10 // Barre
+			}
+			pgm.data[y][x] = uint8(light + (x*7+y*3)%5 - 2)
+		}
+	}
+	return pgm
+}
+
+func TestSauvolaNiblackThreshold(t *testing.T) {
+	pgm := newBarcodePGM()
+	countBars := func(pbm *PBM) (bars, noise int) {
+		for y := 0; y < 12; y++ {
+			for x := 0; x < 80; x++ {
+				switch {
+				case x < 40 && x%4 < 2 && pbm.At(x, y):
+					bars++
+				case pbm.At(x, y):
+					noise++
+				}
+			}
+		}
+		return bars, noise
+	}
+
+	if bars, noise := countBars(pgm.SauvolaThreshold(6, 0.3)); bars != 20*12 || noise != 0 {
+		t.Errorf("Sauvola : %d pixels de barres sur %d, %d pixels parasites", bars, 20*12, noise)
+	}
+	// Niblack trouve les barres mais noircit le grain de la marge unie
+	bars, noise := countBars(pgm.NiblackThreshold(6, -0.2))
+	if bars != 20*12 || noise == 0 {
+		t.Errorf("Niblack : %d pixels de barres sur %d, %d pixels parasites", bars, 20*12, noise)
+	}
+}
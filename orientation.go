@@ -0,0 +1,66 @@
+package Netpbm // ✨ Orientation EXIF
+
+// Orientation reprend les 8 valeurs de l'étiquette EXIF d'orientation : elle indique où se trouvent
+// le haut et la gauche de la scène dans l'image enregistrée.
+type Orientation int
+
+const (
+	OrientationTopLeft     Orientation = iota + 1 // 1 : image droite
+	OrientationTopRight                           // 2 : retournée horizontalement
+	OrientationBottomRight                        // 3 : tournée de 180°
+	OrientationBottomLeft                         // 4 : retournée verticalement
+	OrientationLeftTop                            // 5 : transposée (symétrie par la diagonale principale)
+	OrientationRightTop                           // 6 : à tourner de 90° dans le sens des aiguilles d'une montre
+	OrientationRightBottom                        // 7 : transposée par l'autre diagonale
+	OrientationLeftBottom                         // 8 : à tourner de 90° dans le sens inverse des aiguilles d'une montre
+)
+
+// orientable est une image que l'on peut retourner et faire pivoter.
+type orientable interface {
+	Flip()
+	Flop()
+	Rotate90CW()
+}
+
+// applyOrientation remet l'image droite d'après son orientation EXIF.
+func applyOrientation(img orientable, o Orientation) {
+	switch o {
+	case OrientationTopRight:
+		img.Flip()
+	case OrientationBottomRight:
+		img.Flip()
+		img.Flop()
+	case OrientationBottomLeft:
+		img.Flop()
+	case OrientationLeftTop:
+		img.Rotate90CW()
+		img.Flip()
+	case OrientationRightTop:
+		img.Rotate90CW()
+	case OrientationRightBottom:
+		img.Rotate90CW()
+		img.Flop()
+	case OrientationLeftBottom:
+		img.Rotate90CW()
+		img.Flip()
+		img.Flop()
+	}
+}
+
+// ApplyOrientation remet l'image PBM droite d'après son orientation EXIF. Les valeurs inconnues sont ignorées.
+func (pbm *PBM) ApplyOrientation(o Orientation) {
+	applyOrientation(pbm, o)
+}
+
+// ApplyOrientation remet l'image PGM droite d'après son orientation EXIF. Les valeurs inconnues sont ignorées.
+func (pgm *PGM) ApplyOrientation(o Orientation) {
+	pgm.own()
+	applyOrientation(pgm, o)
+}
+
+// ApplyOrientation remet l'image PPM droite d'après son orientation EXIF, par exemple après la conversion
+// d'une photo JPEG. Les valeurs inconnues sont ignorées.
+func (ppm *PPM) ApplyOrientation(o Orientation) {
+	ppm.own()
+	applyOrientation(ppm, o)
+}
@@ -0,0 +1,68 @@
+package Netpbm // 🧪 Test orientation EXIF
+
+import "testing"
+
+func TestApplyOrientation(t *testing.T) {
+	const w, h = 4, 3
+	upright := newPatternPPM(w, h)
+	// Pour chaque orientation : le pixel (x, y) de l'image enregistrée montre le pixel renvoyé de la scène
+	cases := []struct {
+		o          Orientation
+		transposed bool
+		source     func(x, y int) (int, int)
+	}{
+		{OrientationTopLeft, false, func(x, y int) (int, int) { return x, y }},
+		{OrientationTopRight, false, func(x, y int) (int, int) { return w - 1 - x, y }},
+		{OrientationBottomRight, false, func(x, y int) (int, int) { return w - 1 - x, h - 1 - y }},
+		{OrientationBottomLeft, false, func(x, y int) (int, int) { return x, h - 1 - y }},
+		{OrientationLeftTop, true, func(x, y int) (int, int) { return y, x }},
+		{OrientationRightTop, true, func(x, y int) (int, int) { return w - 1 - y, x }},
+		{OrientationRightBottom, true, func(x, y int) (int, int) { return w - 1 - y, h - 1 - x }},
+		{OrientationLeftBottom, true, func(x, y int) (int, int) { return y, h - 1 - x }},
+	}
+	for _, c := range cases {
+		sw, sh := w, h
+		if c.transposed {
+			sw, sh = h, w
+		}
+		stored := NewPPM(sw, sh, 255)
+		for y := 0; y < sh; y++ {
+			for x := 0; x < sw; x++ {
+				ux, uy := c.source(x, y)
+				stored.data[y][x] = upright.data[uy][ux]
+			}
+		}
+		stored.ApplyOrientation(c.o)
+		if width, height := stored.Size(); width != w || height != h {
+			t.Errorf("Orientation %d: wrong size %dx%d", c.o, width, height)
+			continue
+		}
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if stored.data[y][x] != upright.data[y][x] {
+					t.Fatalf("Orientation %d: pixel (%d, %d) not restored", c.o, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestApplyOrientationPBMAndPGM(t *testing.T) {
+	pbm := NewPBM(3, 2)
+	pbm.data[0][2] = true
+	pbm.ApplyOrientation(OrientationRightTop)
+	if width, height := pbm.Size(); width != 2 || height != 3 || !pbm.At(1, 2) {
+		t.Error("PBM should be rotated clockwise")
+	}
+
+	pgm := NewPGM(3, 2, 255)
+	pgm.data[0][0] = 9
+	pgm.ApplyOrientation(OrientationBottomRight)
+	if pgm.At(2, 1) != 9 {
+		t.Error("PGM should be rotated by 180°")
+	}
+	pgm.ApplyOrientation(Orientation(42))
+	if pgm.At(2, 1) != 9 {
+		t.Error("Unknown orientation should be ignored")
+	}
+}
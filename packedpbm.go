@@ -0,0 +1,250 @@
+package Netpbm // ✨ PBM compacte
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// PackedPBM représente une image PBM dont les pixels sont stockés sur un bit, comme dans le format P4.
+type PackedPBM struct {
+	data          [][]byte // Lignes de l'image, 8 pixels par octet (bit de poids fort en premier, 1 pour noir)
+	width, height int      // Largeur et hauteur de l'image
+	magicNumber   string   // Nombre magique du format PBM ("P1" ou "P4")
+}
+
+// NewPackedPBM crée une image PBM compacte vide (tous les pixels à false).
+func NewPackedPBM(width, height int) *PackedPBM {
+	data := make([][]byte, height)
+	for y := range data {
+		data[y] = make([]byte, (width+7)/8)
+	}
+	return &PackedPBM{data, width, height, "P4"}
+}
+
+// ReadPackedPBM lit une image PBM (P1 ou P4) directement sous forme compacte, sans passer par une
+// image PBM d'un booléen par pixel : la mémoire utilisée reste celle du format P4, même pendant la
+// lecture de grands documents.
+func ReadPackedPBM(filename string) (packed *PackedPBM, err error) {
+	defer func(start time.Time) { traceDecode("pbm", filename, start, packed, err) }(time.Now())
+
+	file, err := openImage(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return decodePackedPBM(file)
+}
+
+// decodePackedPBM lit une image PBM compacte depuis r.
+func decodePackedPBM(r io.Reader) (*PackedPBM, error) {
+	reader := bufio.NewReader(r)
+
+	// Lire le nombre magique
+	magicNumber, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("error reading magic number: %v", err)
+	}
+	magicNumber = strings.TrimSpace(magicNumber)
+	if magicNumber != "P1" && magicNumber != "P4" {
+		return nil, fmt.Errorf("invalid magic number: %s", magicNumber)
+	}
+
+	// Lire les dimensions ; les métadonnées des commentaires ne sont pas conservées
+	dimensions, err := readHeaderLine(reader, make(map[string]string))
+	if err != nil {
+		return nil, fmt.Errorf("error reading dimensions: %v", err)
+	}
+	var width, height int
+	if _, err := fmt.Sscanf(strings.TrimSpace(dimensions), "%d %d", &width, &height); err != nil {
+		return nil, fmt.Errorf("invalid dimensions: %v", err)
+	}
+	if width < 0 || height < 0 {
+		return nil, fmt.Errorf("invalid dimensions: %dx%d", width, height)
+	}
+
+	packed := NewPackedPBM(width, height)
+	packed.magicNumber = magicNumber
+	if magicNumber == "P1" {
+		// Lire le format P1 (ASCII), une ligne de texte par ligne de pixels
+		for y := 0; y < height; y++ {
+			line, err := reader.ReadString('\n')
+			fields := strings.Fields(line)
+			if err != nil && !(err == io.EOF && len(fields) >= width) {
+				return nil, fmt.Errorf("error reading data at row %d: %v", y, err)
+			}
+			if len(fields) > width {
+				return nil, fmt.Errorf("index out of range at row %d", y)
+			}
+			for x, field := range fields {
+				if field == "1" {
+					packed.data[y][x/8] |= 1 << (7 - uint(x%8))
+				}
+			}
+		}
+		return packed, nil
+	}
+
+	// Lire le format P4 (binaire) : les octets vont directement dans les lignes
+	mask := packed.paddingMask()
+	for y := 0; y < height; y++ {
+		n, err := io.ReadFull(reader, packed.data[y])
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("unexpected end of file at row %d, expected %d bytes, got %d", y, len(packed.data[y]), n)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading pixel data at row %d: %v", y, err)
+		}
+		// Les bits de remplissage restent à zéro
+		if len(packed.data[y]) > 0 {
+			packed.data[y][len(packed.data[y])-1] &= mask
+		}
+	}
+	return packed, nil
+}
+
+// Pack convertit l'image PBM en image PBM compacte.
+func (pbm *PBM) Pack() *PackedPBM {
+	packed := NewPackedPBM(pbm.width, pbm.height)
+	packed.magicNumber = pbm.magicNumber
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				packed.data[y][x/8] |= 1 << (7 - uint(x%8))
+			}
+		}
+	}
+	return packed
+}
+
+// ToPBM convertit l'image PBM compacte en image PBM.
+func (packed *PackedPBM) ToPBM() *PBM {
+	pbm := &PBM{
+		data:        make([][]bool, packed.height),
+		width:       packed.width,
+		height:      packed.height,
+		magicNumber: packed.magicNumber,
+	}
+	for y := 0; y < packed.height; y++ {
+		pbm.data[y] = make([]bool, packed.width)
+		for x := 0; x < packed.width; x++ {
+			pbm.data[y][x] = packed.At(x, y)
+		}
+	}
+	return pbm
+}
+
+// Size renvoie la largeur et la hauteur de l'image.
+func (packed *PackedPBM) Size() (int, int) {
+	return packed.width, packed.height
+}
+
+// At renvoie la valeur du pixel en (x, y).
+func (packed *PackedPBM) At(x, y int) bool {
+	if x < 0 || x >= packed.width || y < 0 || y >= packed.height {
+		return false
+	}
+	return packed.data[y][x/8]&(1<<(7-uint(x%8))) != 0
+}
+
+// Set définit la valeur du pixel à (x, y).
+func (packed *PackedPBM) Set(x, y int, value bool) {
+	if x < 0 || x >= packed.width || y < 0 || y >= packed.height {
+		return
+	}
+	if value {
+		packed.data[y][x/8] |= 1 << (7 - uint(x%8))
+	} else {
+		packed.data[y][x/8] &^= 1 << (7 - uint(x%8))
+	}
+}
+
+// Row renvoie les octets de la ligne y, sans copie.
+func (packed *PackedPBM) Row(y int) []byte {
+	return packed.data[y]
+}
+
+// SetMagicNumber définit le nombre magique de l'image PBM compacte.
+func (packed *PackedPBM) SetMagicNumber(magicNumber string) {
+	packed.magicNumber = magicNumber
+}
+
+// paddingMask renvoie le masque des bits utiles du dernier octet d'une ligne.
+func (packed *PackedPBM) paddingMask() byte {
+	if packed.width%8 == 0 {
+		return 0xFF
+	}
+	return byte(0xFF << (8 - uint(packed.width%8)))
+}
+
+// Invert inverse les couleurs de l'image PBM compacte, un octet à la fois.
+func (packed *PackedPBM) Invert() {
+	if packed.width == 0 {
+		return
+	}
+	mask := packed.paddingMask()
+	for _, row := range packed.data {
+		for i := range row {
+			row[i] = ^row[i]
+		}
+		// Les bits de remplissage restent à zéro
+		row[len(row)-1] &= mask
+	}
+}
+
+// And combine l'image avec other par un ET logique, pixel à pixel.
+func (packed *PackedPBM) And(other *PackedPBM) error {
+	return packed.combine(other, func(a, b byte) byte { return a & b })
+}
+
+// Or combine l'image avec other par un OU logique, pixel à pixel.
+func (packed *PackedPBM) Or(other *PackedPBM) error {
+	return packed.combine(other, func(a, b byte) byte { return a | b })
+}
+
+// Xor combine l'image avec other par un OU exclusif, pixel à pixel.
+func (packed *PackedPBM) Xor(other *PackedPBM) error {
+	return packed.combine(other, func(a, b byte) byte { return a ^ b })
+}
+
+// combine applique op à chaque octet des deux images.
+func (packed *PackedPBM) combine(other *PackedPBM, op func(a, b byte) byte) error {
+	if packed.width != other.width || packed.height != other.height {
+		return fmt.Errorf("size mismatch: %dx%d and %dx%d", packed.width, packed.height, other.width, other.height)
+	}
+	for y, row := range packed.data {
+		for i := range row {
+			row[i] = op(row[i], other.data[y][i])
+		}
+	}
+	return nil
+}
+
+// Save enregistre l'image PBM compacte dans un fichier et renvoie une erreur en cas de problème.
+func (packed *PackedPBM) Save(filename string) error {
+	if packed.magicNumber != "P4" {
+		return packed.ToPBM().Save(filename)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s\n%d %d\n", packed.magicNumber, packed.width, packed.height)
+	if err != nil {
+		return err
+	}
+	// Les lignes sont déjà au format P4
+	for _, row := range packed.data {
+		_, err = file.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
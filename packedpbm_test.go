@@ -0,0 +1,166 @@
+package Netpbm // 🧪 Test PBM compacte
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPackedPBMPack(t *testing.T) {
+	pbm, err := ReadPBM("./testImages/pbm/testP4.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	packed := pbm.Pack()
+	w, h := packed.Size()
+	if w != imageWidth || h != imageHeight {
+		t.Error("Wrong size")
+	}
+	if len(packed.Row(0)) != 2 {
+		t.Errorf("Wrong row length %d", len(packed.Row(0)))
+	}
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		var x = i % imageWidth
+		var y = i / imageWidth
+		if packed.At(x, y) != imageDataP1[i] {
+			t.Error("Wrong data")
+		}
+	}
+	back := packed.ToPBM()
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		var x = i % imageWidth
+		var y = i / imageWidth
+		if back.data[y][x] != imageDataP1[i] {
+			t.Error("Wrong data")
+		}
+	}
+}
+
+func TestReadPackedPBM(t *testing.T) {
+	for _, filename := range []string{"./testImages/pbm/testP1.pbm", "./testImages/pbm/testP4.pbm"} {
+		packed, err := ReadPackedPBM(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w, h := packed.Size(); w != imageWidth || h != imageHeight {
+			t.Fatalf("%s: wrong size %dx%d", filename, w, h)
+		}
+		for i := 0; i < imageWidth*imageHeight; i++ {
+			if packed.At(i%imageWidth, i/imageWidth) != imageDataP1[i] {
+				t.Fatalf("%s: wrong pixel (%d, %d)", filename, i%imageWidth, i/imageWidth)
+			}
+		}
+	}
+
+	// Les bits de remplissage d'un fichier P4 sont ignorés
+	filename := "./testImages/pbm/testPackedRead.pbm"
+	defer os.Remove(filename)
+	os.WriteFile(filename, []byte("P4\n3 2\n\xff\x40"), 0644)
+	packed, err := ReadPackedPBM(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packed.Row(0)[0] != 0xE0 || !packed.At(1, 1) || packed.At(0, 1) {
+		t.Errorf("Unexpected rows %v", packed.data)
+	}
+
+	os.WriteFile(filename, []byte("P4\n3 2\n\xff"), 0644)
+	if _, err := ReadPackedPBM(filename); err == nil {
+		t.Error("Expected an error for truncated data")
+	}
+	os.WriteFile(filename, []byte("P1\n2 1\n0 1 1\n"), 0644)
+	if _, err := ReadPackedPBM(filename); err == nil {
+		t.Error("Expected an error for a row that is too long")
+	}
+}
+
+func TestPackedPBMSet(t *testing.T) {
+	packed := NewPackedPBM(10, 2)
+	packed.Set(9, 1, true)
+	if !packed.At(9, 1) || packed.Row(1)[1] != 0x40 {
+		t.Error("Wrong value")
+	}
+	packed.Set(9, 1, false)
+	if packed.At(9, 1) {
+		t.Error("Wrong value")
+	}
+}
+
+func TestPackedPBMInvert(t *testing.T) {
+	pbm, err := ReadPBM("./testImages/pbm/testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	packed := pbm.Pack()
+	packed.Invert()
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		var x = i % imageWidth
+		var y = i / imageWidth
+		if packed.At(x, y) != imageDataInvert[i] {
+			t.Error("Wrong data")
+		}
+	}
+	// le bit de remplissage doit rester à zéro
+	if packed.Row(0)[1]&0x01 != 0 {
+		t.Error("Padding bit set")
+	}
+}
+
+func TestPackedPBMAndOr(t *testing.T) {
+	pbm, err := ReadPBM("./testImages/pbm/testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	a := pbm.Pack()
+	b := pbm.Pack()
+	b.Invert()
+	if err := a.And(b); err != nil {
+		t.Error(err)
+	}
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		if a.At(i%imageWidth, i/imageWidth) {
+			t.Error("Wrong data")
+		}
+	}
+	if err := a.Or(b); err != nil {
+		t.Error(err)
+	}
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		if a.At(i%imageWidth, i/imageWidth) != imageDataInvert[i] {
+			t.Error("Wrong data")
+		}
+	}
+	if err := a.Xor(NewPackedPBM(3, 3)); err == nil {
+		t.Error("Expected size mismatch error")
+	}
+}
+
+func TestPackedPBMSave(t *testing.T) {
+	pbm, err := ReadPBM("./testImages/pbm/testP1.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	packed := pbm.Pack()
+	packed.SetMagicNumber("P4")
+	err = packed.Save("./testImages/pbm/testPackedSave.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	pbm2, err := ReadPBM("./testImages/pbm/testPackedSave.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	if pbm2.magicNumber != "P4" {
+		t.Error("Wrong magic number")
+	}
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		var x = i % imageWidth
+		var y = i / imageWidth
+		if pbm2.data[y][x] != imageDataP1[i] {
+			t.Error("Wrong data")
+		}
+	}
+	err = os.Remove("./testImages/pbm/testPackedSave.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+}
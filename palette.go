@@ -0,0 +1,62 @@
+package Netpbm // ✨ Palettes de couleurs
+
+import "math"
+
+// nearestPaletteColor renvoie la couleur de la palette la plus proche de (r, g, b) (distance euclidienne).
+func nearestPaletteColor(palette []Pixel, r, g, b float64) Pixel {
+	best, bestDistance := palette[0], math.Inf(1)
+	for _, p := range palette {
+		dr, dg, db := r-float64(p.R), g-float64(p.G), b-float64(p.B)
+		if d := dr*dr + dg*dg + db*db; d < bestDistance {
+			best, bestDistance = p, d
+		}
+	}
+	return best
+}
+
+// MapToPalette remplace chaque pixel de l'image PPM par la couleur la plus proche de palette,
+// pour les consoles rétro ou les écrans à encre électronique. Si dither est vrai, l'erreur commise
+// sur chaque pixel est répartie sur ses voisins (diffusion de Floyd-Steinberg) pour mieux rendre les dégradés.
+func (ppm *PPM) MapToPalette(palette []Pixel, dither bool) {
+	ppm.own()
+	if len(palette) == 0 {
+		return
+	}
+	if !dither {
+		for y := 0; y < ppm.height; y++ {
+			for x := 0; x < ppm.width; x++ {
+				p := ppm.data[y][x]
+				ppm.data[y][x] = nearestPaletteColor(palette, float64(p.R), float64(p.G), float64(p.B))
+			}
+		}
+		return
+	}
+
+	// Erreurs accumulées sur la ligne courante et la suivante, avec une case de marge de chaque côté
+	current := make([][3]float64, ppm.width+2)
+	next := make([][3]float64, ppm.width+2)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			wanted := [3]float64{
+				float64(p.R) + current[x+1][0],
+				float64(p.G) + current[x+1][1],
+				float64(p.B) + current[x+1][2],
+			}
+			chosen := nearestPaletteColor(palette, wanted[0], wanted[1], wanted[2])
+			ppm.data[y][x] = chosen
+			got := [3]float64{float64(chosen.R), float64(chosen.G), float64(chosen.B)}
+			for c := 0; c < 3; c++ {
+				e := wanted[c] - got[c]
+				current[x+2][c] += e * 7 / 16
+				next[x][c] += e * 3 / 16
+				next[x+1][c] += e * 5 / 16
+				next[x+2][c] += e * 1 / 16
+			}
+		}
+		current, next = next, current
+		for i := range next {
+			next[i] = [3]float64{}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package Netpbm // 🧪 Test palettes de couleurs
+
+import "testing"
+
+func TestMapToPalette(t *testing.T) {
+	palette := []Pixel{{0, 0, 0}, {255, 255, 255}, {255, 0, 0}}
+	ppm := NewPPM(3, 1, 255)
+	ppm.data[0] = []Pixel{{30, 20, 10}, {200, 210, 220}, {180, 40, 30}}
+	ppm.MapToPalette(palette, false)
+	expected := []Pixel{{0, 0, 0}, {255, 255, 255}, {255, 0, 0}}
+	for x, e := range expected {
+		if ppm.At(x, 0) != e {
+			t.Errorf("Pixel %d: expected %v, got %v", x, e, ppm.At(x, 0))
+		}
+	}
+}
+
+func TestMapToPaletteDither(t *testing.T) {
+	palette := []Pixel{{0, 0, 0}, {255, 255, 255}}
+	ppm := newUniformPPM(16, 16, Pixel{128, 128, 128})
+	flat := newUniformPPM(16, 16, Pixel{128, 128, 128})
+	ppm.MapToPalette(palette, true)
+	flat.MapToPalette(palette, false)
+
+	white := countColor(ppm, Pixel{255, 255, 255})
+	if black := countColor(ppm, Pixel{0, 0, 0}); white+black != 256 {
+		t.Fatal("Dithered pixels should all come from the palette")
+	}
+	// Un gris moyen donne à peu près moitié de blanc
+	if white < 110 || white > 146 {
+		t.Errorf("Expected about half white pixels, got %d", white)
+	}
+	if countColor(flat, Pixel{255, 255, 255}) != 256 {
+		t.Error("Without dithering a uniform image maps to a single color")
+	}
+	ppm.MapToPalette(nil, true)
+}
@@ -7,24 +7,38 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // PBM représente une image PBM.
 type PBM struct {
-	data          [][]bool // Matrice de données représentant les pixels de l'image (true pour blanc, false pour noir)
-	width, height int      // Largeur et hauteur de l'image
-	magicNumber   string   // Nombre magique du format PBM ("P1" ou "P4")
+	data          [][]bool          // Matrice de données représentant les pixels de l'image (true pour blanc, false pour noir)
+	width, height int               // Largeur et hauteur de l'image
+	magicNumber   string            // Nombre magique du format PBM ("P1" ou "P4")
+	metadata      map[string]string // Métadonnées enregistrées dans les commentaires de l'en-tête
 }
 
 // ReadPBM lit une image PBM à partir d'un fichier et renvoie une structure qui représente l'image.
 func ReadPBM(filename string) (*PBM, error) {
-	file, err := os.Open(filename)
+	return readPBM(filename, false, false)
+}
+
+// readPBM lit une image PBM. Si recover est vrai, un fichier tronqué donne une image partielle
+// dont les pixels manquants valent fill, accompagnée d'une *TruncatedError.
+func readPBM(filename string, recover bool, fill bool) (pbm *PBM, err error) {
+	defer func(start time.Time) { traceDecode("pbm", filename, start, pbm, err) }(time.Now())
+
+	file, err := openImage(filename)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
+	return decodePBM(file, recover, fill)
+}
 
-	reader := bufio.NewReader(file)
+// decodePBM lit une image PBM depuis r, comme readPBM.
+func decodePBM(r io.Reader, recover bool, fill bool) (*PBM, error) {
+	reader := bufio.NewReader(r)
 
 	// Lire le nombre magique
 	magicNumber, err := reader.ReadString('\n')
@@ -36,8 +50,9 @@ func ReadPBM(filename string) (*PBM, error) {
 		return nil, fmt.Errorf("invalid magic number: %s", magicNumber)
 	}
 
-	// Lire les dimensions
-	dimensions, err := reader.ReadString('\n')
+	// Lire les dimensions, en relevant les métadonnées des commentaires
+	metadata := make(map[string]string)
+	dimensions, err := readHeaderLine(reader, metadata)
 	if err != nil {
 		return nil, fmt.Errorf("error reading dimensions: %v", err)
 	}
@@ -57,10 +72,18 @@ func ReadPBM(filename string) (*PBM, error) {
 		// Lire le format P1 (ASCII)
 		for y := 0; y < height; y++ {
 			line, err := reader.ReadString('\n')
-			if err != nil {
+			fields := strings.Fields(line)
+			if err != nil && !(recover && err == io.EOF && len(fields) >= width) {
+				if recover {
+					// Garder les pixels de la ligne incomplète et compléter le reste
+					for x := 0; x < min(width, len(fields)); x++ {
+						data[y][x] = fields[x] == "1"
+					}
+					fillPBM(data, y, min(width, len(fields)), fill)
+					return &PBM{data, width, height, magicNumber, metadata}, &TruncatedError{Row: y, Height: height, Err: err}
+				}
 				return nil, fmt.Errorf("error reading data at row %d: %v", y, err)
 			}
-			fields := strings.Fields(line)
 			for x, field := range fields {
 				if x >= width {
 					return nil, fmt.Errorf("index out of range at row %d", y)
@@ -74,16 +97,24 @@ func ReadPBM(filename string) (*PBM, error) {
 		expectedBytesPerRow := (width + 7) / 8
 		for y := 0; y < height; y++ {
 			row := make([]byte, expectedBytesPerRow)
-			n, err := reader.Read(row)
+			n, err := io.ReadFull(reader, row)
+			if err != nil && recover {
+				// Garder les pixels des octets lus et compléter le reste
+				for x := 0; x < min(width, n*8); x++ {
+					data[y][x] = (row[x/8]>>(7-uint(x%8)))&1 != 0
+				}
+				fillPBM(data, y, min(width, n*8), fill)
+				return &PBM{data, width, height, magicNumber, metadata}, &TruncatedError{Row: y, Height: height, Err: err}
+			}
 			if err != nil {
 				if err == io.EOF {
 					return nil, fmt.Errorf("unexpected end of file at row %d", y)
 				}
+				if err == io.ErrUnexpectedEOF {
+					return nil, fmt.Errorf("unexpected end of file at row %d, expected %d bytes, got %d", y, expectedBytesPerRow, n)
+				}
 				return nil, fmt.Errorf("error reading pixel data at row %d: %v", y, err)
 			}
-			if n < expectedBytesPerRow {
-				return nil, fmt.Errorf("unexpected end of file at row %d, expected %d bytes, got %d", y, expectedBytesPerRow, n)
-			}
 
 			for x := 0; x < width; x++ {
 				byteIndex := x / 8
@@ -98,7 +129,7 @@ func ReadPBM(filename string) (*PBM, error) {
 		}
 	}
 
-	return &PBM{data, width, height, magicNumber}, nil
+	return &PBM{data, width, height, magicNumber, metadata}, nil
 }
 
 // Size renvoie la largeur et la hauteur de l'image.
@@ -129,6 +160,13 @@ func (pbm *PBM) Save(filename string) error {
 		return err
 	}
 	defer file.Close()
+	return pbm.encode(file)
+}
+
+// encode écrit l'image PBM dans w, dans le format de son nombre magique.
+func (pbm *PBM) encode(w io.Writer) error {
+	file := bufio.NewWriter(w)
+	var err error
 
 	// Écrire un nombre magique
 	_, err = file.WriteString(pbm.magicNumber + "\n")
@@ -136,6 +174,12 @@ func (pbm *PBM) Save(filename string) error {
 		return err
 	}
 
+	// Écrire les métadonnées en commentaires
+	err = writeMetadata(file, pbm.metadata)
+	if err != nil {
+		return err
+	}
+
 	// Écrire les dimensions
 	_, err = file.WriteString(strconv.Itoa(pbm.width) + " " + strconv.Itoa(pbm.height) + "\n")
 	if err != nil {
@@ -180,13 +224,19 @@ func (pbm *PBM) Save(filename string) error {
 		}
 	}
 
-	return nil
+	return file.Flush()
 }
 
 // Invert inverse les couleurs de l'image PBM.
 func (pbm *PBM) Invert() {
-	for i := 0; i < pbm.height; i++ {
-		for j := 0; j < pbm.width; j++ {
+	pbm.InvertRegion(Rect{0, 0, pbm.width, pbm.height})
+}
+
+// InvertRegion inverse les couleurs de l'image PBM dans la zone r uniquement.
+func (pbm *PBM) InvertRegion(r Rect) {
+	r = r.clip(pbm.width, pbm.height)
+	for i := r.Y; i < r.Y+r.Height; i++ {
+		for j := r.X; j < r.X+r.Width; j++ {
 			pbm.data[i][j] = !pbm.data[i][j]
 		}
 	}
@@ -212,3 +262,44 @@ func (pbm *PBM) Flop() {
 func (pbm *PBM) SetMagicNumber(magicNumber string) {
 	pbm.magicNumber = magicNumber
 }
+
+// Rotate90CW fait pivoter l'image PBM de 90° dans le sens des aiguilles d'une montre.
+func (pbm *PBM) Rotate90CW() {
+	rotated := make([][]bool, pbm.width)
+	for x := 0; x < pbm.width; x++ {
+		rotated[x] = make([]bool, pbm.height)
+		for y := 0; y < pbm.height; y++ {
+			rotated[x][pbm.height-y-1] = pbm.data[y][x]
+		}
+	}
+	pbm.data = rotated
+	pbm.width, pbm.height = pbm.height, pbm.width
+}
+
+// Rotate90CCW fait pivoter l'image PBM de 90° dans le sens inverse des aiguilles d'une montre.
+func (pbm *PBM) Rotate90CCW() {
+	rotated := make([][]bool, pbm.width)
+	for x := 0; x < pbm.width; x++ {
+		rotated[x] = make([]bool, pbm.height)
+		for y := 0; y < pbm.height; y++ {
+			rotated[x][y] = pbm.data[y][pbm.width-x-1]
+		}
+	}
+	pbm.data = rotated
+	pbm.width, pbm.height = pbm.height, pbm.width
+}
+
+// Rotate180 fait pivoter l'image PBM de 180°.
+func (pbm *PBM) Rotate180() {
+	pbm.Flip()
+	pbm.Flop()
+}
+
+// NewPBM crée une nouvelle image PBM dont tous les pixels sont à false.
+func NewPBM(width, height int) *PBM {
+	data := make([][]bool, height)
+	for y := range data {
+		data[y] = make([]bool, width)
+	}
+	return &PBM{data, width, height, "P1", nil}
+}
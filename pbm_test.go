@@ -297,3 +297,68 @@ func TestSetMagicNumber(t *testing.T) {
 		t.Error("Wrong magic number")
 	}
 }
+
+// newTestPBM crée une image PBM 10x3 dont seuls quelques pixels sont allumés.
+func newTestPBM() *PBM {
+	pbm := &PBM{data: make([][]bool, 3), width: 10, height: 3, magicNumber: "P4"}
+	for y := range pbm.data {
+		pbm.data[y] = make([]bool, 10)
+	}
+	pbm.data[0][0] = true
+	pbm.data[0][9] = true
+	pbm.data[2][8] = true
+	return pbm
+}
+
+func TestRotate90CW(t *testing.T) {
+	pbm := newTestPBM()
+	pbm.Rotate90CW()
+	w, h := pbm.Size()
+	if w != 3 || h != 10 {
+		t.Errorf("Wrong size %dx%d", w, h)
+	}
+	if !pbm.At(2, 0) || !pbm.At(2, 9) || !pbm.At(0, 8) || pbm.At(0, 0) {
+		t.Error("Wrong data")
+	}
+	if pbm.magicNumber != "P4" {
+		t.Error("Wrong magic number")
+	}
+}
+
+func TestRotate90CCW(t *testing.T) {
+	pbm := newTestPBM()
+	pbm.Rotate90CCW()
+	w, h := pbm.Size()
+	if w != 3 || h != 10 {
+		t.Errorf("Wrong size %dx%d", w, h)
+	}
+	if !pbm.At(0, 9) || !pbm.At(0, 0) || !pbm.At(2, 1) || pbm.At(2, 9) {
+		t.Error("Wrong data")
+	}
+
+	pbm, err := ReadPBM("./testImages/pbm/testP4.pbm")
+	if err != nil {
+		t.Error(err)
+	}
+	pbm.Rotate90CW()
+	pbm.Rotate90CCW()
+	for i := 0; i < imageWidth*imageHeight; i++ {
+		var x = i % imageWidth
+		var y = i / imageWidth
+		if pbm.data[y][x] != imageDataP1[i] {
+			t.Error("Wrong data")
+		}
+	}
+}
+
+func TestRotate180(t *testing.T) {
+	pbm := newTestPBM()
+	pbm.Rotate180()
+	w, h := pbm.Size()
+	if w != 10 || h != 3 {
+		t.Errorf("Wrong size %dx%d", w, h)
+	}
+	if !pbm.At(9, 2) || !pbm.At(0, 2) || !pbm.At(1, 0) || pbm.At(0, 0) {
+		t.Error("Wrong data")
+	}
+}
@@ -0,0 +1,203 @@
+package Netpbm // ✨ Format PCX
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+)
+
+// pcxHeader est l'en-tête de 128 octets d'un fichier PCX.
+type pcxHeader struct {
+	Manufacturer uint8
+	Version      uint8
+	Encoding     uint8
+	BitsPerPixel uint8
+	XMin, YMin   uint16
+	XMax, YMax   uint16
+	HDPI, VDPI   uint16
+	EGAPalette   [48]byte
+	Reserved     uint8
+	Planes       uint8
+	BytesPerLine uint16
+	PaletteInfo  uint16
+	HScreenSize  uint16
+	VScreenSize  uint16
+	Filler       [54]byte
+}
+
+// ReadPCX lit une image PCX et la convertit en PPM. Sont pris en charge les images noir et blanc (1 bit),
+// à palette de 256 couleurs (8 bits) et en couleurs vraies (3 plans de 8 bits).
+func ReadPCX(filename string) (*PPM, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	if len(content) < 128 {
+		return nil, fmt.Errorf("file too short for a PCX header")
+	}
+	var header pcxHeader
+	binary.Read(bytes.NewReader(content[:128]), binary.LittleEndian, &header)
+	if header.Manufacturer != 0x0A || header.Encoding != 1 {
+		return nil, fmt.Errorf("not a RLE-encoded PCX file")
+	}
+	width := int(header.XMax) - int(header.XMin) + 1
+	height := int(header.YMax) - int(header.YMin) + 1
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid PCX size %dx%d", width, height)
+	}
+
+	mode := fmt.Sprintf("%dx%d", header.BitsPerPixel, header.Planes)
+	if mode != "1x1" && mode != "8x1" && mode != "8x3" {
+		return nil, fmt.Errorf("unsupported PCX format: %d bits, %d planes", header.BitsPerPixel, header.Planes)
+	}
+	// Chaque plan d'une ligne doit contenir au moins les pixels de la largeur
+	minBytes := width
+	if mode == "1x1" {
+		minBytes = (width + 7) / 8
+	}
+	if int(header.BytesPerLine) < minBytes {
+		return nil, fmt.Errorf("invalid PCX line size %d for width %d", header.BytesPerLine, width)
+	}
+
+	// Décompression des lignes, chacune formée des plans les uns après les autres
+	lineSize := int(header.Planes) * int(header.BytesPerLine)
+	// Une plage de 2 octets donne au plus 63 octets : les dimensions ne peuvent dépasser ce que le fichier contient
+	if lineSize*height/63 > len(content)-128 {
+		return nil, fmt.Errorf("file too short for PCX size %dx%d", width, height)
+	}
+	data := make([]byte, 0, lineSize*height)
+	pos := 128
+	for len(data) < lineSize*height {
+		if pos >= len(content) {
+			return nil, fmt.Errorf("unexpected end of pixel data")
+		}
+		b := content[pos]
+		pos++
+		if b >= 0xC0 {
+			if pos >= len(content) {
+				return nil, fmt.Errorf("unexpected end of pixel data")
+			}
+			for i := 0; i < int(b&0x3F); i++ {
+				data = append(data, content[pos])
+			}
+			pos++
+		} else {
+			data = append(data, b)
+		}
+	}
+	data = data[:lineSize*height]
+
+	var palette []Pixel
+	if mode == "8x1" {
+		// Palette de 256 couleurs à la fin du fichier, précédée de l'octet 12
+		if len(content) < 769 || content[len(content)-769] != 0x0C {
+			return nil, fmt.Errorf("missing 256-color palette")
+		}
+		raw := content[len(content)-768:]
+		palette = make([]Pixel, 256)
+		for i := range palette {
+			palette[i] = Pixel{raw[3*i], raw[3*i+1], raw[3*i+2]}
+		}
+	}
+
+	ppm := NewPPM(width, height, 255)
+	ppm.magicNumber = "P6"
+	for y := 0; y < height; y++ {
+		line := data[y*lineSize : (y+1)*lineSize]
+		planeSize := int(header.BytesPerLine)
+		for x := 0; x < width; x++ {
+			switch mode {
+			case "1x1":
+				if line[x/8]&(0x80>>uint(x%8)) != 0 {
+					ppm.data[y][x] = Pixel{255, 255, 255}
+				}
+			case "8x1":
+				ppm.data[y][x] = palette[line[x]]
+			case "8x3":
+				ppm.data[y][x] = Pixel{line[x], line[planeSize+x], line[2*planeSize+x]}
+			}
+		}
+	}
+	return ppm, nil
+}
+
+// SavePCX enregistre l'image PPM au format PCX en couleurs vraies (3 plans de 8 bits).
+func (ppm *PPM) SavePCX(filename string) error {
+	scale := func(v uint8) byte { return byte(int(v) * 255 / max(ppm.max, 1)) }
+	return savePCX(filename, ppm.width, ppm.height, 3, nil, func(y, plane int) []byte {
+		line := make([]byte, ppm.width)
+		for x, p := range ppm.data[y] {
+			line[x] = scale([3]uint8{p.R, p.G, p.B}[plane])
+		}
+		return line
+	})
+}
+
+// SavePCX enregistre l'image PGM au format PCX à palette de 256 niveaux de gris.
+func (pgm *PGM) SavePCX(filename string) error {
+	palette := make([]byte, 768)
+	for i := 0; i < 256; i++ {
+		palette[3*i], palette[3*i+1], palette[3*i+2] = byte(i), byte(i), byte(i)
+	}
+	return savePCX(filename, pgm.width, pgm.height, 1, palette, func(y, plane int) []byte {
+		line := make([]byte, pgm.width)
+		for x, v := range pgm.data[y] {
+			line[x] = byte(int(v) * 255 / max(pgm.max, 1))
+		}
+		return line
+	})
+}
+
+// savePCX écrit un fichier PCX de planes plans de 8 bits, line donnant les octets de chaque plan de chaque ligne.
+// Palette, si elle n'est pas nil, contient les 256 couleurs ajoutées à la fin du fichier.
+func savePCX(filename string, width, height, planes int, palette []byte, line func(y, plane int) []byte) error {
+	// Une ligne de 0xFFFF pixels occuperait 0x10000 octets, qui ne tiennent pas dans BytesPerLine
+	if width <= 0 || height <= 0 || width > 0xFFFE || height > 0xFFFF {
+		return fmt.Errorf("invalid size for PCX: %dx%d", width, height)
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+
+	// Chaque plan d'une ligne occupe un nombre pair d'octets
+	bytesPerLine := width + width%2
+	header := pcxHeader{
+		Manufacturer: 0x0A, Version: 5, Encoding: 1, BitsPerPixel: 8,
+		XMax: uint16(width - 1), YMax: uint16(height - 1), HDPI: 72, VDPI: 72,
+		Planes: uint8(planes), BytesPerLine: uint16(bytesPerLine), PaletteInfo: 1,
+	}
+	if planes == 1 {
+		header.PaletteInfo = 2
+	}
+	if err := binary.Write(writer, binary.LittleEndian, header); err != nil {
+		return err
+	}
+
+	for y := 0; y < height; y++ {
+		for plane := 0; plane < planes; plane++ {
+			data := append(line(y, plane), make([]byte, bytesPerLine-width)...)
+			for i := 0; i < len(data); {
+				run := 1
+				for i+run < len(data) && run < 63 && data[i+run] == data[i] {
+					run++
+				}
+				// Les valeurs dont les deux bits de poids fort sont à 1 doivent passer par une plage
+				if run > 1 || data[i] >= 0xC0 {
+					writer.WriteByte(byte(0xC0 | run))
+				}
+				writer.WriteByte(data[i])
+				i += run
+			}
+		}
+	}
+	if palette != nil {
+		writer.WriteByte(0x0C)
+		writer.Write(palette)
+	}
+	return writer.Flush()
+}
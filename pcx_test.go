@@ -0,0 +1,102 @@
+package Netpbm // 🧪 Test format PCX
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSaveAndReadPCX(t *testing.T) {
+	ppm := newPatternPPM(33, 6)
+	for x := 0; x < 33; x++ {
+		ppm.data[0][x] = Pixel{200, 200, 200} // Valeur ≥ 0xC0 répétée
+	}
+	filename := "./testImages/ppm/test.pcx"
+	defer os.Remove(filename)
+	if err := ppm.SavePCX(filename); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadPCX(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Hash() != ppm.Hash() {
+		t.Error("Round trip changed the image")
+	}
+}
+
+func TestSavePCXGray(t *testing.T) {
+	pgm := newGradientPGM(15, 3)
+	filename := "./testImages/pgm/test.pcx"
+	defer os.Remove(filename)
+	if err := pgm.SavePCX(filename); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadPCX(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := 0; x < 15; x++ {
+		v := pgm.At(x, 1)
+		if read.At(x, 1) != (Pixel{v, v, v}) {
+			t.Fatalf("Pixel %d: expected gray %d, got %v", x, v, read.At(x, 1))
+		}
+	}
+}
+
+func TestReadPCXMonochrome(t *testing.T) {
+	header := make([]byte, 128)
+	header[0], header[1], header[2], header[3] = 0x0A, 5, 1, 1
+	header[8] = 9  // XMax : 10 pixels de large
+	header[10] = 1 // YMax : 2 lignes
+	header[65] = 1 // 1 plan
+	header[66] = 2 // 2 octets par ligne
+	content := append(header, 0xC2, 0xFF, 0x80, 0x00)
+	filename := "./testImages/pbm/mono.pcx"
+	os.WriteFile(filename, content, 0644)
+	defer os.Remove(filename)
+
+	ppm, err := ReadPCX(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	white := Pixel{255, 255, 255}
+	if ppm.At(9, 0) != white || ppm.At(0, 1) != white || ppm.At(1, 1) != (Pixel{}) {
+		t.Error("Unexpected monochrome pixels")
+	}
+
+	os.WriteFile(filename, content[:130], 0644)
+	if _, err := ReadPCX(filename); err == nil {
+		t.Error("Expected an error for truncated data")
+	}
+
+	// En-tête corrompu : 27 pixels de 8 bits pour seulement 6 octets par ligne
+	header[3], header[8], header[66] = 8, 0x1A, 6
+	os.WriteFile(filename, append(header, make([]byte, 1024)...), 0644)
+	if _, err := ReadPCX(filename); err == nil {
+		t.Error("Expected an error for a line size smaller than the width")
+	}
+	header[3], header[8], header[66] = 1, 16, 2
+	os.WriteFile(filename, append(header, 0xC2, 0xFF, 0x80, 0x00, 0x00, 0x00), 0644)
+	if _, err := ReadPCX(filename); err == nil {
+		t.Error("Expected an error for 17 monochrome pixels in 2 bytes per line")
+	}
+
+	// En-tête annonçant 65535×65535 pixels sur 3 plans pour quelques octets de données
+	header[3], header[8], header[9], header[10], header[11], header[65], header[66], header[67] = 8, 0xFF, 0xFF, 0xFF, 0xFF, 3, 0xFF, 0xFF
+	os.WriteFile(filename, append(header, 0xFF, 0x00), 0644)
+	if _, err := ReadPCX(filename); err == nil {
+		t.Error("Expected an error for dimensions larger than the file")
+	}
+}
+
+func TestSavePCXWidthLimit(t *testing.T) {
+	filename := "./testImages/ppm/wide.pcx"
+	defer os.Remove(filename)
+	// 0xFFFF pixels demanderaient 0x10000 octets par ligne, hors de BytesPerLine
+	if err := NewPPM(0xFFFF, 1, 255).SavePCX(filename); err == nil {
+		t.Error("Expected an error for a width of 0xFFFF")
+	}
+	if err := NewPPM(0xFFFE, 1, 255).SavePCX(filename); err != nil {
+		t.Error(err)
+	}
+}
@@ -6,25 +6,41 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 )
 
 // PGM représente une image PGM.
 type PGM struct {
-	data          [][]uint8 // Tableau bidimensionnel pour stocker les valeurs des pixels.
-	width, height int       // Largeur et hauteur de l'image.
-	magicNumber   string    // Le nombre magique spécifiant le format de l'image (P2 ou P5).
-	max           int       // Valeur maximale d'un pixel dans l'image.
+	data          [][]uint8         // Tableau bidimensionnel pour stocker les valeurs des pixels.
+	width, height int               // Largeur et hauteur de l'image.
+	magicNumber   string            // Le nombre magique spécifiant le format de l'image (P2 ou P5).
+	max           int               // Valeur maximale d'un pixel dans l'image.
+	metadata      map[string]string // Métadonnées enregistrées dans les commentaires de l'en-tête.
+	buffers       *BufferPool       // Réserve des tampons de travail des filtres (nil : allocation à chaque appel).
+	shared        *sharedData       // Pixels partagés avec des clones (nil : pixels propres à l'image).
 }
 
 // ReadPGM lit une image PGM à partir d'un fichier et renvoie une structure qui représente l'image.
 func ReadPGM(filename string) (*PGM, error) {
-	file, err := os.Open(filename)
+	return readPGM(filename, false, 0)
+}
+
+// readPGM lit une image PGM. Si recover est vrai, un fichier tronqué donne une image partielle
+// dont les pixels manquants valent fill, accompagnée d'une *TruncatedError.
+func readPGM(filename string, recover bool, fill uint8) (pgm *PGM, err error) {
+	defer func(start time.Time) { traceDecode("pgm", filename, start, pgm, err) }(time.Now())
+
+	file, err := openImage(filename)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
+	return decodePGM(file, recover, fill)
+}
 
-	reader := bufio.NewReader(file)
+// decodePGM lit une image PGM depuis r, comme readPGM.
+func decodePGM(r io.Reader, recover bool, fill uint8) (*PGM, error) {
+	reader := bufio.NewReader(r)
 
 	// Lire le nombre magique
 	magicNumber, err := reader.ReadString('\n')
@@ -36,8 +52,9 @@ func ReadPGM(filename string) (*PGM, error) {
 		return nil, fmt.Errorf("invalid magic number: %s", magicNumber)
 	}
 
-	// Lire les dimensions
-	dimensions, err := reader.ReadString('\n')
+	// Lire les dimensions, en relevant les métadonnées des commentaires
+	metadata := make(map[string]string)
+	dimensions, err := readHeaderLine(reader, metadata)
 	if err != nil {
 		return nil, fmt.Errorf("error reading dimensions: %v", err)
 	}
@@ -51,7 +68,7 @@ func ReadPGM(filename string) (*PGM, error) {
 	}
 
 	// Lire la valeur maximale
-	maxValue, err := reader.ReadString('\n')
+	maxValue, err := readHeaderLine(reader, metadata)
 	if err != nil {
 		return nil, fmt.Errorf("error reading max value: %v", err)
 	}
@@ -61,6 +78,10 @@ func ReadPGM(filename string) (*PGM, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid max value: %v", err)
 	}
+	// Les échantillons sont lus sur un octet : les fichiers de 16 bits par échantillon sont refusés
+	if max < 1 || max > 255 {
+		return nil, fmt.Errorf("unsupported max value %d: only 8-bit samples (1 to 255) are read, use DecodeBinary to scale 16-bit samples", max)
+	}
 
 	// Lire les données de l'image
 	data := make([][]uint8, height)
@@ -70,10 +91,22 @@ func ReadPGM(filename string) (*PGM, error) {
 		// Lire le format P2 (ASCII)
 		for y := 0; y < height; y++ {
 			line, err := reader.ReadString('\n')
-			if err != nil {
+			fields := strings.Fields(line)
+			if err != nil && !(recover && err == io.EOF && len(fields) >= width) {
+				if recover {
+					// Garder les pixels de la ligne incomplète et compléter le reste
+					data[y] = make([]uint8, width)
+					x := 0
+					for ; x < min(width, len(fields)); x++ {
+						if _, err := fmt.Sscanf(fields[x], "%d", &data[y][x]); err != nil {
+							break
+						}
+					}
+					fillPGM(data, y, x, width, fill)
+					return &PGM{data, width, height, magicNumber, max, metadata, nil, nil}, &TruncatedError{Row: y, Height: height, Err: err}
+				}
 				return nil, fmt.Errorf("error reading data at row %d: %v", y, err)
 			}
-			fields := strings.Fields(line)
 			rowData := make([]uint8, width)
 			for x, field := range fields {
 				if x >= width {
@@ -92,16 +125,23 @@ func ReadPGM(filename string) (*PGM, error) {
 		// Lire le format P5 (binaire)
 		for y := 0; y < height; y++ {
 			row := make([]byte, width*expectedBytesPerPixel)
-			n, err := reader.Read(row)
+			n, err := io.ReadFull(reader, row)
+			if err != nil && recover {
+				// Garder les pixels des octets lus et compléter le reste
+				data[y] = make([]uint8, width)
+				copy(data[y], row[:n])
+				fillPGM(data, y, n/expectedBytesPerPixel, width, fill)
+				return &PGM{data, width, height, magicNumber, max, metadata, nil, nil}, &TruncatedError{Row: y, Height: height, Err: err}
+			}
 			if err != nil {
 				if err == io.EOF {
 					return nil, fmt.Errorf("unexpected end of file at row %d", y)
 				}
+				if err == io.ErrUnexpectedEOF {
+					return nil, fmt.Errorf("unexpected end of file at row %d, expected %d bytes, got %d", y, width*expectedBytesPerPixel, n)
+				}
 				return nil, fmt.Errorf("error reading pixel data at row %d: %v", y, err)
 			}
-			if n < width*expectedBytesPerPixel {
-				return nil, fmt.Errorf("unexpected end of file at row %d, expected %d bytes, got %d", y, width*expectedBytesPerPixel, n)
-			}
 
 			rowData := make([]uint8, width)
 			for x := 0; x < width; x++ {
@@ -113,7 +153,7 @@ func ReadPGM(filename string) (*PGM, error) {
 	}
 
 	// Renvoie la structure PGM
-	return &PGM{data, width, height, magicNumber, max}, nil
+	return &PGM{data, width, height, magicNumber, max, metadata, nil, nil}, nil
 }
 
 // Size renvoie la largeur et la hauteur de l'image.
@@ -128,6 +168,7 @@ func (pgm *PGM) At(x, y int) uint8 {
 
 // Set définit la valeur du pixel à (x, y).
 func (pgm *PGM) Set(x, y int, value uint8) {
+	pgm.own()
 	pgm.data[y][x] = value
 }
 
@@ -138,13 +179,23 @@ func (pgm *PGM) Save(filename string) error {
 		return err
 	}
 	defer file.Close()
+	return pgm.encode(file)
+}
 
-	writer := bufio.NewWriter(file)
-	_, err = fmt.Fprintln(writer, pgm.magicNumber)
+// encode écrit l'image PGM dans w, dans le format de son nombre magique.
+func (pgm *PGM) encode(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	_, err := fmt.Fprintln(writer, pgm.magicNumber)
 	if err != nil {
 		return fmt.Errorf("error writing magic number: %v", err)
 	}
 
+	// Écrire les métadonnées en commentaires
+	err = writeMetadata(writer, pgm.metadata)
+	if err != nil {
+		return fmt.Errorf("error writing metadata: %v", err)
+	}
+
 	// Écrire les dimensions
 	_, err = fmt.Fprintf(writer, "%d %d\n", pgm.width, pgm.height)
 	if err != nil {
@@ -222,17 +273,21 @@ func saveP5PGM(file *bufio.Writer, pgm *PGM) error {
 
 // Invert inverse les couleurs de l’image PGM.
 func (pgm *PGM) Invert() {
-	for y := 0; y < pgm.height; y++ {
-		for x := 0; x < pgm.width; x++ {
-			pixel := pgm.data[y][x]
-			invertedPixel := uint8(pgm.max) - pixel
-			pgm.data[y][x] = invertedPixel
-		}
+	pgm.InvertRegion(Rect{0, 0, pgm.width, pgm.height})
+}
+
+// InvertRegion inverse les couleurs de l’image PGM dans la zone r uniquement.
+func (pgm *PGM) InvertRegion(r Rect) {
+	pgm.own()
+	r = r.clip(pgm.width, pgm.height)
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		invertBytes(pgm.data[y][r.X:r.X+r.Width], uint8(pgm.max))
 	}
 }
 
 // Flip retourne l'image PGM horizontalement.
 func (pgm *PGM) Flip() {
+	pgm.own()
 	for y := 0; y < pgm.height; y++ {
 		for x := 0; x < pgm.width/2; x++ {
 			// Swap pixels horizontally
@@ -243,6 +298,7 @@ func (pgm *PGM) Flip() {
 
 // Flop fait basculer l'image PGM verticalement.
 func (pgm *PGM) Flop() {
+	pgm.own()
 	for y := 0; y < pgm.height/2; y++ {
 		// Swap rows vertically
 		pgm.data[y], pgm.data[pgm.height-y-1] = pgm.data[pgm.height-y-1], pgm.data[y]
@@ -254,24 +310,19 @@ func (pgm *PGM) SetMagicNumber(magicNumber string) {
 	pgm.magicNumber = magicNumber
 }
 
-// SetMaxValue définit la valeur maximale de l'image PGM.
+// SetMaxValue définit la valeur maximale de l'image PGM, les valeurs étant remises à l'échelle et arrondies
+// comme par ConvertDepth. Une valeur maximale nulle, la seule que ConvertDepth refuserait, laisse l'image
+// inchangée.
 func (pgm *PGM) SetMaxValue(maxValue uint8) {
-	for y := 0; y < pgm.height; y++ {
-		for x := 0; x < pgm.width; x++ {
-			// Scale the pixel values based on the new max value
-			scaledValue := float64(pgm.data[y][x]) * float64(maxValue) / float64(pgm.max)
-			// Round to the nearest integer
-			newValue := uint8(scaledValue)
-			pgm.data[y][x] = newValue
-		}
+	if maxValue == 0 {
+		return
 	}
-
-	// Mettre à jour la valeur maximale
-	pgm.max = int(maxValue)
+	pgm.ConvertDepth(int(maxValue))
 }
 
 // Rotate90CW fait pivoter l'image PGM de 90° dans le sens des aiguilles d'une montre.
 func (pgm *PGM) Rotate90CW() {
+	pgm.own()
 	if pgm.width <= 0 || pgm.height <= 0 {
 		return
 	}
@@ -313,3 +364,24 @@ func (pgm *PGM) PrintData() {
 		fmt.Println()
 	}
 }
+
+// NewPGM crée une nouvelle image PGM dont tous les pixels sont à 0.
+func NewPGM(width, height, max int) *PGM {
+	data := make([][]uint8, height)
+	for y := range data {
+		data[y] = make([]uint8, width)
+	}
+	return &PGM{data, width, height, "P2", max, nil, nil, nil}
+}
+
+// ToPPM convertit l'image PGM en PPM dont les trois canaux valent le niveau de gris.
+func (pgm *PGM) ToPPM() *PPM {
+	ppm := NewPPM(pgm.width, pgm.height, pgm.max)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			v := pgm.data[y][x]
+			ppm.data[y][x] = Pixel{v, v, v}
+		}
+	}
+	return ppm
+}
@@ -2,6 +2,7 @@ package Netpbm // 🧪 Test PGM
 
 import (
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -332,10 +333,19 @@ func TestSetMaxValuePGM(t *testing.T) {
 	for i := 0; i < imagePGMWidth*imagePGMHeight; i++ {
 		x := i % imagePGMWidth
 		y := i / imagePGMWidth
-		if pgm.data[y][x] != testData[i]*uint8(5)/uint8(oldMax) {
-			t.Errorf("Pixel at (%d, %d) not read correctly, expected %d, got %d", x, y, uint8(float64(testData[i])*float64(5)/float64(oldMax)), pgm.data[y][x])
+		// Valeurs arrondies au plus proche
+		want := uint8((int(testData[i])*5 + oldMax/2) / oldMax)
+		if pgm.data[y][x] != want {
+			t.Errorf("Pixel at (%d, %d) not read correctly, expected %d, got %d", x, y, want, pgm.data[y][x])
 		}
 	}
+
+	// Une valeur maximale nulle laisse l'image inchangée
+	before := pgm.Clone()
+	pgm.SetMaxValue(0)
+	if pgm.max != 5 || !reflect.DeepEqual(pgm.data, before.data) {
+		t.Error("SetMaxValue(0) changed the image")
+	}
 }
 
 func TestToPBM(t *testing.T) {
@@ -361,3 +371,21 @@ func TestToPBM(t *testing.T) {
 		}
 	}
 }
+
+func TestToPPM(t *testing.T) {
+	pgm, err := ReadPGM("./testImages/pgm/testP2.pgm")
+	if err != nil {
+		t.Error(err)
+	}
+	ppm := pgm.ToPPM()
+	if ppm.max != pgm.max {
+		t.Error("Max value not kept")
+	}
+	for i := 0; i < imagePGMWidth*imagePGMHeight; i++ {
+		x := i % imagePGMWidth
+		y := i / imagePGMWidth
+		if ppm.data[y][x] != (Pixel{testData[i], testData[i], testData[i]}) {
+			t.Errorf("Pixel at (%d, %d) not converted correctly", x, y)
+		}
+	}
+}
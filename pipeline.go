@@ -0,0 +1,136 @@
+package Netpbm // ✨ Chaînes de traitements
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Image est une image Netpbm de n'importe quel type : *PBM, *PGM ou *PPM.
+type Image interface {
+	Size() (int, int)
+	Save(filename string) error
+}
+
+// ReadNetpbm lit une image PBM, PGM ou PPM, le type étant reconnu d'après le nombre magique du fichier.
+func ReadNetpbm(filename string) (Image, error) {
+	file, err := openImage(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	magic := make([]byte, 2)
+	_, err = io.ReadFull(file, magic)
+	file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading magic number: %v", err)
+	}
+	switch string(magic) {
+	case "P1", "P4":
+		return asImage(ReadPBM(filename))
+	case "P2", "P5":
+		return asImage(ReadPGM(filename))
+	case "P3", "P6":
+		return asImage(ReadPPM(filename))
+	}
+	return nil, fmt.Errorf("invalid magic number: %q", magic)
+}
+
+// decodeNetpbm lit depuis r une image PBM, PGM ou PPM, selon son nombre magique, éventuellement dans
+// le conteneur compressé PZ.
+func decodeNetpbm(r io.Reader) (Image, error) {
+	reader := bufio.NewReader(r)
+	if magic, _ := reader.Peek(3); isCompressedMagic(magic) {
+		decompressor, err := decompressContainer(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer decompressor.Close()
+		reader = bufio.NewReader(decompressor)
+	}
+	magic, err := reader.Peek(2)
+	if err != nil {
+		return nil, fmt.Errorf("error reading magic number: %v", err)
+	}
+	switch string(magic) {
+	case "P1", "P4":
+		return asImage(decodePBM(reader, false, false))
+	case "P2", "P5":
+		return asImage(decodePGM(reader, false, 0))
+	case "P3", "P6":
+		return asImage(decodePPM(reader, false, Pixel{}))
+	}
+	return nil, fmt.Errorf("invalid magic number: %q", magic)
+}
+
+// asImage renvoie img comme Image, ou une Image nil en cas d'erreur : une image *PPM nil convertie
+// directement en Image ne serait pas égale à nil.
+func asImage(img Image, err error) (Image, error) {
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// Pipeline est une suite d'étapes appliquées l'une après l'autre à une image. Chaque étape peut
+// modifier l'image reçue ou en renvoyer une autre, éventuellement d'un autre type.
+type Pipeline []func(Image) (Image, error)
+
+// Run applique les étapes à l'image et renvoie le résultat, ou la première erreur rencontrée.
+func (p Pipeline) Run(img Image) (Image, error) {
+	return p.run(context.Background(), img)
+}
+
+// run applique les étapes en journalisant leur durée dans le journal de ctx.
+func (p Pipeline) run(ctx context.Context, img Image) (Image, error) {
+	for i, step := range p {
+		start := time.Now()
+		var err error
+		if img, err = step(img); err != nil {
+			return nil, fmt.Errorf("step %d: %v", i, err)
+		}
+		elapsed := time.Since(start)
+		if metrics := currentMetrics(); metrics != nil {
+			metrics.Observe(MetricStepSeconds, elapsed.Seconds(), Label{"step", strconv.Itoa(i)})
+		}
+		if logger := eventLogger(ctx, slog.LevelDebug); logger != nil {
+			logger.LogAttrs(ctx, slog.LevelDebug, "pipeline step", slog.Int("step", i), slog.Duration("duration", elapsed))
+		}
+	}
+	return img, nil
+}
+
+// Process lit le fichier input, lui applique les étapes et enregistre le résultat dans output.
+func (p Pipeline) Process(input, output string) error {
+	return p.process(context.Background(), input, output)
+}
+
+// process fait le travail de Process en journalisant dans le journal de ctx.
+func (p Pipeline) process(ctx context.Context, input, output string) error {
+	img, err := ReadNetpbm(input)
+	if err != nil {
+		return err
+	}
+	if img, err = p.run(ctx, img); err != nil {
+		return err
+	}
+	start := time.Now()
+	if err := img.Save(output); err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+	if metrics := currentMetrics(); metrics != nil {
+		metrics.Observe(MetricEncodeSeconds, elapsed.Seconds())
+		if info, err := os.Stat(output); err == nil {
+			metrics.Add(MetricBytesWritten, float64(info.Size()))
+		}
+	}
+	if logger := eventLogger(ctx, slog.LevelDebug); logger != nil {
+		logger.LogAttrs(ctx, slog.LevelDebug, "encode", slog.String("file", output), slog.Duration("duration", elapsed))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package Netpbm // 🧪 Test chaînes de traitements
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestReadNetpbm(t *testing.T) {
+	filename := "./testImages/pgm/netpbm.pgm"
+	if err := newGradientPGM(4, 3).Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	img, err := ReadNetpbm(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := img.(*PGM); !ok {
+		t.Errorf("Expected a PGM image, got %T", img)
+	}
+	if w, h := img.Size(); w != 4 || h != 3 {
+		t.Errorf("Unexpected size %dx%d", w, h)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	toGray := func(img Image) (Image, error) {
+		ppm, ok := img.(*PPM)
+		if !ok {
+			return nil, fmt.Errorf("expected a PPM image")
+		}
+		return ppm.ToPGM(), nil
+	}
+	invert := func(img Image) (Image, error) {
+		img.(*PGM).Invert()
+		return img, nil
+	}
+	pipeline := Pipeline{toGray, invert}
+
+	input, output := "./testImages/ppm/pipeline.ppm", "./testImages/pgm/pipeline.pgm"
+	if err := newUniformPPM(3, 2, Pixel{255, 255, 255}).Save(input); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(input)
+	if err := pipeline.Process(input, output); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(output)
+	result, err := ReadPGM(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.At(1, 1) != 0 {
+		t.Errorf("Expected an inverted white image, got %d", result.At(1, 1))
+	}
+
+	if _, err := pipeline.Run(NewPBM(1, 1)); err == nil {
+		t.Error("Expected the first step to fail on a PBM image")
+	}
+}
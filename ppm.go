@@ -10,14 +10,18 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 )
 
 // PPM représente une image PPM.
 type PPM struct {
-	data          [][]Pixel // Pixels de l'image PPM représentés par un tableau bidimensionnel de pixels.
-	width, height int       // Largeur et hauteur de l'image
-	magicNumber   string    // Nombre magique du format PBM ("P3" ou "P6")
-	max           int       // Valeur maximale d'un pixel dans l'image.
+	data          [][]Pixel         // Pixels de l'image PPM représentés par un tableau bidimensionnel de pixels.
+	width, height int               // Largeur et hauteur de l'image
+	magicNumber   string            // Nombre magique du format PBM ("P3" ou "P6")
+	max           int               // Valeur maximale d'un pixel dans l'image.
+	metadata      map[string]string // Métadonnées enregistrées dans les commentaires de l'en-tête.
+	buffers       *BufferPool       // Réserve des tampons de travail des filtres (nil : allocation à chaque appel).
+	shared        *sharedData       // Pixels partagés avec des clones (nil : pixels propres à l'image).
 }
 
 // Pixel représente un pixel de couleur.
@@ -32,13 +36,25 @@ type Point struct {
 
 // ReadPPM lit une image PPM à partir d'un fichier et renvoie une structure qui représente l'image.
 func ReadPPM(filename string) (*PPM, error) {
-	file, err := os.Open(filename)
+	return readPPM(filename, false, Pixel{})
+}
+
+// readPPM lit une image PPM. Si recover est vrai, un fichier tronqué donne une image partielle
+// dont les pixels manquants valent fill, accompagnée d'une *TruncatedError.
+func readPPM(filename string, recover bool, fill Pixel) (ppm *PPM, err error) {
+	defer func(start time.Time) { traceDecode("ppm", filename, start, ppm, err) }(time.Now())
+
+	file, err := openImage(filename)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
+	return decodePPM(file, recover, fill)
+}
 
-	reader := bufio.NewReader(file)
+// decodePPM lit une image PPM depuis r, comme readPPM.
+func decodePPM(r io.Reader, recover bool, fill Pixel) (*PPM, error) {
+	reader := bufio.NewReader(r)
 
 	// Lire le nombre magique
 	magicNumber, err := reader.ReadString('\n')
@@ -50,8 +66,9 @@ func ReadPPM(filename string) (*PPM, error) {
 		return nil, fmt.Errorf("invalid magic number: %s", magicNumber)
 	}
 
-	// Lire les dimensions
-	dimensions, err := reader.ReadString('\n')
+	// Lire les dimensions, en relevant les métadonnées des commentaires
+	metadata := make(map[string]string)
+	dimensions, err := readHeaderLine(reader, metadata)
 	if err != nil {
 		return nil, fmt.Errorf("error reading dimensions: %v", err)
 	}
@@ -65,7 +82,7 @@ func ReadPPM(filename string) (*PPM, error) {
 	}
 
 	// Lire la valeur maximale
-	maxValue, err := reader.ReadString('\n')
+	maxValue, err := readHeaderLine(reader, metadata)
 	if err != nil {
 		return nil, fmt.Errorf("error reading max value: %v", err)
 	}
@@ -75,6 +92,10 @@ func ReadPPM(filename string) (*PPM, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid max value: %v", err)
 	}
+	// Les échantillons sont lus sur un octet : les fichiers de 16 bits par échantillon sont refusés
+	if max < 1 || max > 255 {
+		return nil, fmt.Errorf("unsupported max value %d: only 8-bit samples (1 to 255) are read, use DecodeBinary to scale 16-bit samples", max)
+	}
 
 	// Lire les données d'image
 	data := make([][]Pixel, height)
@@ -84,10 +105,23 @@ func ReadPPM(filename string) (*PPM, error) {
 		// Lire le format P3 (ASCII)
 		for y := 0; y < height; y++ {
 			line, err := reader.ReadString('\n')
-			if err != nil {
+			fields := strings.Fields(line)
+			if err != nil && !(recover && err == io.EOF && len(fields) >= width*3) {
+				if recover {
+					// Garder les pixels complets de la ligne et compléter le reste
+					data[y] = make([]Pixel, width)
+					x := 0
+					for ; x < min(width, len(fields)/3); x++ {
+						pixel := &data[y][x]
+						if _, err := fmt.Sscanf(fields[x*3]+" "+fields[x*3+1]+" "+fields[x*3+2], "%d %d %d", &pixel.R, &pixel.G, &pixel.B); err != nil {
+							break
+						}
+					}
+					fillPPM(data, y, x, width, fill)
+					return &PPM{data, width, height, magicNumber, max, metadata, nil, nil}, &TruncatedError{Row: y, Height: height, Err: err}
+				}
 				return nil, fmt.Errorf("error reading data at row %d: %v", y, err)
 			}
-			fields := strings.Fields(line)
 			rowData := make([]Pixel, width)
 			for x := 0; x < width; x++ {
 				if x*3+2 >= len(fields) {
@@ -114,16 +148,25 @@ func ReadPPM(filename string) (*PPM, error) {
 		// Lire le format P6 (binaire)
 		for y := 0; y < height; y++ {
 			row := make([]byte, width*expectedBytesPerPixel)
-			n, err := reader.Read(row)
+			n, err := io.ReadFull(reader, row)
+			if err != nil && recover {
+				// Garder les pixels complets et compléter le reste
+				data[y] = make([]Pixel, width)
+				for x := 0; x < n/expectedBytesPerPixel; x++ {
+					data[y][x] = Pixel{R: row[x*expectedBytesPerPixel], G: row[x*expectedBytesPerPixel+1], B: row[x*expectedBytesPerPixel+2]}
+				}
+				fillPPM(data, y, n/expectedBytesPerPixel, width, fill)
+				return &PPM{data, width, height, magicNumber, max, metadata, nil, nil}, &TruncatedError{Row: y, Height: height, Err: err}
+			}
 			if err != nil {
 				if err == io.EOF {
 					return nil, fmt.Errorf("unexpected end of file at row %d", y)
 				}
+				if err == io.ErrUnexpectedEOF {
+					return nil, fmt.Errorf("unexpected end of file at row %d, expected %d bytes, got %d", y, width*expectedBytesPerPixel, n)
+				}
 				return nil, fmt.Errorf("error reading pixel data at row %d: %v", y, err)
 			}
-			if n < width*expectedBytesPerPixel {
-				return nil, fmt.Errorf("unexpected end of file at row %d, expected %d bytes, got %d", y, width*expectedBytesPerPixel, n)
-			}
 
 			rowData := make([]Pixel, width)
 			for x := 0; x < width; x++ {
@@ -135,7 +178,7 @@ func ReadPPM(filename string) (*PPM, error) {
 	}
 
 	// Renvoie la structure PPM
-	return &PPM{data, width, height, magicNumber, max}, nil
+	return &PPM{data, width, height, magicNumber, max, metadata, nil, nil}, nil
 }
 
 func (ppm *PPM) PrintPPM() {
@@ -166,6 +209,7 @@ func (ppm *PPM) At(x, y int) Pixel {
 
 // Set définit la valeur du pixel à (x, y).
 func (ppm *PPM) Set(x, y int, value Pixel) {
+	ppm.own()
 	ppm.data[y][x] = value
 }
 
@@ -176,8 +220,20 @@ func (ppm *PPM) Save(filename string) error {
 		return err
 	}
 	defer file.Close()
+	return ppm.encode(file)
+}
+
+// encode écrit l'image PPM dans w, dans le format de son nombre magique.
+func (ppm *PPM) encode(w io.Writer) error {
+	file := bufio.NewWriter(w)
+	var err error
 	if ppm.magicNumber == "P6" || ppm.magicNumber == "P3" {
-		fmt.Fprintf(file, "%s\n%d %d\n%d\n", ppm.magicNumber, ppm.width, ppm.height, ppm.max)
+		fmt.Fprintf(file, "%s\n", ppm.magicNumber)
+		err = writeMetadata(file, ppm.metadata)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(file, "%d %d\n%d\n", ppm.width, ppm.height, ppm.max)
 	} else {
 		err = fmt.Errorf("magic number error")
 		return err
@@ -199,22 +255,26 @@ func (ppm *PPM) Save(filename string) error {
 		}
 	}
 
-	return nil
+	return file.Flush()
 }
 
 // Invert inverse les couleurs de l’image PPM.
 func (ppm *PPM) Invert() {
-	for y := 0; y < ppm.height; y++ {
-		for x := 0; x < ppm.width; x++ {
-			ppm.data[y][x].R = uint8(ppm.max) - ppm.data[y][x].R
-			ppm.data[y][x].G = uint8(ppm.max) - ppm.data[y][x].G
-			ppm.data[y][x].B = uint8(ppm.max) - ppm.data[y][x].B
-		}
+	ppm.InvertRegion(Rect{0, 0, ppm.width, ppm.height})
+}
+
+// InvertRegion inverse les couleurs de l’image PPM dans la zone r uniquement.
+func (ppm *PPM) InvertRegion(r Rect) {
+	ppm.own()
+	r = r.clip(ppm.width, ppm.height)
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		invertPixels(ppm.data[y][r.X:r.X+r.Width], uint8(ppm.max))
 	}
 }
 
 // Flip retourne l'image PPM horizontalement.
 func (ppm *PPM) Flip() {
+	ppm.own()
 	for y := 0; y < ppm.height; y++ {
 		for x := 0; x < ppm.width/2; x++ {
 			ppm.data[y][x], ppm.data[y][ppm.width-x-1] = ppm.data[y][ppm.width-x-1], ppm.data[y][x]
@@ -224,6 +284,7 @@ func (ppm *PPM) Flip() {
 
 // Flop fait basculer l'image PPM verticalement.
 func (ppm *PPM) Flop() {
+	ppm.own()
 	for y := 0; y < ppm.height/2; y++ {
 		for x := 0; x < ppm.width; x++ {
 			ppm.data[y][x], ppm.data[ppm.height-y-1][x] = ppm.data[ppm.height-y-1][x], ppm.data[y][x]
@@ -236,23 +297,19 @@ func (ppm *PPM) SetMagicNumber(magicNumber string) {
 	ppm.magicNumber = magicNumber
 }
 
-// SetMaxValue définit la valeur maximale de l'image PPM.
+// SetMaxValue définit la valeur maximale de l'image PPM, les valeurs étant remises à l'échelle et arrondies
+// comme par ConvertDepth. Une valeur maximale nulle, la seule que ConvertDepth refuserait, laisse l'image
+// inchangée.
 func (ppm *PPM) SetMaxValue(maxValue uint8) {
-	for y := 0; y < ppm.height; y++ {
-		for x := 0; x < ppm.width; x++ {
-			// Mettre à l'échelle les valeurs RVB en fonction de la nouvelle valeur maximale
-			ppm.data[y][x].R = uint8(float64(ppm.data[y][x].R) * float64(maxValue) / float64(ppm.max))
-			ppm.data[y][x].G = uint8(float64(ppm.data[y][x].G) * float64(maxValue) / float64(ppm.max))
-			ppm.data[y][x].B = uint8(float64(ppm.data[y][x].B) * float64(maxValue) / float64(ppm.max))
-		}
+	if maxValue == 0 {
+		return
 	}
-
-	// Mettre à jour la valeur maximale
-	ppm.max = int(maxValue)
+	ppm.ConvertDepth(int(maxValue))
 }
 
 // Rotate90CW fait pivoter l'image PPM de 90° dans le sens des aiguilles d'une montre.
 func (ppm *PPM) Rotate90CW() {
+	ppm.own()
 	rotated := make([][]Pixel, ppm.width)
 	for i := range rotated {
 		rotated[i] = make([]Pixel, ppm.height)
@@ -282,11 +339,7 @@ func (ppm *PPM) ToPGM() *PGM {
 	// Convertir chaque pixel de RVB en niveaux de gris et attribuez-le à l'image PGM
 	for y := 0; y < ppm.height; y++ {
 		pgm.data[y] = make([]uint8, ppm.width)
-		for x := 0; x < ppm.width; x++ {
-			pixel := ppm.data[y][x]
-			gray := uint8((float32(pixel.R) + float32(pixel.G) + float32(pixel.B)) / 3)
-			pgm.data[y][x] = gray
-		}
+		grayRow(pgm.data[y], ppm.data[y])
 	}
 
 	return pgm
@@ -321,6 +374,7 @@ func (ppm *PPM) ToPBM() *PBM {
 
 // SetPixel définit la couleur d'un pixel en un point donné.
 func (ppm *PPM) SetPixel(p Point, color Pixel) {
+	ppm.own()
 	// Vérifier si le point se trouve dans les dimensions PPM
 	if p.X >= 0 && p.X < ppm.width && p.Y >= 0 && p.Y < ppm.height {
 		ppm.data[p.Y][p.X] = color
@@ -329,48 +383,11 @@ func (ppm *PPM) SetPixel(p Point, color Pixel) {
 
 // DrawLine trace une ligne entre deux points.
 func (ppm *PPM) DrawLine(p1, p2 Point, color Pixel) {
+	ppm.own()
 	// Algorithme tracé de Bresenham
-	x1, y1 := p1.X, p1.Y
-	x2, y2 := p2.X, p2.Y
-
-	dx := abs(x2 - x1)
-	dy := abs(y2 - y1)
-
-	var sx, sy int
-
-	if x1 < x2 {
-		sx = 1
-	} else {
-		sx = -1
-	}
-
-	if y1 < y2 {
-		sy = 1
-	} else {
-		sy = -1
-	}
-
-	err := dx - dy
-
-	for {
-		ppm.SetPixel(Point{x1, y1}, color)
-
-		if x1 == x2 && y1 == y2 {
-			break
-		}
-
-		e2 := 2 * err
-
-		if e2 > -dy {
-			err -= dy
-			x1 += sx
-		}
-
-		if e2 < dx {
-			err += dx
-			y1 += sy
-		}
-	}
+	bresenham(p1, p2, func(p Point) {
+		ppm.SetPixel(p, color)
+	})
 }
 
 func abs(x int) int {
@@ -382,6 +399,7 @@ func abs(x int) int {
 
 // DrawRectangle dessine un rectangle.
 func (ppm *PPM) DrawRectangle(p1 Point, width, height int, color Pixel) {
+	ppm.own()
 	// Dessiner les quatre côtés du rectangle à l'aide de DrawLine
 	p2 := Point{p1.X + width, p1.Y}
 	p3 := Point{p1.X + width, p1.Y + height}
@@ -395,6 +413,7 @@ func (ppm *PPM) DrawRectangle(p1 Point, width, height int, color Pixel) {
 
 // DrawFilledRectangle dessine un rectangle rempli.
 func (ppm *PPM) DrawFilledRectangle(p1 Point, width, height int, color Pixel) {
+	ppm.own()
 	// Vérifier les dimensions valides
 	if width <= 0 || height <= 0 {
 		return
@@ -427,6 +446,7 @@ func (ppm *PPM) DrawFilledRectangle(p1 Point, width, height int, color Pixel) {
 
 // DrawCircle dessine un cercle.
 func (ppm *PPM) DrawCircle(center Point, radius int, color Pixel) {
+	ppm.own()
 
 	for x := 0; x < ppm.height; x++ {
 		for y := 0; y < ppm.width; y++ {
@@ -445,22 +465,14 @@ func (ppm *PPM) DrawCircle(center Point, radius int, color Pixel) {
 	ppm.Set(center.X, center.Y-(radius-1), color)
 }
 
-// DrawFilledCircle dessine un cercle rempli.
+// DrawFilledCircle dessine un cercle rempli : les pixels peints sont ceux que Circle.Contains retient.
 func (ppm *PPM) DrawFilledCircle(center Point, radius int, color Pixel) {
-	ppm.DrawCircle(center, radius, color)
-
-	for i := 0; i < ppm.height; i++ {
-		var positions []int
-		var numberPoints int
-		for j := 0; j < ppm.width; j++ {
-			if ppm.data[i][j] == color {
-				numberPoints += 1
-				positions = append(positions, j)
-			}
-		}
-		if numberPoints > 1 {
-			for k := positions[0] + 1; k < positions[len(positions)-1]; k++ {
-				ppm.data[i][k] = color
+	ppm.own()
+	disc := Circle{center, radius}
+	for y := max(center.Y-radius, 0); y <= min(center.Y+radius, ppm.height-1); y++ {
+		for x := max(center.X-radius, 0); x <= min(center.X+radius, ppm.width-1); x++ {
+			if disc.Contains(Point{x, y}) {
+				ppm.data[y][x] = color
 			}
 		}
 	}
@@ -468,6 +480,7 @@ func (ppm *PPM) DrawFilledCircle(center Point, radius int, color Pixel) {
 
 // DrawTriangle dessine un triangle.
 func (ppm *PPM) DrawTriangle(p1, p2, p3 Point, color Pixel) {
+	ppm.own()
 	ppm.DrawLine(p1, p2, color)
 	ppm.DrawLine(p2, p3, color)
 	ppm.DrawLine(p3, p1, color)
@@ -479,6 +492,7 @@ func interpolate(p1, p2 Point, y int) float64 {
 }
 
 func (ppm *PPM) DrawFilledTriangle(p1, p2, p3 Point, color Pixel) {
+	ppm.own()
 	vertices := []Point{p1, p2, p3}
 	sort.Slice(vertices, func(i, j int) bool {
 		return vertices[i].Y < vertices[j].Y
@@ -494,6 +508,7 @@ func (ppm *PPM) DrawFilledTriangle(p1, p2, p3 Point, color Pixel) {
 
 // DrawPolygon dessine un polygone.
 func (ppm *PPM) DrawPolygon(points []Point, color Pixel) {
+	ppm.own()
 	for i := 0; i < len(points)-1; i++ {
 		ppm.DrawLine(points[i], points[i+1], color)
 	}
@@ -501,104 +516,135 @@ func (ppm *PPM) DrawPolygon(points []Point, color Pixel) {
 	ppm.DrawLine(points[len(points)-1], points[0], color)
 }
 
-// DrawFilledPolygon dessine un polygone rempli.
+// DrawFilledPolygon dessine un polygone rempli : son contour, puis les pixels dont le centre est à
+// l'intérieur selon la règle pair-impair. Les pixels peints sont ceux que Polygon.Contains retient.
 func (ppm *PPM) DrawFilledPolygon(points []Point, color Pixel) {
+	if len(points) == 0 {
+		return
+	}
+	ppm.own()
 	ppm.DrawPolygon(points, color)
-	for i := 0; i < ppm.height; i++ {
-		var positions []int
-		var numberPoints int
-		for j := 0; j < ppm.width; j++ {
-			if ppm.data[i][j] == color {
-				numberPoints += 1
-				positions = append(positions, j)
-			}
-		}
-		if numberPoints > 1 {
-			for k := positions[0] + 1; k < positions[len(positions)-1]; k++ {
-				ppm.data[i][k] = color
+	ppm.fillPolygonF(Polygon(points).toPointF(), color)
+}
 
-			}
-		}
-	}
+// FractalOptions regroupe les options de dessin des fractales.
+type FractalOptions struct {
+	Size   int     // Longueur du côté du triangle de Sierpinski ; celui de Koch va de start à end
+	Angle  float64 // Rotation en degrés autour du point de départ, dans le sens des aiguilles d'une montre
+	Filled bool    // Remplir la figure au lieu de tracer ses contours
 }
 
 // DrawKochSnowflake dessine un flocon de neige Koch.
-func (ppm *PPM) DrawKochSnowflake(n int, start Point, end Point, width int, color Pixel) {
+func (ppm *PPM) DrawKochSnowflake(n int, start Point, end Point, width int, color Pixel, options FractalOptions) {
 	// N est le nombre d'itérations.
 	// Le flocon de neige de Koch est une courbe de Koch 3 fois supérieure.
-	// Start est le point culminant du flocon de neige.
+	// Start et end sont les extrémités du premier côté du triangle de départ, qui se trouve à sa droite.
 	// Width est la largeur de toutes les lignes.
 	// Color est la couleur des lignes.
-	if n == 0 {
-		ppm.DrawLine(start, end, color)
-	} else {
-		dx := (end.X - start.X) / 3.0
-		dy := (end.Y - start.Y) / 3.0
+	a := pointF{float64(start.X), float64(start.Y)}
+	b := pointF{float64(end.X), float64(end.Y)}
+	c := pointF{(a.X+b.X)/2 - (b.Y-a.Y)*math.Sqrt(3)/2, (a.Y+b.Y)/2 + (b.X-a.X)*math.Sqrt(3)/2}
+	corners := []pointF{a, b, c}
 
-		// Calculer les points pour les segments
-		p1 := Point{start.X + dx, start.Y + dy}
-		p3 := Point{start.X + 2*dx, start.Y + 2*dy}
+	var points []pointF
+	for i, corner := range corners {
+		points = append(points, kochCurve(n, corner, corners[(i+1)%3])...)
+	}
+	for i := range points {
+		points[i] = points[i].rotateAround(a, options.Angle)
+	}
+	ppm.drawFractalPolygon(points, width, color, options)
+}
 
-		angle := math.Pi / 3.0 // 60 degrés
-		p2 := Point{
-			X: int(float64((p1.X+p3.X)/2) - math.Sin(angle)*float64(p1.Y-p3.Y)/2),
-			Y: int(float64(p1.Y+p3.Y)/2 + math.Sin(angle)*float64(p1.X-p3.X)/2),
-		}
+// DrawKochCurve dessine une courbe de Koch entre deux points.
+// Les pointes sont tournées vers la gauche du segment, dans le sens de parcours de start vers end.
+func (ppm *PPM) DrawKochCurve(n int, start, end Point, width int, color Pixel) {
+	// Width est la largeur de toutes les lignes.
+	points := kochCurve(n, pointF{float64(start.X), float64(start.Y)}, pointF{float64(end.X), float64(end.Y)})
+	points = append(points, pointF{float64(end.X), float64(end.Y)})
+	for i := 0; i < len(points)-1; i++ {
+		ppm.drawThickLine(points[i].toPoint(), points[i+1].toPoint(), width, color)
+	}
+}
 
-		// Dessiner récursivement les quatre segments
-		ppm.DrawKochSnowflake(n-1, start, p1, width, color)
-		ppm.DrawKochSnowflake(n-1, p1, p2, width, color)
-		ppm.DrawKochSnowflake(n-1, p2, p3, width, color)
-		ppm.DrawKochSnowflake(n-1, p3, end, width, color)
+// kochCurve renvoie les sommets de la courbe de Koch de a vers b, sans le point b.
+func kochCurve(n int, a, b pointF) []pointF {
+	if n <= 0 {
+		return []pointF{a}
 	}
+	dx, dy := b.X-a.X, b.Y-a.Y
+
+	// Calculer les points pour les segments
+	p1 := pointF{a.X + dx/3, a.Y + dy/3}
+	p3 := pointF{a.X + 2*dx/3, a.Y + 2*dy/3}
+	p2 := pointF{(a.X+b.X)/2 + dy*math.Sqrt(3)/6, (a.Y+b.Y)/2 - dx*math.Sqrt(3)/6}
+
+	// Calculer récursivement les quatre segments
+	points := kochCurve(n-1, a, p1)
+	points = append(points, kochCurve(n-1, p1, p2)...)
+	points = append(points, kochCurve(n-1, p2, p3)...)
+	return append(points, kochCurve(n-1, p3, b)...)
 }
 
 // DrawSierpinskiTriangle dessine un triangle de Sierpinski.
-func (ppm *PPM) DrawSierpinskiTriangle(n int, start Point, width int, color Pixel) {
+func (ppm *PPM) DrawSierpinskiTriangle(n int, start Point, width int, color Pixel, options FractalOptions) {
 	// N est le nombre d'itérations.
-	// Start est le point culminant du triangle.
+	// Start est le coin supérieur gauche du triangle, pointe en bas, de côté options.Size.
 	// Width est la largeur de toutes les lignes.
 	// Color est la couleur des lignes.
-	if n == 0 {
-		// Cas de base : dessiner un triangle
-		p1 := start
-		p2 := Point{start.X + width, start.Y}
-		p3 := Point{start.X + width/2, start.Y + int(float64(width)*math.Sqrt(3)/2)}
-
-		ppm.DrawLine(p1, p2, color)
-		ppm.DrawLine(p2, p3, color)
-		ppm.DrawLine(p3, p1, color)
-	} else {
-		// Cas récursif : divisez le triangle en trois triangles plus petits et dessinez des triangles de Sierpinski sur chacun
-		halfWidth := width / 2
-		halfHeight := int(float64(halfWidth) * math.Sqrt(3) / 2)
-
-		// Calculer les coordonnées des trois points supérieurs des petits triangles
-		top1 := start
-		top2 := Point{start.X + halfWidth, start.Y}
-		top3 := Point{start.X + halfWidth/2, start.Y + halfHeight}
-
-		// Dessiner récursivement des triangles de Sierpinski sur les trois petits triangles
-		ppm.DrawSierpinskiTriangle(n-1, top1, halfWidth, color)
-		ppm.DrawSierpinskiTriangle(n-1, top2, halfWidth, color)
-		ppm.DrawSierpinskiTriangle(n-1, top3, halfWidth, color)
+	a := pointF{float64(start.X), float64(start.Y)}
+	size := float64(options.Size)
+	b := pointF{a.X + size, a.Y}
+	c := pointF{a.X + size/2, a.Y + size*math.Sqrt(3)/2}
+	ppm.sierpinski(n, a, b.rotateAround(a, options.Angle), c.rotateAround(a, options.Angle), width, color, options)
+}
+
+// sierpinski dessine récursivement le triangle de Sierpinski de sommets a, b et c.
+func (ppm *PPM) sierpinski(n int, a, b, c pointF, width int, color Pixel, options FractalOptions) {
+	if n <= 0 {
+		// Cas de base : dessiner un triangle
+		ppm.drawFractalPolygon([]pointF{a, b, c}, width, color, options)
+		return
 	}
+
+	// Cas récursif : diviser le triangle en trois triangles plus petits, un par sommet
+	ab := pointF{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+	bc := pointF{(b.X + c.X) / 2, (b.Y + c.Y) / 2}
+	ca := pointF{(c.X + a.X) / 2, (c.Y + a.Y) / 2}
+	ppm.sierpinski(n-1, a, ab, ca, width, color, options)
+	ppm.sierpinski(n-1, ab, b, bc, width, color, options)
+	ppm.sierpinski(n-1, ca, bc, c, width, color, options)
 }
 
-func perlinNoise(x, y float64) float64 {
-	return (x + y) / 2
+// drawFractalPolygon remplit le polygone ou trace son contour avec des lignes de largeur width selon les options.
+func (ppm *PPM) drawFractalPolygon(points []pointF, width int, color Pixel, options FractalOptions) {
+	if options.Filled {
+		ppm.fillPolygonF(points, color)
+		return
+	}
+	for i, p := range points {
+		ppm.drawThickLine(p.toPoint(), points[(i+1)%len(points)].toPoint(), width, color)
+	}
 }
 
 // DrawPerlinNoise dessine le bruit Perlin.
-// Cette fonction dessine un bruit perlin de toute l'image.
+// Cette fonction dessine un bruit perlin de toute l'image, toujours le même : c'est DrawPerlinNoiseSeed
+// avec la graine 0.
 func DrawPerlinNoise(img *image.RGBA, color1 color.Color, color2 color.Color) {
+	DrawPerlinNoiseSeed(img, color1, color2, 0)
+}
+
+// DrawPerlinNoiseSeed dessine le bruit Perlin de graine seed sur toute l'image ; une même graine donne
+// toujours le même bruit.
+func DrawPerlinNoiseSeed(img *image.RGBA, color1 color.Color, color2 color.Color, seed int64) {
 	// Color1 est la couleur de 0.
 	// Color2 est la couleur de 1.
+	noise2D := newPerlin(seed)
 	bounds := img.Bounds()
 	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
 		for x := bounds.Min.X; x < bounds.Max.X; x++ {
 			// Générer du bruit Perlin
-			noise := perlinNoise(float64(x), float64(y))
+			noise := noise2D.at(float64(x)/perlinCellSize, float64(y)/perlinCellSize)
 
 			// Calculer le coefficient d'interpolation
 			t := 0.5 + 0.5*math.Cos(math.Pi*noise)
@@ -616,8 +662,22 @@ func DrawPerlinNoise(img *image.RGBA, color1 color.Color, color2 color.Color) {
 	}
 }
 
+// DrawPerlinNoiseColormap dessine le bruit Perlin de graine seed sur toute l'image PPM, en le colorant
+// avec la carte de couleurs.
+func (ppm *PPM) DrawPerlinNoiseColormap(colormap Colormap, seed int64) {
+	ppm.own()
+	noise2D := newPerlin(seed)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			t := 0.5 + 0.5*math.Cos(math.Pi*noise2D.at(float64(x)/perlinCellSize, float64(y)/perlinCellSize))
+			ppm.data[y][x] = colormap.Lookup(t)
+		}
+	}
+}
+
 // KNearestNeighbors redimensionne l'image PPM à l'aide de l'algorithme des k-voisins les plus proches.
 func (ppm *PPM) KNearestNeighbors(newWidth, newHeight int) {
+	ppm.own()
 	// Vérifier les dimensions valides
 	if newWidth <= 0 || newHeight <= 0 {
 		fmt.Println("Les dimensions de redimensionnement doivent être positives.")
@@ -653,11 +713,16 @@ func (ppm *PPM) KNearestNeighbors(newWidth, newHeight int) {
 // NewPPM crée une nouvelle instance de PPM.
 func NewPPM(width, height, maxColorValue int) *PPM {
 	// Initialiser et retournez une nouvelle instance de PPM avec les dimensions spécifiées.
+	data := make([][]Pixel, height)
+	for y := range data {
+		data[y] = make([]Pixel, width)
+	}
 	return &PPM{
-		width:  width,
-		height: height,
-		max:    maxColorValue,
-		data:   [][]Pixel{},
+		width:       width,
+		height:      height,
+		magicNumber: "P3",
+		max:         maxColorValue,
+		data:        data,
 	}
 }
 
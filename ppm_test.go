@@ -497,14 +497,16 @@ func TestPPMSetMaxValue(t *testing.T) {
 	for i := 0; i < imagePPMWidth*imagePPMHeight; i++ {
 		x := i % imagePPMWidth
 		y := i / imagePPMWidth
-		if ppm.data[y][x].R != uint8(float64(imagePPMData[i].R)*float64(ppm.max)/float64(oldMax)) {
-			t.Errorf("Red value at (%d, %d) not converted correctly wanted %d got %d", x, y, uint8(float64(imagePPMData[i].R)*float64(ppm.max)/float64(oldMax)), ppm.data[y][x].R)
+		// Valeurs arrondies au plus proche
+		want := func(v uint8) uint8 { return uint8((int(v)*128 + oldMax/2) / oldMax) }
+		if ppm.data[y][x].R != want(imagePPMData[i].R) {
+			t.Errorf("Red value at (%d, %d) not converted correctly wanted %d got %d", x, y, want(imagePPMData[i].R), ppm.data[y][x].R)
 		}
-		if ppm.data[y][x].G != uint8(float64(imagePPMData[i].G)*float64(ppm.max)/float64(oldMax)) {
-			t.Errorf("Green value at (%d, %d) not converted correctly wanted %d got %d", x, y, uint8(float64(imagePPMData[i].G)*float64(ppm.max)/float64(oldMax)), ppm.data[y][x].G)
+		if ppm.data[y][x].G != want(imagePPMData[i].G) {
+			t.Errorf("Green value at (%d, %d) not converted correctly wanted %d got %d", x, y, want(imagePPMData[i].G), ppm.data[y][x].G)
 		}
-		if ppm.data[y][x].B != uint8(float64(imagePPMData[i].B)*float64(ppm.max)/float64(oldMax)) {
-			t.Errorf("Blue value at (%d, %d) not converted correctly wanted %d got %d", x, y, uint8(float64(imagePPMData[i].B)*float64(ppm.max)/float64(oldMax)), ppm.data[y][x].B)
+		if ppm.data[y][x].B != want(imagePPMData[i].B) {
+			t.Errorf("Blue value at (%d, %d) not converted correctly wanted %d got %d", x, y, want(imagePPMData[i].B), ppm.data[y][x].B)
 		}
 	}
 }
@@ -737,3 +739,98 @@ func TestPPMDrawFilledPolygon(t *testing.T) {
 		}
 	}
 }
+
+// countColor compte les pixels de l'image PPM qui ont la couleur donnée.
+func countColor(ppm *PPM, color Pixel) int {
+	count := 0
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			if ppm.data[y][x] == color {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestPPMDrawSierpinskiTriangle(t *testing.T) {
+	green := Pixel{R: 0, G: 255, B: 0}
+	ppm := NewPPM(40, 40, 255)
+	ppm.DrawSierpinskiTriangle(0, Point{X: 10, Y: 2}, 1, green, FractalOptions{Size: 20})
+	if ppm.At(10, 2) != green || ppm.At(30, 2) != green || ppm.At(20, 2) != green || ppm.At(20, 19) != green {
+		t.Error("Triangle outline not drawn")
+	}
+	if ppm.At(20, 8) == green || ppm.At(20, 1) == green {
+		t.Error("Outline should not be filled")
+	}
+
+	thick := NewPPM(40, 40, 255)
+	thick.DrawSierpinskiTriangle(0, Point{X: 10, Y: 2}, 3, green, FractalOptions{Size: 20})
+	if thick.At(20, 1) != green || thick.At(20, 3) != green || thick.At(20, 5) == green {
+		t.Error("Line width not honored")
+	}
+
+	// Tourné d'un demi-tour autour de start, le triangle pointe vers le haut
+	ppm = NewPPM(40, 40, 255)
+	ppm.DrawSierpinskiTriangle(1, Point{X: 36, Y: 30}, 1, green, FractalOptions{Size: 32, Angle: 180, Filled: true})
+	if ppm.At(20, 8) != green || ppm.At(12, 26) != green || ppm.At(28, 26) != green {
+		t.Error("Corner triangles not filled")
+	}
+	if ppm.At(20, 24) == green {
+		t.Error("Middle triangle should stay empty")
+	}
+}
+
+func TestPPMDrawKochSnowflake(t *testing.T) {
+	green := Pixel{R: 0, G: 255, B: 0}
+	start, end := Point{X: 10, Y: 15}, Point{X: 50, Y: 15}
+	triangle := NewPPM(60, 60, 255)
+	triangle.DrawKochSnowflake(0, start, end, 1, green, FractalOptions{Filled: true})
+	snowflake := NewPPM(60, 60, 255)
+	snowflake.DrawKochSnowflake(2, start, end, 1, green, FractalOptions{Filled: true})
+	if countColor(snowflake, green) <= countColor(triangle, green) {
+		t.Error("Snowflake should cover more pixels than its base triangle")
+	}
+	// Le triangle est sous le côté de start à end, la pointe ajoutée au-dessus
+	if triangle.At(30, 40) != green || triangle.At(30, 12) == green || snowflake.At(30, 12) != green {
+		t.Error("Snowflake bump missing above the first side")
+	}
+
+	// De droite à gauche, le triangle est au-dessus du côté
+	reversed := NewPPM(60, 60, 255)
+	reversed.DrawKochSnowflake(0, Point{X: 50, Y: 50}, Point{X: 10, Y: 50}, 1, green, FractalOptions{Filled: true})
+	if reversed.At(30, 45) != green || reversed.At(30, 55) == green {
+		t.Error("Reversed triangle should point up")
+	}
+	// Un demi-tour autour de start envoie le triangle à gauche et au-dessus de start
+	rotated := NewPPM(60, 60, 255)
+	rotated.DrawKochSnowflake(0, Point{X: 50, Y: 50}, Point{X: 90, Y: 50}, 1, green, FractalOptions{Angle: 180, Filled: true})
+	if rotated.At(30, 40) != green || rotated.At(30, 55) == green || rotated.At(55, 40) == green {
+		t.Error("Rotated triangle should point up, left of start")
+	}
+
+	outline := NewPPM(60, 60, 255)
+	outline.DrawKochSnowflake(1, start, end, 1, green, FractalOptions{})
+	if outline.At(10, 15) != green || outline.At(30, 30) == green {
+		t.Error("Outline not drawn correctly")
+	}
+	thick := NewPPM(60, 60, 255)
+	thick.DrawKochSnowflake(1, start, end, 3, green, FractalOptions{})
+	if countColor(thick, green) <= countColor(outline, green) {
+		t.Error("Line width not honored")
+	}
+}
+
+func TestPPMDrawKochCurve(t *testing.T) {
+	green := Pixel{R: 0, G: 255, B: 0}
+	ppm := NewPPM(20, 20, 255)
+	ppm.DrawKochCurve(0, Point{X: 2, Y: 10}, Point{X: 17, Y: 10}, 3, green)
+	for x := 2; x <= 17; x++ {
+		if ppm.At(x, 9) != green || ppm.At(x, 10) != green || ppm.At(x, 11) != green {
+			t.Errorf("Line thickness not honored at x=%d", x)
+		}
+	}
+	if ppm.At(10, 8) == green || ppm.At(10, 12) == green {
+		t.Error("Line is too thick")
+	}
+}
@@ -0,0 +1,76 @@
+package Netpbm // ✨ Export pour imprimantes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ESCPOSOptions règle l'export de ToESCPOSOptions.
+type ESCPOSOptions struct {
+	BandHeight int  // Nombre maximal de lignes par commande d'image (les imprimantes limitent la taille d'une commande)
+	Feed       int  // Nombre de lignes de texte avancées après l'image
+	Cut        bool // Coupe partielle du papier à la fin
+}
+
+// DefaultESCPOSOptions convient aux imprimantes thermiques de tickets courantes (58 et 80 mm).
+var DefaultESCPOSOptions = ESCPOSOptions{BandHeight: 256, Feed: 3, Cut: true}
+
+// ToESCPOS écrit l'image PBM sous forme de commandes ESC/POS pour une imprimante thermique de tickets,
+// avec les options par défaut. L'image doit déjà avoir la largeur de la tête d'impression (384 points
+// pour 58 mm, 576 pour 80 mm à 203 dpi), par exemple grâce à PrepareForEInk.
+func (pbm *PBM) ToESCPOS(w io.Writer) error {
+	return pbm.ToESCPOSOptions(w, DefaultESCPOSOptions)
+}
+
+// ToESCPOSOptions écrit l'image PBM sous forme de commandes ESC/POS selon options : initialisation de
+// l'imprimante (ESC @), puis l'image en bandes de commandes raster GS v 0, chaque pixel noir étant un
+// point imprimé.
+func (pbm *PBM) ToESCPOSOptions(w io.Writer, options ESCPOSOptions) error {
+	rowBytes := (pbm.width + 7) / 8
+	if rowBytes > 0xFFFF {
+		return fmt.Errorf("image too wide for ESC/POS: %d pixels", pbm.width)
+	}
+	band := min(max(options.BandHeight, 1), 0xFFFF)
+	packed := pbm.Pack()
+
+	writer := bufio.NewWriter(w)
+	writer.Write([]byte{0x1B, '@'})
+	for y := 0; y < pbm.height; y += band {
+		rows := min(band, pbm.height-y)
+		writer.Write([]byte{0x1D, 'v', '0', 0, byte(rowBytes), byte(rowBytes >> 8), byte(rows), byte(rows >> 8)})
+		for _, row := range packed.data[y : y+rows] {
+			writer.Write(row)
+		}
+	}
+	if options.Feed > 0 {
+		writer.Write([]byte{0x1B, 'd', byte(min(options.Feed, 255))})
+	}
+	if options.Cut {
+		writer.Write([]byte{0x1D, 'V', 1})
+	}
+	return writer.Flush()
+}
+
+// ToPCL écrit l'image PBM sous forme de page PCL 5 en mode raster, imprimée à dpi points par pouce
+// (75, 100, 150, 200, 300 ou 600) depuis le coin supérieur gauche de la zone imprimable. Les octets
+// blancs en fin de ligne ne sont pas envoyés.
+func (pbm *PBM) ToPCL(w io.Writer, dpi int) error {
+	switch dpi {
+	case 75, 100, 150, 200, 300, 600:
+	default:
+		return fmt.Errorf("unsupported PCL resolution %d dpi", dpi)
+	}
+	packed := pbm.Pack()
+
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "\x1bE\x1b*t%dR\x1b*r%dS\x1b*r0A", dpi, pbm.width)
+	for _, row := range packed.data {
+		row = bytes.TrimRight(row, "\x00")
+		fmt.Fprintf(writer, "\x1b*b%dW", len(row))
+		writer.Write(row)
+	}
+	writer.WriteString("\x1b*rB\x1bE")
+	return writer.Flush()
+}
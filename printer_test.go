@@ -0,0 +1,66 @@
+package Netpbm // 🧪 Test Export pour imprimantes
+
+import (
+	"bytes"
+	"testing"
+)
+
+// newPrinterTestPBM renvoie une image 10×3 : un pixel noir en haut à gauche, la deuxième ligne blanche
+// et la dernière entièrement noire.
+func newPrinterTestPBM() *PBM {
+	pbm := NewPBM(10, 3)
+	pbm.Set(0, 0, true)
+	for x := 0; x < 10; x++ {
+		pbm.Set(x, 2, true)
+	}
+	return pbm
+}
+
+func TestToESCPOS(t *testing.T) {
+	var out bytes.Buffer
+	if err := newPrinterTestPBM().ToESCPOS(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0x1B, '@',
+		0x1D, 'v', '0', 0, 2, 0, 3, 0,
+		0x80, 0x00, 0x00, 0x00, 0xFF, 0xC0,
+		0x1B, 'd', 3,
+		0x1D, 'V', 1,
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("ToESCPOS = % x\nattendu     % x", out.Bytes(), want)
+	}
+}
+
+func TestToESCPOSBands(t *testing.T) {
+	var out bytes.Buffer
+	options := ESCPOSOptions{BandHeight: 2}
+	if err := newPrinterTestPBM().ToESCPOSOptions(&out, options); err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0x1B, '@',
+		0x1D, 'v', '0', 0, 2, 0, 2, 0, 0x80, 0x00, 0x00, 0x00,
+		0x1D, 'v', '0', 0, 2, 0, 1, 0, 0xFF, 0xC0,
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("ToESCPOSOptions = % x\nattendu            % x", out.Bytes(), want)
+	}
+}
+
+func TestToPCL(t *testing.T) {
+	var out bytes.Buffer
+	if err := newPrinterTestPBM().ToPCL(&out, 300); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1bE\x1b*t300R\x1b*r10S\x1b*r0A" +
+		"\x1b*b1W\x80" + "\x1b*b0W" + "\x1b*b2W\xff\xc0" +
+		"\x1b*rB\x1bE"
+	if out.String() != want {
+		t.Errorf("ToPCL = %q\nattendu %q", out.String(), want)
+	}
+	if err := newPrinterTestPBM().ToPCL(&out, 250); err == nil {
+		t.Error("une résolution non prise en charge doit être refusée")
+	}
+}
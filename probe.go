@@ -0,0 +1,140 @@
+package Netpbm // ✨ Lecture de l'en-tête seul
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Info décrit une image Netpbm d'après son seul en-tête.
+type Info struct {
+	MagicNumber   string            // "P1" à "P6"
+	Width, Height int               // Dimensions de l'image
+	Max           int               // Valeur maximale d'un échantillon (1 pour une image PBM)
+	Offset        int64             // Position du premier octet des pixels depuis le début du flux
+	Metadata      map[string]string // Commentaires "# clé: valeur" de l'en-tête
+}
+
+// Binary indique si les pixels sont stockés en binaire (P4, P5, P6) plutôt qu'en texte.
+func (info Info) Binary() bool {
+	return info.MagicNumber >= "P4"
+}
+
+// Channels renvoie le nombre d'échantillons par pixel : 3 pour une image PPM, 1 sinon.
+func (info Info) Channels() int {
+	if info.MagicNumber == "P3" || info.MagicNumber == "P6" {
+		return 3
+	}
+	return 1
+}
+
+// RowSize renvoie la taille en octets d'une ligne de pixels binaire, ou 0 pour un format texte.
+func (info Info) RowSize() int {
+	switch {
+	case !info.Binary():
+		return 0
+	case info.MagicNumber == "P4":
+		return (info.Width + 7) / 8
+	case info.Max > 255:
+		return info.Width * info.Channels() * 2
+	}
+	return info.Width * info.Channels()
+}
+
+// ProbeHeader lit uniquement l'en-tête d'une image Netpbm et renvoie son format, ses dimensions, sa
+// valeur maximale et la position des pixels, sans lire les pixels eux-mêmes : r est ensuite
+// positionné sur le premier octet des pixels. Les champs de l'en-tête peuvent être séparés par des
+// espaces ou des retours à la ligne et entrecoupés de commentaires.
+func ProbeHeader(r io.Reader) (Info, error) {
+	reader := &countingReader{reader: r}
+	info := Info{Metadata: make(map[string]string)}
+
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(reader, magic); err != nil {
+		return info, fmt.Errorf("error reading magic number: %v", err)
+	}
+	info.MagicNumber = string(magic)
+	if magic[0] != 'P' || magic[1] < '1' || magic[1] > '6' {
+		return info, fmt.Errorf("invalid magic number: %q", magic)
+	}
+
+	fields := []*int{&info.Width, &info.Height, &info.Max}
+	names := []string{"width", "height", "max value"}
+	if info.MagicNumber == "P1" || info.MagicNumber == "P4" {
+		fields, info.Max = fields[:2], 1
+	}
+	for i, field := range fields {
+		token, err := reader.headerToken(info.Metadata)
+		if err != nil {
+			return info, fmt.Errorf("error reading %s: %v", names[i], err)
+		}
+		if *field, err = strconv.Atoi(token); err != nil || *field <= 0 {
+			return info, fmt.Errorf("invalid %s: %q", names[i], token)
+		}
+	}
+	if info.Max > 65535 {
+		return info, fmt.Errorf("invalid max value: %d", info.Max)
+	}
+	// Un seul caractère blanc sépare l'en-tête des pixels, déjà lu avec le dernier champ
+	info.Offset = reader.count
+	return info, nil
+}
+
+// countingReader lit l'en-tête octet par octet, pour ne rien lire au-delà, et compte les octets lus.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+	buf    [1]byte
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(c, c.buf[:]); err != nil {
+		return 0, err
+	}
+	return c.buf[0], nil
+}
+
+// headerToken lit le prochain champ de l'en-tête, en sautant les blancs et les commentaires, ainsi que
+// le caractère blanc qui le termine. Les commentaires "# clé: valeur" sont ajoutés à metadata.
+func (c *countingReader) headerToken(metadata map[string]string) (string, error) {
+	var token []byte
+	for {
+		b, err := c.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(token) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+		switch {
+		case b == '#' && len(token) == 0:
+			var comment []byte
+			for b != '\n' && b != '\r' {
+				if b, err = c.ReadByte(); err != nil {
+					return "", err
+				}
+				comment = append(comment, b)
+			}
+			key, value, found := strings.Cut(strings.TrimSpace(string(comment)), ":")
+			if key = strings.TrimSpace(key); found && key != "" {
+				metadata[key] = strings.TrimSpace(value)
+			}
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f':
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			if len(token) >= 10 {
+				return "", fmt.Errorf("header field too long")
+			}
+			token = append(token, b)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package Netpbm // 🧪 Test lecture de l'en-tête seul
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestProbeHeader(t *testing.T) {
+	r := strings.NewReader("P6\n# author: me\n3 2\n# fin\n255\n" + strings.Repeat("x", 18))
+	info, err := ProbeHeader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.MagicNumber != "P6" || info.Width != 3 || info.Height != 2 || info.Max != 255 || info.Offset != 30 {
+		t.Errorf("Unexpected info %+v", info)
+	}
+	if info.Metadata["author"] != "me" || info.RowSize() != 9 || !info.Binary() || info.Channels() != 3 {
+		t.Errorf("Unexpected derived info %+v", info)
+	}
+	// Le flux est positionné sur les pixels
+	if rest, _ := io.ReadAll(r); len(rest) != 18 {
+		t.Errorf("Expected the 18 pixel bytes to remain, got %d", len(rest))
+	}
+
+	// En-tête sur une seule ligne, image PBM sans valeur maximale
+	info, err = ProbeHeader(strings.NewReader("P4 17 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Max != 1 || info.RowSize() != 3 || info.Offset != 8 {
+		t.Errorf("Unexpected PBM info %+v", info)
+	}
+	info, _ = ProbeHeader(strings.NewReader("P5 4 4 65535 "))
+	if info.RowSize() != 8 {
+		t.Errorf("16-bit samples should use two bytes, got a row size of %d", info.RowSize())
+	}
+}
+
+func TestProbeHeaderSavedFile(t *testing.T) {
+	pgm := newGradientPGM(10, 6)
+	pgm.SetMagicNumber("P5")
+	pgm.SetMetadata(MetadataCreator, "test")
+	filename := "./testImages/pgm/probe.pgm"
+	if err := pgm.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	content, _ := os.ReadFile(filename)
+	info, err := ProbeHeader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Offset != int64(len(content)-10*6) || info.Metadata[MetadataCreator] != "test" {
+		t.Errorf("Unexpected info %+v for a %d bytes file", info, len(content))
+	}
+}
+
+func TestProbeHeaderErrors(t *testing.T) {
+	for _, header := range []string{"", "P7\n1 1\n", "P5\n0 4\n255\n", "P5\n4 4\n70000\n", "P5\n4 4", "P2\nfour 4\n255\n"} {
+		if _, err := ProbeHeader(strings.NewReader(header)); err == nil {
+			t.Errorf("Expected an error for %q", header)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package Netpbm // ✨ Pyramides d'images
+
+// BuildPyramid renvoie une pyramide d'images : une copie de l'image PPM, puis levels réductions successives de moitié.
+// La construction s'arrête plus tôt si l'image atteint 1×1.
+func (ppm *PPM) BuildPyramid(levels int, filter Interpolation) []*PPM {
+	pyramid := []*PPM{ppm.Resize(ppm.width, ppm.height, NearestNeighbor)}
+	for i := 0; i < levels; i++ {
+		last := pyramid[len(pyramid)-1]
+		if last.width <= 1 && last.height <= 1 {
+			break
+		}
+		pyramid = append(pyramid, last.Resize((last.width+1)/2, (last.height+1)/2, filter))
+	}
+	return pyramid
+}
+
+// BuildPyramid renvoie une pyramide d'images : une copie de l'image PGM, puis levels réductions successives de moitié.
+// La construction s'arrête plus tôt si l'image atteint 1×1.
+func (pgm *PGM) BuildPyramid(levels int, filter Interpolation) []*PGM {
+	pyramid := []*PGM{pgm.Resize(pgm.width, pgm.height, NearestNeighbor)}
+	for i := 0; i < levels; i++ {
+		last := pyramid[len(pyramid)-1]
+		if last.width <= 1 && last.height <= 1 {
+			break
+		}
+		pyramid = append(pyramid, last.Resize((last.width+1)/2, (last.height+1)/2, filter))
+	}
+	return pyramid
+}
+
+// rgbPlane est une image en couleurs à valeurs réelles, qui peuvent être négatives ou dépasser la valeur maximale.
+type rgbPlane struct {
+	width, height int
+	data          [][][3]float64
+}
+
+// newRGBPlane crée un plan de dimensions width×height rempli de zéros.
+func newRGBPlane(width, height int) *rgbPlane {
+	data := make([][][3]float64, height)
+	for y := range data {
+		data[y] = make([][3]float64, width)
+	}
+	return &rgbPlane{width, height, data}
+}
+
+// planeFromPPM convertit l'image PPM en plan réel.
+func planeFromPPM(ppm *PPM) *rgbPlane {
+	plane := newRGBPlane(ppm.width, ppm.height)
+	for y := 0; y < ppm.height; y++ {
+		for x, pixel := range ppm.data[y] {
+			plane.data[y][x] = [3]float64{float64(pixel.R), float64(pixel.G), float64(pixel.B)}
+		}
+	}
+	return plane
+}
+
+// toPPM arrondit le plan en image PPM de valeur maximale maxValue.
+func (plane *rgbPlane) toPPM(maxValue int) *PPM {
+	ppm := NewPPM(plane.width, plane.height, maxValue)
+	for y := 0; y < plane.height; y++ {
+		for x, v := range plane.data[y] {
+			ppm.data[y][x] = Pixel{clampChannel(v[0], maxValue), clampChannel(v[1], maxValue), clampChannel(v[2], maxValue)}
+		}
+	}
+	return ppm
+}
+
+// resize renvoie une copie du plan redimensionnée par interpolation bilinéaire.
+func (plane *rgbPlane) resize(width, height int) *rgbPlane {
+	resized := newRGBPlane(width, height)
+	xTaps := axisTaps(plane.width, width, Bilinear)
+	yTaps := axisTaps(plane.height, height, Bilinear)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var v [3]float64
+			for _, ty := range yTaps[y] {
+				for _, tx := range xTaps[x] {
+					w := ty.weight * tx.weight
+					s := plane.data[ty.index][tx.index]
+					v[0] += w * s[0]
+					v[1] += w * s[1]
+					v[2] += w * s[2]
+				}
+			}
+			resized.data[y][x] = v
+		}
+	}
+	return resized
+}
+
+// combine renvoie plane + sign*other, pixel à pixel (les deux plans ont la même taille).
+func (plane *rgbPlane) combine(other *rgbPlane, sign float64) *rgbPlane {
+	result := newRGBPlane(plane.width, plane.height)
+	for y := range result.data {
+		for x := range result.data[y] {
+			for c := 0; c < 3; c++ {
+				result.data[y][x][c] = plane.data[y][x][c] + sign*other.data[y][x][c]
+			}
+		}
+	}
+	return result
+}
+
+// LaplacianPyramid représente une image décomposée en bandes de détails de plus en plus grossières.
+// Le dernier niveau contient l'image réduite elle-même.
+type LaplacianPyramid struct {
+	levels []*rgbPlane // Bandes de détails, de la plus fine à la plus grossière
+	max    int         // Valeur maximale de l'image d'origine
+}
+
+// gaussianPlanes renvoie le plan suivi de levels réductions successives de moitié au plus.
+func gaussianPlanes(plane *rgbPlane, levels int) []*rgbPlane {
+	gaussian := []*rgbPlane{plane}
+	for i := 0; i < levels; i++ {
+		last := gaussian[len(gaussian)-1]
+		if last.width <= 1 && last.height <= 1 {
+			break
+		}
+		gaussian = append(gaussian, last.resize((last.width+1)/2, (last.height+1)/2))
+	}
+	return gaussian
+}
+
+// BuildLaplacianPyramid décompose l'image PPM en une pyramide laplacienne de levels réductions au plus.
+func (ppm *PPM) BuildLaplacianPyramid(levels int) *LaplacianPyramid {
+	gaussian := gaussianPlanes(planeFromPPM(ppm), levels)
+
+	// Chaque bande est la différence entre un niveau et l'agrandissement du niveau suivant
+	pyramid := &LaplacianPyramid{max: ppm.max}
+	for i := 0; i < len(gaussian)-1; i++ {
+		expanded := gaussian[i+1].resize(gaussian[i].width, gaussian[i].height)
+		pyramid.levels = append(pyramid.levels, gaussian[i].combine(expanded, -1))
+	}
+	pyramid.levels = append(pyramid.levels, gaussian[len(gaussian)-1])
+	return pyramid
+}
+
+// Levels renvoie le nombre de niveaux de la pyramide, image réduite comprise.
+func (pyramid *LaplacianPyramid) Levels() int {
+	return len(pyramid.levels)
+}
+
+// Reconstruct recompose l'image PPM à partir de la pyramide.
+func (pyramid *LaplacianPyramid) Reconstruct() *PPM {
+	if len(pyramid.levels) == 0 {
+		return NewPPM(0, 0, pyramid.max)
+	}
+	image := pyramid.levels[len(pyramid.levels)-1]
+	for i := len(pyramid.levels) - 2; i >= 0; i-- {
+		band := pyramid.levels[i]
+		image = band.combine(image.resize(band.width, band.height), 1)
+	}
+	return image.toPPM(pyramid.max)
+}
@@ -0,0 +1,48 @@
+package Netpbm // 🧪 Test pyramides
+
+import "testing"
+
+func TestBuildPyramid(t *testing.T) {
+	ppm, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	pyramid := ppm.BuildPyramid(10, Bilinear)
+	sizes := []int{15, 8, 4, 2, 1}
+	if len(pyramid) != len(sizes) {
+		t.Fatalf("Wrong number of levels %d", len(pyramid))
+	}
+	for i, level := range pyramid {
+		w, h := level.Size()
+		if w != sizes[i] || h != sizes[i] {
+			t.Errorf("Level %d has size %dx%d", i, w, h)
+		}
+	}
+	if pyramid[0].Hash() != ppm.Hash() {
+		t.Error("First level should be a copy of the image")
+	}
+	pyramid[0].Set(0, 0, Pixel{1, 2, 3})
+	if ppm.At(0, 0) == (Pixel{1, 2, 3}) {
+		t.Error("First level should not share pixels with the image")
+	}
+
+	pgm := newUniformPGM(10, 6, 90)
+	levels := pgm.BuildPyramid(2, NearestNeighbor)
+	if len(levels) != 3 || levels[2].width != 3 || levels[2].height != 2 || levels[2].At(2, 1) != 90 {
+		t.Error("PGM pyramid not built correctly")
+	}
+}
+
+func TestLaplacianPyramid(t *testing.T) {
+	ppm, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	pyramid := ppm.BuildLaplacianPyramid(3)
+	if pyramid.Levels() != 4 {
+		t.Errorf("Wrong number of levels %d", pyramid.Levels())
+	}
+	if pyramid.Reconstruct().Hash() != ppm.Hash() {
+		t.Error("Reconstruction should give back the original image")
+	}
+}
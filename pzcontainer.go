@@ -0,0 +1,100 @@
+package Netpbm // ✨ Conteneur compressé PZ
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SaveCompressed enregistre l'image *PBM, *PGM ou *PPM dans le conteneur compressé PZ, comme
+// EncodeCompressed.
+func SaveCompressed(filename string, img Image) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	if err := EncodeCompressed(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// EncodeCompressed écrit l'image *PBM, *PGM ou *PPM dans le conteneur compressé PZ, une extension de
+// ce paquet qui n'est pas un format Netpbm standard : l'en-tête est celui du format binaire (P4, P5 ou
+// P6, métadonnées comprises) avec le nombre magique PZ4, PZ5 ou PZ6, suivi des pixels binaires compressés
+// par Deflate. Les grands aplats (masques, pages scannées) y prennent beaucoup moins de place.
+// ReadPBM, ReadPGM, ReadPPM et ReadNetpbm relisent ces fichiers comme des images ordinaires.
+func EncodeCompressed(w io.Writer, img Image) error {
+	// Image au format binaire correspondant, copiée sans ses pixels pour ne pas modifier l'originale
+	var binary Image
+	switch img := img.(type) {
+	case *PBM:
+		clone := *img
+		clone.magicNumber = "P4"
+		binary = &clone
+	case *PGM:
+		clone := *img
+		clone.magicNumber = "P5"
+		binary = &clone
+	case *PPM:
+		clone := *img
+		clone.magicNumber = "P6"
+		binary = &clone
+	default:
+		return fmt.Errorf("unsupported image type %T", img)
+	}
+	var encoded bytes.Buffer
+	if err := encodeImage(&encoded, binary); err != nil {
+		return err
+	}
+	info, err := ProbeHeader(bytes.NewReader(encoded.Bytes()))
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(w)
+	writer.WriteString("PZ")
+	writer.Write(encoded.Bytes()[1:info.Offset])
+	compressor, _ := flate.NewWriter(writer, flate.BestCompression)
+	if _, err := compressor.Write(encoded.Bytes()[info.Offset:]); err != nil {
+		return fmt.Errorf("error compressing pixels: %v", err)
+	}
+	if err := compressor.Close(); err != nil {
+		return fmt.Errorf("error compressing pixels: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing compressed image: %v", err)
+	}
+	return nil
+}
+
+// isCompressedMagic indique si magic commence par le nombre magique du conteneur PZ.
+func isCompressedMagic(magic []byte) bool {
+	return len(magic) >= 3 && magic[0] == 'P' && magic[1] == 'Z' && magic[2] >= '4' && magic[2] <= '6'
+}
+
+// decompressContainer lit un conteneur PZ depuis reader et renvoie le flux de l'image binaire standard
+// (P4, P5 ou P6) qu'il contient : son en-tête est relu tel quel, au nombre magique près, et ses pixels
+// décompressés à la volée. Le lecteur renvoyé doit être fermé pour libérer le décompresseur.
+func decompressContainer(reader *bufio.Reader) (io.ReadCloser, error) {
+	magic := make([]byte, 3)
+	if _, err := io.ReadFull(reader, magic); err != nil || !isCompressedMagic(magic) {
+		return nil, fmt.Errorf("invalid compressed container magic number: %q", magic)
+	}
+	// ProbeHeader lit l'en-tête octet par octet : il s'arrête au premier octet compressé
+	var header bytes.Buffer
+	source := io.TeeReader(io.MultiReader(strings.NewReader("P"+string(magic[2])), reader), &header)
+	if _, err := ProbeHeader(source); err != nil {
+		return nil, err
+	}
+	decompressor := flate.NewReader(reader)
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(&header, decompressor), decompressor}, nil
+}
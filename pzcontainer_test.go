@@ -0,0 +1,96 @@
+package Netpbm // 🧪 Test Conteneur compressé PZ
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompressedRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	mask := NewPBM(200, 100)
+	for y := 20; y < 60; y++ {
+		for x := 30; x < 170; x++ {
+			mask.Set(x, y, true)
+		}
+	}
+	gray := newUniformPGM(50, 40, 90)
+	gray.Set(3, 4, 200)
+	color := newUniformPPM(30, 20, Pixel{10, 20, 30})
+	color.Set(5, 6, Pixel{255, 0, 128})
+	magic := color.magicNumber
+
+	for _, img := range []Image{mask, gray, color} {
+		filename := filepath.Join(dir, "image.pz")
+		if err := SaveCompressed(filename, img); err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadNetpbm(filename)
+		if err != nil {
+			t.Fatalf("%T : %v", img, err)
+		}
+		switch original := img.(type) {
+		case *PBM:
+			got, ok := read.(*PBM)
+			if !ok || got.width != 200 || got.height != 100 || countBlack(got) != countBlack(original) || !got.data[20][30] || got.magicNumber != "P4" {
+				t.Errorf("image PBM relue incorrecte")
+			}
+		case *PGM:
+			got, ok := read.(*PGM)
+			if !ok || got.At(3, 4) != 200 || got.At(0, 0) != 90 || got.max != 255 || got.magicNumber != "P5" {
+				t.Errorf("image PGM relue incorrecte")
+			}
+		case *PPM:
+			got, ok := read.(*PPM)
+			if !ok || !samePixels(got, original) || got.magicNumber != "P6" {
+				t.Errorf("image PPM relue incorrecte")
+			}
+		}
+	}
+	if color.magicNumber != magic {
+		t.Errorf("nombre magique de l'original modifié : %q", color.magicNumber)
+	}
+
+	// Les lecteurs propres à chaque type acceptent aussi le conteneur
+	filename := filepath.Join(dir, "mask.pz")
+	if err := SaveCompressed(filename, mask); err != nil {
+		t.Fatal(err)
+	}
+	if pbm, err := ReadPBM(filename); err != nil || countBlack(pbm) != 40*140 {
+		t.Errorf("ReadPBM : %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("PZ4\n")) || len(data) >= 200*100/8 {
+		t.Errorf("conteneur de %d octets, commençant par %q", len(data), data[:4])
+	}
+}
+
+func TestDecodeCompressed(t *testing.T) {
+	var buf bytes.Buffer
+	gray := newUniformPGM(8, 8, 7)
+	if err := EncodeCompressed(&buf, gray); err != nil {
+		t.Fatal(err)
+	}
+	img, err := decodeNetpbm(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm, ok := img.(*PGM); !ok || pgm.At(7, 7) != 7 {
+		t.Errorf("image décodée incorrecte : %T", img)
+	}
+
+	if _, err := decodeNetpbm(bytes.NewReader([]byte("PZ5\n8 8\n255\nnot deflate"))); err == nil {
+		t.Error("des pixels non compressés doivent être refusés")
+	}
+	if _, err := decodeNetpbm(bytes.NewReader([]byte("PZ5\n8 x\n"))); err == nil {
+		t.Error("un en-tête invalide doit être refusé")
+	}
+	if err := EncodeCompressed(&buf, nil); err == nil {
+		t.Error("une image nil doit être refusée")
+	}
+}
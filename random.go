@@ -0,0 +1,73 @@
+package Netpbm // ✨ Aléatoire reproductible
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Convention du paquet : toute fonction aléatoire reçoit une graine explicite (seed int64) et ne lit
+// jamais le générateur global de math/rand. Une même graine donne donc toujours la même image, d'une
+// exécution à l'autre et quelle que soit la plateforme. Les fonctions internes qui tirent plusieurs
+// nombres reçoivent le générateur lui-même (*rand.Rand), créé une seule fois par newRandom.
+
+// newRandom renvoie le générateur pseudo-aléatoire associé à la graine seed.
+func newRandom(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// perlinCellSize est la taille, en pixels, d'une cellule de la grille du bruit de Perlin.
+const perlinCellSize = 16
+
+// perlin est un bruit de Perlin 2D dont la table de permutation dépend d'une graine.
+type perlin struct {
+	permutation [512]uint8
+}
+
+// newPerlin renvoie le bruit de Perlin associé à la graine seed.
+func newPerlin(seed int64) *perlin {
+	p := &perlin{}
+	for i, v := range newRandom(seed).Perm(256) {
+		p.permutation[i] = uint8(v)
+		p.permutation[i+256] = uint8(v)
+	}
+	return p
+}
+
+// at renvoie le bruit au point (x, y), entre -1 et 1. Il vaut 0 sur les nœuds de la grille entière.
+func (p *perlin) at(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	ix, iy := int(x0)&255, int(y0)&255
+	fade := func(t float64) float64 { return t * t * t * (t*(t*6-15) + 10) }
+	gradient := func(hash uint8, dx, dy float64) float64 {
+		// Huit directions : les axes et les diagonales
+		switch hash & 7 {
+		case 0:
+			return dx + dy
+		case 1:
+			return dx - dy
+		case 2:
+			return -dx + dy
+		case 3:
+			return -dx - dy
+		case 4:
+			return dx
+		case 5:
+			return -dx
+		case 6:
+			return dy
+		default:
+			return -dy
+		}
+	}
+	hash := func(i, j int) uint8 { return p.permutation[int(p.permutation[i])+j] }
+
+	u, v := fade(fx), fade(fy)
+	n00 := gradient(hash(ix, iy), fx, fy)
+	n10 := gradient(hash(ix+1, iy), fx-1, fy)
+	n01 := gradient(hash(ix, iy+1), fx, fy-1)
+	n11 := gradient(hash(ix+1, iy+1), fx-1, fy-1)
+	top := n00 + u*(n10-n00)
+	bottom := n01 + u*(n11-n01)
+	return math.Max(-1, math.Min(1, top+v*(bottom-top)))
+}
@@ -0,0 +1,127 @@
+package Netpbm // 🧪 Test aléatoire reproductible
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestPerlinNoise(t *testing.T) {
+	a, b := newPerlin(7), newPerlin(8)
+	differ := false
+	for i := 0; i < 200; i++ {
+		x, y := float64(i)*0.37, float64(i)*0.21
+		v := a.at(x, y)
+		if v < -1 || v > 1 {
+			t.Fatalf("Noise %v out of range at (%v, %v)", v, x, y)
+		}
+		if v != newPerlin(7).at(x, y) {
+			t.Fatal("The same seed should give the same noise")
+		}
+		differ = differ || v != b.at(x, y)
+	}
+	if !differ {
+		t.Error("Different seeds should give different noise")
+	}
+	if a.at(3, 5) != 0 {
+		t.Error("The noise should be zero on grid nodes")
+	}
+	// Le bruit est continu : deux points voisins ont des valeurs proches
+	if math.Abs(a.at(2.5, 2.5)-a.at(2.501, 2.5)) > 0.01 {
+		t.Error("The noise should be continuous")
+	}
+}
+
+func TestDrawPerlinNoiseSeed(t *testing.T) {
+	draw := func(seed int64) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+		DrawPerlinNoiseSeed(img, color.Black, color.White, seed)
+		return img
+	}
+	if string(draw(3).Pix) != string(draw(3).Pix) {
+		t.Error("The same seed should draw the same image")
+	}
+	if string(draw(3).Pix) == string(draw(4).Pix) {
+		t.Error("Different seeds should draw different images")
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	DrawPerlinNoise(img, color.Black, color.White)
+	if string(img.Pix) != string(draw(0).Pix) {
+		t.Error("DrawPerlinNoise should draw the noise of seed 0")
+	}
+}
+
+func TestSeededFeaturesAreReproducible(t *testing.T) {
+	features := map[string]func(seed int64) string{
+		"BlueNoiseMask": func(seed int64) string { return BlueNoiseMask(16, seed).Hash() },
+		"GenerateMaze":  func(seed int64) string { return fmt.Sprint(GenerateMaze(8, 8, MazePrim, seed).data) },
+		"AddGaussianNoise": func(seed int64) string {
+			pgm := newUniformPGM(16, 16, 128)
+			pgm.AddGaussianNoise(20, seed)
+			return pgm.Hash()
+		},
+		"AddSaltPepperNoise": func(seed int64) string {
+			pgm := newUniformPGM(16, 16, 128)
+			pgm.AddSaltPepperNoise(0.2, seed)
+			return pgm.Hash()
+		},
+		"AddPoissonNoise": func(seed int64) string {
+			ppm := newPatternPPM(16, 16)
+			ppm.AddPoissonNoise(seed)
+			return ppm.Hash()
+		},
+		"ScanlineGlitch": func(seed int64) string {
+			ppm := newPatternPPM(16, 16)
+			ppm.ScanlineGlitch(seed)
+			return ppm.Hash()
+		},
+		"DrawPerlinNoiseColormap": func(seed int64) string {
+			ppm := NewPPM(40, 40, 255)
+			ppm.DrawPerlinNoiseColormap(Viridis, seed)
+			return ppm.Hash()
+		},
+		"Stipple": func(seed int64) string {
+			pgm := newUniformPGM(16, 16, 100)
+			return fmt.Sprint(pgm.Stipple(20, seed))
+		},
+	}
+	for name, feature := range features {
+		if feature(42) != feature(42) {
+			t.Errorf("%s should give the same result for the same seed", name)
+		}
+		if feature(42) == feature(43) {
+			t.Errorf("%s should depend on the seed", name)
+		}
+	}
+}
+
+func TestTiledFlushOrder(t *testing.T) {
+	store := &orderedTileStore{}
+	ti := NewTiledImage(64, 64, 16, 255, store, 16)
+	for _, p := range []Point{{50, 50}, {5, 40}, {40, 5}, {5, 5}} {
+		ti.Set(p.X, p.Y, 1)
+	}
+	if err := ti.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := [][2]int{{0, 0}, {2, 0}, {0, 2}, {3, 3}}
+	for i := range expected {
+		if store.order[i] != expected[i] {
+			t.Fatalf("Tiles should be stored row by row, got %v", store.order)
+		}
+	}
+}
+
+// orderedTileStore retient l'ordre des tuiles enregistrées.
+type orderedTileStore struct {
+	order [][2]int
+}
+
+func (s *orderedTileStore) Load(tx, ty int) ([]uint8, bool, error) { return nil, false, nil }
+
+func (s *orderedTileStore) Store(tx, ty int, data []uint8) error {
+	s.order = append(s.order, [2]int{tx, ty})
+	return nil
+}
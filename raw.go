@@ -0,0 +1,77 @@
+package Netpbm // ✨ Données brutes sans en-tête
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// ReadRawGray lit une image en niveaux de gris sans en-tête (vidage de capteur, données scientifiques) :
+// width×height échantillons de bitDepth bits (1 à 16), ligne par ligne. Jusqu'à 8 bits, chaque échantillon
+// occupe un octet ; au-delà, deux octets, dans l'ordre donné par bigEndian.
+// Comme une image PGM stocke des octets, les échantillons de plus de 8 bits sont ramenés sur 0-255.
+func ReadRawGray(r io.Reader, width, height, bitDepth int, bigEndian bool) (*PGM, error) {
+	if bitDepth < 1 || bitDepth > 16 {
+		return nil, fmt.Errorf("unsupported bit depth %d", bitDepth)
+	}
+	if width < 0 || height < 0 {
+		return nil, fmt.Errorf("invalid size %dx%d", width, height)
+	}
+	size := 1
+	maxValue := 1<<uint(bitDepth) - 1
+	pgm := NewPGM(width, height, maxValue)
+	if bitDepth > 8 {
+		size, pgm.max = 2, 255
+	}
+	pgm.magicNumber = "P5"
+
+	row := make([]byte, width*size)
+	reader := bufio.NewReader(r)
+	for y := 0; y < height; y++ {
+		if _, err := io.ReadFull(reader, row); err != nil {
+			return nil, fmt.Errorf("error reading row %d: %v", y, err)
+		}
+		for x := 0; x < width; x++ {
+			var v int
+			if size == 1 {
+				v = int(row[x])
+			} else if bigEndian {
+				v = int(row[2*x])<<8 | int(row[2*x+1])
+			} else {
+				v = int(row[2*x]) | int(row[2*x+1])<<8
+			}
+			if v > maxValue {
+				return nil, fmt.Errorf("sample %d at (%d, %d) exceeds %d bits", v, x, y, bitDepth)
+			}
+			if size == 2 {
+				v = (v*255 + maxValue/2) / maxValue
+			}
+			pgm.data[y][x] = uint8(v)
+		}
+	}
+	return pgm, nil
+}
+
+// WriteRawGray écrit les pixels de l'image PGM sans en-tête, ligne par ligne, sur bitDepth bits (1 à 16),
+// au même format que ReadRawGray. Les valeurs sont ramenées de 0-max à 0-(2^bitDepth - 1).
+func (pgm *PGM) WriteRawGray(w io.Writer, bitDepth int, bigEndian bool) error {
+	if bitDepth < 1 || bitDepth > 16 {
+		return fmt.Errorf("unsupported bit depth %d", bitDepth)
+	}
+	maxValue := 1<<uint(bitDepth) - 1
+	writer := bufio.NewWriter(w)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			v := (int(pgm.data[y][x])*maxValue + pgm.max/2) / max(pgm.max, 1)
+			switch {
+			case bitDepth <= 8:
+				writer.WriteByte(byte(v))
+			case bigEndian:
+				writer.Write([]byte{byte(v >> 8), byte(v)})
+			default:
+				writer.Write([]byte{byte(v), byte(v >> 8)})
+			}
+		}
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,72 @@
+package Netpbm // 🧪 Test données brutes sans en-tête
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadRawGray8(t *testing.T) {
+	pgm, err := ReadRawGray(bytes.NewReader([]byte{0, 10, 20, 30, 40, 50}), 3, 2, 8, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgm.At(2, 1) != 50 || pgm.At(0, 1) != 30 || pgm.max != 255 {
+		t.Errorf("Unexpected image: %v", pgm.data)
+	}
+}
+
+func TestReadRawGray16(t *testing.T) {
+	big := []byte{0x00, 0x00, 0x0F, 0xFF, 0x08, 0x00}
+	little := []byte{0x00, 0x00, 0xFF, 0x0F, 0x00, 0x08}
+	for _, c := range []struct {
+		data      []byte
+		bigEndian bool
+	}{{big, true}, {little, false}} {
+		pgm, err := ReadRawGray(bytes.NewReader(c.data), 3, 1, 12, c.bigEndian)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pgm.At(0, 0) != 0 || pgm.At(1, 0) != 255 || pgm.At(2, 0) != 128 {
+			t.Errorf("bigEndian=%v: unexpected samples %v", c.bigEndian, pgm.data[0])
+		}
+	}
+	if _, err := ReadRawGray(bytes.NewReader(big), 3, 1, 10, true); err == nil {
+		t.Error("Expected an error for samples exceeding the bit depth")
+	}
+}
+
+func TestReadRawGrayErrors(t *testing.T) {
+	if _, err := ReadRawGray(bytes.NewReader([]byte{1, 2, 3}), 2, 2, 8, false); err == nil {
+		t.Error("Expected an error for truncated data")
+	}
+	if _, err := ReadRawGray(bytes.NewReader(nil), 1, 1, 17, false); err == nil {
+		t.Error("Expected an error for an unsupported bit depth")
+	}
+}
+
+func TestWriteRawGray(t *testing.T) {
+	pgm := NewPGM(2, 1, 255)
+	pgm.data[0] = []uint8{255, 51}
+	var buffer bytes.Buffer
+	if err := pgm.WriteRawGray(&buffer, 16, true); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buffer.Bytes(), []byte{0xFF, 0xFF, 0x33, 0x33}) {
+		t.Errorf("Unexpected 16-bit output % x", buffer.Bytes())
+	}
+
+	// Aller-retour sur 8 bits, avec une valeur maximale différente
+	small := NewPGM(3, 2, 15)
+	small.data[0] = []uint8{0, 7, 15}
+	buffer.Reset()
+	if err := small.WriteRawGray(&buffer, 4, false); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadRawGray(&buffer, 3, 2, 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Hash() != small.Hash() {
+		t.Error("Round trip changed the image")
+	}
+}
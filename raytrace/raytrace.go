@@ -0,0 +1,208 @@
+// Package raytrace rend des scènes 3D de sphères et de plans en images PPM par lancer de rayons. Vec3 et
+// Camera servent aussi au rendu de maillages du paquet mesh.
+package raytrace // ✨ Lancer de rayons
+
+import (
+	"math"
+
+	"github.com/YOYOPX15/Netpbm"
+)
+
+// Vec3 est un vecteur ou un point de l'espace.
+type Vec3 struct {
+	X, Y, Z float64
+}
+
+// Add renvoie v + w.
+func (v Vec3) Add(w Vec3) Vec3 {
+	return Vec3{v.X + w.X, v.Y + w.Y, v.Z + w.Z}
+}
+
+// Sub renvoie v - w.
+func (v Vec3) Sub(w Vec3) Vec3 {
+	return Vec3{v.X - w.X, v.Y - w.Y, v.Z - w.Z}
+}
+
+// Scale renvoie v multiplié par k.
+func (v Vec3) Scale(k float64) Vec3 {
+	return Vec3{v.X * k, v.Y * k, v.Z * k}
+}
+
+// Dot renvoie le produit scalaire de v et w.
+func (v Vec3) Dot(w Vec3) float64 {
+	return v.X*w.X + v.Y*w.Y + v.Z*w.Z
+}
+
+// Cross renvoie le produit vectoriel de v et w.
+func (v Vec3) Cross(w Vec3) Vec3 {
+	return Vec3{v.Y*w.Z - v.Z*w.Y, v.Z*w.X - v.X*w.Z, v.X*w.Y - v.Y*w.X}
+}
+
+// Length renvoie la norme de v.
+func (v Vec3) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Normalize renvoie le vecteur de même direction que v et de norme 1 (ou le vecteur nul).
+func (v Vec3) Normalize() Vec3 {
+	if l := v.Length(); l > 0 {
+		return v.Scale(1 / l)
+	}
+	return v
+}
+
+// Material décrit l'aspect d'une surface : Diffuse et Specular pondèrent les éclairages de Lambert et
+// de Phong, Shininess règle la taille des reflets (plus elle est grande, plus ils sont petits).
+type Material struct {
+	Color     Netpbm.Pixel
+	Diffuse   float64
+	Specular  float64
+	Shininess float64
+}
+
+// Sphere est une sphère de centre Center et de rayon Radius.
+type Sphere struct {
+	Center   Vec3
+	Radius   float64
+	Material Material
+}
+
+// Plane est le plan passant par Point et perpendiculaire à Normal.
+type Plane struct {
+	Point    Vec3
+	Normal   Vec3
+	Material Material
+}
+
+// Light est une lumière ponctuelle blanche.
+type Light struct {
+	Position  Vec3
+	Intensity float64
+}
+
+// Camera est placée en Position et regarde vers LookAt. Up indique le haut de l'image et FOV est
+// l'angle de vue vertical en degrés.
+type Camera struct {
+	Position, LookAt, Up Vec3
+	FOV                  float64
+}
+
+// Basis renvoie le repère de la caméra (forward vers la cible, right et up dans le plan de l'image)
+// et les demi-dimensions du champ de vision à distance 1, pour une image de width×height pixels.
+func (c Camera) Basis(width, height int) (forward, right, up Vec3, halfWidth, halfHeight float64) {
+	forward = c.LookAt.Sub(c.Position).Normalize()
+	right = forward.Cross(c.Up).Normalize()
+	up = right.Cross(forward)
+	halfHeight = math.Tan(c.FOV * math.Pi / 360)
+	halfWidth = halfHeight * float64(width) / float64(max(height, 1))
+	return
+}
+
+// Scene est une scène à rendre par lancer de rayons. Ambient est la part de lumière reçue partout,
+// même à l'ombre, et Background la couleur des rayons qui ne touchent rien.
+type Scene struct {
+	Camera     Camera
+	Spheres    []Sphere
+	Planes     []Plane
+	Lights     []Light
+	Ambient    float64
+	Background Netpbm.Pixel
+}
+
+// rayEpsilon évite qu'un rayon parte de la surface et la touche aussitôt à cause des arrondis.
+const rayEpsilon = 1e-6
+
+// Render rend la scène dans une nouvelle image PPM de width×height pixels, avec ombres portées.
+func (s *Scene) Render(width, height int) *Netpbm.PPM {
+	ppm := Netpbm.NewPPM(width, height, 255)
+	ppm.SetMagicNumber("P6")
+
+	forward, right, up, halfWidth, halfHeight := s.Camera.Basis(width, height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// Rayon passant par le centre du pixel
+			u := (2*(float64(x)+0.5)/float64(width) - 1) * halfWidth
+			v := (1 - 2*(float64(y)+0.5)/float64(height)) * halfHeight
+			direction := forward.Add(right.Scale(u)).Add(up.Scale(v)).Normalize()
+			ppm.Set(x, y, s.trace(s.Camera.Position, direction))
+		}
+	}
+	return ppm
+}
+
+// intersect renvoie la distance au premier objet touché par le rayon, sa normale au point touché et son
+// matériau. La distance est infinie si le rayon ne touche rien.
+func (s *Scene) intersect(origin, direction Vec3) (float64, Vec3, Material) {
+	nearest, normal, material := math.Inf(1), Vec3{}, Material{}
+	for _, sphere := range s.Spheres {
+		// Résolution de |origin + t·direction - center|² = radius², direction étant unitaire
+		oc := origin.Sub(sphere.Center)
+		b := oc.Dot(direction)
+		delta := b*b - oc.Dot(oc) + sphere.Radius*sphere.Radius
+		if delta < 0 {
+			continue
+		}
+		root := math.Sqrt(delta)
+		t := -b - root
+		if t <= rayEpsilon {
+			t = -b + root
+		}
+		if t > rayEpsilon && t < nearest {
+			nearest, material = t, sphere.Material
+			normal = origin.Add(direction.Scale(t)).Sub(sphere.Center).Normalize()
+		}
+	}
+	for _, plane := range s.Planes {
+		n := plane.Normal.Normalize()
+		denominator := n.Dot(direction)
+		if math.Abs(denominator) < rayEpsilon {
+			continue
+		}
+		t := plane.Point.Sub(origin).Dot(n) / denominator
+		if t > rayEpsilon && t < nearest {
+			nearest, material = t, plane.Material
+			// La normale est tournée vers l'origine du rayon
+			normal = n
+			if denominator > 0 {
+				normal = n.Scale(-1)
+			}
+		}
+	}
+	return nearest, normal, material
+}
+
+// trace renvoie la couleur vue le long du rayon, éclairée selon les modèles de Lambert et de Phong.
+func (s *Scene) trace(origin, direction Vec3) Netpbm.Pixel {
+	distance, normal, material := s.intersect(origin, direction)
+	if math.IsInf(distance, 1) {
+		return s.Background
+	}
+	point := origin.Add(direction.Scale(distance))
+	view := direction.Scale(-1)
+
+	diffuse, specular := s.Ambient, 0.0
+	for _, light := range s.Lights {
+		toLight := light.Position.Sub(point)
+		lightDistance := toLight.Length()
+		toLight = toLight.Normalize()
+		lambert := normal.Dot(toLight)
+		if lambert <= 0 {
+			continue
+		}
+		// Ombre portée : un objet se trouve entre le point et la lumière
+		if blocker, _, _ := s.intersect(point, toLight); blocker < lightDistance {
+			continue
+		}
+		diffuse += material.Diffuse * lambert * light.Intensity
+		reflected := normal.Scale(2 * lambert).Sub(toLight)
+		if r := reflected.Dot(view); r > 0 {
+			specular += material.Specular * math.Pow(r, material.Shininess) * light.Intensity
+		}
+	}
+
+	shade := func(c uint8) uint8 {
+		return uint8(math.Round(min(float64(c)*diffuse+255*specular, 255)))
+	}
+	return Netpbm.Pixel{R: shade(material.Color.R), G: shade(material.Color.G), B: shade(material.Color.B)}
+}
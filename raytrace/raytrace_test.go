@@ -0,0 +1,60 @@
+package raytrace // 🧪 Test lancer de rayons
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/YOYOPX15/Netpbm"
+)
+
+func TestVec3(t *testing.T) {
+	v, w := Vec3{1, 0, 0}, Vec3{0, 1, 0}
+	if v.Cross(w) != (Vec3{0, 0, 1}) || v.Dot(w) != 0 {
+		t.Error("Unexpected cross or dot product")
+	}
+	if l := (Vec3{3, 4, 0}).Normalize().Length(); math.Abs(l-1) > 1e-12 {
+		t.Errorf("Expected a unit vector, got length %v", l)
+	}
+}
+
+// newTestScene renvoie une sphère rouge posée sur un sol gris, éclairée d'en haut à gauche.
+func newTestScene() *Scene {
+	matte := Material{Color: Netpbm.Pixel{R: 200, G: 200, B: 200}, Diffuse: 0.8}
+	return &Scene{
+		Camera:     Camera{Position: Vec3{0, 1, -5}, LookAt: Vec3{0, 1, 0}, Up: Vec3{0, 1, 0}, FOV: 60},
+		Spheres:    []Sphere{{Vec3{0, 1, 0}, 1, Material{Color: Netpbm.Pixel{R: 255}, Diffuse: 0.9, Specular: 0.5, Shininess: 30}}},
+		Planes:     []Plane{{Vec3{0, 0, 0}, Vec3{0, 1, 0}, matte}},
+		Lights:     []Light{{Vec3{-5, 8, -5}, 1}},
+		Ambient:    0.1,
+		Background: Netpbm.Pixel{R: 20, G: 30, B: 60},
+	}
+}
+
+func TestSceneRender(t *testing.T) {
+	ppm := newTestScene().Render(64, 48)
+	if width, height := ppm.Size(); width != 64 || height != 48 {
+		t.Fatalf("Unexpected size %dx%d", width, height)
+	}
+	center := ppm.At(32, 24)
+	if center.R < 100 || center.G != center.B {
+		t.Errorf("Expected the red sphere at the center, got %v", center)
+	}
+	if ppm.At(32, 0) != (Netpbm.Pixel{R: 20, G: 30, B: 60}) {
+		t.Errorf("Expected the background at the top, got %v", ppm.At(32, 0))
+	}
+	floor := ppm.At(2, 47)
+	if floor.R != floor.G || floor.R == 0 {
+		t.Errorf("Expected the lit gray floor at the bottom, got %v", floor)
+	}
+
+	// L'ombre de la sphère tombe sur le sol, du côté opposé à la lumière
+	shadowed, _, _ := newTestScene().intersect(Vec3{1, 0, 1}, Vec3{-5, 8, -5}.Sub(Vec3{1, 0, 1}).Normalize())
+	if math.IsInf(shadowed, 1) {
+		t.Error("The sphere should cast a shadow behind it")
+	}
+
+	if err := ppm.Save(filepath.Join(t.TempDir(), "raytrace.ppm")); err != nil {
+		t.Fatal(err)
+	}
+}
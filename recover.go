@@ -0,0 +1,73 @@
+package Netpbm // ✨ Récupération des fichiers tronqués
+
+import "fmt"
+
+// TruncatedError signale qu'un fichier s'est terminé avant la fin des données de l'image.
+// En mode récupération, elle est renvoyée avec l'image partiellement décodée.
+type TruncatedError struct {
+	Row    int   // Première ligne incomplète
+	Height int   // Nombre de lignes attendues
+	Err    error // Erreur de lecture d'origine
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("truncated file: %d of %d rows decoded: %v", e.Row, e.Height, e.Err)
+}
+
+func (e *TruncatedError) Unwrap() error {
+	return e.Err
+}
+
+// ReadPBMRecover lit une image PBM comme ReadPBM, mais un fichier tronqué n'est pas une erreur fatale :
+// l'image partielle est renvoyée, les pixels manquants valent fill, et l'erreur est une *TruncatedError.
+func ReadPBMRecover(filename string, fill bool) (*PBM, error) {
+	return readPBM(filename, true, fill)
+}
+
+// fillPBM remplit les pixels à partir de (x, y) jusqu'à la fin de l'image.
+func fillPBM(data [][]bool, y, x int, fill bool) {
+	for ; y < len(data); y++ {
+		for ; x < len(data[y]); x++ {
+			data[y][x] = fill
+		}
+		x = 0
+	}
+}
+
+// ReadPGMRecover lit une image PGM comme ReadPGM, mais un fichier tronqué n'est pas une erreur fatale :
+// l'image partielle est renvoyée, les pixels manquants valent fill, et l'erreur est une *TruncatedError.
+func ReadPGMRecover(filename string, fill uint8) (*PGM, error) {
+	return readPGM(filename, true, fill)
+}
+
+// fillPGM remplit les pixels à partir de (x, y) jusqu'à la fin de l'image, en créant les lignes manquantes.
+func fillPGM(data [][]uint8, y, x, width int, fill uint8) {
+	for ; y < len(data); y++ {
+		if data[y] == nil {
+			data[y] = make([]uint8, width)
+		}
+		for ; x < width; x++ {
+			data[y][x] = fill
+		}
+		x = 0
+	}
+}
+
+// ReadPPMRecover lit une image PPM comme ReadPPM, mais un fichier tronqué n'est pas une erreur fatale :
+// l'image partielle est renvoyée, les pixels manquants valent fill, et l'erreur est une *TruncatedError.
+func ReadPPMRecover(filename string, fill Pixel) (*PPM, error) {
+	return readPPM(filename, true, fill)
+}
+
+// fillPPM remplit les pixels à partir de (x, y) jusqu'à la fin de l'image, en créant les lignes manquantes.
+func fillPPM(data [][]Pixel, y, x, width int, fill Pixel) {
+	for ; y < len(data); y++ {
+		if data[y] == nil {
+			data[y] = make([]Pixel, width)
+		}
+		for ; x < width; x++ {
+			data[y][x] = fill
+		}
+		x = 0
+	}
+}
@@ -0,0 +1,85 @@
+package Netpbm // 🧪 Test récupération
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestReadPBMRecover(t *testing.T) {
+	// 2 lignes complètes sur 4, puis un octet de la troisième
+	err := os.WriteFile("./testImages/pbm/truncated.pbm", []byte("P4\n10 4\n\xff\xc0\x00\x00\xaa"), 0644)
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.Remove("./testImages/pbm/truncated.pbm")
+
+	if _, err := ReadPBM("./testImages/pbm/truncated.pbm"); err == nil {
+		t.Error("Expected an error without recover mode")
+	}
+	pbm, err := ReadPBMRecover("./testImages/pbm/truncated.pbm", true)
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("Expected a TruncatedError, got %v", err)
+	}
+	if truncated.Row != 2 || truncated.Height != 4 {
+		t.Errorf("Wrong truncation row %d/%d", truncated.Row, truncated.Height)
+	}
+	if !pbm.At(9, 0) || pbm.At(0, 1) {
+		t.Error("Decoded rows not kept")
+	}
+	if !pbm.At(0, 2) || pbm.At(1, 2) || pbm.At(7, 2) || !pbm.At(8, 2) || !pbm.At(9, 3) {
+		t.Error("Missing pixels not filled")
+	}
+}
+
+func TestReadPGMRecover(t *testing.T) {
+	err := os.WriteFile("./testImages/pgm/truncated.pgm", []byte("P2\n3 3\n255\n1 2 3\n4 5"), 0644)
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.Remove("./testImages/pgm/truncated.pgm")
+
+	pgm, err := ReadPGMRecover("./testImages/pgm/truncated.pgm", 9)
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("Expected a TruncatedError, got %v", err)
+	}
+	if pgm.At(2, 0) != 3 || pgm.At(1, 1) != 5 {
+		t.Error("Decoded pixels not kept")
+	}
+	if pgm.At(2, 1) != 9 || pgm.At(0, 2) != 9 {
+		t.Error("Missing pixels not filled")
+	}
+
+	// Une dernière ligne complète sans retour à la ligne n'est pas une troncature
+	err = os.WriteFile("./testImages/pgm/truncated.pgm", []byte("P5\n2 2\n255\n\x01\x02\x03\x04"), 0644)
+	if err != nil {
+		t.Error(err)
+	}
+	pgm, err = ReadPGMRecover("./testImages/pgm/truncated.pgm", 9)
+	if err != nil || pgm.At(1, 1) != 4 {
+		t.Errorf("Complete file should decode without error: %v", err)
+	}
+}
+
+func TestReadPPMRecover(t *testing.T) {
+	err := os.WriteFile("./testImages/ppm/truncated.ppm", []byte("P6\n2 2\n255\n\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a"), 0644)
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.Remove("./testImages/ppm/truncated.ppm")
+
+	fill := Pixel{255, 0, 255}
+	ppm, err := ReadPPMRecover("./testImages/ppm/truncated.ppm", fill)
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("Expected a TruncatedError, got %v", err)
+	}
+	if ppm.At(1, 0) != (Pixel{4, 5, 6}) || ppm.At(0, 1) != (Pixel{7, 8, 9}) {
+		t.Error("Decoded pixels not kept")
+	}
+	if ppm.At(1, 1) != fill {
+		t.Error("Missing pixels not filled")
+	}
+}
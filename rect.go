@@ -0,0 +1,32 @@
+package Netpbm // ✨ Rectangles
+
+// Rect représente une zone rectangulaire de l'image, à partir du coin supérieur gauche (X, Y).
+type Rect struct {
+	X, Y          int // Coin supérieur gauche
+	Width, Height int // Largeur et hauteur de la zone
+}
+
+// Empty indique si la zone ne contient aucun pixel.
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Contains indique si le point p se trouve dans la zone.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.X && p.X < r.X+r.Width && p.Y >= r.Y && p.Y < r.Y+r.Height
+}
+
+// Intersect renvoie la partie commune de deux zones (vide si elles ne se touchent pas).
+func (r Rect) Intersect(other Rect) Rect {
+	x0, y0 := max(r.X, other.X), max(r.Y, other.Y)
+	x1, y1 := min(r.X+r.Width, other.X+other.Width), min(r.Y+r.Height, other.Y+other.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{}
+	}
+	return Rect{x0, y0, x1 - x0, y1 - y0}
+}
+
+// clip limite la zone aux dimensions d'une image width×height.
+func (r Rect) clip(width, height int) Rect {
+	return r.Intersect(Rect{0, 0, width, height})
+}
@@ -0,0 +1,38 @@
+package Netpbm // ✨ Yeux rouges
+
+const (
+	redEyeRatio    = 1.8  // Rouge minimal par rapport à la moyenne du vert et du bleu pour un pixel d'œil rouge
+	redEyeMinimum  = 0.25 // Rouge minimal, en fraction de la valeur maximale, pour ignorer les pixels sombres
+	redEyeSoftness = 0.6  // Écart au-delà du seuil sur lequel la correction passe progressivement de 0 à 1
+)
+
+// RemoveRedEye corrige les yeux rouges dans les zones regions de l'image PPM (autour des yeux, ou d'un
+// visage entier) et renvoie le nombre de pixels corrigés. Un pixel est jugé rouge si son rouge dépasse
+// nettement la moyenne de son vert et de son bleu ; son rouge est alors ramené vers cette moyenne, ce
+// qui rend la pupille sombre sans changer sa luminosité apparente. Les pixels à peine rouges (peau,
+// bord de la pupille) ne sont corrigés que partiellement, pour éviter un contour visible.
+func (ppm *PPM) RemoveRedEye(regions []Rect) int {
+	ppm.own()
+	corrected := 0
+	minimum := redEyeMinimum * float64(ppm.max)
+	for _, r := range regions {
+		r = r.clip(ppm.width, ppm.height)
+		for y := r.Y; y < r.Y+r.Height; y++ {
+			for x := r.X; x < r.X+r.Width; x++ {
+				p := &ppm.data[y][x]
+				red, others := float64(p.R), (float64(p.G)+float64(p.B))/2
+				if red < minimum || red <= redEyeRatio*others {
+					continue
+				}
+				// Force de la correction : 0 au seuil, 1 à partir de redEyeRatio+redEyeSoftness
+				strength := 1.0
+				if others > 0 {
+					strength = min((red/others-redEyeRatio)/redEyeSoftness, 1)
+				}
+				p.R = uint8(red - (red-others)*strength + 0.5)
+				corrected++
+			}
+		}
+	}
+	return corrected
+}
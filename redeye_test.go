@@ -0,0 +1,43 @@
+package Netpbm // 🧪 Test Yeux rouges
+
+import "testing"
+
+func TestRemoveRedEye(t *testing.T) {
+	skin, pupil := Pixel{220, 170, 140}, Pixel{200, 30, 40}
+	ppm := newUniformPPM(20, 10, skin)
+	for _, x := range []int{4, 14} {
+		ppm.DrawFilledCircle(Point{x, 5}, 2, pupil)
+	}
+	before := countColor(ppm, pupil)
+	// La pupille de droite est hors des zones et ne doit pas changer
+	corrected := ppm.RemoveRedEye([]Rect{{0, 0, 10, 10}})
+
+	if corrected == 0 || countColor(ppm, pupil) != before-corrected {
+		t.Errorf("%d pixels corrigés sur %d", corrected, before)
+	}
+	if got := ppm.At(4, 5); got != (Pixel{35, 30, 40}) {
+		t.Errorf("pupille corrigée = %v, attendu %v", got, Pixel{35, 30, 40})
+	}
+	if ppm.At(14, 5) != pupil {
+		t.Error("un œil hors des zones a été corrigé")
+	}
+	if ppm.At(0, 0) != skin {
+		t.Error("la peau ne doit pas être corrigée")
+	}
+}
+
+func TestRemoveRedEyeSoftEdge(t *testing.T) {
+	ppm := newUniformPPM(3, 1, Pixel{0, 0, 0})
+	ppm.Set(0, 0, Pixel{200, 100, 100}) // 2 fois la moyenne : correction partielle
+	ppm.Set(1, 0, Pixel{200, 50, 50})   // 4 fois la moyenne : correction complète
+	ppm.Set(2, 0, Pixel{40, 0, 0})      // Trop sombre pour être un œil rouge
+	if n := ppm.RemoveRedEye([]Rect{{0, 0, 3, 1}}); n != 2 {
+		t.Errorf("%d pixels corrigés, attendu 2", n)
+	}
+	if r := ppm.At(0, 0).R; r <= 100 || r >= 200 {
+		t.Errorf("rouge du bord = %d, attendu une correction partielle", r)
+	}
+	if ppm.At(1, 0) != (Pixel{50, 50, 50}) || ppm.At(2, 0) != (Pixel{40, 0, 0}) {
+		t.Errorf("pixels %v, %v", ppm.At(1, 0), ppm.At(2, 0))
+	}
+}
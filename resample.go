@@ -0,0 +1,98 @@
+package Netpbm // ✨ Rééchantillonnage
+
+import "math"
+
+// Interpolation choisit la méthode de calcul des pixels lors d'un redimensionnement : c'est l'un des
+// noyaux prédéfinis (NearestNeighbor, Bilinear, Lanczos...) ou tout autre ResampleKernel.
+type Interpolation = ResampleKernel
+
+// tap représente la contribution d'un pixel source à un pixel de destination.
+type tap struct {
+	index  int
+	weight float64
+}
+
+// axisTaps calcule, pour chaque coordonnée de destination, les pixels sources utilisés sur un axe et leurs poids.
+func axisTaps(src, dst int, filter Interpolation) [][]tap {
+	taps := make([][]tap, dst)
+	scale := float64(src) / float64(dst)
+	for i := range taps {
+		// Centre du pixel de destination, exprimé en coordonnées sources
+		center := (float64(i) + 0.5) * scale
+		if _, ok := filter.(nearestKernel); ok || filter == nil {
+			taps[i] = []tap{{min(int(center), src-1), 1}}
+			continue
+		}
+
+		// Noyau élargi lors d'une réduction pour couvrir tous les pixels sources, les bords étant prolongés
+		stretch := math.Max(scale, 1)
+		support := filter.Support() * stretch
+		total := 0.0
+		for j := int(math.Floor(center - support)); j <= int(math.Ceil(center+support)); j++ {
+			weight := filter.Weight((float64(j) + 0.5 - center) / stretch)
+			if weight == 0 {
+				continue
+			}
+			taps[i] = append(taps[i], tap{min(max(j, 0), src-1), weight})
+			total += weight
+		}
+		if total == 0 {
+			taps[i] = []tap{{min(int(center), src-1), 1}}
+			continue
+		}
+		for k := range taps[i] {
+			taps[i][k].weight /= total
+		}
+	}
+	return taps
+}
+
+// Resize renvoie une copie de l'image PPM redimensionnée à width×height.
+func (ppm *PPM) Resize(width, height int, filter Interpolation) *PPM {
+	resized := NewPPM(max(width, 0), max(height, 0), ppm.max)
+	resized.magicNumber = ppm.magicNumber
+	if width <= 0 || height <= 0 || ppm.width == 0 || ppm.height == 0 {
+		return resized
+	}
+	xTaps := axisTaps(ppm.width, width, filter)
+	yTaps := axisTaps(ppm.height, height, filter)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var r, g, b float64
+			for _, ty := range yTaps[y] {
+				row := ppm.data[ty.index]
+				for _, tx := range xTaps[x] {
+					w := ty.weight * tx.weight
+					r += w * float64(row[tx.index].R)
+					g += w * float64(row[tx.index].G)
+					b += w * float64(row[tx.index].B)
+				}
+			}
+			resized.data[y][x] = Pixel{clampChannel(r, ppm.max), clampChannel(g, ppm.max), clampChannel(b, ppm.max)}
+		}
+	}
+	return resized
+}
+
+// Resize renvoie une copie de l'image PGM redimensionnée à width×height.
+func (pgm *PGM) Resize(width, height int, filter Interpolation) *PGM {
+	resized := NewPGM(max(width, 0), max(height, 0), pgm.max)
+	resized.magicNumber = pgm.magicNumber
+	if width <= 0 || height <= 0 || pgm.width == 0 || pgm.height == 0 {
+		return resized
+	}
+	xTaps := axisTaps(pgm.width, width, filter)
+	yTaps := axisTaps(pgm.height, height, filter)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var v float64
+			for _, ty := range yTaps[y] {
+				for _, tx := range xTaps[x] {
+					v += ty.weight * tx.weight * float64(pgm.data[ty.index][tx.index])
+				}
+			}
+			resized.data[y][x] = clampChannel(v, pgm.max)
+		}
+	}
+	return resized
+}
@@ -0,0 +1,41 @@
+package Netpbm // 🧪 Test rééchantillonnage
+
+import "testing"
+
+func TestResizeNearestNeighbor(t *testing.T) {
+	ppm, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	resized := ppm.Resize(imagePPMWidth*2, imagePPMHeight*2, NearestNeighbor)
+	for y := 0; y < imagePPMHeight*2; y++ {
+		for x := 0; x < imagePPMWidth*2; x++ {
+			if resized.At(x, y) != imagePPMData[(y/2)*imagePPMWidth+x/2] {
+				t.Fatalf("Pixel at (%d, %d) not resized correctly", x, y)
+			}
+		}
+	}
+	same := ppm.Resize(imagePPMWidth, imagePPMHeight, Bilinear)
+	if same.Hash() != ppm.Hash() {
+		t.Error("Resizing to the same size should not change the image")
+	}
+}
+
+func TestResizeBilinear(t *testing.T) {
+	pgm := NewPGM(4, 1, 255)
+	for x, v := range []uint8{0, 80, 160, 240} {
+		pgm.Set(x, 0, v)
+	}
+	half := pgm.Resize(2, 1, Bilinear)
+	// Noyau [1 3 3 1]/8 avec répétition des bords
+	if half.At(0, 0) != 50 || half.At(1, 0) != 190 {
+		t.Errorf("Wrong downsampled values %d %d", half.At(0, 0), half.At(1, 0))
+	}
+	double := pgm.Resize(8, 1, Bilinear)
+	if double.At(0, 0) != 0 || double.At(1, 0) != 20 || double.At(2, 0) != 60 || double.At(7, 0) != 240 {
+		t.Errorf("Wrong upsampled values %v", double.data[0])
+	}
+	if empty := pgm.Resize(0, 3, Bilinear); empty.width != 0 {
+		t.Error("Empty size should give an empty image")
+	}
+}
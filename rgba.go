@@ -0,0 +1,37 @@
+package Netpbm // ✨ Pixels RGBA
+
+import "fmt"
+
+// RGBA renvoie les pixels de l'image PPM sous forme d'octets R, G, B, A consécutifs, ligne par ligne,
+// mis à l'échelle 0..255 et entièrement opaques : le format de ImageData.data et de image.RGBA.Pix.
+func (ppm *PPM) RGBA() []byte {
+	pix := make([]byte, 0, ppm.width*ppm.height*4)
+	scale := func(v uint8) byte { return byte(int(v) * 255 / max(ppm.max, 1)) }
+	for _, row := range ppm.data {
+		for _, p := range row {
+			pix = append(pix, scale(p.R), scale(p.G), scale(p.B), 255)
+		}
+	}
+	return pix
+}
+
+// PPMFromRGBA crée une image PPM (P6, valeur maximale 255) à partir d'octets R, G, B, A consécutifs.
+// Les pixels transparents sont composés sur un fond blanc.
+func PPMFromRGBA(pix []byte, width, height int) (*PPM, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid dimensions: width and height must be positive")
+	}
+	if len(pix) != width*height*4 {
+		return nil, fmt.Errorf("expected %d bytes for %dx%d RGBA pixels, got %d", width*height*4, width, height, len(pix))
+	}
+	ppm := NewPPM(width, height, 255)
+	ppm.magicNumber = "P6"
+	over := func(v, a byte) uint8 { return uint8((int(v)*int(a) + 255*(255-int(a)) + 127) / 255) }
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := pix[(y*width+x)*4:]
+			ppm.data[y][x] = Pixel{over(p[0], p[3]), over(p[1], p[3]), over(p[2], p[3])}
+		}
+	}
+	return ppm, nil
+}
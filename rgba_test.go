@@ -0,0 +1,38 @@
+package Netpbm // 🧪 Test pixels RGBA
+
+import "testing"
+
+func TestRGBARoundTrip(t *testing.T) {
+	ppm := newPatternPPM(6, 4)
+	pix := ppm.RGBA()
+	if len(pix) != 6*4*4 || pix[3] != 255 {
+		t.Fatalf("Unexpected RGBA bytes %v", pix[:4])
+	}
+	back, err := PPMFromRGBA(pix, 6, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Hash() != ppm.Hash() {
+		t.Error("The round trip should keep the pixels")
+	}
+
+	// Une image de valeur maximale 15 est mise à l'échelle 0..255
+	small := NewPPM(1, 1, 15)
+	small.Set(0, 0, Pixel{15, 0, 5})
+	if pix := small.RGBA(); pix[0] != 255 || pix[1] != 0 || pix[2] != 85 {
+		t.Errorf("Unexpected scaled bytes %v", pix)
+	}
+}
+
+func TestPPMFromRGBAAlpha(t *testing.T) {
+	ppm, err := PPMFromRGBA([]byte{0, 0, 0, 0, 0, 0, 0, 255, 200, 100, 0, 128}, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ppm.At(0, 0) != (Pixel{255, 255, 255}) || ppm.At(1, 0) != (Pixel{}) || ppm.At(2, 0) != (Pixel{227, 177, 127}) {
+		t.Errorf("Unexpected composited pixels %v %v %v", ppm.At(0, 0), ppm.At(1, 0), ppm.At(2, 0))
+	}
+	if _, err := PPMFromRGBA(make([]byte, 10), 3, 1); err == nil {
+		t.Error("Expected an error for a wrong buffer length")
+	}
+}
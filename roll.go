@@ -0,0 +1,102 @@
+package Netpbm // ✨ Décalage des lignes et des colonnes
+
+import "slices"
+
+// Roll décale l'image PBM de dx pixels vers la droite et de dy pixels vers le bas (vers la gauche ou le
+// haut s'ils sont négatifs), sans changer sa taille. Avec wrap, les pixels qui sortent d'un côté rentrent
+// de l'autre (défilement en boucle) ; sinon ils sont perdus et les pixels libérés sont blancs.
+func (pbm *PBM) Roll(dx, dy int, wrap bool) {
+	rollData(pbm.data, dx, dy, wrap, false)
+}
+
+// ShearRows décale chaque ligne y de l'image PBM de offsets[y] pixels vers la droite (vers la gauche si
+// négatif), sans changer sa taille : les pixels qui sortent sont perdus et les pixels libérés sont blancs.
+// Les lignes au-delà de offsets ne bougent pas. Une suite de décalages proportionnels à y incline l'image ;
+// trois inclinaisons successives, alternant lignes et colonnes, la font tourner d'un angle quelconque.
+func (pbm *PBM) ShearRows(offsets []int) {
+	shearData(pbm.data, offsets, false)
+}
+
+// Roll décale l'image PGM comme Roll pour une image PBM ; sans wrap, les pixels libérés sont noirs.
+func (pgm *PGM) Roll(dx, dy int, wrap bool) {
+	pgm.own()
+	rollData(pgm.data, dx, dy, wrap, 0)
+}
+
+// ShearRows décale les lignes de l'image PGM comme ShearRows pour une image PBM ; les pixels libérés sont
+// noirs.
+func (pgm *PGM) ShearRows(offsets []int) {
+	pgm.own()
+	shearData(pgm.data, offsets, 0)
+}
+
+// Roll décale l'image PPM comme Roll pour une image PBM ; sans wrap, les pixels libérés sont noirs.
+func (ppm *PPM) Roll(dx, dy int, wrap bool) {
+	ppm.own()
+	rollData(ppm.data, dx, dy, wrap, Pixel{})
+}
+
+// ShearRows décale les lignes de l'image PPM comme ShearRows pour une image PBM ; les pixels libérés sont
+// noirs.
+func (ppm *PPM) ShearRows(offsets []int) {
+	ppm.own()
+	shearData(ppm.data, offsets, Pixel{})
+}
+
+// rollData décale les pixels de data de dx colonnes et dy lignes, comme Roll.
+func rollData[T any](data [][]T, dx, dy int, wrap bool, blank T) {
+	// Les lignes sont échangées en bloc, puis celles qui sont rentrées de l'autre côté sont vidées
+	height := len(data)
+	shiftSlice(data, dy, true, nil)
+	if !wrap && height > 0 {
+		vacated := data[:min(max(dy, 0), height)]
+		if dy < 0 {
+			vacated = data[max(height+dy, 0):]
+		}
+		for _, row := range vacated {
+			for x := range row {
+				row[x] = blank
+			}
+		}
+	}
+	for _, row := range data {
+		shiftSlice(row, dx, wrap, blank)
+	}
+}
+
+// shearData décale chaque ligne y de data de offsets[y] colonnes, comme ShearRows.
+func shearData[T any](data [][]T, offsets []int, blank T) {
+	for y, offset := range offsets[:min(len(offsets), len(data))] {
+		shiftSlice(data[y], offset, false, blank)
+	}
+}
+
+// shiftSlice décale les éléments de s de shift positions vers la fin (vers le début si shift est
+// négatif). Avec wrap, ceux qui sortent rentrent de l'autre côté ; sinon, les places libérées prennent
+// la valeur blank.
+func shiftSlice[T any](s []T, shift int, wrap bool, blank T) {
+	n := len(s)
+	if n == 0 || shift == 0 {
+		return
+	}
+	if wrap {
+		// Rotation en place par trois retournements
+		shift = (shift%n + n) % n
+		slices.Reverse(s)
+		slices.Reverse(s[:shift])
+		slices.Reverse(s[shift:])
+		return
+	}
+	shift = min(max(shift, -n), n)
+	var vacated []T
+	if shift > 0 {
+		copy(s[shift:], s[:n-shift])
+		vacated = s[:shift]
+	} else {
+		copy(s, s[-shift:])
+		vacated = s[n+shift:]
+	}
+	for i := range vacated {
+		vacated[i] = blank
+	}
+}
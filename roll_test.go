@@ -0,0 +1,95 @@
+package Netpbm // 🧪 Test Décalage des lignes et des colonnes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShiftSlice(t *testing.T) {
+	tests := []struct {
+		shift int
+		wrap  bool
+		want  []int
+	}{
+		{2, true, []int{4, 5, 1, 2, 3}},
+		{-1, true, []int{2, 3, 4, 5, 1}},
+		{7, true, []int{4, 5, 1, 2, 3}},
+		{2, false, []int{0, 0, 1, 2, 3}},
+		{-3, false, []int{4, 5, 0, 0, 0}},
+		{9, false, []int{0, 0, 0, 0, 0}},
+		{0, false, []int{1, 2, 3, 4, 5}},
+	}
+	for _, test := range tests {
+		s := []int{1, 2, 3, 4, 5}
+		shiftSlice(s, test.shift, test.wrap, 0)
+		if !slices.Equal(s, test.want) {
+			t.Errorf("shiftSlice(%d, %v) = %v, attendu %v", test.shift, test.wrap, s, test.want)
+		}
+	}
+}
+
+func TestPGMRoll(t *testing.T) {
+	pgm := NewPGM(3, 3, 255)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			pgm.Set(x, y, uint8(10*y+x+1))
+		}
+	}
+	clone := pgm.Clone()
+
+	pgm.Roll(1, -1, true)
+	want := [][]uint8{{13, 11, 12}, {23, 21, 22}, {3, 1, 2}}
+	for y := range want {
+		if !slices.Equal(pgm.data[y], want[y]) {
+			t.Errorf("ligne %d = %v, attendu %v", y, pgm.data[y], want[y])
+		}
+	}
+
+	rolled := clone.Clone()
+	rolled.Roll(-1, 1, false)
+	want = [][]uint8{{0, 0, 0}, {2, 3, 0}, {12, 13, 0}}
+	for y := range want {
+		if !slices.Equal(rolled.data[y], want[y]) {
+			t.Errorf("sans boucle, ligne %d = %v, attendu %v", y, rolled.data[y], want[y])
+		}
+	}
+	if clone.At(0, 0) != 1 || clone.At(2, 2) != 23 {
+		t.Error("le clone a été modifié")
+	}
+}
+
+func TestPBMRollAndShear(t *testing.T) {
+	pbm := NewPBM(4, 3)
+	pbm.Set(0, 0, true)
+	pbm.Roll(0, 5, false)
+	if countBlack(pbm) != 0 {
+		t.Error("un décalage plus grand que l'image doit la vider")
+	}
+
+	pbm = NewPBM(4, 3)
+	for y := 0; y < 3; y++ {
+		pbm.Set(0, y, true)
+	}
+	pbm.ShearRows([]int{0, 1, 3, 2})
+	for y, x := range []int{0, 1, 3} {
+		if !pbm.data[y][x] || countBlack(pbm) != 3 {
+			t.Errorf("ligne %d : pixel noir attendu en %d, %v", y, x, pbm.data[y])
+		}
+	}
+}
+
+func TestPPMShearRows(t *testing.T) {
+	red := Pixel{255, 0, 0}
+	ppm := newUniformPPM(3, 3, red)
+	ppm.ShearRows([]int{-1, 5})
+	if ppm.data[0][1] != red || ppm.data[0][2] != (Pixel{}) {
+		t.Errorf("ligne 0 = %v", ppm.data[0])
+	}
+	if countColor(ppm, red) != 2+0+3 {
+		t.Errorf("%d pixels rouges, attendu 5", countColor(ppm, red))
+	}
+	ppm.Roll(3, 3, true)
+	if ppm.data[0][1] != red || ppm.data[0][2] != (Pixel{}) {
+		t.Errorf("un tour complet doit laisser l'image inchangée : %v", ppm.data[0])
+	}
+}
@@ -0,0 +1,96 @@
+package Netpbm // ✨ Collage sans raccord
+
+import "math"
+
+const (
+	seamlessIterations = 5000 // Nombre maximal de passes du solveur
+	seamlessTolerance  = 1e-3 // Variation maximale d'un pixel en dessous de laquelle le solveur s'arrête
+	seamlessRelaxation = 1.9  // Facteur de sur-relaxation (entre 1 et 2)
+)
+
+// SeamlessPaste colle l'image src sur l'image PPM, son coin supérieur gauche en at, sans raccord visible
+// (édition d'image de Poisson) : les pixels collés gardent les détails de src, c'est-à-dire les écarts
+// entre pixels voisins, mais leurs couleurs sont recalculées pour rejoindre celles de l'image au bord
+// de la zone collée. Mask, s'il n'est pas nil, doit avoir la taille de src et désigne les pixels à
+// coller (true) ; sinon toute l'image src est collée. Les parties qui dépassent sont ignorées.
+func (ppm *PPM) SeamlessPaste(src *PPM, mask *PBM, at Point) {
+	ppm.own()
+	// Indice de chaque pixel collé dans les inconnues, -1 hors de la zone
+	index := make([]int, ppm.width*ppm.height)
+	for i := range index {
+		index[i] = -1
+	}
+	var inside []Point
+	for y := max(0, -at.Y); y < src.height && at.Y+y < ppm.height; y++ {
+		for x := max(0, -at.X); x < src.width && at.X+x < ppm.width; x++ {
+			if mask != nil && (x >= mask.width || y >= mask.height || !mask.data[y][x]) {
+				continue
+			}
+			index[(at.Y+y)*ppm.width+at.X+x] = len(inside)
+			inside = append(inside, Point{at.X + x, at.Y + y})
+		}
+	}
+	if len(inside) == 0 {
+		return
+	}
+
+	scale := float64(ppm.max) / float64(max(src.max, 1))
+	guide := func(p Point, c int) float64 {
+		s := src.data[p.Y-at.Y][p.X-at.X]
+		return float64([3]uint8{s.R, s.G, s.B}[c]) * scale
+	}
+	result := make([][3]uint8, len(inside))
+	for c := 0; c < 3; c++ {
+		// Second membre : somme des écarts de src avec les voisins et valeurs fixes du bord
+		values := make([]float64, len(inside))
+		rhs := make([]float64, len(inside))
+		neighbors := make([][]int, len(inside))
+		counts := make([]float64, len(inside))
+		for i, p := range inside {
+			values[i] = guide(p, c)
+			for _, n := range [4]Point{{p.X - 1, p.Y}, {p.X + 1, p.Y}, {p.X, p.Y - 1}, {p.X, p.Y + 1}} {
+				if n.X < 0 || n.Y < 0 || n.X >= ppm.width || n.Y >= ppm.height {
+					continue
+				}
+				counts[i]++
+				if sx, sy := n.X-at.X, n.Y-at.Y; sx >= 0 && sy >= 0 && sx < src.width && sy < src.height {
+					rhs[i] += values[i] - guide(n, c)
+				}
+				if j := index[n.Y*ppm.width+n.X]; j >= 0 {
+					neighbors[i] = append(neighbors[i], j)
+				} else {
+					d := ppm.data[n.Y][n.X]
+					rhs[i] += float64([3]uint8{d.R, d.G, d.B}[c])
+				}
+			}
+		}
+
+		// Résolution de l'équation de Poisson discrète par sur-relaxation successive
+		for iteration := 0; iteration < seamlessIterations; iteration++ {
+			change := 0.0
+			for i := range values {
+				if counts[i] == 0 {
+					continue
+				}
+				sum := rhs[i]
+				for _, j := range neighbors[i] {
+					sum += values[j]
+				}
+				delta := seamlessRelaxation * (sum/counts[i] - values[i])
+				values[i] += delta
+				change = math.Max(change, math.Abs(delta))
+			}
+			if change < seamlessTolerance {
+				break
+			}
+		}
+
+		// Les autres canaux lisent encore le bord dans l'image : elle n'est modifiée qu'à la fin
+		for i := range inside {
+			result[i][c] = uint8(math.Round(math.Min(math.Max(values[i], 0), float64(ppm.max))))
+		}
+	}
+	for i, p := range inside {
+		ppm.data[p.Y][p.X] = Pixel{result[i][0], result[i][1], result[i][2]}
+	}
+}
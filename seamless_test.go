@@ -0,0 +1,59 @@
+package Netpbm // 🧪 Test Collage sans raccord
+
+import "testing"
+
+func TestSeamlessPasteMatchesBackground(t *testing.T) {
+	ppm := newUniformPPM(20, 20, Pixel{100, 120, 140})
+	patch := newUniformPPM(8, 8, Pixel{220, 30, 60})
+	patch.Set(4, 4, Pixel{255, 80, 110})
+
+	ppm.SeamlessPaste(patch, nil, Point{6, 6})
+	// Une couleur uniforme prend celle du fond ; seul le détail ressort, de son écart d'origine
+	if got := ppm.At(7, 7); got != (Pixel{100, 120, 140}) {
+		t.Errorf("pixel collé = %v, attendu la couleur du fond", got)
+	}
+	center := ppm.At(10, 10)
+	if center.R <= 100 || center.G <= 120 || center.B <= 140 {
+		t.Errorf("le détail a disparu : %v", center)
+	}
+	if ppm.At(5, 5) != (Pixel{100, 120, 140}) {
+		t.Error("les pixels hors de la zone collée ne doivent pas changer")
+	}
+}
+
+func TestSeamlessPasteMask(t *testing.T) {
+	left, right := Pixel{0, 0, 0}, Pixel{200, 200, 200}
+	ppm := newUniformPPM(10, 3, left)
+	for y := 0; y < 3; y++ {
+		for x := 5; x < 10; x++ {
+			ppm.Set(x, y, right)
+		}
+	}
+	patch := newUniformPPM(8, 1, Pixel{50, 50, 50})
+	mask := NewPBM(8, 1)
+	for x := 1; x < 7; x++ {
+		mask.Set(x, 0, true)
+	}
+
+	ppm.SeamlessPaste(patch, mask, Point{1, 1})
+	if ppm.At(1, 1) != left || ppm.At(8, 1) != right {
+		t.Error("les pixels hors du masque ne doivent pas changer")
+	}
+	// La zone collée passe progressivement du noir au gris clair, sans saut
+	previous := 0
+	for x := 2; x < 8; x++ {
+		v := int(ppm.At(x, 1).R)
+		if v < previous || v == 0 || v == 200 {
+			t.Errorf("ligne collée non progressive en x=%d : %d après %d", x, v, previous)
+		}
+		previous = v
+	}
+}
+
+func TestSeamlessPasteOutside(t *testing.T) {
+	ppm := newUniformPPM(4, 4, Pixel{10, 10, 10})
+	ppm.SeamlessPaste(newUniformPPM(2, 2, Pixel{90, 90, 90}), nil, Point{10, 10})
+	if countColor(ppm, Pixel{10, 10, 10}) != 16 {
+		t.Error("un collage hors de l'image ne doit rien changer")
+	}
+}
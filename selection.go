@@ -0,0 +1,112 @@
+package Netpbm // ✨ Sélections du dessin
+
+import (
+	"fmt"
+	"math"
+)
+
+// SelectRect remplace la sélection du dessin par le rectangle r : les commandes enregistrées ensuite
+// ne modifient plus que les pixels sélectionnés, comme dans un logiciel de retouche.
+func (c *Canvas) SelectRect(r Rect) {
+	r = r.clip(c.width, c.height)
+	mask := NewPBM(c.width, c.height)
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		for x := r.X; x < r.X+r.Width; x++ {
+			mask.data[y][x] = true
+		}
+	}
+	c.selectMask(mask, fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d"/>`, r.X, r.Y, r.Width, r.Height))
+}
+
+// SelectEllipse remplace la sélection par l'ellipse de centre center et de demi-axes radiusX et
+// radiusY, en gardant les pixels comme CropEllipse.
+func (c *Canvas) SelectEllipse(center Point, radiusX, radiusY int) {
+	radiusX, radiusY = max(radiusX, 0), max(radiusY, 0)
+	rx, ry := float64(radiusX)+0.5, float64(radiusY)+0.5
+	mask := NewPBM(c.width, c.height)
+	for y := max(center.Y-radiusY, 0); y <= min(center.Y+radiusY, c.height-1); y++ {
+		for x := max(center.X-radiusX, 0); x <= min(center.X+radiusX, c.width-1); x++ {
+			dx, dy := float64(x-center.X)/rx, float64(y-center.Y)/ry
+			mask.data[y][x] = dx*dx+dy*dy <= 1
+		}
+	}
+	c.selectMask(mask, fmt.Sprintf(`<ellipse cx="%g" cy="%g" rx="%g" ry="%g"/>`,
+		float64(center.X)+0.5, float64(center.Y)+0.5, rx, ry))
+}
+
+// SelectPolygon remplace la sélection par l'intérieur du polygone (règle pair-impair) : un pixel est
+// sélectionné si son centre est à l'intérieur, les bords droit et bas étant exclus comme pour le
+// remplissage des polygones.
+func (c *Canvas) SelectPolygon(points []Point) {
+	mask := NewPBM(c.width, c.height)
+	if len(points) >= 3 {
+		polygon := make([]pointF, len(points))
+		for i, p := range points {
+			polygon[i] = pointF{float64(p.X), float64(p.Y)}
+		}
+		var crossings []float64
+		for y := 0; y < c.height; y++ {
+			crossings = scanlineCrossings(polygon, float64(y), crossings[:0])
+			for i := 0; i+1 < len(crossings); i += 2 {
+				for x := max(int(math.Ceil(crossings[i])), 0); x < min(int(math.Ceil(crossings[i+1])), c.width); x++ {
+					mask.data[y][x] = true
+				}
+			}
+		}
+	}
+	c.selectMask(mask, fmt.Sprintf(`<polygon points="%s" fill-rule="evenodd"/>`, svgPoints(points)))
+}
+
+// SelectAll annule la sélection : les commandes suivantes modifient de nouveau tout le dessin.
+func (c *Canvas) SelectAll() {
+	c.selection = nil
+}
+
+// Selection renvoie une copie du masque de la sélection en cours (true : pixel sélectionné), ou nil
+// si tout le dessin est sélectionné.
+func (c *Canvas) Selection() *PBM {
+	if c.selection == nil {
+		return nil
+	}
+	mask := NewPBM(c.selection.width, c.selection.height)
+	for y := range mask.data {
+		copy(mask.data[y], c.selection.data[y])
+	}
+	return mask
+}
+
+// Filter enregistre un filtre quelconque appliqué à l'image PPM, limité à la sélection en cours.
+// Le filtre doit garder la taille de l'image ; il n'a pas d'équivalent SVG et n'est appliqué que sur
+// l'image PPM.
+func (c *Canvas) Filter(filter func(ppm *PPM)) {
+	c.record(filter, "")
+}
+
+// selectMask remplace la sélection par mask et enregistre son tracé SVG, qui servira de zone de
+// découpe aux commandes suivantes.
+func (c *Canvas) selectMask(mask *PBM, shape string) {
+	c.clipPaths++
+	c.selection = mask
+	c.selectionID = fmt.Sprintf("selection%d", c.clipPaths)
+	c.commands = append(c.commands, drawCommand{svg: fmt.Sprintf(`<clipPath id="%s">%s</clipPath>`, c.selectionID, shape)})
+}
+
+// drawSelected applique draw à l'image PPM puis rend leur valeur aux pixels hors du masque.
+func drawSelected(ppm *PPM, mask *PBM, draw func(ppm *PPM)) {
+	before := ppm.Clone()
+	draw(ppm)
+	if before.shared != nil {
+		release(&before.shared)
+	}
+	if ppm.width != before.width || ppm.height != before.height {
+		return
+	}
+	ppm.own()
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			if x >= mask.width || y >= mask.height || !mask.data[y][x] {
+				ppm.data[y][x] = before.data[y][x]
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package Netpbm // 🧪 Test Sélections du dessin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanvasSelectRect(t *testing.T) {
+	white, red := Pixel{255, 255, 255}, Pixel{255, 0, 0}
+	c := NewCanvas(10, 10, white)
+	c.SelectRect(Rect{2, 2, 3, 4})
+	c.DrawFilledRectangle(Point{0, 0}, 9, 9, red)
+	c.SelectAll()
+	c.DrawLine(Point{0, 9}, Point{9, 9}, red)
+
+	ppm := c.ToPPM()
+	if got := countColor(ppm, red); got != 3*4+10 {
+		t.Errorf("%d pixels rouges, attendu %d", got, 3*4+10)
+	}
+	if ppm.At(2, 2) != red || ppm.At(4, 5) != red || ppm.At(5, 5) != white || ppm.At(1, 2) != white {
+		t.Error("le remplissage déborde de la sélection")
+	}
+
+	svg := new(strings.Builder)
+	if err := c.WriteSVG(svg); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<clipPath id="selection1"><rect x="2" y="2" width="3" height="4"/></clipPath>`,
+		`<g clip-path="url(#selection1)"><rect`} {
+		if !strings.Contains(svg.String(), want) {
+			t.Errorf("SVG sans %s :\n%s", want, svg)
+		}
+	}
+	if strings.Count(svg.String(), "clip-path=") != 1 {
+		t.Error("la ligne tracée après SelectAll ne doit pas être découpée")
+	}
+}
+
+func TestCanvasSelectEllipseAndPolygon(t *testing.T) {
+	c := NewCanvas(20, 20, Pixel{})
+	c.SelectEllipse(Point{10, 10}, 3, 2)
+	ellipse := c.Selection()
+	if !ellipse.At(13, 10) || ellipse.At(14, 10) || !ellipse.At(10, 12) || ellipse.At(10, 13) {
+		t.Error("masque de l'ellipse incorrect")
+	}
+
+	c.SelectPolygon([]Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}})
+	square := c.Selection()
+	if countBlack(square) != 16 || !square.At(3, 3) || square.At(4, 4) {
+		t.Errorf("masque du carré : %d pixels", countBlack(square))
+	}
+	square.Set(10, 10, true)
+	if c.Selection().At(10, 10) {
+		t.Error("Selection doit renvoyer une copie")
+	}
+
+	c.SelectAll()
+	if c.Selection() != nil {
+		t.Error("SelectAll doit effacer la sélection")
+	}
+}
+
+func TestCanvasFilterInSelection(t *testing.T) {
+	gray := Pixel{100, 100, 100}
+	c := NewCanvas(6, 6, gray)
+	c.SelectEllipse(Point{2, 2}, 1, 1)
+	c.Filter(func(ppm *PPM) { ppm.Invert() })
+
+	ppm := c.ToPPM()
+	inverted := Pixel{155, 155, 155}
+	if got := countColor(ppm, inverted); got != 9 {
+		t.Errorf("%d pixels inversés, attendu 9", got)
+	}
+	if ppm.At(2, 2) != inverted || ppm.At(0, 2) != gray {
+		t.Error("le filtre déborde de la sélection")
+	}
+}
@@ -0,0 +1,262 @@
+package Netpbm // ✨ Séquences d'images compressées
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Une séquence est un fichier unique qui contient de nombreuses images Netpbm, compressées une à une
+// pour pouvoir relire n'importe laquelle sans décompresser les autres :
+//
+//	en-tête   "NPBMSEQ" puis l'octet du codec
+//	images    chaque image dans son format Netpbm, compressée séparément
+//	index     position et taille de chaque image (2 × uint64)
+//	fin       position de l'index (uint64), nombre d'images (uint32) puis "NPBMIDX"
+//
+// Les entiers sont en petit-boutiste.
+const (
+	sequenceMagic      = "NPBMSEQ"
+	sequenceIndexMagic = "NPBMIDX"
+	sequenceTrailer    = 8 + 4 + len(sequenceIndexMagic)
+)
+
+// SequenceCodec désigne la compression des images d'une séquence.
+type SequenceCodec byte
+
+const (
+	SequenceStored  SequenceCodec = iota // Images non compressées
+	SequenceDeflate                      // Compression Deflate de la bibliothèque standard
+	SequenceZstd                         // Compression Zstandard, enregistrée en important le module zstd
+)
+
+// sequenceCodec compresse et décompresse les images d'une séquence.
+type sequenceCodec struct {
+	compress   func(io.Writer) (io.WriteCloser, error)
+	decompress func(io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	sequenceCodecsMu sync.RWMutex
+	sequenceCodecs   = map[SequenceCodec]sequenceCodec{
+		SequenceStored: {
+			compress:   func(w io.Writer) (io.WriteCloser, error) { return nopWriteCloser{w}, nil },
+			decompress: func(r io.Reader) (io.ReadCloser, error) { return io.NopCloser(r), nil },
+		},
+		SequenceDeflate: {
+			compress:   func(w io.Writer) (io.WriteCloser, error) { return flate.NewWriter(w, flate.DefaultCompression) },
+			decompress: func(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil },
+		},
+	}
+)
+
+// RegisterSequenceCodec installe la compression codec. Le paquet n'a aucune dépendance externe : pour
+// SequenceZstd, il faut importer le module facultatif github.com/YOYOPX15/Netpbm/zstd, qui l'enregistre,
+// avant de créer ou de lire une séquence qui l'utilise.
+func RegisterSequenceCodec(codec SequenceCodec, compress func(io.Writer) (io.WriteCloser, error), decompress func(io.Reader) (io.ReadCloser, error)) {
+	sequenceCodecsMu.Lock()
+	defer sequenceCodecsMu.Unlock()
+	sequenceCodecs[codec] = sequenceCodec{compress, decompress}
+}
+
+// lookupSequenceCodec renvoie la compression codec, ou une erreur si elle n'est pas enregistrée.
+func lookupSequenceCodec(codec SequenceCodec) (sequenceCodec, error) {
+	sequenceCodecsMu.RLock()
+	defer sequenceCodecsMu.RUnlock()
+	c, ok := sequenceCodecs[codec]
+	if !ok {
+		return c, fmt.Errorf("sequence codec %d is not registered", codec)
+	}
+	return c, nil
+}
+
+// nopWriteCloser ajoute une méthode Close sans effet à un io.Writer.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// SequenceWriter écrit une séquence d'images dans un fichier.
+type SequenceWriter struct {
+	file   *os.File
+	writer *bufio.Writer
+	codec  sequenceCodec
+	offset uint64      // Position de la prochaine image dans le fichier
+	index  [][2]uint64 // Position et taille de chaque image écrite
+}
+
+// CreateSequence crée le fichier filename et renvoie un SequenceWriter qui y ajoute des images
+// compressées avec codec. Close doit être appelée pour écrire l'index.
+func CreateSequence(filename string, codec SequenceCodec) (*SequenceWriter, error) {
+	c, err := lookupSequenceCodec(codec)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error creating file: %v", err)
+	}
+	w := &SequenceWriter{file: file, writer: bufio.NewWriter(file), codec: c}
+	w.writer.WriteString(sequenceMagic)
+	w.writer.WriteByte(byte(codec))
+	w.offset = uint64(len(sequenceMagic) + 1)
+	return w, nil
+}
+
+// Len renvoie le nombre d'images déjà ajoutées.
+func (w *SequenceWriter) Len() int {
+	return len(w.index)
+}
+
+// Add ajoute une image *PBM, *PGM ou *PPM à la fin de la séquence.
+func (w *SequenceWriter) Add(img Image) error {
+	var frame bytes.Buffer
+	compressor, err := w.codec.compress(&frame)
+	if err != nil {
+		return fmt.Errorf("error creating compressor: %v", err)
+	}
+	if err := encodeImage(compressor, img); err != nil {
+		return err
+	}
+	if err := compressor.Close(); err != nil {
+		return fmt.Errorf("error compressing frame: %v", err)
+	}
+	if _, err := w.writer.Write(frame.Bytes()); err != nil {
+		return fmt.Errorf("error writing frame: %v", err)
+	}
+	w.index = append(w.index, [2]uint64{w.offset, uint64(frame.Len())})
+	w.offset += uint64(frame.Len())
+	return nil
+}
+
+// Close écrit l'index et ferme le fichier.
+func (w *SequenceWriter) Close() error {
+	defer w.file.Close()
+	entry := make([]byte, 16)
+	for _, e := range w.index {
+		binary.LittleEndian.PutUint64(entry, e[0])
+		binary.LittleEndian.PutUint64(entry[8:], e[1])
+		w.writer.Write(entry)
+	}
+	trailer := make([]byte, 12, sequenceTrailer)
+	binary.LittleEndian.PutUint64(trailer, w.offset)
+	binary.LittleEndian.PutUint32(trailer[8:], uint32(len(w.index)))
+	w.writer.Write(append(trailer, sequenceIndexMagic...))
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("error writing index: %v", err)
+	}
+	return w.file.Close()
+}
+
+// SequenceReader lit les images d'une séquence dans un ordre quelconque. Frame peut être appelée
+// depuis plusieurs goroutines à la fois.
+type SequenceReader struct {
+	file  *os.File
+	codec sequenceCodec
+	index [][2]uint64
+}
+
+// OpenSequence ouvre la séquence filename et lit son index.
+func OpenSequence(filename string) (*SequenceReader, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	r, err := readSequenceIndex(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// readSequenceIndex vérifie l'en-tête et la fin du fichier, puis lit l'index.
+func readSequenceIndex(file *os.File) (*SequenceReader, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error reading file size: %v", err)
+	}
+	header := make([]byte, len(sequenceMagic)+1)
+	if _, err := file.ReadAt(header, 0); err != nil || string(header[:len(sequenceMagic)]) != sequenceMagic {
+		return nil, fmt.Errorf("not a sequence file")
+	}
+	codec, err := lookupSequenceCodec(SequenceCodec(header[len(sequenceMagic)]))
+	if err != nil {
+		return nil, err
+	}
+
+	trailer := make([]byte, sequenceTrailer)
+	if info.Size() < int64(len(header)+sequenceTrailer) {
+		return nil, fmt.Errorf("sequence file too short, index missing")
+	}
+	if _, err := file.ReadAt(trailer, info.Size()-int64(sequenceTrailer)); err != nil || string(trailer[12:]) != sequenceIndexMagic {
+		return nil, fmt.Errorf("sequence index missing, the file may not have been closed")
+	}
+	indexOffset := binary.LittleEndian.Uint64(trailer)
+	count := uint64(binary.LittleEndian.Uint32(trailer[8:]))
+	// L'index tient entre l'en-tête et la fin : count est borné avant tout calcul ou allocation
+	indexEnd := uint64(info.Size() - int64(sequenceTrailer))
+	if count > (indexEnd-uint64(len(header)))/16 || indexOffset != indexEnd-16*count {
+		return nil, fmt.Errorf("sequence index does not match the file size")
+	}
+
+	entries := make([]byte, 16*count)
+	if _, err := file.ReadAt(entries, int64(indexOffset)); err != nil {
+		return nil, fmt.Errorf("error reading index: %v", err)
+	}
+	r := &SequenceReader{file: file, codec: codec, index: make([][2]uint64, count)}
+	for i := range r.index {
+		r.index[i] = [2]uint64{binary.LittleEndian.Uint64(entries[16*i:]), binary.LittleEndian.Uint64(entries[16*i+8:])}
+		if r.index[i][0] > indexOffset || r.index[i][1] > indexOffset-r.index[i][0] {
+			return nil, fmt.Errorf("frame %d lies outside the frame data", i)
+		}
+	}
+	return r, nil
+}
+
+// Len renvoie le nombre d'images de la séquence.
+func (r *SequenceReader) Len() int {
+	return len(r.index)
+}
+
+// Frame lit et décompresse l'image numéro i (à partir de 0).
+func (r *SequenceReader) Frame(i int) (Image, error) {
+	if i < 0 || i >= len(r.index) {
+		return nil, fmt.Errorf("frame %d out of range [0, %d)", i, len(r.index))
+	}
+	section := io.NewSectionReader(r.file, int64(r.index[i][0]), int64(r.index[i][1]))
+	decompressor, err := r.codec.decompress(section)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing frame %d: %v", i, err)
+	}
+	defer decompressor.Close()
+	img, err := decodeNetpbm(decompressor)
+	if err != nil {
+		return nil, fmt.Errorf("frame %d: %v", i, err)
+	}
+	return img, nil
+}
+
+// Close ferme le fichier de la séquence.
+func (r *SequenceReader) Close() error {
+	return r.file.Close()
+}
+
+// encodeImage écrit l'image *PBM, *PGM ou *PPM dans w, dans son format Netpbm.
+func encodeImage(w io.Writer, img Image) error {
+	switch img := img.(type) {
+	case *PBM:
+		return img.encode(w)
+	case *PGM:
+		return img.encode(w)
+	case *PPM:
+		return img.encode(w)
+	}
+	return fmt.Errorf("unsupported image type %T", img)
+}
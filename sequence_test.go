@@ -0,0 +1,167 @@
+package Netpbm // 🧪 Test séquences d'images compressées
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// sequenceFrame renvoie l'image numéro i d'une séquence de test, en alternant PGM et PPM.
+func sequenceFrame(i int) Image {
+	if i%2 == 0 {
+		pgm := newGradientPGM(32, 24)
+		pgm.SetMagicNumber("P5")
+		pgm.InvertRegion(Rect{i % 32, 0, 4, 24})
+		return pgm
+	}
+	ppm := newPatternPPM(32, 24)
+	ppm.SetMagicNumber("P6")
+	ppm.Set(i%32, i%24, Pixel{255, 0, 0})
+	return ppm
+}
+
+// frameHash renvoie l'empreinte d'une image PGM ou PPM.
+func frameHash(img Image) string {
+	switch img := img.(type) {
+	case *PGM:
+		return img.Hash()
+	case *PPM:
+		return img.Hash()
+	}
+	return ""
+}
+
+func TestSequence(t *testing.T) {
+	for _, codec := range []SequenceCodec{SequenceStored, SequenceDeflate} {
+		filename := filepath.Join(t.TempDir(), "frames.seq")
+		w, err := CreateSequence(filename, codec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 40; i++ {
+			if err := w.Add(sequenceFrame(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := OpenSequence(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Len() != 40 {
+			t.Fatalf("Expected 40 frames, got %d", r.Len())
+		}
+		// Lecture dans le désordre, depuis plusieurs goroutines
+		var wg sync.WaitGroup
+		for _, i := range []int{39, 0, 17, 8, 8, 23} {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				img, err := r.Frame(i)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if frameHash(img) != frameHash(sequenceFrame(i)) {
+					t.Errorf("Codec %d: frame %d differs", codec, i)
+				}
+			}(i)
+		}
+		wg.Wait()
+		if _, err := r.Frame(40); err == nil {
+			t.Error("Expected an error for a frame out of range")
+		}
+		r.Close()
+	}
+}
+
+func TestSequenceCompression(t *testing.T) {
+	dir := t.TempDir()
+	sizes := map[SequenceCodec]int64{}
+	for _, codec := range []SequenceCodec{SequenceStored, SequenceDeflate} {
+		filename := filepath.Join(dir, "frames.seq")
+		w, _ := CreateSequence(filename, codec)
+		for i := 0; i < 10; i++ {
+			w.Add(sequenceFrame(i))
+		}
+		w.Close()
+		info, _ := os.Stat(filename)
+		sizes[codec] = info.Size()
+	}
+	if sizes[SequenceDeflate] >= sizes[SequenceStored] {
+		t.Errorf("Deflate should reduce the size: %v", sizes)
+	}
+}
+
+func TestSequenceCodecs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "frames.seq")
+	if _, err := CreateSequence(filename, SequenceZstd); err == nil {
+		t.Error("Zstandard should need a registered codec")
+	}
+
+	// Un codec enregistré par l'utilisateur, ici gzip
+	const custom SequenceCodec = 200
+	RegisterSequenceCodec(custom,
+		func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+		func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) })
+	w, err := CreateSequence(filename, custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Add(sequenceFrame(3))
+	w.Close()
+	r, err := OpenSequence(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if img, err := r.Frame(0); err != nil || frameHash(img) != frameHash(sequenceFrame(3)) {
+		t.Errorf("Unexpected frame with a custom codec: %v", err)
+	}
+}
+
+func TestSequenceNotClosed(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "frames.seq")
+	w, _ := CreateSequence(filename, SequenceDeflate)
+	w.Add(sequenceFrame(0))
+	w.writer.Flush()
+	if _, err := OpenSequence(filename); err == nil {
+		t.Error("Expected an error for a sequence without index")
+	}
+	w.Close()
+}
+
+func TestSequenceCorruptIndex(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "frames.seq")
+	w, _ := CreateSequence(filename, SequenceStored)
+	w.Add(sequenceFrame(0))
+	w.Close()
+	content, _ := os.ReadFile(filename)
+
+	// Nombre d'images énorme, la position de l'index étant choisie pour que le calcul déborde
+	indexEnd := uint64(len(content) - sequenceTrailer)
+	trailer := content[len(content)-sequenceTrailer:]
+	binary.LittleEndian.PutUint32(trailer[8:], 0xFFFFFFFF)
+	binary.LittleEndian.PutUint64(trailer, indexEnd-16*0xFFFFFFFF)
+	os.WriteFile(filename, content, 0644)
+	if _, err := OpenSequence(filename); err == nil {
+		t.Error("Expected an error for an index larger than the file")
+	}
+
+	// Image dont la position plus la taille déborde
+	binary.LittleEndian.PutUint32(trailer[8:], 1)
+	binary.LittleEndian.PutUint64(trailer, indexEnd-16)
+	binary.LittleEndian.PutUint64(content[indexEnd-16:], 1<<63)
+	binary.LittleEndian.PutUint64(content[indexEnd-8:], 1<<63)
+	os.WriteFile(filename, content, 0644)
+	if _, err := OpenSequence(filename); err == nil {
+		t.Error("Expected an error for a frame outside the file")
+	}
+}
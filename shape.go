@@ -0,0 +1,71 @@
+package Netpbm // ✨ Formes et tests d'appartenance
+
+import "math"
+
+// Shape est une forme dont on peut tester si elle contient un pixel, par exemple pour sélectionner
+// un objet dessiné sur une image. Rect, Polygon et Circle la satisfont.
+type Shape interface {
+	Contains(p Point) bool
+}
+
+// Polygon est un polygone fermé, donné par ses sommets.
+type Polygon []Point
+
+// Contains indique si le pixel p fait partie du polygone tel que DrawFilledPolygon le peint : pixel de
+// son contour, tracé comme par DrawPolygon, ou pixel dont le centre est à l'intérieur (règle pair-impair).
+func (poly Polygon) Contains(p Point) bool {
+	if poly.onOutline(p) {
+		return true
+	}
+	if len(poly) < 3 {
+		return false
+	}
+	inside := false
+	for _, c := range scanlineCrossings(poly.toPointF(), float64(p.Y), nil) {
+		if int(math.Ceil(c)) <= p.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// onOutline indique si le pixel p est sur l'un des côtés du polygone, tracés par l'algorithme de
+// Bresenham dans le même sens que DrawPolygon.
+func (poly Polygon) onOutline(p Point) bool {
+	found := false
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		if p.X < min(a.X, b.X) || p.X > max(a.X, b.X) || p.Y < min(a.Y, b.Y) || p.Y > max(a.Y, b.Y) {
+			continue
+		}
+		bresenham(a, b, func(q Point) {
+			found = found || q == p
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// toPointF convertit les sommets du polygone en coordonnées réelles.
+func (poly Polygon) toPointF() []pointF {
+	points := make([]pointF, len(poly))
+	for i, p := range poly {
+		points[i] = pointF{float64(p.X), float64(p.Y)}
+	}
+	return points
+}
+
+// Circle est un disque de centre Center et de rayon Radius.
+type Circle struct {
+	Center Point
+	Radius int
+}
+
+// Contains indique si le pixel p est à l'intérieur du disque tel que DrawFilledCircle le peint : son
+// centre est à une distance strictement inférieure au rayon.
+func (c Circle) Contains(p Point) bool {
+	dx, dy := p.X-c.Center.X, p.Y-c.Center.Y
+	return dx*dx+dy*dy < c.Radius*c.Radius
+}
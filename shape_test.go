@@ -0,0 +1,75 @@
+package Netpbm // 🧪 Test formes et tests d'appartenance
+
+import "testing"
+
+func TestPolygonContains(t *testing.T) {
+	square := Polygon{{2, 2}, {6, 2}, {6, 6}, {2, 6}}
+	// Le contour fait partie du polygone, comme pour DrawFilledPolygon
+	for _, p := range []Point{{2, 2}, {5, 5}, {3, 4}, {6, 3}, {3, 6}} {
+		if !square.Contains(p) {
+			t.Errorf("%v should be inside", p)
+		}
+	}
+	for _, p := range []Point{{7, 3}, {3, 7}, {1, 3}, {0, 0}} {
+		if square.Contains(p) {
+			t.Errorf("%v should be outside", p)
+		}
+	}
+	segment := Polygon{{0, 0}, {5, 5}}
+	if !segment.Contains(Point{2, 2}) || segment.Contains(Point{2, 3}) {
+		t.Error("Degenerate polygon should contain its segment only")
+	}
+}
+
+// checkMatchesDrawing vérifie que shape contient exactement les pixels que draw peint sur une image noire.
+func checkMatchesDrawing(t *testing.T, shape Shape, draw func(ppm *PPM, color Pixel)) {
+	t.Helper()
+	white := Pixel{255, 255, 255}
+	ppm := NewPPM(21, 21, 255)
+	draw(ppm, white)
+	for y := 0; y < 21; y++ {
+		for x := 0; x < 21; x++ {
+			if shape.Contains(Point{x, y}) != (ppm.data[y][x] == white) {
+				t.Fatalf("Contains and drawing disagree at (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+func TestPolygonContainsMatchesFill(t *testing.T) {
+	star := Polygon{{10, 0}, {13, 18}, {0, 7}, {20, 7}, {7, 18}}
+	checkMatchesDrawing(t, star, func(ppm *PPM, color Pixel) { ppm.DrawFilledPolygon(star, color) })
+	// Règle pair-impair : le centre de l'étoile est un trou
+	if star.Contains(Point{10, 10}) {
+		t.Error("Star center should be outside with the even-odd rule")
+	}
+	for _, poly := range []Polygon{
+		{{2, 2}, {18, 5}, {10, 19}},
+		{{1, 1}, {19, 1}, {19, 19}, {10, 8}, {1, 19}},
+	} {
+		checkMatchesDrawing(t, poly, func(ppm *PPM, color Pixel) { ppm.DrawFilledPolygon(poly, color) })
+	}
+}
+
+func TestCircleContains(t *testing.T) {
+	circle := Circle{Center: Point{5, 5}, Radius: 3}
+	if !circle.Contains(Point{5, 5}) || !circle.Contains(Point{7, 5}) || !circle.Contains(Point{7, 7}) {
+		t.Error("Center and inner points should be inside")
+	}
+	// Distance égale au rayon : hors du disque, comme pour DrawFilledCircle
+	if circle.Contains(Point{8, 5}) || circle.Contains(Point{8, 8}) {
+		t.Error("Edge and far points should be outside")
+	}
+	for _, c := range []Circle{{Point{10, 10}, 5}, {Point{3, 17}, 6}, {Point{10, 10}, 1}} {
+		checkMatchesDrawing(t, c, func(ppm *PPM, color Pixel) { ppm.DrawFilledCircle(c.Center, c.Radius, color) })
+	}
+}
+
+func TestShapeInterface(t *testing.T) {
+	shapes := []Shape{Rect{0, 0, 4, 4}, Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}, Circle{Point{2, 2}, 1}}
+	for i, shape := range shapes {
+		if !shape.Contains(Point{2, 2}) || shape.Contains(Point{10, 10}) {
+			t.Errorf("Shape %d gives a wrong answer", i)
+		}
+	}
+}
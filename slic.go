@@ -0,0 +1,217 @@
+package Netpbm // ✨ Superpixels SLIC
+
+import "math"
+
+// slicIterations est le nombre de passes de l'algorithme SLIC, qui converge en général en moins de dix.
+const slicIterations = 10
+
+// Superpixels est le découpage d'une image en petites zones connexes de couleur homogène.
+type Superpixels struct {
+	Labels [][]int // Numéro du superpixel de chaque pixel, indexé par [y][x], de 0 à len(Colors)-1
+	Colors []Pixel // Couleur moyenne de chaque superpixel
+}
+
+// slicCenter est un centre de superpixel : sa couleur et sa position.
+type slicCenter struct {
+	r, g, b, x, y float64
+}
+
+// SLIC découpe l'image PPM en environ numSegments superpixels par l'algorithme SLIC (Simple Linear
+// Iterative Clustering) : des k-moyennes sur la couleur et la position, chaque centre ne cherchant ses
+// pixels que dans un voisinage de deux fois la taille de la grille. Compactness règle l'importance de
+// la position face à la couleur (les couleurs allant de 0 à 255) : vers 10, les superpixels suivent les
+// contours de l'image ; vers 40 et au-delà, ils deviennent des carrés réguliers. Les morceaux détachés
+// d'un superpixel sont ensuite rattachés à un voisin, si bien que chaque superpixel est d'un seul tenant.
+func (ppm *PPM) SLIC(numSegments int, compactness float64) Superpixels {
+	width, height := ppm.width, ppm.height
+	labels := make([][]int, height)
+	for y := range labels {
+		labels[y] = make([]int, width)
+	}
+	if width == 0 || height == 0 {
+		return Superpixels{Labels: labels}
+	}
+	step := max(int(math.Round(math.Sqrt(float64(width*height)/float64(max(numSegments, 1))))), 1)
+	color := func(x, y int) (float64, float64, float64) {
+		p := ppm.data[y][x]
+		return float64(p.R), float64(p.G), float64(p.B)
+	}
+
+	// Centres initiaux sur une grille régulière, déplacés vers le plus faible gradient de leur voisinage
+	// pour ne pas démarrer sur un contour
+	gradient := func(x, y int) float64 {
+		if x < 1 || y < 1 || x >= width-1 || y >= height-1 {
+			return math.Inf(1)
+		}
+		r1, g1, b1 := color(x+1, y)
+		r2, g2, b2 := color(x-1, y)
+		r3, g3, b3 := color(x, y+1)
+		r4, g4, b4 := color(x, y-1)
+		return (r1-r2)*(r1-r2) + (g1-g2)*(g1-g2) + (b1-b2)*(b1-b2) + (r3-r4)*(r3-r4) + (g3-g4)*(g3-g4) + (b3-b4)*(b3-b4)
+	}
+	var centers []slicCenter
+	for y := step / 2; y < height; y += step {
+		for x := step / 2; x < width; x += step {
+			best := Point{x, y}
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if gradient(x+dx, y+dy) < gradient(best.X, best.Y) {
+						best = Point{x + dx, y + dy}
+					}
+				}
+			}
+			r, g, b := color(best.X, best.Y)
+			centers = append(centers, slicCenter{r, g, b, float64(best.X), float64(best.Y)})
+		}
+	}
+
+	distances := make([][]float64, height)
+	for y := range distances {
+		distances[y] = make([]float64, width)
+	}
+	weight := compactness * compactness / float64(step*step)
+	for iteration := 0; iteration < slicIterations; iteration++ {
+		for y := range distances {
+			for x := range distances[y] {
+				distances[y][x] = math.Inf(1)
+			}
+		}
+		for i, c := range centers {
+			cx, cy := int(math.Round(c.x)), int(math.Round(c.y))
+			for y := max(cy-step, 0); y < min(cy+step+1, height); y++ {
+				for x := max(cx-step, 0); x < min(cx+step+1, width); x++ {
+					r, g, b := color(x, y)
+					dx, dy := float64(x)-c.x, float64(y)-c.y
+					d := (r-c.r)*(r-c.r) + (g-c.g)*(g-c.g) + (b-c.b)*(b-c.b) + (dx*dx+dy*dy)*weight
+					if d < distances[y][x] {
+						distances[y][x], labels[y][x] = d, i
+					}
+				}
+			}
+		}
+		sums := make([]slicCenter, len(centers))
+		counts := make([]float64, len(centers))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				r, g, b := color(x, y)
+				s := &sums[labels[y][x]]
+				s.r, s.g, s.b, s.x, s.y = s.r+r, s.g+g, s.b+b, s.x+float64(x), s.y+float64(y)
+				counts[labels[y][x]]++
+			}
+		}
+		for i, s := range sums {
+			if n := counts[i]; n > 0 {
+				centers[i] = slicCenter{s.r / n, s.g / n, s.b / n, s.x / n, s.y / n}
+			}
+		}
+	}
+
+	return ppm.superpixels(enforceConnectivity(labels, step*step/4))
+}
+
+// enforceConnectivity renumérote les morceaux connexes (4-voisinage) de la carte labels à partir de 0,
+// en rattachant au morceau voisin déjà numéroté ceux qui ont moins de minSize pixels.
+func enforceConnectivity(labels [][]int, minSize int) [][]int {
+	height, width := len(labels), len(labels[0])
+	result := make([][]int, height)
+	for y := range result {
+		result[y] = make([]int, width)
+		for x := range result[y] {
+			result[y][x] = -1
+		}
+	}
+	steps := [4]Point{{-1, 0}, {0, -1}, {1, 0}, {0, 1}}
+	next := 0
+	var segment []Point
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if result[y][x] >= 0 {
+				continue
+			}
+			// Numéro d'un morceau voisin déjà traité, qui recevra ce morceau s'il est trop petit
+			adjacent := -1
+			for _, s := range steps {
+				if n := (Point{x + s.X, y + s.Y}); n.X >= 0 && n.Y >= 0 && n.X < width && n.Y < height && result[n.Y][n.X] >= 0 {
+					adjacent = result[n.Y][n.X]
+				}
+			}
+			segment = append(segment[:0], Point{x, y})
+			result[y][x] = next
+			for i := 0; i < len(segment); i++ {
+				p := segment[i]
+				for _, s := range steps {
+					n := Point{p.X + s.X, p.Y + s.Y}
+					if n.X >= 0 && n.Y >= 0 && n.X < width && n.Y < height && result[n.Y][n.X] < 0 && labels[n.Y][n.X] == labels[y][x] {
+						result[n.Y][n.X] = next
+						segment = append(segment, n)
+					}
+				}
+			}
+			if len(segment) < minSize && adjacent >= 0 {
+				for _, p := range segment {
+					result[p.Y][p.X] = adjacent
+				}
+				continue
+			}
+			next++
+		}
+	}
+	return result
+}
+
+// superpixels calcule la couleur moyenne de chaque superpixel de la carte labels, numérotée sans trou.
+func (ppm *PPM) superpixels(labels [][]int) Superpixels {
+	count := 0
+	for _, row := range labels {
+		for _, label := range row {
+			count = max(count, label+1)
+		}
+	}
+	sums := make([][4]int, count)
+	for y, row := range labels {
+		for x, label := range row {
+			p := ppm.data[y][x]
+			s := &sums[label]
+			s[0], s[1], s[2], s[3] = s[0]+int(p.R), s[1]+int(p.G), s[2]+int(p.B), s[3]+1
+		}
+	}
+	colors := make([]Pixel, count)
+	for i, s := range sums {
+		if s[3] > 0 {
+			colors[i] = Pixel{uint8((s[0] + s[3]/2) / s[3]), uint8((s[1] + s[3]/2) / s[3]), uint8((s[2] + s[3]/2) / s[3])}
+		}
+	}
+	return Superpixels{Labels: labels, Colors: colors}
+}
+
+// ToPPM renvoie l'image où chaque pixel prend la couleur moyenne de son superpixel, de valeur
+// maximale maxValue (celle de l'image découpée).
+func (s Superpixels) ToPPM(maxValue int) *PPM {
+	height, width := len(s.Labels), 0
+	if height > 0 {
+		width = len(s.Labels[0])
+	}
+	ppm := NewPPM(width, height, maxValue)
+	for y, row := range s.Labels {
+		for x, label := range row {
+			ppm.data[y][x] = s.Colors[label]
+		}
+	}
+	return ppm
+}
+
+// Borders renvoie le masque (true : bord) des pixels dont le voisin de droite ou du dessous appartient
+// à un autre superpixel, à superposer à l'image avec FillMask pour un outil d'annotation.
+func (s Superpixels) Borders() *PBM {
+	height, width := len(s.Labels), 0
+	if height > 0 {
+		width = len(s.Labels[0])
+	}
+	pbm := NewPBM(width, height)
+	for y, row := range s.Labels {
+		for x, label := range row {
+			pbm.data[y][x] = (x+1 < width && row[x+1] != label) || (y+1 < height && s.Labels[y+1][x] != label)
+		}
+	}
+	return pbm
+}
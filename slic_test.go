@@ -0,0 +1,52 @@
+package Netpbm // 🧪 Test Superpixels SLIC
+
+import "testing"
+
+func TestSLICFollowsEdges(t *testing.T) {
+	// Deux moitiés de couleurs très différentes, coupées hors de la grille des superpixels
+	ppm := newUniformPPM(40, 40, Pixel{220, 40, 40})
+	for y := 0; y < 40; y++ {
+		for x := 17; x < 40; x++ {
+			ppm.Set(x, y, Pixel{40, 40, 220})
+		}
+	}
+	s := ppm.SLIC(16, 10)
+	if len(s.Colors) < 8 || len(s.Colors) > 32 {
+		t.Errorf("%d superpixels, attendu environ 16", len(s.Colors))
+	}
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			if s.Colors[s.Labels[y][x]] != ppm.At(x, y) {
+				t.Fatalf("le superpixel de (%d, %d) chevauche les deux moitiés : %v", x, y, s.Colors[s.Labels[y][x]])
+			}
+		}
+	}
+
+	average := s.ToPPM(255)
+	if countColor(average, Pixel{220, 40, 40}) != 17*40 {
+		t.Error("ToPPM doit reprendre la couleur moyenne de chaque superpixel")
+	}
+	borders := s.Borders()
+	if !borders.At(16, 20) {
+		t.Error("la limite entre les deux moitiés doit être un bord")
+	}
+}
+
+func TestSLICConnectedSegments(t *testing.T) {
+	ppm := newPatternPPM(30, 20)
+	s := ppm.SLIC(12, 20)
+	// Chaque numéro ne doit former qu'un seul morceau connexe
+	relabeled := enforceConnectivity(s.Labels, 0)
+	seen := map[int]int{}
+	for y := range s.Labels {
+		for x, label := range s.Labels[y] {
+			if other, ok := seen[label]; ok && other != relabeled[y][x] {
+				t.Fatalf("le superpixel %d est en plusieurs morceaux", label)
+			}
+			seen[label] = relabeled[y][x]
+		}
+	}
+	if len(seen) != len(s.Colors) {
+		t.Errorf("%d numéros utilisés pour %d couleurs", len(seen), len(s.Colors))
+	}
+}
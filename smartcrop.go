@@ -0,0 +1,59 @@
+package Netpbm // ✨ Recadrage intelligent
+
+// SmartCrop renvoie une vignette width×height de l'image PPM : parmi les plus grandes zones aux bonnes
+// proportions, celle qui contient le plus de contours est gardée, puis redimensionnée.
+// La vignette montre ainsi la partie intéressante de l'image plutôt que son centre.
+func (ppm *PPM) SmartCrop(width, height int) *PPM {
+	if width <= 0 || height <= 0 || ppm.width == 0 || ppm.height == 0 {
+		return ppm.Resize(width, height, Bilinear)
+	}
+	r := ppm.smartCropRect(width, height)
+	cropped := NewPPM(r.Width, r.Height, ppm.max)
+	cropped.magicNumber = ppm.magicNumber
+	for y := 0; y < r.Height; y++ {
+		copy(cropped.data[y], ppm.data[r.Y+y][r.X:r.X+r.Width])
+	}
+	return cropped.Resize(width, height, Bilinear)
+}
+
+// smartCropRect renvoie la zone aux proportions width×height qui contient le plus de contours.
+func (ppm *PPM) smartCropRect(width, height int) Rect {
+	// Plus grande zone aux bonnes proportions
+	cw, ch := ppm.width, ppm.height
+	if ppm.width*height > ppm.height*width {
+		cw = max(min((ppm.height*width+height/2)/height, ppm.width), 1)
+	} else {
+		ch = max(min((ppm.width*height+width/2)/width, ppm.height), 1)
+	}
+
+	// Somme cumulée de l'énergie des contours, pour évaluer chaque zone en temps constant
+	sums := make([][]int, ppm.height+1)
+	sums[0] = make([]int, ppm.width+1)
+	for y := 0; y < ppm.height; y++ {
+		sums[y+1] = make([]int, ppm.width+1)
+		for x := 0; x < ppm.width; x++ {
+			b := ppm.data[y][x].brightness()
+			energy := 0
+			if x+1 < ppm.width {
+				energy += abs(ppm.data[y][x+1].brightness() - b)
+			}
+			if y+1 < ppm.height {
+				energy += abs(ppm.data[y+1][x].brightness() - b)
+			}
+			sums[y+1][x+1] = energy + sums[y][x+1] + sums[y+1][x] - sums[y][x]
+		}
+	}
+
+	// À score égal, la zone la plus proche du centre est préférée
+	best, bestScore, bestOffset := Rect{}, -1, 0
+	for y := 0; y+ch <= ppm.height; y++ {
+		for x := 0; x+cw <= ppm.width; x++ {
+			score := sums[y+ch][x+cw] - sums[y][x+cw] - sums[y+ch][x] + sums[y][x]
+			offset := abs(2*x+cw-ppm.width) + abs(2*y+ch-ppm.height)
+			if score > bestScore || (score == bestScore && offset < bestOffset) {
+				best, bestScore, bestOffset = Rect{x, y, cw, ch}, score, offset
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,38 @@
+package Netpbm // 🧪 Test recadrage intelligent
+
+import "testing"
+
+func TestSmartCrop(t *testing.T) {
+	// Image large et unie, avec un damier sur la droite
+	ppm := newUniformPPM(60, 20, Pixel{100, 100, 100})
+	for y := 4; y < 16; y++ {
+		for x := 44; x < 56; x++ {
+			if (x+y)%2 == 0 {
+				ppm.data[y][x] = Pixel{255, 255, 255}
+			}
+		}
+	}
+	r := ppm.smartCropRect(10, 10)
+	if r.Width != 20 || r.Height != 20 || r.X < 36 || r.X > 44 {
+		t.Errorf("Crop should cover the checkerboard, got %+v", r)
+	}
+
+	thumbnail := ppm.SmartCrop(10, 10)
+	if width, height := thumbnail.Size(); width != 10 || height != 10 {
+		t.Fatalf("Wrong thumbnail size %dx%d", width, height)
+	}
+	if thumbnail.At(0, 0) != (Pixel{100, 100, 100}) || countColor(thumbnail, Pixel{100, 100, 100}) == 100 {
+		t.Error("Thumbnail should show the checkerboard")
+	}
+}
+
+func TestSmartCropUniform(t *testing.T) {
+	// Sans contours, la zone centrale est gardée
+	ppm := newUniformPPM(30, 10, Pixel{50, 60, 70})
+	if r := ppm.smartCropRect(1, 1); r != (Rect{10, 0, 10, 10}) {
+		t.Errorf("Expected the centered crop, got %+v", r)
+	}
+	if r := ppm.smartCropRect(6, 1); r != (Rect{0, 2, 30, 5}) {
+		t.Errorf("Expected a centered full-width crop, got %+v", r)
+	}
+}
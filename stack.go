@@ -0,0 +1,234 @@
+package Netpbm // ✨ Empilement d'images
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// stackMedianBudget est le nombre maximal de valeurs gardées en mémoire à la fois par StackMedianFrames.
+const stackMedianBudget = 1 << 22
+
+// FrameSource fournit une à une les images d'une pile, sans qu'elles aient à être toutes en mémoire.
+// SequenceReader la satisfait, et PGMFrames adapte une liste d'images déjà chargées.
+type FrameSource interface {
+	Len() int
+	Frame(i int) (Image, error)
+}
+
+// PGMFrames est une pile d'images PGM déjà en mémoire, utilisable comme FrameSource.
+type PGMFrames []*PGM
+
+// Len renvoie le nombre d'images de la pile.
+func (f PGMFrames) Len() int {
+	return len(f)
+}
+
+// Frame renvoie l'image numéro i de la pile.
+func (f PGMFrames) Frame(i int) (Image, error) {
+	return f[i], nil
+}
+
+// StackMean renvoie la moyenne, pixel par pixel, d'images PGM de même taille prises dans les mêmes
+// conditions (poses successives d'un ciel ou d'un échantillon au microscope) : le bruit aléatoire
+// diminue comme la racine carrée du nombre d'images.
+func StackMean(frames []*PGM) (*PGM, error) {
+	return StackMeanFrames(PGMFrames(frames))
+}
+
+// StackMeanFrames renvoie la moyenne des images PGM de source, comme StackMean. Chaque image n'est lue
+// qu'une fois et une seule image est en mémoire à la fois.
+func StackMeanFrames(source FrameSource) (*PGM, error) {
+	stack, err := newPGMStack(source)
+	if err != nil {
+		return nil, err
+	}
+	sum := stack.plane()
+	err = stack.each(func(y, x int, v float64) {
+		sum[y][x] += v
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stack.result(func(y, x int) float64 {
+		return sum[y][x] / float64(stack.count)
+	}), nil
+}
+
+// StackMedian renvoie la médiane, pixel par pixel, d'images PGM de même taille. Moins sensible que la
+// moyenne aux valeurs aberrantes (satellite, rayon cosmique, pixel chaud), elle réduit un peu moins le
+// bruit.
+func StackMedian(frames []*PGM) (*PGM, error) {
+	return StackMedianFrames(PGMFrames(frames))
+}
+
+// StackMedianFrames renvoie la médiane des images PGM de source, comme StackMedian. La médiane demande
+// toutes les valeurs d'un pixel : l'image est traitée par bandes de lignes, chaque bande relisant toutes
+// les images, pour ne garder en mémoire que quelques millions de valeurs à la fois.
+func StackMedianFrames(source FrameSource) (*PGM, error) {
+	stack, err := newPGMStack(source)
+	if err != nil {
+		return nil, err
+	}
+	band := min(max(stackMedianBudget/max(stack.width*stack.count, 1), 1), stack.height)
+	median := stack.plane()
+	values := make([]float64, 0, band*stack.width*stack.count)
+	for top := 0; top < stack.height; top += band {
+		bottom := min(top+band, stack.height)
+		values = values[:(bottom-top)*stack.width*stack.count]
+		frame := 0
+		err := stack.eachFrame(func(pgm *PGM, scale float64) {
+			for y := top; y < bottom; y++ {
+				for x, v := range pgm.data[y] {
+					values[((y-top)*stack.width+x)*stack.count+frame] = float64(v) * scale
+				}
+			}
+			frame++
+		})
+		if err != nil {
+			return nil, err
+		}
+		for y := top; y < bottom; y++ {
+			for x := 0; x < stack.width; x++ {
+				i := ((y-top)*stack.width + x) * stack.count
+				pixel := values[i : i+stack.count]
+				slices.Sort(pixel)
+				median[y][x] = (pixel[(stack.count-1)/2] + pixel[stack.count/2]) / 2
+			}
+		}
+	}
+	return stack.result(func(y, x int) float64 { return median[y][x] }), nil
+}
+
+// StackSigmaClip renvoie la moyenne, pixel par pixel, d'images PGM de même taille, en écartant les
+// valeurs à plus de kappa écarts types de la moyenne de leur pixel. L'écartement est répété iterations
+// fois, moyenne et écart type étant recalculés sur les valeurs restantes. C'est la méthode usuelle en
+// astrophotographie : presque aussi efficace que la moyenne contre le bruit, et insensible comme la médiane
+// aux valeurs aberrantes. Avec kappa entre 2 et 3 et 2 ou 3 itérations, les traces de satellites disparaissent.
+func StackSigmaClip(frames []*PGM, kappa float64, iterations int) (*PGM, error) {
+	return StackSigmaClipFrames(PGMFrames(frames), kappa, iterations)
+}
+
+// StackSigmaClipFrames renvoie la moyenne écrêtée des images PGM de source, comme StackSigmaClip. Une
+// seule image est en mémoire à la fois, mais toutes sont relues à chaque itération.
+func StackSigmaClipFrames(source FrameSource, kappa float64, iterations int) (*PGM, error) {
+	if kappa <= 0 {
+		return nil, fmt.Errorf("invalid kappa %g, must be positive", kappa)
+	}
+	stack, err := newPGMStack(source)
+	if err != nil {
+		return nil, err
+	}
+	// Moyenne et écart type des valeurs retenues de chaque pixel : au départ, toutes les valeurs
+	mean, deviation := stack.plane(), stack.plane()
+	for pass := 0; pass <= max(iterations, 0); pass++ {
+		sum, squares, count := stack.plane(), stack.plane(), stack.plane()
+		err := stack.each(func(y, x int, v float64) {
+			if pass > 0 && math.Abs(v-mean[y][x]) > kappa*deviation[y][x] {
+				return
+			}
+			sum[y][x] += v
+			squares[y][x] += v * v
+			count[y][x]++
+		})
+		if err != nil {
+			return nil, err
+		}
+		for y := range mean {
+			for x := range mean[y] {
+				// Sans valeur retenue, le pixel garde sa moyenne précédente
+				if n := count[y][x]; n > 0 {
+					m := sum[y][x] / n
+					mean[y][x] = m
+					deviation[y][x] = math.Sqrt(math.Max(squares[y][x]/n-m*m, 0))
+				}
+			}
+		}
+	}
+	return stack.result(func(y, x int) float64 { return mean[y][x] }), nil
+}
+
+// pgmStack parcourt les images d'une FrameSource en vérifiant qu'elles sont des images PGM de même taille.
+// Les valeurs sont ramenées à la valeur maximale de la première image.
+type pgmStack struct {
+	source        FrameSource
+	count         int
+	width, height int
+	max           int
+	magicNumber   string
+}
+
+// newPGMStack lit la première image de source pour connaître la taille et la valeur maximale de la pile.
+func newPGMStack(source FrameSource) (*pgmStack, error) {
+	stack := &pgmStack{source: source, count: source.Len()}
+	if stack.count == 0 {
+		return nil, fmt.Errorf("no frames to stack")
+	}
+	first, err := stack.frame(0)
+	if err != nil {
+		return nil, err
+	}
+	stack.width, stack.height, stack.max, stack.magicNumber = first.width, first.height, first.max, first.magicNumber
+	return stack, nil
+}
+
+// frame lit l'image numéro i et vérifie son type.
+func (s *pgmStack) frame(i int) (*PGM, error) {
+	img, err := s.source.Frame(i)
+	if err != nil {
+		return nil, err
+	}
+	pgm, ok := img.(*PGM)
+	if !ok {
+		return nil, fmt.Errorf("frame %d is %T, expected *PGM", i, img)
+	}
+	return pgm, nil
+}
+
+// eachFrame appelle visit pour chaque image de la pile, dans l'ordre, avec le facteur qui ramène ses
+// valeurs à la valeur maximale de la pile.
+func (s *pgmStack) eachFrame(visit func(pgm *PGM, scale float64)) error {
+	for i := 0; i < s.count; i++ {
+		pgm, err := s.frame(i)
+		if err != nil {
+			return err
+		}
+		if pgm.width != s.width || pgm.height != s.height {
+			return fmt.Errorf("frame %d has size %dx%d, expected %dx%d", i, pgm.width, pgm.height, s.width, s.height)
+		}
+		visit(pgm, float64(s.max)/float64(max(pgm.max, 1)))
+	}
+	return nil
+}
+
+// each appelle visit pour chaque pixel de chaque image de la pile.
+func (s *pgmStack) each(visit func(y, x int, v float64)) error {
+	return s.eachFrame(func(pgm *PGM, scale float64) {
+		for y := 0; y < s.height; y++ {
+			for x, v := range pgm.data[y] {
+				visit(y, x, float64(v)*scale)
+			}
+		}
+	})
+}
+
+// plane renvoie un plan de valeurs nulles de la taille de la pile.
+func (s *pgmStack) plane() [][]float64 {
+	plane := make([][]float64, s.height)
+	for y := range plane {
+		plane[y] = make([]float64, s.width)
+	}
+	return plane
+}
+
+// result renvoie l'image PGM de la taille de la pile dont value donne la valeur de chaque pixel.
+func (s *pgmStack) result(value func(y, x int) float64) *PGM {
+	pgm := NewPGM(s.width, s.height, s.max)
+	pgm.magicNumber = s.magicNumber
+	for y := 0; y < s.height; y++ {
+		for x := 0; x < s.width; x++ {
+			pgm.data[y][x] = clampChannel(value(y, x), s.max)
+		}
+	}
+	return pgm
+}
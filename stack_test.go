@@ -0,0 +1,132 @@
+package Netpbm // 🧪 Test Empilement d'images
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// newStackFrames renvoie cinq images 4×3 de valeur 100, 102, 98, 101 et 99, la troisième portant en
+// (1, 1) une valeur aberrante de 250 (rayon cosmique).
+func newStackFrames() []*PGM {
+	var frames []*PGM
+	for _, v := range []uint8{100, 102, 98, 101, 99} {
+		frames = append(frames, newUniformPGM(4, 3, v))
+	}
+	frames[2].Set(1, 1, 250)
+	return frames
+}
+
+func TestStackMean(t *testing.T) {
+	mean, err := StackMean(newStackFrames())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mean.At(0, 0) != 100 || mean.At(1, 1) != 130 {
+		t.Errorf("moyenne = %d et %d, attendu 100 et 130", mean.At(0, 0), mean.At(1, 1))
+	}
+}
+
+func TestStackMedian(t *testing.T) {
+	median, err := StackMedian(newStackFrames())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if median.At(0, 0) != 100 || median.At(1, 1) != 101 {
+		t.Errorf("médiane = %d et %d, attendu 100 et 101", median.At(0, 0), median.At(1, 1))
+	}
+
+	// Nombre pair d'images : moyenne des deux valeurs centrales
+	median, err = StackMedian(newStackFrames()[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if median.At(3, 2) != 101 {
+		t.Errorf("médiane de 4 images = %d, attendu 101", median.At(3, 2))
+	}
+}
+
+func TestStackSigmaClip(t *testing.T) {
+	frames := newStackFrames()
+	frames = append(frames, frames[0], frames[1], frames[3], frames[4])
+	clipped, err := StackSigmaClip(frames, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clipped.At(1, 1) != 101 || clipped.At(0, 0) != 100 {
+		t.Errorf("moyenne écrêtée = %d et %d, attendu 101 et 100", clipped.At(1, 1), clipped.At(0, 0))
+	}
+	if _, err := StackSigmaClip(frames, 0, 1); err == nil {
+		t.Error("kappa nul doit être refusé")
+	}
+}
+
+func TestStackErrors(t *testing.T) {
+	if _, err := StackMean(nil); err == nil {
+		t.Error("une pile vide doit être refusée")
+	}
+	frames := append(newStackFrames(), NewPGM(3, 3, 255))
+	if _, err := StackMedian(frames); err == nil {
+		t.Error("des images de tailles différentes doivent être refusées")
+	}
+}
+
+// countingFrames compte les images lues et vérifie qu'une seule est utilisée à la fois.
+type countingFrames struct {
+	frames []*PGM
+	reads  int
+}
+
+func (c *countingFrames) Len() int { return len(c.frames) }
+
+func (c *countingFrames) Frame(i int) (Image, error) {
+	c.reads++
+	return c.frames[i].Clone(), nil
+}
+
+func TestStackFramesStreaming(t *testing.T) {
+	source := &countingFrames{frames: newStackFrames()}
+	if _, err := StackMeanFrames(source); err != nil {
+		t.Fatal(err)
+	}
+	// La première image est lue une fois de plus pour connaître la taille de la pile
+	if source.reads != 6 {
+		t.Errorf("%d images lues, attendu 6", source.reads)
+	}
+
+	source.reads = 0
+	if _, err := StackSigmaClipFrames(source, 2.5, 2); err != nil {
+		t.Fatal(err)
+	}
+	if source.reads != 16 {
+		t.Errorf("%d images lues, attendu 16", source.reads)
+	}
+}
+
+func TestStackSequence(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "frames.seq")
+	writer, err := CreateSequence(filename, SequenceStored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, frame := range newStackFrames() {
+		if err := writer.Add(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := OpenSequence(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	median, err := StackMedianFrames(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if median.At(1, 1) != 101 {
+		t.Errorf("médiane = %d, attendu 101", median.At(1, 1))
+	}
+}
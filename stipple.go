@@ -0,0 +1,216 @@
+package Netpbm // ✨ Pointillisme
+
+import (
+	"math"
+	"sort"
+)
+
+// stippleIterations est le nombre de relaxations de Lloyd appliquées par Stipple.
+const stippleIterations = 12
+
+// GeneratePoissonDisk renvoie des points répartis au hasard dans un rectangle width×height, deux points
+// étant toujours à au moins minDist pixels l'un de l'autre (échantillonnage de Poisson par l'algorithme
+// de Bridson). Seed initialise le tirage : une même graine donne toujours les mêmes points.
+func GeneratePoissonDisk(width, height int, minDist float64, seed int64) []Point {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	minDist = math.Max(minDist, 1)
+
+	// Grille d'accélération : une case de côté minDist/√2 contient au plus un point
+	cell := minDist / math.Sqrt2
+	gridWidth := int(math.Ceil(float64(width) / cell))
+	gridHeight := int(math.Ceil(float64(height) / cell))
+	grid := make([]int, gridWidth*gridHeight)
+	for i := range grid {
+		grid[i] = -1
+	}
+	cellOf := func(p Point) (int, int) {
+		return int(float64(p.X) / cell), int(float64(p.Y) / cell)
+	}
+	fits := func(p Point, points []Point) bool {
+		cx, cy := cellOf(p)
+		for y := max(cy-2, 0); y <= min(cy+2, gridHeight-1); y++ {
+			for x := max(cx-2, 0); x <= min(cx+2, gridWidth-1); x++ {
+				if i := grid[y*gridWidth+x]; i >= 0 {
+					dx, dy := float64(points[i].X-p.X), float64(points[i].Y-p.Y)
+					if dx*dx+dy*dy < minDist*minDist {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	random := newRandom(seed)
+	var points, active []Point
+	add := func(p Point) {
+		cx, cy := cellOf(p)
+		grid[cy*gridWidth+cx] = len(points)
+		points = append(points, p)
+		active = append(active, p)
+	}
+	add(Point{random.Intn(width), random.Intn(height)})
+
+	// Chaque point actif propose jusqu'à 30 voisins dans l'anneau [minDist, 2·minDist[
+	for len(active) > 0 {
+		i := random.Intn(len(active))
+		origin := active[i]
+		found := false
+		for attempt := 0; attempt < 30; attempt++ {
+			angle := random.Float64() * 2 * math.Pi
+			radius := minDist * (1 + random.Float64())
+			p := Point{
+				int(math.Round(float64(origin.X) + radius*math.Cos(angle))),
+				int(math.Round(float64(origin.Y) + radius*math.Sin(angle))),
+			}
+			if p.X >= 0 && p.X < width && p.Y >= 0 && p.Y < height && fits(p, points) {
+				add(p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			active[i] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
+
+// Stipple renvoie nPoints points répartis sur l'image PGM, d'autant plus serrés que l'image est sombre
+// (pointillisme de Secord : tirage pondéré par l'obscurité puis relaxations de Lloyd pondérées).
+// Le tirage initial dépend de la graine seed : une même image et une même graine donnent toujours les
+// mêmes points.
+func (pgm *PGM) Stipple(nPoints int, seed int64) []Point {
+	if nPoints <= 0 || pgm.width == 0 || pgm.height == 0 {
+		return nil
+	}
+	weights := make([]float64, pgm.width*pgm.height)
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			w := 1 - float64(pgm.data[y][x])/float64(max(pgm.max, 1))
+			weights[y*pgm.width+x] = w
+			total += w
+			cumulative[y*pgm.width+x] = total
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	// Tirage initial : chaque pixel a une probabilité proportionnelle à son obscurité
+	random := newRandom(seed)
+	points := make([]pointF, nPoints)
+	for i := range points {
+		j := sort.SearchFloat64s(cumulative, random.Float64()*total)
+		j = min(j, len(cumulative)-1)
+		points[i] = pointF{float64(j%pgm.width) + random.Float64() - 0.5, float64(j/pgm.width) + random.Float64() - 0.5}
+	}
+
+	// Relaxation : chaque point va au centre de gravité, pondéré par l'obscurité, de sa cellule de Voronoï
+	sums := make([][3]float64, nPoints)
+	for iteration := 0; iteration < stippleIterations; iteration++ {
+		nearest := newNearestGrid(points, pgm.width, pgm.height)
+		for i := range sums {
+			sums[i] = [3]float64{}
+		}
+		for y := 0; y < pgm.height; y++ {
+			for x := 0; x < pgm.width; x++ {
+				w := weights[y*pgm.width+x]
+				if w == 0 {
+					continue
+				}
+				i := nearest.find(float64(x), float64(y))
+				sums[i][0] += w
+				sums[i][1] += w * float64(x)
+				sums[i][2] += w * float64(y)
+			}
+		}
+		for i, s := range sums {
+			if s[0] > 0 {
+				points[i] = pointF{s[1] / s[0], s[2] / s[0]}
+			}
+		}
+	}
+
+	result := make([]Point, nPoints)
+	for i, p := range points {
+		result[i] = p.toPoint()
+	}
+	return result
+}
+
+// nearestGrid range des points dans des cases carrées pour trouver rapidement le plus proche d'une position.
+type nearestGrid struct {
+	points        []pointF
+	cell          float64
+	width, height int
+	buckets       [][]int
+}
+
+// newNearestGrid crée la grille des points, pour une zone de width×height pixels.
+func newNearestGrid(points []pointF, width, height int) *nearestGrid {
+	cell := math.Max(1, math.Sqrt(float64(width*height)/float64(len(points))))
+	g := &nearestGrid{points: points, cell: cell}
+	g.width = int(float64(width)/cell) + 1
+	g.height = int(float64(height)/cell) + 1
+	g.buckets = make([][]int, g.width*g.height)
+	for i, p := range points {
+		cx, cy := g.cellOf(p.X, p.Y)
+		g.buckets[cy*g.width+cx] = append(g.buckets[cy*g.width+cx], i)
+	}
+	return g
+}
+
+// cellOf renvoie la case contenant la position (x, y), ramenée dans la grille.
+func (g *nearestGrid) cellOf(x, y float64) (int, int) {
+	cx := min(max(int(math.Floor(x/g.cell)), 0), g.width-1)
+	cy := min(max(int(math.Floor(y/g.cell)), 0), g.height-1)
+	return cx, cy
+}
+
+// find renvoie l'indice du point le plus proche de (x, y), en parcourant les cases par anneaux successifs.
+func (g *nearestGrid) find(x, y float64) int {
+	cx, cy := g.cellOf(x, y)
+	best, bestDist := -1, math.Inf(1)
+	for r := 0; r <= max(g.width, g.height); r++ {
+		for gy := cy - r; gy <= cy+r; gy++ {
+			for gx := cx - r; gx <= cx+r; gx++ {
+				if gx < 0 || gy < 0 || gx >= g.width || gy >= g.height || max(abs(gx-cx), abs(gy-cy)) != r {
+					continue
+				}
+				for _, i := range g.buckets[gy*g.width+gx] {
+					dx, dy := g.points[i].X-x, g.points[i].Y-y
+					if d := dx*dx + dy*dy; d < bestDist {
+						best, bestDist = i, d
+					}
+				}
+			}
+		}
+		// Les cases de l'anneau suivant sont au moins à r cases de distance
+		if limit := float64(r) * g.cell; best >= 0 && bestDist <= limit*limit {
+			break
+		}
+	}
+	return best
+}
+
+// RenderStipple dessine les points sur une image PBM blanche de width×height pixels, chacun sous forme
+// d'un disque noir de rayon radius (0 pour un seul pixel).
+func RenderStipple(points []Point, width, height, radius int) *PBM {
+	pbm := NewPBM(width, height)
+	for _, p := range points {
+		for y := p.Y - radius; y <= p.Y+radius; y++ {
+			for x := p.X - radius; x <= p.X+radius; x++ {
+				if dx, dy := x-p.X, y-p.Y; dx*dx+dy*dy <= radius*radius {
+					pbm.Set(x, y, true)
+				}
+			}
+		}
+	}
+	return pbm
+}
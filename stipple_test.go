@@ -0,0 +1,67 @@
+package Netpbm // 🧪 Test pointillisme
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGeneratePoissonDisk(t *testing.T) {
+	points := GeneratePoissonDisk(60, 40, 5, 7)
+	if len(points) < 40 {
+		t.Fatalf("Expected the area to be filled, got %d points", len(points))
+	}
+	for i, p := range points {
+		if p.X < 0 || p.X >= 60 || p.Y < 0 || p.Y >= 40 {
+			t.Errorf("Point %v is outside the area", p)
+		}
+		for _, q := range points[:i] {
+			dx, dy := p.X-q.X, p.Y-q.Y
+			if dx*dx+dy*dy < 25 {
+				t.Fatalf("Points %v and %v are too close", p, q)
+			}
+		}
+	}
+	again := GeneratePoissonDisk(60, 40, 5, 7)
+	if !samePoints(points, again) {
+		t.Error("The same seed should give the same points")
+	}
+	if GeneratePoissonDisk(0, 10, 5, 7) != nil {
+		t.Error("An empty area should give no point")
+	}
+}
+
+func TestStipple(t *testing.T) {
+	// Moitié gauche noire, moitié droite gris clair
+	pgm := newUniformPGM(40, 20, 200)
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			pgm.data[y][x] = 0
+		}
+	}
+	points := pgm.Stipple(100, 1)
+	if len(points) != 100 {
+		t.Fatalf("Expected 100 points, got %d", len(points))
+	}
+	dark := 0
+	for _, p := range points {
+		if p.X < 20 {
+			dark++
+		}
+	}
+	if dark < 70 {
+		t.Errorf("Expected most points on the dark side, got %d", dark)
+	}
+	if newUniformPGM(10, 10, 255).Stipple(10, 1) != nil {
+		t.Error("A white image should give no point")
+	}
+
+	pbm := RenderStipple(points, 40, 20, 1)
+	if countBlack(pbm) < 100 {
+		t.Errorf("Expected the dots to be drawn, got %d black pixels", countBlack(pbm))
+	}
+	err := pbm.Save("./testImages/pbm/stipple.pbm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/pbm/stipple.pbm")
+}
@@ -0,0 +1,102 @@
+package Netpbm // ✨ Assemblage de bandes
+
+import (
+	"fmt"
+	"math"
+)
+
+// StitchHorizontal assemble des images PPM de même hauteur de gauche à droite.
+// Overlap est le recouvrement maximal entre deux images voisines : le recouvrement réel est choisi
+// entre overlap/2 et overlap pour que les deux bords se ressemblent le plus.
+// Si blend est vrai, la couture passe progressivement d'une image à l'autre ; sinon, elle est nette.
+func StitchHorizontal(images []*PPM, overlap int, blend bool) (*PPM, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no images to stitch")
+	}
+	result := images[0].Resize(images[0].width, images[0].height, NearestNeighbor)
+	for i, next := range images[1:] {
+		if next.height != result.height {
+			return nil, fmt.Errorf("image %d has height %d, expected %d", i+1, next.height, result.height)
+		}
+		result = stitchPair(result, next, bestOverlap(result, next, overlap), blend)
+	}
+	return result, nil
+}
+
+// StitchVertical assemble des images PPM de même largeur de haut en bas, comme StitchHorizontal.
+func StitchVertical(images []*PPM, overlap int, blend bool) (*PPM, error) {
+	transposed := make([]*PPM, len(images))
+	for i, image := range images {
+		transposed[i] = image.transpose()
+	}
+	result, err := StitchHorizontal(transposed, overlap, blend)
+	if err != nil {
+		return nil, err
+	}
+	return result.transpose(), nil
+}
+
+// transpose renvoie une copie de l'image PPM dont les lignes sont devenues des colonnes.
+func (ppm *PPM) transpose() *PPM {
+	transposed := NewPPM(ppm.height, ppm.width, ppm.max)
+	transposed.magicNumber = ppm.magicNumber
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			transposed.data[x][y] = ppm.data[y][x]
+		}
+	}
+	return transposed
+}
+
+// bestOverlap renvoie le recouvrement, entre overlap/2 et overlap, qui minimise l'écart quadratique moyen
+// entre le bord droit de left et le bord gauche de right.
+func bestOverlap(left, right *PPM, overlap int) int {
+	overlap = min(overlap, left.width, right.width)
+	if overlap <= 0 {
+		return 0
+	}
+	best, bestError := overlap, math.Inf(1)
+	for o := overlap; o >= max(overlap/2, 1); o-- {
+		sum := 0.0
+		for y := 0; y < left.height; y++ {
+			for x := 0; x < o; x++ {
+				a, b := left.data[y][left.width-o+x], right.data[y][x]
+				dr, dg, db := float64(a.R)-float64(b.R), float64(a.G)-float64(b.G), float64(a.B)-float64(b.B)
+				sum += dr*dr + dg*dg + db*db
+			}
+		}
+		if mse := sum / float64(o*left.height); mse < bestError {
+			best, bestError = o, mse
+		}
+	}
+	return best
+}
+
+// stitchPair colle right à droite de left, avec un recouvrement de overlap colonnes.
+func stitchPair(left, right *PPM, overlap int, blend bool) *PPM {
+	width := left.width + right.width - overlap
+	result := NewPPM(width, left.height, max(left.max, right.max))
+	result.magicNumber = left.magicNumber
+	start := left.width - overlap
+	for y := 0; y < left.height; y++ {
+		copy(result.data[y], left.data[y][:start])
+		copy(result.data[y][left.width:], right.data[y][overlap:])
+		for x := 0; x < overlap; x++ {
+			a, b := left.data[y][start+x], right.data[y][x]
+			if blend {
+				// Poids de right croissant linéairement à travers le recouvrement
+				w := (float64(x) + 0.5) / float64(overlap)
+				result.data[y][start+x] = Pixel{
+					R: uint8(math.Round(float64(a.R)*(1-w) + float64(b.R)*w)),
+					G: uint8(math.Round(float64(a.G)*(1-w) + float64(b.G)*w)),
+					B: uint8(math.Round(float64(a.B)*(1-w) + float64(b.B)*w)),
+				}
+			} else if x < overlap/2 {
+				result.data[y][start+x] = a
+			} else {
+				result.data[y][start+x] = b
+			}
+		}
+	}
+	return result
+}
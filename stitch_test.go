@@ -0,0 +1,58 @@
+package Netpbm // 🧪 Test assemblage
+
+import "testing"
+
+// newPatternPPM crée une image PPM dont chaque pixel dépend de ses coordonnées.
+func newPatternPPM(width, height int) *PPM {
+	ppm := NewPPM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			ppm.data[y][x] = Pixel{uint8(x * 7), uint8(y * 11), uint8((x * y) % 251)}
+		}
+	}
+	return ppm
+}
+
+// cropForTest renvoie la zone r de l'image PPM.
+func cropForTest(ppm *PPM, r Rect) *PPM {
+	cropped := NewPPM(r.Width, r.Height, ppm.max)
+	for y := 0; y < r.Height; y++ {
+		copy(cropped.data[y], ppm.data[r.Y+y][r.X:r.X+r.Width])
+	}
+	return cropped
+}
+
+func TestStitchHorizontal(t *testing.T) {
+	full := newPatternPPM(30, 6)
+	left := cropForTest(full, Rect{0, 0, 18, 6})
+	right := cropForTest(full, Rect{12, 0, 18, 6})
+
+	for _, blend := range []bool{false, true} {
+		stitched, err := StitchHorizontal([]*PPM{left, right}, 8, blend)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stitched.Hash() != full.Hash() {
+			t.Errorf("Stitched image (blend=%v) should match the original", blend)
+		}
+	}
+
+	if _, err := StitchHorizontal([]*PPM{left, NewPPM(5, 3, 255)}, 4, true); err == nil {
+		t.Error("Expected a height mismatch error")
+	}
+}
+
+func TestStitchVertical(t *testing.T) {
+	full := newPatternPPM(5, 20)
+	top := cropForTest(full, Rect{0, 0, 5, 12})
+	middle := cropForTest(full, Rect{0, 8, 5, 8})
+	bottom := cropForTest(full, Rect{0, 13, 5, 7})
+	stitched, err := StitchVertical([]*PPM{top, middle, bottom}, 5, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stitched.Hash() != full.Hash() {
+		w, h := stitched.Size()
+		t.Errorf("Stitched image should match the original, got %dx%d", w, h)
+	}
+}
@@ -0,0 +1,156 @@
+package Netpbm // ✨ Traits
+
+import "math"
+
+// Marker représente la forme dessinée à une extrémité d'un trait.
+type Marker int
+
+const (
+	MarkerNone   Marker = iota // Pas de marqueur
+	MarkerArrow                // Pointe de flèche dirigée vers l'extérieur du trait
+	MarkerCircle               // Disque centré sur l'extrémité
+	MarkerSquare               // Carré centré sur l'extrémité
+)
+
+// StrokeOptions regroupe les options de tracé des lignes.
+type StrokeOptions struct {
+	Width       int    // Épaisseur du trait en pixels (1 par défaut)
+	Dash        []int  // Longueurs alternées des tirets et des espaces en pixels, nil pour un trait plein
+	StartMarker Marker // Marqueur au point de départ
+	EndMarker   Marker // Marqueur au point d'arrivée
+	MarkerSize  int    // Taille des marqueurs en pixels (calculée à partir de Width si 0)
+}
+
+// dashState suit la position courante dans un motif de tirets.
+type dashState struct {
+	pattern   []int
+	index     int // Élément courant du motif (pair pour un tiret, impair pour un espace)
+	remaining int // Pixels restants dans l'élément courant
+}
+
+// next indique si le pixel suivant doit être dessiné, puis avance dans le motif.
+func (d *dashState) next() bool {
+	if len(d.pattern) == 0 {
+		return true
+	}
+	// Passer les éléments épuisés ou de longueur nulle
+	for guard := 0; d.remaining <= 0 && guard <= len(d.pattern); guard++ {
+		d.index = (d.index + 1) % len(d.pattern)
+		d.remaining = d.pattern[d.index]
+	}
+	if d.remaining <= 0 {
+		return true
+	}
+	d.remaining--
+	return d.index%2 == 0
+}
+
+// newDashState crée l'état d'un motif de tirets, placé au début du premier tiret.
+func newDashState(pattern []int) *dashState {
+	d := &dashState{pattern: pattern, index: -1}
+	if len(pattern) > 0 {
+		d.index = 0
+		d.remaining = pattern[0]
+	}
+	return d
+}
+
+// DrawLineStroke trace une ligne entre deux points avec les options données.
+func (ppm *PPM) DrawLineStroke(p1, p2 Point, color Pixel, options StrokeOptions) {
+	ppm.own()
+	ppm.strokeSegment(p1, p2, color, options, newDashState(options.Dash), true)
+	ppm.drawMarker(p2, p1, options.StartMarker, color, options)
+	ppm.drawMarker(p1, p2, options.EndMarker, color, options)
+}
+
+// DrawPolygonStroke trace un polygone fermé avec les options données.
+// Le motif de tirets se poursuit d'un côté à l'autre ; les marqueurs ne s'appliquent pas à un polygone fermé.
+func (ppm *PPM) DrawPolygonStroke(points []Point, color Pixel, options StrokeOptions) {
+	ppm.own()
+	if len(points) == 0 {
+		return
+	}
+	dash := newDashState(options.Dash)
+	for i, p := range points {
+		// Le dernier pixel de chaque côté est le premier du suivant
+		ppm.strokeSegment(p, points[(i+1)%len(points)], color, options, dash, false)
+	}
+}
+
+// DrawPolylineStroke trace une ligne brisée ouverte avec les options données, marqueurs compris.
+func (ppm *PPM) DrawPolylineStroke(points []Point, color Pixel, options StrokeOptions) {
+	ppm.own()
+	if len(points) < 2 {
+		return
+	}
+	dash := newDashState(options.Dash)
+	for i := 0; i < len(points)-1; i++ {
+		ppm.strokeSegment(points[i], points[i+1], color, options, dash, i == len(points)-2)
+	}
+	ppm.drawMarker(points[1], points[0], options.StartMarker, color, options)
+	ppm.drawMarker(points[len(points)-2], points[len(points)-1], options.EndMarker, color, options)
+}
+
+// strokeSegment trace un segment en suivant le motif de tirets.
+// Si last est faux, le dernier pixel est omis pour ne pas le compter deux fois avec le segment suivant.
+func (ppm *PPM) strokeSegment(p1, p2 Point, color Pixel, options StrokeOptions, dash *dashState, last bool) {
+	bresenham(p1, p2, func(p Point) {
+		if !last && p == p2 {
+			return
+		}
+		if dash.next() {
+			ppm.stamp(p, options.Width, color)
+		}
+	})
+}
+
+// markerSize renvoie la taille des marqueurs pour les options données.
+func (options StrokeOptions) markerSize() int {
+	if options.MarkerSize > 0 {
+		return options.MarkerSize
+	}
+	return max(6, 3*options.Width)
+}
+
+// drawMarker dessine un marqueur en tip, orienté dans la direction from → tip.
+func (ppm *PPM) drawMarker(from, tip Point, marker Marker, color Pixel, options StrokeOptions) {
+	size := float64(options.markerSize())
+	center := pointF{float64(tip.X), float64(tip.Y)}
+	switch marker {
+	case MarkerArrow:
+		dx, dy := float64(tip.X-from.X), float64(tip.Y-from.Y)
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			return
+		}
+		dx, dy = dx/length, dy/length
+		base := pointF{center.X - dx*size, center.Y - dy*size}
+		ppm.fillPolygonF([]pointF{
+			center,
+			{base.X - dy*size/2, base.Y + dx*size/2},
+			{base.X + dy*size/2, base.Y - dx*size/2},
+		}, color)
+	case MarkerCircle:
+		ppm.fillCircleF(center, size/2, color)
+	case MarkerSquare:
+		half := size / 2
+		ppm.fillPolygonF([]pointF{
+			{center.X - half, center.Y - half},
+			{center.X + half, center.Y - half},
+			{center.X + half, center.Y + half},
+			{center.X - half, center.Y + half},
+		}, color)
+	}
+}
+
+// fillCircleF remplit le disque de centre center et de rayon radius.
+func (ppm *PPM) fillCircleF(center pointF, radius float64, color Pixel) {
+	for y := max(int(math.Ceil(center.Y-radius)), 0); y <= min(int(math.Floor(center.Y+radius)), ppm.height-1); y++ {
+		for x := max(int(math.Ceil(center.X-radius)), 0); x <= min(int(math.Floor(center.X+radius)), ppm.width-1); x++ {
+			dx, dy := float64(x)-center.X, float64(y)-center.Y
+			if dx*dx+dy*dy <= radius*radius {
+				ppm.data[y][x] = color
+			}
+		}
+	}
+}
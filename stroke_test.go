@@ -0,0 +1,56 @@
+package Netpbm // 🧪 Test traits
+
+import "testing"
+
+func TestDrawLineStrokeDash(t *testing.T) {
+	red := Pixel{R: 255}
+	ppm := NewPPM(15, 3, 255)
+	ppm.DrawLineStroke(Point{X: 0, Y: 1}, Point{X: 14, Y: 1}, red, StrokeOptions{Dash: []int{4, 2}})
+	for x := 0; x < 15; x++ {
+		expected := x%6 < 4
+		if (ppm.At(x, 1) == red) != expected {
+			t.Errorf("Pixel at (%d, 1) wanted drawn=%v", x, expected)
+		}
+	}
+}
+
+func TestDrawPolygonStrokeDash(t *testing.T) {
+	red := Pixel{R: 255}
+	ppm := NewPPM(10, 10, 255)
+	square := []Point{{X: 1, Y: 1}, {X: 8, Y: 1}, {X: 8, Y: 8}, {X: 1, Y: 8}}
+	ppm.DrawPolygonStroke(square, red, StrokeOptions{Dash: []int{1, 1}})
+	// Le motif se poursuit aux angles : un pixel sur deux du contour
+	if countColor(ppm, red) != 14 {
+		t.Errorf("Wrong number of dashed pixels: %d", countColor(ppm, red))
+	}
+	ppm = NewPPM(10, 10, 255)
+	ppm.DrawPolygonStroke(square, red, StrokeOptions{})
+	if countColor(ppm, red) != 28 {
+		t.Errorf("Wrong number of solid pixels: %d", countColor(ppm, red))
+	}
+}
+
+func TestDrawLineStrokeMarkers(t *testing.T) {
+	red := Pixel{R: 255}
+	ppm := NewPPM(20, 20, 255)
+	ppm.DrawLineStroke(Point{X: 3, Y: 10}, Point{X: 15, Y: 10}, red, StrokeOptions{
+		StartMarker: MarkerCircle,
+		EndMarker:   MarkerArrow,
+		MarkerSize:  6,
+	})
+	if ppm.At(3, 7) != red || ppm.At(0, 10) != red || ppm.At(3, 6) == red {
+		t.Error("Circle marker not drawn correctly")
+	}
+	if ppm.At(15, 10) != red || ppm.At(12, 9) != red || ppm.At(10, 8) != red || ppm.At(16, 10) == red || ppm.At(14, 8) == red {
+		t.Error("Arrow marker not drawn correctly")
+	}
+
+	ppm = NewPPM(20, 20, 255)
+	ppm.DrawLineStroke(Point{X: 3, Y: 10}, Point{X: 15, Y: 10}, red, StrokeOptions{Width: 3, EndMarker: MarkerSquare, MarkerSize: 4})
+	if ppm.At(8, 9) != red || ppm.At(8, 11) != red || ppm.At(8, 12) == red {
+		t.Error("Line width not honored")
+	}
+	if ppm.At(13, 8) != red || ppm.At(16, 11) != red || ppm.At(17, 11) == red || ppm.At(16, 12) == red {
+		t.Error("Square marker not drawn correctly")
+	}
+}
@@ -0,0 +1,41 @@
+package Netpbm // 🧪 Test Texte
+
+import "testing"
+
+func TestTextWidth(t *testing.T) {
+	if w := TextWidth("", 2); w != 0 {
+		t.Errorf("TextWidth(\"\") = %d, attendu 0", w)
+	}
+	if w := TextWidth("abc", 1); w != 17 {
+		t.Errorf("TextWidth(\"abc\", 1) = %d, attendu 17", w)
+	}
+	if w := TextWidth("abc", 2); w != 34 {
+		t.Errorf("TextWidth(\"abc\", 2) = %d, attendu 34", w)
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	ppm := NewPPM(20, 10, 255)
+	red := Pixel{255, 0, 0}
+	ppm.DrawText(Point{1, 1}, "I", 1, red)
+
+	// Le I est une barre verticale sur la colonne du milieu, avec empattements en haut et en bas
+	for y := 1; y < 1+GlyphHeight; y++ {
+		if ppm.data[y][3] != red {
+			t.Errorf("pixel (3, %d) non coloré", y)
+		}
+	}
+	if ppm.data[1][2] != red || ppm.data[4][2] == red {
+		t.Error("empattements du I incorrects")
+	}
+	if got := countColor(ppm, red); got != 11 {
+		t.Errorf("%d pixels colorés, attendu 11", got)
+	}
+
+	// Agrandi deux fois, le même caractère colore quatre fois plus de pixels ; il déborde sans erreur
+	ppm = NewPPM(20, 10, 255)
+	ppm.DrawText(Point{-2, 0}, "I?\x01", 2, red)
+	if got := countColor(ppm, red); got == 0 {
+		t.Error("aucun pixel coloré")
+	}
+}
@@ -0,0 +1,236 @@
+package Netpbm // ✨ Format TGA
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Types d'image TGA pris en charge.
+const (
+	tgaColorMapped    = 1
+	tgaTrueColor      = 2
+	tgaGray           = 3
+	tgaColorMappedRLE = 9
+	tgaTrueColorRLE   = 10
+	tgaGrayRLE        = 11
+)
+
+// tgaHeader est l'en-tête de 18 octets d'un fichier TGA.
+type tgaHeader struct {
+	IDLength       uint8
+	ColorMapType   uint8
+	ImageType      uint8
+	ColorMapStart  uint16
+	ColorMapLength uint16
+	ColorMapDepth  uint8
+	XOrigin        uint16
+	YOrigin        uint16
+	Width          uint16
+	Height         uint16
+	PixelDepth     uint8
+	Descriptor     uint8
+}
+
+// ReadTGA lit une image TGA (Targa), compressée ou non, en couleurs, en niveaux de gris ou à palette,
+// et la convertit en PPM. La transparence est ignorée.
+func ReadTGA(filename string) (*PPM, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %v", err)
+	}
+	reader := bufio.NewReader(file)
+
+	var header tgaHeader
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("error reading header: %v", err)
+	}
+	if _, err := reader.Discard(int(header.IDLength)); err != nil {
+		return nil, fmt.Errorf("error reading image ID: %v", err)
+	}
+
+	// Palette éventuelle
+	var palette []Pixel
+	remaining := int(info.Size()) - 18 - int(header.IDLength)
+	if header.ColorMapType == 1 {
+		entrySize := (int(header.ColorMapDepth) + 7) / 8
+		remaining -= int(header.ColorMapLength) * entrySize
+		palette = make([]Pixel, int(header.ColorMapStart)+int(header.ColorMapLength))
+		entry := make([]byte, entrySize)
+		for i := int(header.ColorMapStart); i < len(palette); i++ {
+			if _, err := io.ReadFull(reader, entry); err != nil {
+				return nil, fmt.Errorf("error reading color map: %v", err)
+			}
+			palette[i] = tgaPixel(entry)
+		}
+	}
+
+	rle := header.ImageType == tgaColorMappedRLE || header.ImageType == tgaTrueColorRLE || header.ImageType == tgaGrayRLE
+	switch header.ImageType {
+	case tgaColorMapped, tgaColorMappedRLE:
+		if palette == nil || header.PixelDepth != 8 {
+			return nil, fmt.Errorf("unsupported color-mapped image")
+		}
+	case tgaTrueColor, tgaTrueColorRLE:
+		if header.PixelDepth != 16 && header.PixelDepth != 24 && header.PixelDepth != 32 {
+			return nil, fmt.Errorf("unsupported pixel depth %d", header.PixelDepth)
+		}
+	case tgaGray, tgaGrayRLE:
+		if header.PixelDepth != 8 {
+			return nil, fmt.Errorf("unsupported pixel depth %d", header.PixelDepth)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported TGA image type %d", header.ImageType)
+	}
+
+	width, height := int(header.Width), int(header.Height)
+	size := (int(header.PixelDepth) + 7) / 8
+	// Un paquet RLE de 1+size octets donne au plus 128 pixels : le fichier doit contenir assez de données
+	// pour les dimensions de l'en-tête avant que l'image ne soit allouée
+	needed := width * height * size
+	if rle {
+		needed = (width*height + 127) / 128 * (1 + size)
+	}
+	if needed > remaining {
+		return nil, fmt.Errorf("file too short for TGA size %dx%d", width, height)
+	}
+	pixels := make([]Pixel, 0, width*height)
+	value := make([]byte, size)
+	decode := func() (Pixel, error) {
+		switch header.ImageType {
+		case tgaColorMapped, tgaColorMappedRLE:
+			if int(value[0]) >= len(palette) {
+				return Pixel{}, fmt.Errorf("color index %d out of range", value[0])
+			}
+			return palette[value[0]], nil
+		case tgaGray, tgaGrayRLE:
+			return Pixel{value[0], value[0], value[0]}, nil
+		}
+		return tgaPixel(value), nil
+	}
+	for len(pixels) < width*height {
+		count, repeat := 1, false
+		if rle {
+			// Paquet : 1 bit de répétition puis 7 bits pour le nombre de pixels moins un
+			packet, err := reader.ReadByte()
+			if err != nil {
+				return nil, fmt.Errorf("error reading pixels: %v", err)
+			}
+			count, repeat = int(packet&0x7F)+1, packet&0x80 != 0
+		}
+		for i := 0; i < count && len(pixels) < width*height; i++ {
+			if i == 0 || !repeat {
+				if _, err := io.ReadFull(reader, value); err != nil {
+					return nil, fmt.Errorf("error reading pixels: %v", err)
+				}
+			}
+			pixel, err := decode()
+			if err != nil {
+				return nil, err
+			}
+			pixels = append(pixels, pixel)
+		}
+	}
+
+	// Par défaut, les lignes vont de bas en haut et les pixels de gauche à droite
+	ppm := NewPPM(width, height, 255)
+	ppm.magicNumber = "P6"
+	for i, pixel := range pixels {
+		x, y := i%width, i/width
+		if header.Descriptor&0x10 != 0 {
+			x = width - 1 - x
+		}
+		if header.Descriptor&0x20 == 0 {
+			y = height - 1 - y
+		}
+		ppm.data[y][x] = pixel
+	}
+	return ppm, nil
+}
+
+// tgaPixel convertit une valeur TGA de 2, 3 ou 4 octets (bleu, vert, rouge) en pixel.
+func tgaPixel(value []byte) Pixel {
+	if len(value) == 2 {
+		// 5 bits par canal : 0RRRRRGG GGGBBBBB, en petit-boutiste
+		v := uint16(value[0]) | uint16(value[1])<<8
+		scale := func(c uint16) uint8 { return uint8(c * 255 / 31) }
+		return Pixel{scale(v >> 10 & 0x1F), scale(v >> 5 & 0x1F), scale(v & 0x1F)}
+	}
+	return Pixel{value[2], value[1], value[0]}
+}
+
+// SaveTGA enregistre l'image PPM au format TGA 24 bits, compressé par plages si rle est vrai.
+func (ppm *PPM) SaveTGA(filename string, rle bool) error {
+	scale := func(v uint8) byte { return byte(int(v) * 255 / max(ppm.max, 1)) }
+	return saveTGA(filename, ppm.width, ppm.height, tgaTrueColor, 24, rle, func(x, y int) []byte {
+		p := ppm.data[y][x]
+		return []byte{scale(p.B), scale(p.G), scale(p.R)}
+	})
+}
+
+// SaveTGA enregistre l'image PGM au format TGA en niveaux de gris, compressé par plages si rle est vrai.
+func (pgm *PGM) SaveTGA(filename string, rle bool) error {
+	return saveTGA(filename, pgm.width, pgm.height, tgaGray, 8, rle, func(x, y int) []byte {
+		return []byte{byte(int(pgm.data[y][x]) * 255 / max(pgm.max, 1))}
+	})
+}
+
+// saveTGA écrit un fichier TGA dont les lignes vont de haut en bas, value donnant les octets de chaque pixel.
+func saveTGA(filename string, width, height int, imageType, depth uint8, rle bool, value func(x, y int) []byte) error {
+	if width > 0xFFFF || height > 0xFFFF {
+		return fmt.Errorf("image too large for TGA: %dx%d", width, height)
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+
+	if rle {
+		imageType += 8
+	}
+	header := tgaHeader{ImageType: imageType, Width: uint16(width), Height: uint16(height), PixelDepth: depth, Descriptor: 0x20}
+	if err := binary.Write(writer, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	for y := 0; y < height; y++ {
+		if !rle {
+			for x := 0; x < width; x++ {
+				writer.Write(value(x, y))
+			}
+			continue
+		}
+		// Chaque ligne est compressée séparément, comme le recommande la spécification
+		for x := 0; x < width; {
+			current := value(x, y)
+			run := 1
+			for x+run < width && run < 128 && string(value(x+run, y)) == string(current) {
+				run++
+			}
+			if run > 1 {
+				writer.WriteByte(byte(0x80 | (run - 1)))
+				writer.Write(current)
+				x += run
+				continue
+			}
+			// Suite de pixels différents, jusqu'à la prochaine répétition
+			end := x + 1
+			for end < width && end-x < 128 && (end+1 >= width || string(value(end, y)) != string(value(end+1, y))) {
+				end++
+			}
+			writer.WriteByte(byte(end - x - 1))
+			for ; x < end; x++ {
+				writer.Write(value(x, y))
+			}
+		}
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,88 @@
+package Netpbm // 🧪 Test format TGA
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSaveAndReadTGA(t *testing.T) {
+	ppm := newPatternPPM(37, 5)
+	// Plages de pixels identiques pour la compression
+	for x := 0; x < 30; x++ {
+		ppm.data[2][x] = Pixel{9, 8, 7}
+	}
+	filename := "./testImages/ppm/test.tga"
+	defer os.Remove(filename)
+	for _, rle := range []bool{false, true} {
+		if err := ppm.SaveTGA(filename, rle); err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadTGA(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read.Hash() != ppm.Hash() {
+			t.Errorf("Round trip with rle=%v changed the image", rle)
+		}
+	}
+}
+
+func TestSaveTGAGray(t *testing.T) {
+	pgm := newGradientPGM(20, 4)
+	filename := "./testImages/pgm/test.tga"
+	defer os.Remove(filename)
+	if err := pgm.SaveTGA(filename, true); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadTGA(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := 0; x < 20; x++ {
+		v := pgm.At(x, 3)
+		if read.At(x, 3) != (Pixel{v, v, v}) {
+			t.Fatalf("Pixel %d: expected gray %d, got %v", x, v, read.At(x, 3))
+		}
+	}
+}
+
+func TestReadTGABottomUp(t *testing.T) {
+	// Image 2×2 à palette, enregistrée de bas en haut avec une identification de 3 octets
+	content := []byte{
+		3, 1, 1, 0, 0, 2, 0, 24, 0, 0, 0, 0, 2, 0, 2, 0, 8, 0,
+		'a', 'b', 'c',
+		0, 0, 255, 255, 0, 0, // Palette : rouge, bleu
+		0, 1, // Ligne du bas
+		1, 1, // Ligne du haut
+	}
+	filename := "./testImages/ppm/bottomup.tga"
+	os.WriteFile(filename, content, 0644)
+	defer os.Remove(filename)
+	ppm, err := ReadTGA(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	red, blue := Pixel{255, 0, 0}, Pixel{0, 0, 255}
+	if ppm.At(0, 0) != blue || ppm.At(1, 0) != blue || ppm.At(0, 1) != red || ppm.At(1, 1) != blue {
+		t.Errorf("Unexpected pixels %v %v %v %v", ppm.At(0, 0), ppm.At(1, 0), ppm.At(0, 1), ppm.At(1, 1))
+	}
+
+	content[2] = 4
+	os.WriteFile(filename, content, 0644)
+	if _, err := ReadTGA(filename); err == nil {
+		t.Error("Expected an error for an unsupported image type")
+	}
+}
+
+func TestReadTGATooShort(t *testing.T) {
+	// En-têtes annonçant 65535×65535 pixels pour quelques octets de données
+	filename := "./testImages/ppm/short.tga"
+	defer os.Remove(filename)
+	for _, imageType := range []byte{tgaTrueColor, tgaTrueColorRLE} {
+		content := []byte{0, 0, imageType, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0xFF, 0xFF, 0xFF, 24, 0, 0xFF, 1, 2, 3}
+		os.WriteFile(filename, content, 0644)
+		if _, err := ReadTGA(filename); err == nil {
+			t.Errorf("Expected an error for image type %d with too few pixel bytes", imageType)
+		}
+	}
+}
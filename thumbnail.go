@@ -0,0 +1,54 @@
+package Netpbm // ✨ Vignettes
+
+import "math"
+
+// Thumbnail renvoie une vignette de l'image PPM tenant dans maxWidth×maxHeight, proportions conservées.
+// L'image n'est jamais agrandie : si elle tient déjà, une copie est renvoyée. Les fortes réductions
+// commencent par une moyenne exacte par blocs, plus rapide et sans crénelage, avant le filtre Bilinear ;
+// un léger masque flou, plus marqué quand la réduction est forte, rend ensuite la netteté perdue.
+func (ppm *PPM) Thumbnail(maxWidth, maxHeight int) *PPM {
+	if ppm.width == 0 || ppm.height == 0 || maxWidth <= 0 || maxHeight <= 0 {
+		return NewPPM(0, 0, ppm.max)
+	}
+	scale := math.Min(float64(maxWidth)/float64(ppm.width), float64(maxHeight)/float64(ppm.height))
+	if scale >= 1 {
+		return ppm.Resize(ppm.width, ppm.height, NearestNeighbor)
+	}
+	width := max(int(math.Round(float64(ppm.width)*scale)), 1)
+	height := max(int(math.Round(float64(ppm.height)*scale)), 1)
+
+	// Réduction par blocs entiers tant qu'il reste au moins un facteur 2 pour le filtre
+	src := ppm
+	if factor := int(1 / scale / 2); factor >= 2 {
+		src = ppm.boxReduce(factor)
+	}
+	thumbnail := src.Resize(width, height, Bilinear)
+
+	amount := 0.25
+	if scale < 0.5 {
+		amount = 0.5
+	}
+	thumbnail.UnsharpMask(1, amount)
+	return thumbnail
+}
+
+// boxReduce renvoie une copie de l'image PPM réduite d'un facteur entier, chaque pixel étant la moyenne
+// d'un bloc factor×factor (les blocs du bord droit et du bas peuvent être incomplets).
+func (ppm *PPM) boxReduce(factor int) *PPM {
+	reduced := NewPPM((ppm.width+factor-1)/factor, (ppm.height+factor-1)/factor, ppm.max)
+	reduced.magicNumber = ppm.magicNumber
+	for y := 0; y < reduced.height; y++ {
+		for x := 0; x < reduced.width; x++ {
+			var r, g, b, count float64
+			for sy := y * factor; sy < min((y+1)*factor, ppm.height); sy++ {
+				for sx := x * factor; sx < min((x+1)*factor, ppm.width); sx++ {
+					p := ppm.data[sy][sx]
+					r, g, b = r+float64(p.R), g+float64(p.G), b+float64(p.B)
+					count++
+				}
+			}
+			reduced.data[y][x] = Pixel{clampChannel(r/count, ppm.max), clampChannel(g/count, ppm.max), clampChannel(b/count, ppm.max)}
+		}
+	}
+	return reduced
+}
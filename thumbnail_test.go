@@ -0,0 +1,46 @@
+package Netpbm // 🧪 Test vignettes
+
+import (
+	"os"
+	"testing"
+)
+
+func TestThumbnail(t *testing.T) {
+	ppm := newPatternPPM(200, 100)
+	thumbnail := ppm.Thumbnail(50, 50)
+	if thumbnail.width != 50 || thumbnail.height != 25 {
+		t.Errorf("Expected 50x25, got %dx%d", thumbnail.width, thumbnail.height)
+	}
+
+	// Jamais d'agrandissement
+	small := newPatternPPM(20, 10)
+	same := small.Thumbnail(100, 100)
+	if same.width != 20 || same.height != 10 || same.Hash() != small.Hash() {
+		t.Error("A small image should be copied unchanged")
+	}
+	same.data[0][0] = Pixel{1, 2, 3}
+	if small.data[0][0] == same.data[0][0] {
+		t.Error("The thumbnail should not share pixels with the source")
+	}
+
+	// Une image unie le reste malgré le masque flou
+	uniform := newUniformPPM(300, 300, Pixel{100, 150, 200}).Thumbnail(16, 16)
+	if countColor(uniform, Pixel{100, 150, 200}) != 16*16 {
+		t.Error("A uniform image should stay uniform")
+	}
+
+	err := thumbnail.Save("./testImages/ppm/thumbnail.ppm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/ppm/thumbnail.ppm")
+}
+
+func TestBoxReduce(t *testing.T) {
+	ppm := newUniformPPM(5, 4, Pixel{0, 0, 0})
+	ppm.data[0][0] = Pixel{40, 40, 40}
+	reduced := ppm.boxReduce(2)
+	if reduced.width != 3 || reduced.height != 2 || reduced.At(0, 0) != (Pixel{10, 10, 10}) {
+		t.Errorf("Unexpected reduction %dx%d %v", reduced.width, reduced.height, reduced.At(0, 0))
+	}
+}
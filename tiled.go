@@ -0,0 +1,214 @@
+package Netpbm // ✨ Images en tuiles sur disque
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// TileStore conserve les tuiles d'une TiledImage en dehors de la mémoire. Load renvoie ok à faux,
+// sans erreur, pour une tuile jamais enregistrée.
+type TileStore interface {
+	Load(tx, ty int) (data []uint8, ok bool, err error)
+	Store(tx, ty int, data []uint8) error
+}
+
+// DirTileStore enregistre chaque tuile dans un fichier brut du dossier dir.
+type DirTileStore struct {
+	dir string
+}
+
+// NewDirTileStore crée le dossier dir s'il n'existe pas et renvoie un TileStore qui l'utilise.
+func NewDirTileStore(dir string) (*DirTileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating tile directory: %v", err)
+	}
+	return &DirTileStore{dir}, nil
+}
+
+// path renvoie le chemin du fichier de la tuile (tx, ty).
+func (s *DirTileStore) path(tx, ty int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%d_%d.tile", tx, ty))
+}
+
+// Load lit la tuile (tx, ty) depuis son fichier.
+func (s *DirTileStore) Load(tx, ty int) ([]uint8, bool, error) {
+	data, err := os.ReadFile(s.path(tx, ty))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Store écrit la tuile (tx, ty) dans son fichier.
+func (s *DirTileStore) Store(tx, ty int, data []uint8) error {
+	return os.WriteFile(s.path(tx, ty), data, 0644)
+}
+
+// tile est une tuile chargée en mémoire.
+type tile struct {
+	x, y  int
+	data  []uint8
+	dirty bool
+	used  int // Date de dernier accès, pour évincer la tuile la moins récemment utilisée
+}
+
+// TiledImage est une image en niveaux de gris découpée en tuiles carrées de tileSize pixels, gardées
+// dans un TileStore : seules les tuiles touchées sont chargées, et au plus capacity à la fois, ce qui
+// permet de traiter des images bien plus grandes que la mémoire disponible. Les pixels jamais écrits
+// valent 0. Les erreurs du TileStore sont conservées et renvoyées par Err et Flush.
+type TiledImage struct {
+	width, height, tileSize, max int
+	store                        TileStore
+	capacity                     int
+	tiles                        map[[2]int]*tile
+	clock                        int
+	err                          error
+}
+
+// NewTiledImage crée une image en tuiles de width×height pixels, de valeur maximale max. Une taille de
+// tuile inférieure à 1 est ramenée à 1, comme capacity.
+func NewTiledImage(width, height, tileSize, max int, store TileStore, capacity int) *TiledImage {
+	if tileSize < 1 {
+		tileSize = 1
+	}
+	return &TiledImage{
+		width: width, height: height, tileSize: tileSize, max: max,
+		store: store, capacity: capacity, tiles: make(map[[2]int]*tile),
+	}
+}
+
+// Size renvoie la largeur et la hauteur de l'image.
+func (ti *TiledImage) Size() (int, int) {
+	return ti.width, ti.height
+}
+
+// Err renvoie la première erreur rencontrée en lisant ou en écrivant une tuile.
+func (ti *TiledImage) Err() error {
+	return ti.err
+}
+
+// tileAt renvoie la tuile contenant le pixel (x, y), en la chargeant au besoin.
+func (ti *TiledImage) tileAt(x, y int) *tile {
+	key := [2]int{x / ti.tileSize, y / ti.tileSize}
+	ti.clock++
+	if t, ok := ti.tiles[key]; ok {
+		t.used = ti.clock
+		return t
+	}
+	if len(ti.tiles) >= max(ti.capacity, 1) {
+		ti.evict()
+	}
+	t := &tile{x: key[0], y: key[1], used: ti.clock}
+	data, ok, err := ti.store.Load(key[0], key[1])
+	if err != nil && ti.err == nil {
+		ti.err = fmt.Errorf("error loading tile %d,%d: %v", key[0], key[1], err)
+	}
+	if ok && len(data) == ti.tileSize*ti.tileSize {
+		t.data = data
+	} else {
+		t.data = make([]uint8, ti.tileSize*ti.tileSize)
+	}
+	ti.tiles[key] = t
+	return t
+}
+
+// evict enregistre si besoin puis retire de la mémoire la tuile la moins récemment utilisée.
+func (ti *TiledImage) evict() {
+	var oldest *tile
+	for _, t := range ti.tiles {
+		if oldest == nil || t.used < oldest.used {
+			oldest = t
+		}
+	}
+	ti.save(oldest)
+	delete(ti.tiles, [2]int{oldest.x, oldest.y})
+}
+
+// save enregistre la tuile si elle a été modifiée.
+func (ti *TiledImage) save(t *tile) {
+	if !t.dirty {
+		return
+	}
+	if err := ti.store.Store(t.x, t.y, t.data); err != nil && ti.err == nil {
+		ti.err = fmt.Errorf("error storing tile %d,%d: %v", t.x, t.y, err)
+	}
+	t.dirty = false
+}
+
+// At renvoie la valeur du pixel (x, y), ou 0 hors de l'image.
+func (ti *TiledImage) At(x, y int) uint8 {
+	if x < 0 || x >= ti.width || y < 0 || y >= ti.height {
+		return 0
+	}
+	return ti.tileAt(x, y).data[(y%ti.tileSize)*ti.tileSize+x%ti.tileSize]
+}
+
+// Set définit la valeur du pixel (x, y).
+func (ti *TiledImage) Set(x, y int, value uint8) {
+	if x < 0 || x >= ti.width || y < 0 || y >= ti.height {
+		return
+	}
+	t := ti.tileAt(x, y)
+	t.data[(y%ti.tileSize)*ti.tileSize+x%ti.tileSize] = value
+	t.dirty = true
+}
+
+// Flush enregistre toutes les tuiles modifiées, ligne de tuiles par ligne de tuiles, et renvoie la
+// première erreur rencontrée.
+func (ti *TiledImage) Flush() error {
+	tiles := make([]*tile, 0, len(ti.tiles))
+	for _, t := range ti.tiles {
+		tiles = append(tiles, t)
+	}
+	sort.Slice(tiles, func(i, j int) bool {
+		return tiles[i].y < tiles[j].y || tiles[i].y == tiles[j].y && tiles[i].x < tiles[j].x
+	})
+	for _, t := range tiles {
+		ti.save(t)
+	}
+	return ti.err
+}
+
+// Region renvoie une copie en mémoire de la zone r de l'image, limitée à l'image.
+func (ti *TiledImage) Region(r Rect) *PGM {
+	r = r.clip(ti.width, ti.height)
+	pgm := NewPGM(max(r.Width, 0), max(r.Height, 0), ti.max)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = ti.At(r.X+x, r.Y+y)
+		}
+	}
+	return pgm
+}
+
+// SetRegion copie l'image PGM dans l'image en tuiles, son coin supérieur gauche en at.
+func (ti *TiledImage) SetRegion(pgm *PGM, at Point) {
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			ti.Set(at.X+x, at.Y+y, pgm.data[y][x])
+		}
+	}
+}
+
+// Filter applique filter à l'image tuile par tuile et écrit le résultat dans dst, de même taille.
+// Filter reçoit chaque tuile entourée d'une marge de margin pixels, pour les filtres qui lisent les
+// voisins d'un pixel (flou, convolution) ; seule la tuile elle-même est recopiée dans dst.
+func (ti *TiledImage) Filter(dst *TiledImage, margin int, filter func(*PGM)) {
+	for ty := 0; ty*ti.tileSize < ti.height; ty++ {
+		for tx := 0; tx*ti.tileSize < ti.width; tx++ {
+			inner := Rect{tx * ti.tileSize, ty * ti.tileSize, ti.tileSize, ti.tileSize}.clip(ti.width, ti.height)
+			outer := Rect{inner.X - margin, inner.Y - margin, inner.Width + 2*margin, inner.Height + 2*margin}.clip(ti.width, ti.height)
+			region := ti.Region(outer)
+			filter(region)
+			region.Crop(Rect{inner.X - outer.X, inner.Y - outer.Y, inner.Width, inner.Height})
+			dst.SetRegion(region, Point{inner.X, inner.Y})
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package Netpbm // 🧪 Test images en tuiles sur disque
+
+import "testing"
+
+// memoryTileStore garde les tuiles dans une table et compte les écritures.
+type memoryTileStore struct {
+	tiles  map[[2]int][]uint8
+	stores int
+}
+
+func (s *memoryTileStore) Load(tx, ty int) ([]uint8, bool, error) {
+	data, ok := s.tiles[[2]int{tx, ty}]
+	return append([]uint8(nil), data...), ok, nil
+}
+
+func (s *memoryTileStore) Store(tx, ty int, data []uint8) error {
+	s.tiles[[2]int{tx, ty}] = append([]uint8(nil), data...)
+	s.stores++
+	return nil
+}
+
+func TestTiledImage(t *testing.T) {
+	store, err := NewDirTileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ti := NewTiledImage(100, 50, 16, 255, store, 2)
+	for i := 0; i < 50; i++ {
+		ti.Set(2*i, i, uint8(i+1))
+	}
+	if len(ti.tiles) > 2 {
+		t.Errorf("Expected at most 2 tiles in memory, got %d", len(ti.tiles))
+	}
+	for i := 0; i < 50; i++ {
+		if ti.At(2*i, i) != uint8(i+1) {
+			t.Fatalf("Pixel %d lost after eviction", i)
+		}
+	}
+	if ti.At(99, 0) != 0 || ti.At(200, 0) != 0 {
+		t.Error("Unwritten pixels should be 0")
+	}
+	if err := ti.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Une nouvelle image sur le même stockage retrouve les pixels
+	reopened := NewTiledImage(100, 50, 16, 255, store, 4)
+	if reopened.At(60, 30) != 31 {
+		t.Errorf("Expected 31 after reopening, got %d", reopened.At(60, 30))
+	}
+	region := reopened.Region(Rect{58, 29, 10, 10})
+	if region.At(2, 1) != 31 || region.At(0, 0) != 30 {
+		t.Errorf("Unexpected region %v", region.data[:2])
+	}
+}
+
+func TestTiledImageFilter(t *testing.T) {
+	src := NewTiledImage(40, 30, 8, 255, &memoryTileStore{tiles: map[[2]int][]uint8{}}, 3)
+	dstStore := &memoryTileStore{tiles: map[[2]int][]uint8{}}
+	dst := NewTiledImage(40, 30, 8, 255, dstStore, 3)
+	reference := newGradientPGM(40, 30)
+	src.SetRegion(reference, Point{0, 0})
+
+	src.Filter(dst, 1, func(pgm *PGM) { pgm.Blur(1) })
+	reference.Blur(1)
+	if err := dst.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := dst.Region(Rect{0, 0, 40, 30}); got.Hash() != reference.Hash() {
+		t.Error("Filtering by tiles should match filtering the whole image")
+	}
+	if dstStore.stores < 20 {
+		t.Errorf("Expected every tile to be stored, got %d writes", dstStore.stores)
+	}
+}
+
+func TestTiledImageZeroTileSize(t *testing.T) {
+	ti := NewTiledImage(4, 3, 0, 255, &memoryTileStore{tiles: map[[2]int][]uint8{}}, 2)
+	ti.Set(3, 2, 7)
+	if ti.At(3, 2) != 7 {
+		t.Errorf("Expected 7, got %d", ti.At(3, 2))
+	}
+	dst := NewTiledImage(4, 3, -5, 255, &memoryTileStore{tiles: map[[2]int][]uint8{}}, 2)
+	ti.Filter(dst, 0, func(pgm *PGM) {})
+	if dst.At(3, 2) != 7 {
+		t.Error("Filtering with 1-pixel tiles should copy the image")
+	}
+}
@@ -0,0 +1,192 @@
+package Netpbm // ✨ Transformations affines
+
+import "math"
+
+// Transform est une transformation affine du plan : x' = A·x + B·y + C et y' = D·x + E·y + F.
+// Les coordonnées sont continues : le pixel (x, y) couvre le carré [x, x+1[ × [y, y+1[.
+type Transform struct {
+	A, B, C float64
+	D, E, F float64
+}
+
+// IdentityTransform renvoie la transformation qui ne change rien.
+func IdentityTransform() Transform {
+	return Transform{A: 1, E: 1}
+}
+
+// TranslateTransform renvoie un déplacement de (dx, dy).
+func TranslateTransform(dx, dy float64) Transform {
+	return Transform{A: 1, C: dx, E: 1, F: dy}
+}
+
+// ScaleTransform renvoie un agrandissement de sx horizontalement et sy verticalement, autour de l'origine.
+func ScaleTransform(sx, sy float64) Transform {
+	return Transform{A: sx, E: sy}
+}
+
+// RotateTransform renvoie une rotation de angle degrés autour de l'origine (sens horaire à l'écran).
+func RotateTransform(angle float64) Transform {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	return Transform{A: cos, B: -sin, D: sin, E: cos}
+}
+
+// QuadTransform renvoie la transformation qui place une image width×height dans le parallélogramme
+// dont les coins supérieur gauche, supérieur droit et inférieur gauche sont donnés.
+func QuadTransform(width, height int, topLeft, topRight, bottomLeft Point) Transform {
+	w, h := float64(width), float64(height)
+	return Transform{
+		A: float64(topRight.X-topLeft.X) / w, B: float64(bottomLeft.X-topLeft.X) / h, C: float64(topLeft.X),
+		D: float64(topRight.Y-topLeft.Y) / w, E: float64(bottomLeft.Y-topLeft.Y) / h, F: float64(topLeft.Y),
+	}
+}
+
+// Then renvoie la transformation qui applique t, puis next.
+func (t Transform) Then(next Transform) Transform {
+	return Transform{
+		A: next.A*t.A + next.B*t.D, B: next.A*t.B + next.B*t.E, C: next.A*t.C + next.B*t.F + next.C,
+		D: next.D*t.A + next.E*t.D, E: next.D*t.B + next.E*t.E, F: next.D*t.C + next.E*t.F + next.F,
+	}
+}
+
+// Invert renvoie la transformation inverse, ou false si t écrase le plan sur une droite ou un point.
+func (t Transform) Invert() (Transform, bool) {
+	det := t.A*t.E - t.B*t.D
+	if math.Abs(det) < 1e-12 {
+		return Transform{}, false
+	}
+	inverse := Transform{A: t.E / det, B: -t.B / det, D: -t.D / det, E: t.A / det}
+	inverse.C = -(inverse.A*t.C + inverse.B*t.F)
+	inverse.F = -(inverse.D*t.C + inverse.E*t.F)
+	return inverse, true
+}
+
+// Apply renvoie l'image du point (x, y) par la transformation.
+func (t Transform) Apply(x, y float64) (float64, float64) {
+	return t.A*x + t.B*y + t.C, t.D*x + t.E*y + t.F
+}
+
+// Mask donne la taille d'une image collée et l'opacité de chacun de ses pixels : *PBM (pixels noirs opaques)
+// et *PGM (opacité proportionnelle à l'intensité) la satisfont.
+type Mask interface {
+	Size() (int, int)
+	opacity(x, y int) float64
+}
+
+// opacity renvoie 1 pour un pixel noir et 0 pour un pixel blanc ou hors de l'image.
+func (pbm *PBM) opacity(x, y int) float64 {
+	if x < 0 || y < 0 || x >= pbm.width || y >= pbm.height || !pbm.data[y][x] {
+		return 0
+	}
+	return 1
+}
+
+// opacity renvoie l'intensité du pixel ramenée entre 0 et 1, ou 0 hors de l'image.
+func (pgm *PGM) opacity(x, y int) float64 {
+	if x < 0 || y < 0 || x >= pgm.width || y >= pgm.height || pgm.max == 0 {
+		return 0
+	}
+	return float64(pgm.data[y][x]) / float64(pgm.max)
+}
+
+// DrawImageTransformed dessine l'image src sur l'image PPM après lui avoir appliqué transform,
+// par exemple pour placer un sprite tourné ou agrandi. Mask, s'il n'est pas nil, doit avoir la taille
+// de src et indique l'opacité de chacun de ses pixels.
+func (ppm *PPM) DrawImageTransformed(src *PPM, transform Transform, filter Interpolation, mask Mask) {
+	ppm.own()
+	inverse, ok := transform.Invert()
+	if !ok || src.width == 0 || src.height == 0 {
+		return
+	}
+
+	// Zone de destination couverte par l'image transformée
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, corner := range [][2]float64{{0, 0}, {float64(src.width), 0}, {0, float64(src.height)}, {float64(src.width), float64(src.height)}} {
+		x, y := transform.Apply(corner[0], corner[1])
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+
+	for y := max(int(math.Floor(minY)), 0); y < min(int(math.Ceil(maxY)), ppm.height); y++ {
+		for x := max(int(math.Floor(minX)), 0); x < min(int(math.Ceil(maxX)), ppm.width); x++ {
+			// Position, dans src, du centre du pixel de destination
+			u, v := inverse.Apply(float64(x)+0.5, float64(y)+0.5)
+			if u < 0 || v < 0 || u >= float64(src.width) || v >= float64(src.height) {
+				continue
+			}
+			color, alpha := src.sample(u, v, filter, mask)
+			if alpha <= 0 {
+				continue
+			}
+			dst := &ppm.data[y][x]
+			mix := func(a uint8, b float64) uint8 {
+				return clampChannel(float64(a)*(1-alpha)+b*alpha, ppm.max)
+			}
+			dst.R, dst.G, dst.B = mix(dst.R, color[0]), mix(dst.G, color[1]), mix(dst.B, color[2])
+		}
+	}
+}
+
+// sample renvoie la couleur et l'opacité de l'image au point continu (u, v).
+func (ppm *PPM) sample(u, v float64, filter Interpolation, mask Mask) ([3]float64, float64) {
+	opacity := func(x, y int) float64 {
+		if mask == nil {
+			return 1
+		}
+		return mask.opacity(x, y)
+	}
+	// Pixels voisins pondérés par le noyau, les bords étant prolongés
+	var color [3]float64
+	alpha := 0.0
+	for _, ty := range pointTaps(v, filter) {
+		for _, tx := range pointTaps(u, filter) {
+			x, y := min(max(tx.index, 0), ppm.width-1), min(max(ty.index, 0), ppm.height-1)
+			w := tx.weight * ty.weight
+			p := ppm.data[y][x]
+			color[0] += w * float64(p.R)
+			color[1] += w * float64(p.G)
+			color[2] += w * float64(p.B)
+			alpha += w * opacity(x, y)
+		}
+	}
+	return color, alpha
+}
+
+// Rotate fait pivoter l'image PGM de angle degrés autour de son centre (sens horaire à l'écran),
+// sans changer sa taille : les coins qui sortent sont perdus et ceux qui entrent prennent la valeur fill.
+func (pgm *PGM) Rotate(angle float64, fill uint8) {
+	pgm.RotateWith(angle, fill, Bilinear)
+}
+
+// RotateWith fait pivoter l'image PGM comme Rotate, en calculant les pixels avec le noyau kernel.
+func (pgm *PGM) RotateWith(angle float64, fill uint8, kernel ResampleKernel) {
+	pgm.own()
+	// Pour chaque pixel de destination, on cherche le point source par la rotation inverse
+	inverse := TranslateTransform(-float64(pgm.width)/2, -float64(pgm.height)/2).
+		Then(RotateTransform(-angle)).
+		Then(TranslateTransform(float64(pgm.width)/2, float64(pgm.height)/2))
+	at := func(x, y int) float64 {
+		if x < 0 || y < 0 || x >= pgm.width || y >= pgm.height {
+			return float64(fill)
+		}
+		return float64(pgm.data[y][x])
+	}
+	rotated := make([][]uint8, pgm.height)
+	for y := range rotated {
+		rotated[y] = pgm.buffers.getBytes(pgm.width)
+		for x := range rotated[y] {
+			u, v := inverse.Apply(float64(x)+0.5, float64(y)+0.5)
+			value := 0.0
+			for _, ty := range pointTaps(v, kernel) {
+				for _, tx := range pointTaps(u, kernel) {
+					value += tx.weight * ty.weight * at(tx.index, ty.index)
+				}
+			}
+			rotated[y][x] = clampChannel(value, pgm.max)
+		}
+	}
+	// Les anciennes lignes deviennent des tampons libres
+	for _, row := range pgm.data {
+		pgm.buffers.putBytes(row)
+	}
+	pgm.data = rotated
+}
@@ -0,0 +1,84 @@
+package Netpbm // 🧪 Test transformations affines
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransformInvert(t *testing.T) {
+	transform := ScaleTransform(2, 3).Then(RotateTransform(30)).Then(TranslateTransform(5, -4))
+	inverse, ok := transform.Invert()
+	if !ok {
+		t.Fatal("Transform should be invertible")
+	}
+	x, y := inverse.Apply(transform.Apply(7, 11))
+	if math.Abs(x-7) > 1e-9 || math.Abs(y-11) > 1e-9 {
+		t.Errorf("Round trip gave (%v, %v)", x, y)
+	}
+	if _, ok := ScaleTransform(0, 1).Invert(); ok {
+		t.Error("Flattening transform should not be invertible")
+	}
+	// Rotation dans le sens horaire à l'écran : l'axe x part vers le bas
+	if x, y := RotateTransform(90).Apply(1, 0); math.Abs(x) > 1e-9 || math.Abs(y-1) > 1e-9 {
+		t.Errorf("Rotation gave (%v, %v)", x, y)
+	}
+}
+
+func TestDrawImageTransformedTranslate(t *testing.T) {
+	sprite := newPatternPPM(4, 3)
+	canvas := NewPPM(10, 10, 255)
+	canvas.DrawImageTransformed(sprite, TranslateTransform(5, 2), NearestNeighbor, nil)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			if canvas.At(x+5, y+2) != sprite.At(x, y) {
+				t.Fatalf("Pixel (%d, %d) not copied", x, y)
+			}
+		}
+	}
+	if canvas.At(4, 2) != (Pixel{}) || canvas.At(9, 2) != (Pixel{}) {
+		t.Error("Pixels outside the sprite should be untouched")
+	}
+}
+
+func TestDrawImageTransformedQuad(t *testing.T) {
+	sprite := newUniformPPM(4, 2, Pixel{255, 0, 0})
+	sprite.data[0][0] = Pixel{0, 255, 0}
+	canvas := NewPPM(10, 10, 255)
+	// Rotation d'un quart de tour : le haut de l'image devient la droite
+	canvas.DrawImageTransformed(sprite, QuadTransform(4, 2, Point{6, 1}, Point{6, 5}, Point{4, 1}), NearestNeighbor, nil)
+	if countColor(canvas, Pixel{255, 0, 0}) != 7 || canvas.At(5, 1) != (Pixel{0, 255, 0}) {
+		t.Errorf("Unexpected rotated sprite: %d red pixels, corner %v", countColor(canvas, Pixel{255, 0, 0}), canvas.At(5, 1))
+	}
+}
+
+func TestDrawImageTransformedMask(t *testing.T) {
+	sprite := newUniformPPM(4, 4, Pixel{200, 200, 200})
+	canvas := NewPPM(4, 4, 255)
+
+	stencil := NewPBM(4, 4)
+	stencil.data[1][1] = true
+	canvas.DrawImageTransformed(sprite, IdentityTransform(), NearestNeighbor, stencil)
+	if countColor(canvas, Pixel{200, 200, 200}) != 1 || canvas.At(1, 1) != (Pixel{200, 200, 200}) {
+		t.Error("Only black stencil pixels should be drawn")
+	}
+
+	alpha := newUniformPGM(4, 4, 128)
+	canvas.DrawImageTransformed(sprite, IdentityTransform(), Bilinear, alpha)
+	if canvas.At(3, 3) != (Pixel{100, 100, 100}) {
+		t.Errorf("Half-opaque mask should mix colors, got %v", canvas.At(3, 3))
+	}
+}
+
+func TestPGMRotate(t *testing.T) {
+	pgm := NewPGM(5, 5, 255)
+	pgm.data[2][4] = 200
+	pgm.Rotate(90, 7)
+	if pgm.At(2, 4) != 200 || pgm.At(4, 2) != 0 {
+		t.Errorf("Pixel should move from the right to the bottom, got %d", pgm.At(2, 4))
+	}
+	large := NewPGM(9, 9, 255)
+	large.Rotate(45, 7)
+	if large.At(0, 0) != 7 || large.At(4, 4) != 0 {
+		t.Errorf("Uncovered corners should be filled, got %d", large.At(0, 0))
+	}
+}
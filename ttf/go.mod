@@ -0,0 +1,12 @@
+module github.com/YOYOPX15/Netpbm/ttf
+
+go 1.21.4
+
+require (
+	github.com/YOYOPX15/Netpbm v0.0.0
+	golang.org/x/image v0.18.0
+)
+
+require golang.org/x/text v0.16.0 // indirect
+
+replace github.com/YOYOPX15/Netpbm => ../
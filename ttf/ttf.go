@@ -0,0 +1,73 @@
+// Package ttf rasterise du texte TrueType ou OpenType avec golang.org/x/image/font en masques PGM et
+// l'écrit en couleur sur des images PPM. C'est un module à part, pour que Netpbm reste sans dépendance.
+package ttf // ✨ Texte TrueType
+
+import (
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/YOYOPX15/Netpbm"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// LoadFace lit le fichier de police TrueType ou OpenType filename et renvoie sa face de size pixels.
+func LoadFace(filename string, size float64) (font.Face, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading font: %v", err)
+	}
+	return ParseFace(data, size)
+}
+
+// ParseFace renvoie la face de size pixels de la police TrueType ou OpenType contenue dans data.
+func ParseFace(data []byte, size float64) (font.Face, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid font size %v", size)
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing font: %v", err)
+	}
+	// À 72 ppp, un point vaut un pixel
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("error creating font face: %v", err)
+	}
+	return face, nil
+}
+
+// Width renvoie la largeur en pixels du texte écrit avec la face, crénage compris.
+func Width(face font.Face, text string) int {
+	return font.MeasureString(face, text).Ceil()
+}
+
+// Rasterize dessine le texte sur une ligne dans un masque PGM de maximum 255 dont chaque pixel est la
+// couverture anticrénelée du texte, et renvoie aussi la distance en pixels du haut du masque à la ligne
+// de base. Le masque a la hauteur d'une ligne de la face, même pour un texte sans jambage.
+func Rasterize(face font.Face, text string) (*Netpbm.PGM, int) {
+	metrics := face.Metrics()
+	ascent := metrics.Ascent.Ceil()
+	width, height := Width(face, text), ascent+metrics.Descent.Ceil()
+	alpha := image.NewAlpha(image.Rect(0, 0, width, height))
+	drawer := font.Drawer{Dst: alpha, Src: image.Opaque, Face: face, Dot: fixed.P(0, ascent)}
+	drawer.DrawString(text)
+
+	mask := Netpbm.NewPGM(width, height, 255)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mask.Set(x, y, alpha.AlphaAt(x, y).A)
+		}
+	}
+	return mask, ascent
+}
+
+// DrawTextTTF écrit le texte sur une ligne dans la couleur color avec la face, le coin supérieur gauche
+// de la ligne étant en p comme pour DrawText. Les bords des lettres sont mélangés à l'image selon leur
+// couverture ; les pixels hors de l'image sont ignorés.
+func DrawTextTTF(ppm *Netpbm.PPM, face font.Face, p Netpbm.Point, text string, color Netpbm.Pixel) {
+	mask, _ := Rasterize(face, text)
+	ppm.FillMask(mask, p, color)
+}
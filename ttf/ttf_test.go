@@ -0,0 +1,84 @@
+package ttf // 🧪 Test texte TrueType
+
+import (
+	"testing"
+
+	"github.com/YOYOPX15/Netpbm"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestParseFace(t *testing.T) {
+	if _, err := ParseFace([]byte("not a font"), 12); err == nil {
+		t.Errorf("Parsing garbage should fail")
+	}
+	if _, err := ParseFace(goregular.TTF, 0); err == nil {
+		t.Errorf("Size 0 should fail")
+	}
+	if _, err := LoadFace("missing.ttf", 12); err == nil {
+		t.Errorf("Loading a missing file should fail")
+	}
+}
+
+func TestRasterize(t *testing.T) {
+	face, err := ParseFace(goregular.TTF, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mask, baseline := Rasterize(face, "Hg")
+	width, height := mask.Size()
+	if width != Width(face, "Hg") || width == 0 {
+		t.Errorf("Mask width is %d, want %d", width, Width(face, "Hg"))
+	}
+	if baseline <= 0 || baseline >= height {
+		t.Fatalf("Baseline %d outside mask of height %d", baseline, height)
+	}
+	// Le H couvre des pixels pleins, le jambage du g descend sous la ligne de base
+	full, below := 0, 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if mask.At(x, y) == 255 {
+				full++
+			}
+			if y > baseline && mask.At(x, y) > 0 {
+				below++
+			}
+		}
+	}
+	if full == 0 || below == 0 {
+		t.Errorf("Expected full coverage and a descender, got %d full and %d below the baseline", full, below)
+	}
+	empty, _ := Rasterize(face, "")
+	if width, _ := empty.Size(); width != 0 {
+		t.Errorf("Empty text should give an empty mask")
+	}
+}
+
+func TestDrawTextTTF(t *testing.T) {
+	face, err := ParseFace(goregular.TTF, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ppm := Netpbm.NewPPM(80, 30, 255)
+	red := Netpbm.Pixel{R: 255}
+	DrawTextTTF(ppm, face, Netpbm.Point{X: 5, Y: 5}, "Netpbm", red)
+	// Partiellement hors de l'image : ne doit pas paniquer
+	DrawTextTTF(ppm, face, Netpbm.Point{X: 70, Y: -8}, "Netpbm", red)
+	count := 0
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 80; x++ {
+			p := ppm.At(x, y)
+			if p.G != 0 || p.B != 0 {
+				t.Fatalf("Pixel (%d, %d) is %v, only red should be painted on black", x, y, p)
+			}
+			if p.R == 255 {
+				count++
+			}
+			if x < 5 && y > 8 && p.R != 0 {
+				t.Errorf("Pixel (%d, %d) painted left of the text", x, y)
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("No fully covered pixel was painted")
+	}
+}
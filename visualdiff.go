@@ -0,0 +1,119 @@
+package Netpbm // ✨ Visualisation des différences
+
+import (
+	"fmt"
+	"sort"
+)
+
+// diffRegionGap est l'écart maximal, en pixels, entre deux pixels modifiés d'une même zone de VisualDiff.
+const diffRegionGap = 2
+
+// DiffRegion décrit une zone de pixels modifiés trouvée par VisualDiff.
+type DiffRegion struct {
+	Bounds    Rect    // Rectangle englobant la zone
+	Changed   int     // Nombre de pixels modifiés dans la zone
+	MeanDelta float64 // Écart moyen des pixels modifiés, entre 0 et 1 (plus grand écart entre canaux)
+	MaxDelta  float64 // Plus grand écart d'un pixel de la zone, entre 0 et 1
+}
+
+// VisualDiff compare deux images PPM de même taille et renvoie une image de revue construite à partir
+// de b : les pixels inchangés y sont passés en gris assombri, les pixels modifiés teintés de highlight
+// (d'autant plus que l'écart est grand) et chaque zone modifiée encadrée de highlight. Les pixels modifiés
+// sont regroupés en zones, deux pixels distants d'au plus deux pixels appartenant à la même zone ; les
+// statistiques de chaque zone sont renvoyées, de la plus étendue à la moins étendue. Les valeurs des deux
+// images sont comparées relativement à leur valeur maximale ; highlight est exprimée avec celle de b.
+func VisualDiff(a, b *PPM, highlight Pixel) (*PPM, []DiffRegion, error) {
+	if a.width != b.width || a.height != b.height {
+		return nil, nil, fmt.Errorf("size mismatch: %dx%d and %dx%d", a.width, a.height, b.width, b.height)
+	}
+	width, height := a.width, a.height
+
+	// Écart de chaque pixel, entre 0 et 1
+	scaleA, scaleB := 1/float64(max(a.max, 1)), 1/float64(max(b.max, 1))
+	delta := make([][]float64, height)
+	for y := range delta {
+		delta[y] = make([]float64, width)
+		for x := range delta[y] {
+			pa, pb := a.data[y][x], b.data[y][x]
+			for _, d := range [3]float64{
+				float64(pa.R)*scaleA - float64(pb.R)*scaleB,
+				float64(pa.G)*scaleA - float64(pb.G)*scaleB,
+				float64(pa.B)*scaleA - float64(pb.B)*scaleB,
+			} {
+				delta[y][x] = max(delta[y][x], d, -d)
+			}
+		}
+	}
+
+	// Image de revue : gris assombri, ou teinte de highlight proportionnelle à l'écart
+	composite := NewPPM(width, height, b.max)
+	composite.magicNumber = b.magicNumber
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			gray := uint8(b.data[y][x].brightness() * 2 / 5)
+			p := Pixel{gray, gray, gray}
+			if d := delta[y][x]; d > 0 {
+				// Au moins moitié teinte, pour que les plus petits écarts restent visibles
+				w := 0.5 + d/2
+				p = Pixel{
+					mixChannel(b.data[y][x].R, highlight.R, w),
+					mixChannel(b.data[y][x].G, highlight.G, w),
+					mixChannel(b.data[y][x].B, highlight.B, w),
+				}
+			}
+			composite.data[y][x] = p
+		}
+	}
+
+	// Regroupement des pixels modifiés en zones
+	var regions []DiffRegion
+	visited := make([][]bool, height)
+	for y := range visited {
+		visited[y] = make([]bool, width)
+	}
+	var stack []Point
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if delta[y][x] == 0 || visited[y][x] {
+				continue
+			}
+			minX, minY, maxX, maxY := x, y, x, y
+			region := DiffRegion{}
+			total := 0.0
+			stack = append(stack[:0], Point{x, y})
+			visited[y][x] = true
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				d := delta[p.Y][p.X]
+				region.Changed++
+				total += d
+				region.MaxDelta = max(region.MaxDelta, d)
+				minX, minY, maxX, maxY = min(minX, p.X), min(minY, p.Y), max(maxX, p.X), max(maxY, p.Y)
+				for qy := max(p.Y-diffRegionGap, 0); qy <= min(p.Y+diffRegionGap, height-1); qy++ {
+					for qx := max(p.X-diffRegionGap, 0); qx <= min(p.X+diffRegionGap, width-1); qx++ {
+						if delta[qy][qx] > 0 && !visited[qy][qx] {
+							visited[qy][qx] = true
+							stack = append(stack, Point{qx, qy})
+						}
+					}
+				}
+			}
+			region.Bounds = Rect{minX, minY, maxX - minX + 1, maxY - minY + 1}
+			region.MeanDelta = total / float64(region.Changed)
+			regions = append(regions, region)
+		}
+	}
+	sort.SliceStable(regions, func(i, j int) bool { return regions[i].Changed > regions[j].Changed })
+
+	// Cadre d'un pixel autour de chaque zone
+	for _, r := range regions {
+		composite.DrawRectangle(Point{r.Bounds.X - 1, r.Bounds.Y - 1}, r.Bounds.Width+1, r.Bounds.Height+1, highlight)
+	}
+	return composite, regions, nil
+}
+
+// mixChannel mélange deux valeurs de canal, w étant la part de to, entre 0 et 1.
+func mixChannel(from, to uint8, w float64) uint8 {
+	return uint8(float64(from)*(1-w) + float64(to)*w + 0.5)
+}
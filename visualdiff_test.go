@@ -0,0 +1,55 @@
+package Netpbm // 🧪 Test Visualisation des différences
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVisualDiff(t *testing.T) {
+	a := newUniformPPM(40, 30, Pixel{100, 100, 100})
+	b := a.Clone()
+	// Deux zones modifiées : un carré 4×4 très changé et deux pixels proches légèrement changés
+	for y := 5; y < 9; y++ {
+		for x := 5; x < 9; x++ {
+			b.Set(x, y, Pixel{255, 100, 100})
+		}
+	}
+	b.Set(30, 20, Pixel{110, 100, 100})
+	b.Set(32, 21, Pixel{100, 100, 120})
+
+	highlight := Pixel{255, 0, 255}
+	composite, regions, err := VisualDiff(a, b, highlight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(regions) != 2 {
+		t.Fatalf("%d zones, attendu 2 : %+v", len(regions), regions)
+	}
+	if r := regions[0]; r.Bounds != (Rect{5, 5, 4, 4}) || r.Changed != 16 || math.Abs(r.MaxDelta-r.MeanDelta) > 1e-9 || math.Abs(r.MaxDelta-155.0/255) > 1e-9 {
+		t.Errorf("première zone = %+v", r)
+	}
+	if r := regions[1]; r.Bounds != (Rect{30, 20, 3, 2}) || r.Changed != 2 || r.MaxDelta <= r.MeanDelta {
+		t.Errorf("deuxième zone = %+v", r)
+	}
+
+	// Pixels inchangés assombris, pixels modifiés teintés, cadre autour des zones
+	if p := composite.data[15][15]; p != (Pixel{40, 40, 40}) {
+		t.Errorf("pixel inchangé = %v, attendu gris assombri", p)
+	}
+	if p := composite.data[6][6]; p.G > 50 || p.B < 150 {
+		t.Errorf("pixel modifié = %v, attendu teinté", p)
+	}
+	if composite.data[4][4] != highlight || composite.data[9][9] != highlight {
+		t.Error("cadre absent autour de la première zone")
+	}
+	if a.data[6][6] != (Pixel{100, 100, 100}) || b.data[6][6] != (Pixel{255, 100, 100}) {
+		t.Error("les images comparées ont été modifiées")
+	}
+
+	if _, _, err := VisualDiff(a, NewPPM(10, 10, 255), highlight); err == nil {
+		t.Error("des images de tailles différentes doivent être refusées")
+	}
+	if _, regions, _ := VisualDiff(a, a.Clone(), highlight); len(regions) != 0 {
+		t.Errorf("images identiques : %d zones", len(regions))
+	}
+}
@@ -0,0 +1,68 @@
+package Netpbm // ✨ Baguette magique
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SelectByColor renvoie le masque (true : pixel sélectionné) des pixels de l'image PPM dont la couleur
+// ne s'écarte pas de plus de tolerance, sur chaque canal, de celle du pixel seed, comme la baguette
+// magique d'un logiciel de retouche. Si contiguous est vrai, seuls les pixels reliés à seed par des
+// voisins (haut, bas, gauche, droite) eux-mêmes sélectionnés sont gardés ; sinon tous les pixels de
+// couleur proche le sont. Un seed hors de l'image donne un masque vide.
+func (ppm *PPM) SelectByColor(seed Point, tolerance uint8, contiguous bool) *PBM {
+	mask := NewPBM(ppm.width, ppm.height)
+	if seed.X < 0 || seed.Y < 0 || seed.X >= ppm.width || seed.Y >= ppm.height {
+		return mask
+	}
+	reference := ppm.data[seed.Y][seed.X]
+	similar := func(x, y int) bool {
+		return colorDistance(ppm.data[y][x], reference) <= int(tolerance)
+	}
+
+	if !contiguous {
+		for y := 0; y < ppm.height; y++ {
+			for x := 0; x < ppm.width; x++ {
+				mask.data[y][x] = similar(x, y)
+			}
+		}
+		return mask
+	}
+
+	// Parcours par pile : le masque sert aussi à marquer les pixels déjà visités
+	mask.data[seed.Y][seed.X] = true
+	stack := []Point{seed}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, n := range [4]Point{{p.X - 1, p.Y}, {p.X + 1, p.Y}, {p.X, p.Y - 1}, {p.X, p.Y + 1}} {
+			if n.X < 0 || n.Y < 0 || n.X >= ppm.width || n.Y >= ppm.height || mask.data[n.Y][n.X] || !similar(n.X, n.Y) {
+				continue
+			}
+			mask.data[n.Y][n.X] = true
+			stack = append(stack, n)
+		}
+	}
+	return mask
+}
+
+// SelectMask remplace la sélection du dessin par le masque mask (true : pixel sélectionné), par exemple
+// celui renvoyé par SelectByColor. Les pixels du dessin hors du masque ne sont pas sélectionnés.
+func (c *Canvas) SelectMask(mask *PBM) {
+	selection := NewPBM(c.width, c.height)
+	var path strings.Builder
+	for y := 0; y < min(c.height, mask.height); y++ {
+		copy(selection.data[y], mask.data[y])
+		// Zone de découpe SVG : un rectangle par suite de pixels sélectionnés de la ligne
+		for x := 0; x < c.width; x++ {
+			start := x
+			for x < c.width && selection.data[y][x] {
+				x++
+			}
+			if x > start {
+				fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+			}
+		}
+	}
+	c.selectMask(selection, fmt.Sprintf(`<path d="%s"/>`, path.String()))
+}
@@ -0,0 +1,64 @@
+package Netpbm // 🧪 Test Baguette magique
+
+import (
+	"strings"
+	"testing"
+)
+
+// newTwoSquaresPPM renvoie une image blanche avec deux carrés rouges séparés et un carré presque rouge.
+func newTwoSquaresPPM() *PPM {
+	ppm := newUniformPPM(12, 4, Pixel{255, 255, 255})
+	for y := 1; y < 3; y++ {
+		for x := 0; x < 2; x++ {
+			ppm.Set(1+x, y, Pixel{255, 0, 0})
+			ppm.Set(5+x, y, Pixel{255, 0, 0})
+			ppm.Set(9+x, y, Pixel{250, 8, 0})
+		}
+	}
+	return ppm
+}
+
+func TestSelectByColor(t *testing.T) {
+	ppm := newTwoSquaresPPM()
+	tests := []struct {
+		tolerance  uint8
+		contiguous bool
+		want       int
+	}{
+		{0, true, 4},
+		{0, false, 8},
+		{10, false, 12},
+		{10, true, 4},
+	}
+	for _, test := range tests {
+		mask := ppm.SelectByColor(Point{1, 1}, test.tolerance, test.contiguous)
+		if got := countBlack(mask); got != test.want {
+			t.Errorf("tolérance %d, contigu %v : %d pixels, attendu %d", test.tolerance, test.contiguous, got, test.want)
+		}
+	}
+	if mask := ppm.SelectByColor(Point{0, 0}, 0, true); countBlack(mask) != 48-12 {
+		t.Errorf("fond : %d pixels, attendu %d", countBlack(mask), 48-12)
+	}
+	if mask := ppm.SelectByColor(Point{-1, 0}, 255, false); countBlack(mask) != 0 {
+		t.Error("un seed hors de l'image doit donner un masque vide")
+	}
+}
+
+func TestCanvasSelectMask(t *testing.T) {
+	ppm := newTwoSquaresPPM()
+	c := NewCanvas(12, 4, Pixel{})
+	c.SelectMask(ppm.SelectByColor(Point{5, 1}, 0, true))
+	c.DrawFilledRectangle(Point{0, 0}, 11, 3, Pixel{0, 0, 255})
+
+	drawn := c.ToPPM()
+	if got := countColor(drawn, Pixel{0, 0, 255}); got != 4 {
+		t.Errorf("%d pixels bleus, attendu 4", got)
+	}
+	svg := new(strings.Builder)
+	if err := c.WriteSVG(svg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(svg.String(), `<path d="M5 1h2v1h-2zM5 2h2v1h-2z"/>`) {
+		t.Errorf("zone de découpe SVG incorrecte :\n%s", svg)
+	}
+}
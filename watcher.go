@@ -0,0 +1,114 @@
+package Netpbm // ✨ Dossier de dépôt
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Watcher surveille un dossier et applique une chaîne de traitements à chaque fichier .pbm, .pgm ou
+// .ppm qui y est déposé ou modifié, en enregistrant le résultat sous le même nom dans un autre dossier.
+// La surveillance se fait par relevés successifs du contenu du dossier.
+type Watcher struct {
+	input, output string
+	pipeline      Pipeline
+	pending       map[string]fileState // Fichiers vus au relevé précédent, peut-être encore en cours d'écriture
+	done          map[string]fileState // Fichiers déjà traités, dans l'état où ils l'ont été
+}
+
+// fileState résume l'état d'un fichier pour détecter ses modifications.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// NewWatcher crée un Watcher qui traite avec pipeline les images déposées dans input et écrit les
+// résultats dans output.
+func NewWatcher(input, output string, pipeline Pipeline) *Watcher {
+	return &Watcher{
+		input: input, output: output, pipeline: pipeline,
+		pending: make(map[string]fileState), done: make(map[string]fileState),
+	}
+}
+
+// Poll fait un relevé du dossier surveillé et traite les fichiers prêts. Un fichier n'est traité que
+// lorsque sa taille et sa date n'ont pas changé depuis le relevé précédent, pour ne pas lire un fichier
+// en cours de copie : un fichier déposé est donc traité au deuxième relevé qui le voit.
+// Poll renvoie les chemins des résultats écrits, ainsi qu'une erreur regroupant les fichiers en échec.
+func (w *Watcher) Poll() ([]string, error) {
+	return w.poll(context.Background())
+}
+
+// poll fait le travail de Poll en journalisant dans le journal de ctx.
+func (w *Watcher) poll(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(w.input)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory: %v", err)
+	}
+	var written, failures []string
+	seen := make(map[string]fileState)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".pbm" && ext != ".pgm" && ext != ".ppm") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		name := entry.Name()
+		state := fileState{info.Size(), info.ModTime()}
+		if previous, ok := w.done[name]; ok && previous == state {
+			continue
+		}
+		if previous, ok := w.pending[name]; !ok || previous != state {
+			seen[name] = state
+			continue
+		}
+
+		output := filepath.Join(w.output, name)
+		result := "ok"
+		if err := w.pipeline.process(ctx, filepath.Join(w.input, name), output); err != nil {
+			result = "error"
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			if logger := eventLogger(ctx, slog.LevelWarn); logger != nil {
+				logger.LogAttrs(ctx, slog.LevelWarn, "watched file failed", slog.String("file", name), slog.Any("error", err))
+			}
+		} else {
+			written = append(written, output)
+		}
+		if metrics := currentMetrics(); metrics != nil {
+			metrics.Add(MetricFilesProcessed, 1, Label{"result", result})
+		}
+		// Un fichier en échec n'est retenté que s'il est modifié
+		w.done[name] = state
+	}
+	w.pending = seen
+
+	if len(failures) > 0 {
+		return written, fmt.Errorf("%d file(s) failed: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return written, nil
+}
+
+// Run relève le dossier toutes les interval jusqu'à l'annulation de ctx. Si onError n'est pas nil, elle
+// reçoit les erreurs de chaque relevé, qui n'interrompent pas la surveillance. Les événements vont au
+// journal porté par ctx (voir WithLogger), ou à défaut à celui installé par SetLogger.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.poll(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package Netpbm // 🧪 Test dossier de dépôt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	input, output := t.TempDir(), t.TempDir()
+	invert := func(img Image) (Image, error) {
+		img.(*PGM).Invert()
+		return img, nil
+	}
+	watcher := NewWatcher(input, output, Pipeline{invert})
+
+	if err := newUniformPGM(2, 2, 0).Save(filepath.Join(input, "a.pgm")); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(input, "notes.txt"), []byte("ignored"), 0644)
+
+	// Premier relevé : le fichier est peut-être encore en cours d'écriture
+	if written, err := watcher.Poll(); err != nil || len(written) != 0 {
+		t.Fatalf("Expected nothing on the first poll, got %v, %v", written, err)
+	}
+	written, err := watcher.Poll()
+	if err != nil || len(written) != 1 || written[0] != filepath.Join(output, "a.pgm") {
+		t.Fatalf("Expected a.pgm to be processed, got %v, %v", written, err)
+	}
+	result, err := ReadPGM(written[0])
+	if err != nil || result.At(0, 0) != 255 {
+		t.Errorf("Unexpected result %v", err)
+	}
+	if written, _ := watcher.Poll(); len(written) != 0 {
+		t.Error("A processed file should not be processed again")
+	}
+
+	// Un fichier invalide est signalé une seule fois
+	os.WriteFile(filepath.Join(input, "broken.ppm"), []byte("P9\n"), 0644)
+	watcher.Poll()
+	if _, err := watcher.Poll(); err == nil {
+		t.Error("Expected an error for an invalid file")
+	}
+	if _, err := watcher.Poll(); err != nil {
+		t.Errorf("A failed file should not be retried unless modified: %v", err)
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	input, output := t.TempDir(), t.TempDir()
+	newUniformPPM(1, 1, Pixel{1, 2, 3}).Save(filepath.Join(input, "b.ppm"))
+	watcher := NewWatcher(input, output, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := watcher.Run(ctx, 10*time.Millisecond, nil); err != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(output, "b.ppm")); err != nil {
+		t.Errorf("Expected b.ppm to be copied: %v", err)
+	}
+}
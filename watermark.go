@@ -0,0 +1,62 @@
+package Netpbm // ✨ Filigranes
+
+import "math"
+
+// Paste colle l'image src sur l'image PPM, son coin supérieur gauche en at. Les parties qui dépassent
+// sont ignorées.
+func (ppm *PPM) Paste(src *PPM, at Point) {
+	ppm.own()
+	ppm.pasteBlend(src, at, nil, 1)
+}
+
+// Watermark incruste stamp (un logo par exemple) dans l'image PPM avec l'opacité donnée, entre 0 et 1.
+// Mask, s'il n'est pas nil, doit avoir la taille de stamp et donne l'opacité de chacun de ses pixels
+// (blanc opaque). Le logo est placé selon position ou, si tile est vrai, répété en mosaïque sur toute
+// l'image, la mosaïque étant alignée sur la position d'un logo placé selon position.
+func (ppm *PPM) Watermark(stamp *PPM, mask *PGM, position Gravity, opacity float64, tile bool) {
+	ppm.own()
+	if stamp.width == 0 || stamp.height == 0 {
+		return
+	}
+	opacity = math.Min(math.Max(opacity, 0), 1)
+	origin := position.place(ppm.width, ppm.height, stamp.width, stamp.height)
+	if !tile {
+		ppm.pasteBlend(stamp, origin, mask, opacity)
+		return
+	}
+
+	// Premier logo en haut à gauche qui touche l'image
+	startX := origin.X - ((origin.X%stamp.width)+stamp.width)%stamp.width
+	startY := origin.Y - ((origin.Y%stamp.height)+stamp.height)%stamp.height
+	if startX > 0 {
+		startX -= stamp.width
+	}
+	if startY > 0 {
+		startY -= stamp.height
+	}
+	for y := startY; y < ppm.height; y += stamp.height {
+		for x := startX; x < ppm.width; x += stamp.width {
+			ppm.pasteBlend(stamp, Point{x, y}, mask, opacity)
+		}
+	}
+}
+
+// pasteBlend mélange src à l'image PPM à partir de at, avec l'opacité opacity multipliée par celle du masque.
+func (ppm *PPM) pasteBlend(src *PPM, at Point, mask *PGM, opacity float64) {
+	for y := max(0, -at.Y); y < src.height && at.Y+y < ppm.height; y++ {
+		for x := max(0, -at.X); x < src.width && at.X+x < ppm.width; x++ {
+			alpha := opacity
+			if mask != nil {
+				alpha *= mask.opacity(x, y)
+			}
+			if alpha <= 0 {
+				continue
+			}
+			p, s := &ppm.data[at.Y+y][at.X+x], src.data[y][x]
+			mix := func(u, v uint8) uint8 {
+				return uint8(math.Round(float64(u)*(1-alpha) + float64(v)*alpha))
+			}
+			*p = Pixel{mix(p.R, s.R), mix(p.G, s.G), mix(p.B, s.B)}
+		}
+	}
+}
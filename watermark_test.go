@@ -0,0 +1,42 @@
+package Netpbm // 🧪 Test filigranes
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPaste(t *testing.T) {
+	ppm := newUniformPPM(4, 3, Pixel{0, 0, 0})
+	ppm.Paste(newUniformPPM(2, 2, Pixel{9, 9, 9}), Point{3, -1})
+	if countColor(ppm, Pixel{9, 9, 9}) != 1 || ppm.At(3, 0) != (Pixel{9, 9, 9}) {
+		t.Error("Only the overlapping pixel should be pasted")
+	}
+}
+
+func TestWatermark(t *testing.T) {
+	white := Pixel{255, 255, 255}
+	stamp := newUniformPPM(2, 2, white)
+	ppm := newUniformPPM(8, 6, Pixel{0, 0, 0})
+	ppm.Watermark(stamp, nil, GravitySouthEast, 0.5, false)
+	if ppm.At(7, 5) != (Pixel{128, 128, 128}) || ppm.At(6, 4) != (Pixel{128, 128, 128}) || ppm.At(5, 5) != (Pixel{}) {
+		t.Errorf("Unexpected watermark %v", ppm.data)
+	}
+
+	// Le masque rend transparente la moitié gauche du logo
+	mask := newUniformPGM(2, 2, 255)
+	mask.data[0][0], mask.data[1][0] = 0, 0
+	tiled := newUniformPPM(7, 5, Pixel{0, 0, 0})
+	tiled.Watermark(stamp, mask, GravityCenter, 1, true)
+	// Le logo central commence en (2, 1) : les colonnes impaires sont peintes sur toute la hauteur
+	for x := 0; x < 7; x++ {
+		if (tiled.At(x, 0) == white) != (x%2 == 1) || (tiled.At(x, 4) == white) != (x%2 == 1) {
+			t.Fatalf("Unexpected tiling at column %d", x)
+		}
+	}
+
+	err := tiled.Save("./testImages/ppm/watermark.ppm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("./testImages/ppm/watermark.ppm")
+}
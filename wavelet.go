@@ -0,0 +1,231 @@
+package Netpbm // ✨ Transformée en ondelettes
+
+import "math"
+
+// Wavelet choisit l'ondelette de WaveletTransform.
+type Wavelet int
+
+const (
+	WaveletHaar  Wavelet = iota // Moyennes et différences de paires de pixels : simple, mais en blocs une fois quantifiée
+	WaveletCDF53                // Ondelette biorthogonale de Cohen-Daubechies-Feauveau 5/3, celle de JPEG 2000 sans perte
+)
+
+// WaveletDecomposition est la décomposition en ondelettes d'une image PGM. Les coefficients sont rangés
+// comme dans une image : à chaque niveau, la zone en haut à gauche est coupée en quatre, l'approximation
+// (moitié de la taille) en haut à gauche, et les détails horizontaux, verticaux et diagonaux dans les trois
+// autres quarts. Les coefficients sont réels : sans quantification, Reconstruct rend l'image exacte.
+type WaveletDecomposition struct {
+	data          [][]float64
+	width, height int
+	levels        int
+	wavelet       Wavelet
+	max           int // Valeur maximale de l'image d'origine
+}
+
+// WaveletTransform décompose l'image PGM en ondelettes sur levels niveaux (limité pour que la dernière
+// approximation garde au moins un pixel de côté), par schéma de lifting.
+func (pgm *PGM) WaveletTransform(wavelet Wavelet, levels int) *WaveletDecomposition {
+	d := &WaveletDecomposition{data: make([][]float64, pgm.height), width: pgm.width, height: pgm.height, wavelet: wavelet, max: pgm.max}
+	for y := range d.data {
+		d.data[y] = make([]float64, pgm.width)
+		for x, v := range pgm.data[y] {
+			d.data[y][x] = float64(v)
+		}
+	}
+	width, height := pgm.width, pgm.height
+	for d.levels < levels && (width > 1 || height > 1) {
+		d.transformBand(width, height, false)
+		width, height = (width+1)/2, (height+1)/2
+		d.levels++
+	}
+	return d
+}
+
+// Levels renvoie le nombre de niveaux de la décomposition.
+func (d *WaveletDecomposition) Levels() int {
+	return d.levels
+}
+
+// Quantize arrondit chaque coefficient de détail au multiple de step le plus proche, l'approximation
+// restant exacte, et renvoie le nombre de coefficients non nuls. Plus step est grand, plus il y a de zéros
+// (donc plus l'image se compresse) et plus l'image reconstruite s'éloigne de l'originale.
+func (d *WaveletDecomposition) Quantize(step float64) int {
+	approxWidth, approxHeight := d.approximationSize()
+	nonZero := 0
+	for y := range d.data {
+		for x, c := range d.data[y] {
+			if step > 0 && (x >= approxWidth || y >= approxHeight) {
+				c = math.Round(c/step) * step
+				d.data[y][x] = c
+			}
+			if c != 0 {
+				nonZero++
+			}
+		}
+	}
+	return nonZero
+}
+
+// Entropy renvoie l'entropie de Shannon des coefficients arrondis à l'entier, en bits par coefficient :
+// une estimation de la taille qu'aurait la décomposition après un bon codage entropique, à comparer aux
+// 8 bits par pixel de l'image d'origine.
+func (d *WaveletDecomposition) Entropy() float64 {
+	counts := map[float64]int{}
+	for y := range d.data {
+		for _, c := range d.data[y] {
+			counts[math.Round(c)]++
+		}
+	}
+	total, entropy := float64(d.width*d.height), 0.0
+	for _, n := range counts {
+		p := float64(n) / total
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Reconstruct reconstruit l'image PGM à partir des coefficients, avec la valeur maximale de l'image
+// d'origine.
+func (d *WaveletDecomposition) Reconstruct() *PGM {
+	work := &WaveletDecomposition{data: make([][]float64, d.height), width: d.width, height: d.height, wavelet: d.wavelet}
+	for y := range work.data {
+		work.data[y] = append([]float64(nil), d.data[y]...)
+	}
+	// Tailles de la zone transformée à chaque niveau, défaites du plus profond au premier
+	sizes := make([][2]int, d.levels)
+	width, height := d.width, d.height
+	for level := range sizes {
+		sizes[level] = [2]int{width, height}
+		width, height = (width+1)/2, (height+1)/2
+	}
+	for level := d.levels - 1; level >= 0; level-- {
+		work.transformBand(sizes[level][0], sizes[level][1], true)
+	}
+	pgm := NewPGM(d.width, d.height, d.max)
+	for y := range pgm.data {
+		for x := range pgm.data[y] {
+			pgm.data[y][x] = clampChannel(work.data[y][x], d.max)
+		}
+	}
+	return pgm
+}
+
+// ToPGM renvoie les coefficients en image PGM 8 bits, pour les visualiser : l'approximation en niveaux
+// de gris, les détails autour du gris moyen (128 pour un détail nul).
+func (d *WaveletDecomposition) ToPGM() *PGM {
+	approxWidth, approxHeight := d.approximationSize()
+	scale := 255 / float64(max(d.max, 1))
+	pgm := NewPGM(d.width, d.height, 255)
+	for y := range d.data {
+		for x, c := range d.data[y] {
+			if x < approxWidth && y < approxHeight {
+				pgm.data[y][x] = clampChannel(c*scale, 255)
+			} else {
+				pgm.data[y][x] = clampChannel(128+c*scale, 255)
+			}
+		}
+	}
+	return pgm
+}
+
+// approximationSize renvoie la taille de l'approximation du dernier niveau.
+func (d *WaveletDecomposition) approximationSize() (int, int) {
+	width, height := d.width, d.height
+	for level := 0; level < d.levels; level++ {
+		width, height = (width+1)/2, (height+1)/2
+	}
+	return width, height
+}
+
+// transformBand transforme (ou détransforme) la zone width×height en haut à gauche des coefficients :
+// lignes puis colonnes, dans l'ordre inverse pour la détransformation.
+func (d *WaveletDecomposition) transformBand(width, height int, inverse bool) {
+	signal := make([]float64, max(width, height))
+	rows := func() {
+		for y := 0; y < height; y++ {
+			waveletLift(d.data[y][:width], signal[:width], d.wavelet, inverse)
+		}
+	}
+	columns := func() {
+		column := make([]float64, height)
+		for x := 0; x < width; x++ {
+			for y := range column {
+				column[y] = d.data[y][x]
+			}
+			waveletLift(column, signal[:height], d.wavelet, inverse)
+			for y, c := range column {
+				d.data[y][x] = c
+			}
+		}
+	}
+	if inverse {
+		columns()
+		rows()
+	} else {
+		rows()
+		columns()
+	}
+}
+
+// waveletLift transforme s sur place par lifting : les (n+1)/2 coefficients d'approximation, puis les n/2
+// coefficients de détail. Avec inverse, s contient ces coefficients et redevient le signal. Tmp est un
+// tampon de même longueur que s.
+func waveletLift(s, tmp []float64, wavelet Wavelet, inverse bool) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+	half := (n + 1) / 2
+	even, odd := tmp[:half], tmp[half:n]
+	// at renvoie even[i], prolongé par symétrie au-delà de la fin
+	at := func(i int) float64 { return even[min(i, half-1)] }
+	// detail renvoie odd[i], prolongé par symétrie avant le début et au-delà de la fin
+	detail := func(i int) float64 { return odd[min(max(i, 0), len(odd)-1)] }
+
+	if !inverse {
+		for i := range even {
+			even[i] = s[2*i]
+		}
+		for i := range odd {
+			odd[i] = s[2*i+1]
+		}
+		switch wavelet {
+		case WaveletCDF53:
+			for i := range odd {
+				odd[i] -= (even[i] + at(i+1)) / 2
+			}
+			for i := range even {
+				even[i] += (detail(i-1) + detail(i)) / 4
+			}
+		default:
+			for i := range odd {
+				odd[i] -= even[i]
+				even[i] += odd[i] / 2
+			}
+		}
+		copy(s, tmp[:n])
+		return
+	}
+
+	copy(tmp[:n], s)
+	switch wavelet {
+	case WaveletCDF53:
+		for i := range even {
+			even[i] -= (detail(i-1) + detail(i)) / 4
+		}
+		for i := range odd {
+			odd[i] += (even[i] + at(i+1)) / 2
+		}
+	default:
+		for i := range odd {
+			even[i] -= odd[i] / 2
+			odd[i] += even[i]
+		}
+	}
+	for i := range even {
+		s[2*i] = even[i]
+	}
+	for i := range odd {
+		s[2*i+1] = odd[i]
+	}
+}
@@ -0,0 +1,96 @@
+package Netpbm // 🧪 Test Transformée en ondelettes
+
+import (
+	"math"
+	"testing"
+)
+
+// newWaveletTestPGM renvoie une image 13×10 en dégradé barrée d'un carré clair.
+func newWaveletTestPGM() *PGM {
+	pgm := NewPGM(13, 10, 255)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 13; x++ {
+			v := uint8(10*x + 5*y)
+			if x >= 4 && x < 9 && y >= 3 && y < 7 {
+				v = 240
+			}
+			pgm.Set(x, y, v)
+		}
+	}
+	return pgm
+}
+
+func TestWaveletLift(t *testing.T) {
+	for _, wavelet := range []Wavelet{WaveletHaar, WaveletCDF53} {
+		for n := 1; n <= 7; n++ {
+			s, tmp := make([]float64, n), make([]float64, n)
+			for i := range s {
+				s[i] = float64(i*i%5) + 1
+			}
+			original := append([]float64(nil), s...)
+			waveletLift(s, tmp, wavelet, false)
+			waveletLift(s, tmp, wavelet, true)
+			for i := range s {
+				if math.Abs(s[i]-original[i]) > 1e-12 {
+					t.Errorf("ondelette %d, n = %d : %v, attendu %v", wavelet, n, s, original)
+					break
+				}
+			}
+		}
+	}
+
+	// Un signal constant n'a aucun détail
+	s := []float64{7, 7, 7, 7, 7}
+	waveletLift(s, make([]float64, 5), WaveletCDF53, false)
+	if s[0] != 7 || s[2] != 7 || s[3] != 0 || s[4] != 0 {
+		t.Errorf("signal constant : %v", s)
+	}
+}
+
+func TestWaveletRoundTrip(t *testing.T) {
+	pgm := newWaveletTestPGM()
+	for _, wavelet := range []Wavelet{WaveletHaar, WaveletCDF53} {
+		d := pgm.WaveletTransform(wavelet, 10)
+		if d.Levels() != 4 {
+			t.Errorf("ondelette %d : %d niveaux, attendu 4", wavelet, d.Levels())
+		}
+		restored := d.Reconstruct()
+		for y := 0; y < pgm.height; y++ {
+			for x := 0; x < pgm.width; x++ {
+				if restored.At(x, y) != pgm.At(x, y) {
+					t.Fatalf("ondelette %d, pixel (%d, %d) = %d, attendu %d", wavelet, x, y, restored.At(x, y), pgm.At(x, y))
+				}
+			}
+		}
+	}
+}
+
+func TestWaveletQuantize(t *testing.T) {
+	pgm := newWaveletTestPGM()
+	d := pgm.WaveletTransform(WaveletCDF53, 2)
+	exact, entropy := d.Quantize(0), d.Entropy()
+	coarse := d.Quantize(20)
+	if coarse >= exact || d.Entropy() >= entropy {
+		t.Errorf("quantification : %d coefficients non nuls (avant %d), entropie %.2f (avant %.2f)", coarse, exact, d.Entropy(), entropy)
+	}
+
+	// L'image reconstruite reste proche de l'originale
+	restored := d.Reconstruct()
+	total := 0.0
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			total += math.Abs(float64(restored.At(x, y)) - float64(pgm.At(x, y)))
+		}
+	}
+	if mean := total / float64(pgm.width*pgm.height); mean > 10 {
+		t.Errorf("écart moyen après quantification %.1f", mean)
+	}
+}
+
+func TestWaveletToPGM(t *testing.T) {
+	view := newUniformPGM(8, 8, 100).WaveletTransform(WaveletHaar, 1).ToPGM()
+	// Approximation 4×4 au niveau de l'image, détails nuls au gris moyen
+	if view.At(1, 1) != 100 || view.At(6, 1) != 128 || view.At(6, 6) != 128 {
+		t.Errorf("visualisation : %d, %d, %d", view.At(1, 1), view.At(6, 1), view.At(6, 6))
+	}
+}
@@ -0,0 +1,126 @@
+package Netpbm // ✨ Format XBM
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	xbmDefine = regexp.MustCompile(`#define\s+\S*?_?(width|height)\s+(\d+)`)
+	xbmBits   = regexp.MustCompile(`0[xX][0-9a-fA-F]+|\d+`)
+)
+
+// ReadXBM lit une image XBM (bitmap X11) et la convertit en PBM. Les bits à 1 deviennent des pixels noirs.
+func ReadXBM(filename string) (*PBM, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	text := string(content)
+
+	width, height := -1, -1
+	for _, match := range xbmDefine.FindAllStringSubmatch(text, -1) {
+		value, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", match[1], match[2], err)
+		}
+		if match[1] == "width" {
+			width = value
+		} else {
+			height = value
+		}
+	}
+	if width < 0 || height < 0 {
+		return nil, fmt.Errorf("missing width or height definition")
+	}
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("missing bits array")
+	}
+
+	values := xbmBits.FindAllString(text[start+1:end], -1)
+	// La hauteur est bornée par division pour que rowBytes*height ne puisse déborder avant l'allocation
+	rowBytes := width/8 + min(width%8, 1)
+	if height > len(values)/max(rowBytes, 1) {
+		return nil, fmt.Errorf("expected %d rows of %d bytes, got %d bytes", height, rowBytes, len(values))
+	}
+	pbm := NewPBM(width, height)
+	for y := 0; y < height; y++ {
+		for i := 0; i < rowBytes; i++ {
+			b, err := strconv.ParseUint(values[y*rowBytes+i], 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid byte %q: %v", values[y*rowBytes+i], err)
+			}
+			// Dans un octet XBM, le premier pixel est le bit de poids faible
+			for bit := 0; bit < 8 && i*8+bit < width; bit++ {
+				pbm.data[y][i*8+bit] = b&(1<<uint(bit)) != 0
+			}
+		}
+	}
+	return pbm, nil
+}
+
+// SaveXBM enregistre l'image PBM au format XBM, le nom des variables C venant du nom du fichier.
+func (pbm *PBM) SaveXBM(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	name := cIdentifier(strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+	writer := bufio.NewWriter(file)
+	fmt.Fprintf(writer, "#define %s_width %d\n#define %[1]s_height %[3]d\n", name, pbm.width, pbm.height)
+	fmt.Fprintf(writer, "static unsigned char %s_bits[] = {", name)
+	rowBytes := (pbm.width + 7) / 8
+	count := 0
+	for y := 0; y < pbm.height; y++ {
+		for i := 0; i < rowBytes; i++ {
+			var b byte
+			for bit := 0; bit < 8 && i*8+bit < pbm.width; bit++ {
+				if pbm.data[y][i*8+bit] {
+					b |= 1 << uint(bit)
+				}
+			}
+			if count > 0 {
+				writer.WriteString(",")
+			}
+			if count%12 == 0 {
+				writer.WriteString("\n  ")
+			} else {
+				writer.WriteString(" ")
+			}
+			fmt.Fprintf(writer, "0x%02x", b)
+			count++
+		}
+	}
+	writer.WriteString(" };\n")
+	return writer.Flush()
+}
+
+// cIdentifier transforme name en identifiant C valide.
+func cIdentifier(name string) string {
+	var builder strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			builder.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune('_')
+		}
+	}
+	if builder.Len() == 0 {
+		return "image"
+	}
+	return builder.String()
+}
@@ -0,0 +1,57 @@
+package Netpbm // 🧪 Test format XBM
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndReadXBM(t *testing.T) {
+	pbm := NewPBM(10, 3)
+	pbm.data[0][0] = true
+	pbm.data[1][9] = true
+	pbm.data[2][3] = true
+	filename := "./testImages/pbm/test-xbm.xbm"
+	if err := pbm.SaveXBM(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"#define test_xbm_width 10\n", "#define test_xbm_height 3\n", "static unsigned char test_xbm_bits[] = {", "0x01, 0x00, 0x00, 0x02, 0x08, 0x00 };"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("XBM should contain %q:\n%s", want, content)
+		}
+	}
+
+	read, err := ReadXBM(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Hash() != pbm.Hash() {
+		t.Error("Read image differs from the saved one")
+	}
+}
+
+func TestReadXBMErrors(t *testing.T) {
+	filename := "./testImages/pbm/broken.xbm"
+	defer os.Remove(filename)
+	for _, content := range []string{
+		"static unsigned char x_bits[] = { 0x00 };",
+		"#define x_width 16\n#define x_height 2\nstatic unsigned char x_bits[] = { 0x00, 0x01 };",
+		"#define x_width 99999999999999999999\n#define x_height 1\nstatic unsigned char x_bits[] = { 0x00 };",
+		"#define x_width 9223372036854775807\n#define x_height 2\nstatic unsigned char x_bits[] = { 0x00 };",
+		"#define x_width 8\n#define x_height 9223372036854775807\nstatic unsigned char x_bits[] = { 0x00 };",
+	} {
+		os.WriteFile(filename, []byte(content), 0644)
+		if _, err := ReadXBM(filename); err == nil {
+			t.Errorf("Expected an error for %q", content)
+		}
+	}
+	if _, err := ReadXBM("./testImages/pbm/missing.xbm"); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,177 @@
+package Netpbm // ✨ Format XPM
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// xpmChars sont les caractères utilisés pour nommer les couleurs d'une image XPM.
+const xpmChars = ".#abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789+@$%&*=-;:>,</()[]{}|~^`'_!?"
+
+var xpmString = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// xpmNamedColors donne les quelques noms de couleurs X11 les plus courants.
+var xpmNamedColors = map[string]Pixel{
+	"black": {0, 0, 0}, "white": {255, 255, 255}, "red": {255, 0, 0}, "green": {0, 255, 0},
+	"blue": {0, 0, 255}, "yellow": {255, 255, 0}, "cyan": {0, 255, 255}, "magenta": {255, 0, 255},
+	"gray": {190, 190, 190}, "grey": {190, 190, 190},
+}
+
+// ReadXPM lit une image XPM (pixmap X11) et la convertit en PPM.
+// La couleur transparente « None » devient blanche.
+func ReadXPM(filename string) (*PPM, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	var lines []string
+	for _, match := range xpmString.FindAllStringSubmatch(string(content), -1) {
+		lines = append(lines, match[1])
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("missing XPM values")
+	}
+
+	var width, height, colors, cpp int
+	if _, err := fmt.Sscan(lines[0], &width, &height, &colors, &cpp); err != nil {
+		return nil, fmt.Errorf("invalid XPM values %q: %v", lines[0], err)
+	}
+	if width <= 0 || height <= 0 || colors <= 0 {
+		return nil, fmt.Errorf("invalid XPM size %dx%d with %d colors", width, height, colors)
+	}
+	// Comparaisons par soustraction et division pour qu'aucune valeur de l'en-tête ne déborde
+	if cpp < 1 || colors > len(lines)-1 || height > len(lines)-1-colors {
+		return nil, fmt.Errorf("expected %d colors and %d rows", colors, height)
+	}
+	rows := lines[1+colors : 1+colors+height]
+	for y, line := range rows {
+		if len(line)/cpp < width {
+			return nil, fmt.Errorf("row %d is too short", y)
+		}
+	}
+
+	palette := make(map[string]Pixel, colors)
+	for _, line := range lines[1 : 1+colors] {
+		if len(line) < cpp {
+			return nil, fmt.Errorf("invalid color line %q", line)
+		}
+		color, err := xpmColor(strings.Fields(line[cpp:]))
+		if err != nil {
+			return nil, err
+		}
+		palette[line[:cpp]] = color
+	}
+
+	ppm := NewPPM(width, height, 255)
+	for y, line := range rows {
+		for x := 0; x < width; x++ {
+			color, ok := palette[line[x*cpp:(x+1)*cpp]]
+			if !ok {
+				return nil, fmt.Errorf("unknown color %q at (%d, %d)", line[x*cpp:(x+1)*cpp], x, y)
+			}
+			ppm.data[y][x] = color
+		}
+	}
+	return ppm, nil
+}
+
+// xpmColor renvoie la couleur d'une définition XPM, formée de paires clé-valeur (seule la clé « c » est lue).
+func xpmColor(fields []string) (Pixel, error) {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] != "c" {
+			continue
+		}
+		value := strings.ToLower(fields[i+1])
+		if value == "none" {
+			return Pixel{255, 255, 255}, nil
+		}
+		if color, ok := xpmNamedColors[value]; ok {
+			return color, nil
+		}
+		// Au plus 8 chiffres par canal, pour que la valeur maximale tienne dans le calcul
+		if strings.HasPrefix(value, "#") && len(value) > 1 && len(value) <= 25 && (len(value)-1)%3 == 0 {
+			digits := (len(value) - 1) / 3
+			var channels [3]uint8
+			for c := range channels {
+				v, err := strconv.ParseUint(value[1+c*digits:1+(c+1)*digits], 16, 64)
+				if err != nil {
+					return Pixel{}, fmt.Errorf("invalid color %q: %v", value, err)
+				}
+				// Ramène la valeur sur 8 bits, quelle que soit la longueur du code
+				channels[c] = uint8(v * 255 / (1<<(4*uint(digits)) - 1))
+			}
+			return Pixel{channels[0], channels[1], channels[2]}, nil
+		}
+		return Pixel{}, fmt.Errorf("unsupported color %q", fields[i+1])
+	}
+	return Pixel{}, fmt.Errorf("missing color in %q", strings.Join(fields, " "))
+}
+
+// SaveXPM enregistre l'image PPM au format XPM, le nom de la variable C venant du nom du fichier.
+func (ppm *PPM) SaveXPM(filename string) error {
+	// Couleurs de l'image, ramenées sur 8 bits et triées pour un résultat stable
+	scale := func(v uint8) uint8 { return uint8(int(v) * 255 / max(ppm.max, 1)) }
+	seen := map[Pixel]bool{}
+	var colors []Pixel
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			p = Pixel{scale(p.R), scale(p.G), scale(p.B)}
+			if !seen[p] {
+				seen[p] = true
+				colors = append(colors, p)
+			}
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		a, b := colors[i], colors[j]
+		return int(a.R)<<16|int(a.G)<<8|int(a.B) < int(b.R)<<16|int(b.G)<<8|int(b.B)
+	})
+
+	// Nombre de caractères par pixel nécessaire pour nommer toutes les couleurs
+	cpp := 1
+	for n := len(xpmChars); n < len(colors); n *= len(xpmChars) {
+		cpp++
+	}
+	keys := make(map[Pixel]string, len(colors))
+	for i, color := range colors {
+		key := make([]byte, cpp)
+		for c, n := cpp-1, i; c >= 0; c, n = c-1, n/len(xpmChars) {
+			key[c] = xpmChars[n%len(xpmChars)]
+		}
+		keys[color] = string(key)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	name := cIdentifier(strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+	fmt.Fprintf(writer, "/* XPM */\nstatic char *%s[] = {\n", name)
+	fmt.Fprintf(writer, "\"%d %d %d %d\",\n", ppm.width, ppm.height, len(colors), cpp)
+	for _, color := range colors {
+		fmt.Fprintf(writer, "\"%s c #%02X%02X%02X\",\n", keys[color], color.R, color.G, color.B)
+	}
+	for y := 0; y < ppm.height; y++ {
+		writer.WriteString("\"")
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			writer.WriteString(keys[Pixel{scale(p.R), scale(p.G), scale(p.B)}])
+		}
+		if y < ppm.height-1 {
+			writer.WriteString("\",\n")
+		} else {
+			writer.WriteString("\"\n")
+		}
+	}
+	writer.WriteString("};\n")
+	return writer.Flush()
+}
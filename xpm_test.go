@@ -0,0 +1,82 @@
+package Netpbm // 🧪 Test format XPM
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndReadXPM(t *testing.T) {
+	ppm := newPatternPPM(5, 4)
+	filename := "./testImages/ppm/test.xpm"
+	if err := ppm.SaveXPM(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "/* XPM */\nstatic char *test[] = {\n\"5 4 20 1\",\n") {
+		t.Errorf("Unexpected XPM header:\n%s", content)
+	}
+
+	read, err := ReadXPM(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Hash() != ppm.Hash() {
+		t.Error("Read image differs from the saved one")
+	}
+}
+
+func TestReadXPMColors(t *testing.T) {
+	filename := "./testImages/ppm/colors.xpm"
+	defer os.Remove(filename)
+	content := `/* XPM */
+static char * colors_xpm[] = {
+"3 2 4 2",
+"   c None",
+".. c #F00",
+"++ s accent c #0000FFFF0000",
+"@@ m black c Red",
+"  ..++",
+"@@@@  "};`
+	os.WriteFile(filename, []byte(content), 0644)
+	ppm, err := ReadXPM(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]Pixel{
+		{{255, 255, 255}, {255, 0, 0}, {0, 255, 0}},
+		{{255, 0, 0}, {255, 0, 0}, {255, 255, 255}},
+	}
+	for y, row := range expected {
+		for x, e := range row {
+			if ppm.At(x, y) != e {
+				t.Errorf("Pixel (%d, %d): expected %v, got %v", x, y, e, ppm.At(x, y))
+			}
+		}
+	}
+
+	os.WriteFile(filename, []byte(`"1 1 1 1", ". c chartreuse", "."`), 0644)
+	if _, err := ReadXPM(filename); err == nil {
+		t.Error("Expected an error for an unknown color name")
+	}
+	for _, content := range []string{
+		`"-3 7 91 1"`,
+		`"2 -1 1 1", ". c red", ".."`,
+		`"1 1 -1 1", "."`,
+		`"1 1 1 1", ". c #000000000000000000000000000", "."`,
+		`"1 1 9223372036854775807 1", ". c red", "."`,
+		`"1 9223372036854775807 1 1", ". c red", "."`,
+		`"1000000000000 1 1 1", ". c red", "."`,
+		`"4611686018427387904 1 1 2", ".. c red", ".."`,
+	} {
+		os.WriteFile(filename, []byte(content), 0644)
+		if _, err := ReadXPM(filename); err == nil {
+			t.Errorf("Expected an error for %s", content)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package Netpbm // ✨ Agrandissement en grille
+
+// RenderZoomed renvoie une nouvelle image PPM où chaque pixel devient un bloc scale×scale.
+// Si gridColor n'est pas nil, les blocs sont séparés et entourés par des lignes d'un pixel de cette couleur.
+func (ppm *PPM) RenderZoomed(scale int, gridColor *Pixel) *PPM {
+	return renderZoomed(ppm.width, ppm.height, ppm.max, scale, gridColor, func(x, y int) Pixel {
+		return ppm.data[y][x]
+	})
+}
+
+// RenderZoomed renvoie une image PPM où chaque pixel de l'image PGM devient un bloc gris scale×scale.
+// Si gridColor n'est pas nil, les blocs sont séparés et entourés par des lignes d'un pixel de cette couleur.
+func (pgm *PGM) RenderZoomed(scale int, gridColor *Pixel) *PPM {
+	return renderZoomed(pgm.width, pgm.height, pgm.max, scale, gridColor, func(x, y int) Pixel {
+		v := pgm.data[y][x]
+		return Pixel{v, v, v}
+	})
+}
+
+// RenderZoomed renvoie une image PPM où chaque pixel de l'image PBM devient un bloc noir ou blanc scale×scale.
+// Si gridColor n'est pas nil, les blocs sont séparés et entourés par des lignes d'un pixel de cette couleur.
+func (pbm *PBM) RenderZoomed(scale int, gridColor *Pixel) *PPM {
+	return renderZoomed(pbm.width, pbm.height, 255, scale, gridColor, func(x, y int) Pixel {
+		// Un pixel à true est noir, comme un 1 dans le fichier
+		if pbm.data[y][x] {
+			return Pixel{0, 0, 0}
+		}
+		return Pixel{255, 255, 255}
+	})
+}
+
+// renderZoomed construit l'image agrandie à partir de la couleur de chaque pixel source.
+func renderZoomed(width, height, maxValue, scale int, gridColor *Pixel, pixel func(x, y int) Pixel) *PPM {
+	if scale < 1 {
+		scale = 1
+	}
+	step, offset := scale, 0
+	if gridColor != nil {
+		// Une ligne de grille avant chaque bloc, plus une pour fermer la grille
+		step, offset = scale+1, 1
+	}
+	zoomed := NewPPM(width*step+offset, height*step+offset, maxValue)
+	if gridColor != nil {
+		for y := range zoomed.data {
+			for x := range zoomed.data[y] {
+				zoomed.data[y][x] = *gridColor
+			}
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			color := pixel(x, y)
+			for dy := 0; dy < scale; dy++ {
+				row := zoomed.data[y*step+offset+dy]
+				for dx := 0; dx < scale; dx++ {
+					row[x*step+offset+dx] = color
+				}
+			}
+		}
+	}
+	return zoomed
+}
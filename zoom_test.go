@@ -0,0 +1,53 @@
+package Netpbm // 🧪 Test agrandissement
+
+import "testing"
+
+func TestRenderZoomed(t *testing.T) {
+	ppm, err := ReadPPM("./testImages/ppm/testP3.ppm")
+	if err != nil {
+		t.Error(err)
+	}
+	zoomed := ppm.RenderZoomed(3, nil)
+	w, h := zoomed.Size()
+	if w != imagePPMWidth*3 || h != imagePPMHeight*3 {
+		t.Errorf("Wrong size %dx%d", w, h)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if zoomed.At(x, y) != imagePPMData[(y/3)*imagePPMWidth+x/3] {
+				t.Fatalf("Pixel at (%d, %d) not zoomed correctly", x, y)
+			}
+		}
+	}
+}
+
+func TestRenderZoomedGrid(t *testing.T) {
+	grid := Pixel{R: 255}
+	pbm := NewPBM(2, 1)
+	pbm.Set(1, 0, true)
+	zoomed := pbm.RenderZoomed(2, &grid)
+	w, h := zoomed.Size()
+	if w != 7 || h != 4 {
+		t.Errorf("Wrong size %dx%d", w, h)
+	}
+	white, black := Pixel{255, 255, 255}, Pixel{0, 0, 0}
+	expected := [][]Pixel{
+		{grid, grid, grid, grid, grid, grid, grid},
+		{grid, white, white, grid, black, black, grid},
+		{grid, white, white, grid, black, black, grid},
+		{grid, grid, grid, grid, grid, grid, grid},
+	}
+	for y := range expected {
+		for x := range expected[y] {
+			if zoomed.At(x, y) != expected[y][x] {
+				t.Errorf("Pixel at (%d, %d) wanted %v got %v", x, y, expected[y][x], zoomed.At(x, y))
+			}
+		}
+	}
+
+	pgm := NewPGM(1, 1, 255)
+	pgm.Set(0, 0, 77)
+	if pgm.RenderZoomed(4, nil).At(3, 3) != (Pixel{77, 77, 77}) {
+		t.Error("PGM pixel not rendered as gray")
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/YOYOPX15/Netpbm/zstd
+
+go 1.21.4
+
+require (
+	github.com/YOYOPX15/Netpbm v0.0.0
+	github.com/klauspost/compress v1.17.11
+)
+
+replace github.com/YOYOPX15/Netpbm => ../
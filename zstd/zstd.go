@@ -0,0 +1,33 @@
+// Package zstd enregistre la compression Zstandard des séquences d'images Netpbm (Netpbm.SequenceZstd)
+// avec github.com/klauspost/compress/zstd. Il suffit de l'importer pour ses effets :
+//
+//	import _ "github.com/YOYOPX15/Netpbm/zstd"
+//
+// C'est un module à part, pour que Netpbm reste sans dépendance.
+package zstd // ✨ Séquences Zstandard
+
+import (
+	"io"
+
+	"github.com/YOYOPX15/Netpbm"
+	"github.com/klauspost/compress/zstd"
+)
+
+func init() {
+	Netpbm.RegisterSequenceCodec(Netpbm.SequenceZstd, compress, decompress)
+}
+
+// compress renvoie un compresseur Zstandard qui écrit dans w.
+func compress(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+// decompress renvoie un décompresseur Zstandard qui lit r. Chaque image est décompressée par un seul
+// décodeur, sans goroutine de fond, libéré par Close.
+func decompress(r io.Reader) (io.ReadCloser, error) {
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
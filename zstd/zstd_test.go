@@ -0,0 +1,60 @@
+package zstd // 🧪 Test séquences Zstandard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YOYOPX15/Netpbm"
+)
+
+// frame renvoie l'image PGM numéro i de la séquence de test.
+func frame(i int) *Netpbm.PGM {
+	pgm := Netpbm.NewPGM(64, 48, 255)
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			pgm.Set(x, y, uint8((x+y+i)%256))
+		}
+	}
+	return pgm
+}
+
+func TestSequenceZstd(t *testing.T) {
+	dir := t.TempDir()
+	sizes := map[Netpbm.SequenceCodec]int64{}
+	for _, codec := range []Netpbm.SequenceCodec{Netpbm.SequenceStored, Netpbm.SequenceZstd} {
+		filename := filepath.Join(dir, "frames.seq")
+		w, err := Netpbm.CreateSequence(filename, codec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 20; i++ {
+			if err := w.Add(frame(i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		info, _ := os.Stat(filename)
+		sizes[codec] = info.Size()
+
+		r, err := Netpbm.OpenSequence(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, i := range []int{19, 0, 7} {
+			img, err := r.Frame(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pgm, ok := img.(*Netpbm.PGM); !ok || pgm.Hash() != frame(i).Hash() {
+				t.Errorf("Codec %d: frame %d differs", codec, i)
+			}
+		}
+		r.Close()
+	}
+	if sizes[Netpbm.SequenceZstd] >= sizes[Netpbm.SequenceStored] {
+		t.Errorf("Zstandard should reduce the size: %v", sizes)
+	}
+}